@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+
+	"github.com/pb33f/harific/motor"
+	"github.com/spf13/cobra"
+)
+
+var (
+	extractFields     []string
+	extractGroup      int
+	extractUnique     bool
+	extractIgnoreCase bool
+	extractWorkers    int
+	extractChunkSize  int
+)
+
+var extractCmd = &cobra.Command{
+	Use:   "extract <har-file> <regex>",
+	Short: "Pull regex captures out of a HAR file as JSON lines",
+	Long: `Extract values from a HAR file, such as every bearer token or order id. Every match of the
+regular expression, in every field including response bodies, prints one JSON object per line
+with the entry index, matched field and the captured text, in entry order. The first capture
+group is printed when the expression has one, otherwise the whole match.
+
+Examples:
+  harific extract recording.har 'Bearer ([\w.-]+)' --field request.headers --unique
+  harific extract recording.har '"orderId":\s*"?(\d+)' --field response.body
+  harific extract recording.har 'session=(\w+)' --field cookie,response.cookie | jq -r .capture
+  harific extract recording.har '(\w+)@example\.com' --group 0 --unique`,
+	Args: cobra.ExactArgs(2),
+	RunE: runExtract,
+}
+
+func init() {
+	rootCmd.AddCommand(extractCmd)
+
+	extractCmd.Flags().StringSliceVar(&extractFields, "field", []string{}, "Restrict extraction to fields: url,metadata,request.headers,query.param,cookie,request.body,response.headers,response.cookie,websocket.message,response.body,comment,redirectURL (default: all)")
+	extractCmd.Flags().IntVar(&extractGroup, "group", -1, "Capture group to print, 0 for the whole match (default: 1 if the expression has a group, else 0)")
+	extractCmd.Flags().BoolVar(&extractUnique, "unique", false, "Print each captured value only once")
+	extractCmd.Flags().BoolVarP(&extractIgnoreCase, "ignore-case", "i", false, "Ignore case when matching")
+	extractCmd.Flags().IntVar(&extractWorkers, "workers", 0, "Number of search workers (default: number of CPUs, at most 64)")
+	extractCmd.Flags().IntVar(&extractChunkSize, "chunk-size", 0, "Entries per search work batch (default: split evenly across the workers)")
+}
+
+// extractCapture is the JSON line printed for each captured value
+type extractCapture struct {
+	Index   int    `json:"index"`
+	Field   string `json:"field"`
+	Capture string `json:"capture"`
+}
+
+func runExtract(cmd *cobra.Command, args []string) error {
+	harFile, pattern := args[0], args[1]
+
+	if err := ValidateHARFile(harFile); err != nil {
+		return fmt.Errorf("invalid HAR file: %w", err)
+	}
+
+	opts, err := extractOptions(pattern)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	streamer, err := InitializeStreamer(ctx, harFile, Logger)
+	if err != nil {
+		return err
+	}
+	defer streamer.Close()
+
+	index := streamer.GetIndex()
+	reader, err := motor.NewEntryReader(index.FilePath, index) // the file actually indexed, for gzip or stdin input
+	if err != nil {
+		return fmt.Errorf("failed to create entry reader: %w", err)
+	}
+	defer reader.Close()
+
+	searcher := motor.NewSearcher(streamer, reader)
+	results, err := searcher.Search(ctx, pattern, opts)
+	if err != nil {
+		return fmt.Errorf("extract failed: %w", err)
+	}
+	defer logSearchStats(searcher)
+
+	// workers finish out of order; collect everything so captures print in entry order
+	var matches []motor.SearchResult
+	for batch := range results {
+		for _, result := range batch {
+			if result.Error != nil {
+				Logger.Warn("failed to search entry", "index", result.Index, "error", result.Error)
+				continue
+			}
+			matches = append(matches, result)
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b motor.SearchResult) int {
+		return cmp.Compare(a.Index, b.Index)
+	})
+
+	encoder := json.NewEncoder(os.Stdout)
+	seen := make(map[string]struct{})
+	for _, match := range matches {
+		for _, capture := range match.Captures {
+			if extractUnique {
+				if _, dup := seen[capture]; dup {
+					continue
+				}
+				seen[capture] = struct{}{}
+			}
+			if err := encoder.Encode(extractCapture{Index: match.Index, Field: match.Field, Capture: capture}); err != nil {
+				return fmt.Errorf("failed to write capture: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// extractOptions builds regex search options that collect the captures of every match
+func extractOptions(pattern string) (motor.SearchOptions, error) {
+	opts := motor.DefaultSearchOptions
+	opts.Mode = motor.Regex
+	opts.Extract = true
+	opts.FirstMatchOnly = false
+	opts.SearchResponseBody = true
+	opts.CaseInsensitive = extractIgnoreCase
+	opts.SnippetContext = -1 // only the captures are printed
+
+	opts.CaptureGroup = extractGroup
+	if extractGroup < 0 {
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return opts, fmt.Errorf("invalid regex pattern: %w", err)
+		}
+		opts.CaptureGroup = min(regex.NumSubexp(), 1)
+	}
+
+	if extractWorkers < 0 {
+		return opts, fmt.Errorf("--workers must not be negative, got %d", extractWorkers)
+	}
+	if extractWorkers > 0 {
+		opts.WorkerCount = extractWorkers
+	}
+	if extractChunkSize < 0 {
+		return opts, fmt.Errorf("--chunk-size must not be negative, got %d", extractChunkSize)
+	}
+	opts.ChunkSize = extractChunkSize
+
+	for _, name := range extractFields {
+		field, err := motor.ParseSearchField(name)
+		if err != nil {
+			return opts, err
+		}
+		opts.Fields |= field
+	}
+	if opts.Fields&motor.SearchFieldWebSockets != 0 {
+		opts.SearchWebSockets = true
+	}
+	return opts, nil
+}
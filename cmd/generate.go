@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"cmp"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/pb33f/harific/hargen"
@@ -19,6 +22,13 @@ var (
 	genMaxNodes       int
 	genShowInjections bool
 	genFatMode        bool
+	genFatTarget      int
+	genReportFile     string
+	genStatusWeights  []string
+	genBodyTypes      []string
+	genTimings        bool
+	genOutputFormat   string
+	genSplitFiles     int
 )
 
 var generateCmd = &cobra.Command{
@@ -29,9 +39,16 @@ Can inject specific search terms into known locations for testing search functio
 
 Examples:
   harific generate -n 100 -o test.har
+  harific generate -n 100 -o - | gzip > test.har.gz
   harific generate -n 1000 -i apple,banana -l url,request.body
   harific generate --fat-mode -n 50 -o large.har
-  harific generate --entries 10 --inject searchterm --show-injections`,
+  harific generate --fat-target 1000000 -n 500 -o 500mb.har
+  harific generate --entries 10 --inject searchterm --show-injections
+  harific generate -n 100 -o test.har -i apple --report test.injections.json
+  harific generate -n 1000 -o errors.har --status 200=95,500=5
+  harific generate -n 100 -o mixed.har --body-types json,html,xml,text,binary
+  harific generate -n 1000 -o - --output-format ndjson | head -1
+  harific generate -n 1000 -o capture.har --output-format split --split-files 4`,
 	RunE: runGenerate,
 }
 
@@ -39,7 +56,7 @@ func init() {
 	rootCmd.AddCommand(generateCmd)
 
 	generateCmd.Flags().IntVarP(&genEntryCount, "entries", "n", 10, "Number of HAR entries to generate")
-	generateCmd.Flags().StringVarP(&genOutputFile, "output", "o", "", "Output file path (default: hargen-{timestamp}.har)")
+	generateCmd.Flags().StringVarP(&genOutputFile, "output", "o", "", "Output file path, or - for stdout (default: hargen-{timestamp}.har)")
 	generateCmd.Flags().StringSliceVarP(&genInjectTerms, "inject", "i", []string{}, "Terms to inject (comma-separated)")
 	generateCmd.Flags().StringSliceVarP(&genLocations, "locations", "l", []string{}, "Injection locations: url,request.body,response.body,request.header,response.header,query.param,cookie (default: all)")
 	generateCmd.Flags().Int64VarP(&genSeed, "seed", "s", 0, "Random seed for reproducibility (0 = use current time)")
@@ -48,34 +65,55 @@ func init() {
 	generateCmd.Flags().IntVar(&genMaxNodes, "max-nodes", 10, "Maximum JSON nodes per level")
 	generateCmd.Flags().BoolVar(&genShowInjections, "show-injections", true, "Show injection details after generation")
 	generateCmd.Flags().BoolVar(&genFatMode, "fat-mode", false, "Generate huge entries (~100KB each) with base64 blobs")
+	generateCmd.Flags().IntVar(&genFatTarget, "fat-target", 0, "Approximate response body bytes per fat mode entry, implies --fat-mode (default: 100000)")
+	generateCmd.Flags().BoolVar(&genTimings, "timings", true, "Fill in request timings (dns, connect, ssl, send, wait, receive) that add up to each entry's time")
+	generateCmd.Flags().StringSliceVar(&genStatusWeights, "status", []string{}, "Response status weights as code=weight, e.g. 200=95,500=5 (default: uniform over common codes)")
+	generateCmd.Flags().StringSliceVar(&genBodyTypes, "body-types", []string{}, "Body content types picked per entry: json,html,xml,text,binary (default: json)")
+	generateCmd.Flags().StringVar(&genOutputFormat, "output-format", "har", "Output format: har, ndjson (one entry per line) or split (several HAR files named after -o)")
+	generateCmd.Flags().IntVar(&genSplitFiles, "split-files", hargen.DefaultSplitFiles, "Number of files written by --output-format split")
+	generateCmd.Flags().StringVar(&genReportFile, "report", "", "Write the injection report (JSON) to this path, for use with 'harific view --injections'")
 }
 
 func runGenerate(cmd *cobra.Command, args []string) error {
+	if genFatTarget < 0 {
+		return fmt.Errorf("--fat-target must not be negative, got %d", genFatTarget)
+	}
+	if genFatTarget > 0 {
+		genFatMode = true
+	}
+
+	outputFormat, err := hargen.ParseOutputFormat(genOutputFormat)
+	if err != nil {
+		return err
+	}
+	if outputFormat == hargen.FormatSplit && (genOutputFile == "" || genOutputFile == "-") {
+		return fmt.Errorf("--output-format split needs a file path in -o to name the parts after")
+	}
+	if genSplitFiles <= 0 {
+		return fmt.Errorf("--split-files must be positive, got %d", genSplitFiles)
+	}
+
 	// Parse injection locations
 	var injectionLocs []hargen.InjectionLocation
 	if len(genLocations) > 0 {
 		for _, loc := range genLocations {
-			switch strings.ToLower(loc) {
-			case "url":
-				injectionLocs = append(injectionLocs, hargen.URL)
-			case "request.body", "requestbody":
-				injectionLocs = append(injectionLocs, hargen.RequestBody)
-			case "response.body", "responsebody":
-				injectionLocs = append(injectionLocs, hargen.ResponseBody)
-			case "request.header", "requestheader":
-				injectionLocs = append(injectionLocs, hargen.RequestHeader)
-			case "response.header", "responseheader":
-				injectionLocs = append(injectionLocs, hargen.ResponseHeader)
-			case "query.param", "queryparam":
-				injectionLocs = append(injectionLocs, hargen.QueryParam)
-			case "cookie":
-				injectionLocs = append(injectionLocs, hargen.Cookie)
-			default:
-				return fmt.Errorf("unknown injection location: %s", loc)
+			parsed, err := hargen.ParseInjectionLocation(loc)
+			if err != nil {
+				return err
 			}
+			injectionLocs = append(injectionLocs, parsed)
 		}
 	}
 
+	var statusDistribution map[int]float64
+	if len(genStatusWeights) > 0 {
+		parsed, err := hargen.ParseStatusDistribution(genStatusWeights)
+		if err != nil {
+			return err
+		}
+		statusDistribution = parsed
+	}
+
 	// Build options
 	opts := hargen.GenerateOptions{
 		EntryCount:         genEntryCount,
@@ -86,22 +124,45 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		MaxJSONNodes:       genMaxNodes,
 		Seed:               genSeed,
 		FatMode:            genFatMode,
+		FatTargetBytes:     genFatTarget,
+		StatusDistribution: statusDistribution,
+		BodyContentTypes:   genBodyTypes,
+		WithTimings:        genTimings,
+		OutputFormat:       outputFormat,
+		SplitFiles:         genSplitFiles,
 	}
 
-	fmt.Printf("Generating HAR file with %d entries", genEntryCount)
+	// with -o - the har goes to stdout, so progress and summaries move to stderr
+	toStdout := genOutputFile == "-"
+	var msgs io.Writer = os.Stdout
+	if toStdout {
+		msgs = os.Stderr
+	}
+
+	fmt.Fprintf(msgs, "Generating HAR file with %d entries", genEntryCount)
 	if genFatMode {
-		fmt.Printf(" (fat mode: ~100KB per entry)")
+		fmt.Fprintf(msgs, " (fat mode: ~%s per entry)", formatBytes(int64(cmp.Or(genFatTarget, hargen.DefaultFatTargetBytes))))
 	}
-	fmt.Println("...")
+	fmt.Fprintln(msgs, "...")
 	if len(genInjectTerms) > 0 {
-		fmt.Printf("Injecting terms: %v\n", genInjectTerms)
+		fmt.Fprintf(msgs, "Injecting terms: %v\n", genInjectTerms)
 	}
 
 	var result *hargen.GenerateResult
 	var injected []hargen.InjectedTerm
-	var err error
 
-	if genOutputFile != "" {
+	switch {
+	case toStdout:
+		injected, err = hargen.GenerateTo(os.Stdout, opts)
+		if err != nil {
+			return fmt.Errorf("failed to generate HAR: %w", err)
+		}
+		result = &hargen.GenerateResult{
+			HARFilePath:   "stdout",
+			InjectedTerms: injected,
+			TotalEntries:  genEntryCount,
+		}
+	case genOutputFile != "":
 		// Generate to specific file
 		injected, err = hargen.GenerateToFile(genOutputFile, opts)
 		if err != nil {
@@ -112,7 +173,7 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 			InjectedTerms: injected,
 			TotalEntries:  genEntryCount,
 		}
-	} else {
+	default:
 		// Generate to temp file
 		result, err = hargen.Generate(opts)
 		if err != nil {
@@ -120,18 +181,32 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	fmt.Printf("\n✓ Generated HAR file: %s\n", result.HARFilePath)
-	fmt.Printf("  Total entries: %d\n", result.TotalEntries)
+	if outputFormat == hargen.FormatSplit {
+		fmt.Fprintf(msgs, "\n✓ Generated HAR files: %s\n", strings.Join(hargen.SplitPaths(genOutputFile, genSplitFiles), ", "))
+	} else {
+		fmt.Fprintf(msgs, "\n✓ Generated HAR file: %s\n", result.HARFilePath)
+	}
+	fmt.Fprintf(msgs, "  Total entries: %d\n", result.TotalEntries)
 
 	if genShowInjections && len(result.InjectedTerms) > 0 {
-		fmt.Printf("\nInjected terms:\n")
+		fmt.Fprintf(msgs, "\nInjected terms:\n")
 		for _, inj := range result.InjectedTerms {
-			fmt.Printf("  • '%s' at entry %d in %s", inj.Term, inj.EntryIndex, inj.Location)
+			fmt.Fprintf(msgs, "  • '%s' at entry %d in %s", inj.Term, inj.EntryIndex, inj.Location)
+			if inj.File != "" {
+				fmt.Fprintf(msgs, " of %s", inj.File)
+			}
 			if inj.FieldPath != "" {
-				fmt.Printf(" (%s)", inj.FieldPath)
+				fmt.Fprintf(msgs, " (%s)", inj.FieldPath)
 			}
-			fmt.Println()
+			fmt.Fprintln(msgs)
+		}
+	}
+
+	if genReportFile != "" {
+		if err := hargen.WriteInjectionReport(genReportFile, result.InjectedTerms); err != nil {
+			return fmt.Errorf("failed to write injection report: %w", err)
 		}
+		fmt.Fprintf(msgs, "\n✓ Wrote injection report: %s\n", genReportFile)
 	}
 
 	return nil
@@ -28,6 +28,7 @@ Features:
   • Mock server for replaying captured responses (coming soon)
 
 Usage:
+  harific                      Reopen a recently viewed HAR file
   harific <har-file>           View a HAR file (backward compatible)
   harific view <har-file>      Explicitly view a HAR file
   harific generate [options]   Generate test HAR files
@@ -36,12 +37,28 @@ Usage:
   harific recording.har
   harific view recording.har
 
+  # Compare captures, one tab per file (ctrl+n / ctrl+p to switch)
+  harific before.har after.har
+
+  # Read a HAR from a pipe ("-" names stdin for any command)
+  curl -s https://example.com/capture.har | harific
+  harific stats - < recording.har
+
   # Generate test HAR files
   harific generate -n 100 -o test.har
   harific generate --inject apple,banana --locations url,request.body
 
+  # Add entries to the table as a proxy appends them to the file
+  harific live-capture.har --follow
+
+  # Triage a huge capture from its first 50,000 entries
+  harific huge.har --max-entries 50000
+
   # With verbose logging
-  harific recording.har -v`,
+  harific recording.har -v
+
+  # Colors come from ~/.config/harific/theme.toml (or $HARIFIC_THEME)
+  harific recording.har --no-color`,
         PersistentPreRun: func(cmd *cobra.Command, args []string) {
             setupLogger()
         },
@@ -57,28 +74,55 @@ func Execute() error {
 func init() {
     rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging")
     rootCmd.Flags().IntVarP(&port, "port", "p", 9876, "Port for mock server (future functionality)")
+    rootCmd.Flags().BoolVar(&webSocketSupport, "websockets", false, "Index and search WebSocket frames (_webSocketMessages)")
+    rootCmd.Flags().BoolVar(&decodeBodies, "decode-bodies", false, "Show and search base64 encoded response bodies as decoded text")
+    rootCmd.Flags().IntVar(&maxEntrySizeMB, "max-entry-size", motor.MaxEntrySize/(1024*1024), "Largest single entry to load, in MB")
+    rootCmd.Flags().StringVar(&injectionReportFile, "injections", "", "Injection report from 'harific generate --report' to highlight injected terms")
+    rootCmd.Flags().BoolVar(&noColor, "no-color", false, "Disable colors in the terminal UI")
+    rootCmd.Flags().BoolVar(&followFiles, "follow", false, "Watch the files for entries appended while they are open")
+    rootCmd.Flags().DurationVar(&searchDebounce, "debounce", 0, "Live search delay after a keystroke, e.g. 150ms (default from view.json, else 300ms)")
+    rootCmd.Flags().IntVar(&startEntry, "start-entry", 0, "Skip this many entries without indexing them")
+    rootCmd.Flags().IntVar(&maxEntries, "max-entries", 0, "Index at most this many entries and leave the rest of the file unread (0 = all)")
+    rootCmd.Flags().StringVar(&bodyDir, "body-dir", "", "Read response bodies stored as separate files (content._file) from within this directory")
 
     // will be reconfigured in PersistentPreRun based on flags
     setupLogger()
 }
 
 func runRootCommand(cmd *cobra.Command, args []string) error {
-    // If no arguments, show banner and help
-    if len(args) == 0 {
-        fmt.Println(RenderColorfulBanner())
-        return cmd.Help()
+    // If no arguments, offer recently opened files, otherwise show banner and help
+    harFiles := args
+    if len(args) == 0 && stdinPiped() {
+        // curl ... | harific
+        harFiles = []string{stdinFile}
+    } else if len(args) == 0 {
+        selected, shown, err := LaunchRecentFiles()
+        if err != nil {
+            return err
+        }
+        if !shown {
+            fmt.Println(RenderColorfulBanner())
+            return cmd.Help()
+        }
+        if selected == "" {
+            return nil
+        }
+        harFiles = []string{selected}
     }
 
-    // Backward compatibility: if a file is provided, view it
-    harFile := args[0]
-
-    if err := ValidateHARFile(harFile); err != nil {
-        return fmt.Errorf("invalid HAR file: %w", err)
+    // Backward compatibility: files given as arguments are viewed, each in its own tab
+    if err := validateTUIFiles(harFiles); err != nil {
+        return err
     }
 
     // TODO: When server functionality is implemented, it will start here
     // For now, just launch the TUI
-    if err := LaunchTUI(harFile); err != nil {
+    opts, err := viewTUIOptions()
+    if err != nil {
+        return err
+    }
+
+    if err := LaunchTUI(harFiles, opts); err != nil {
         return fmt.Errorf("failed to launch TUI: %w", err)
     }
 
@@ -125,6 +169,9 @@ func ValidateHARFile(harFile string) error {
     if harFile == "" {
         return fmt.Errorf("HAR file path is required")
     }
+    if harFile == stdinFile {
+        return nil
+    }
 
     info, err := os.Stat(harFile)
     if err != nil {
@@ -141,10 +188,26 @@ func ValidateHARFile(harFile string) error {
     return nil
 }
 
+// stdinFile is the file argument that reads the HAR from standard input
+const stdinFile = "-"
+
+// stdinPiped reports whether standard input is a pipe or file rather than a terminal
+func stdinPiped() bool {
+    info, err := os.Stdin.Stat()
+    return err == nil && info.Mode()&os.ModeCharDevice == 0
+}
+
 // InitializeStreamer creates and initializes a HAR streamer with standard logging
 func InitializeStreamer(ctx context.Context, harFile string, logger *slog.Logger) (motor.HARStreamer, error) {
     opts := motor.DefaultStreamerOptions()
-    streamer, err := motor.NewHARStreamer(harFile, opts)
+    var streamer *motor.DefaultHARStreamer
+    var err error
+    if harFile == stdinFile {
+        logger.Debug("reading HAR from stdin...")
+        streamer, err = motor.NewHARStreamerFromReader(os.Stdin, opts)
+    } else {
+        streamer, err = motor.NewHARStreamer(harFile, opts)
+    }
     if err != nil {
         return nil, fmt.Errorf("failed to create HAR streamer: %w", err)
     }
@@ -173,6 +236,12 @@ func InitializeStreamer(ctx context.Context, harFile string, logger *slog.Logger
     if index.Browser != nil {
         logger.Debug("HAR browser", "name", index.Browser.Name, "version", index.Browser.Version)
     }
+    interned := index.InternStats()
+    logger.Debug("string interning",
+        "interned", interned.Interned,
+        "unique", interned.Unique,
+        "bytes_saved", interned.BytesSaved,
+        "table_bytes", interned.TableBytes)
 
     return streamer, nil
 }
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pb33f/harific/motor"
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchRegex          bool
+	searchResponseBodies bool
+	searchRequestBodies  bool
+	searchIgnoreCase     bool
+	searchAllMatches     bool
+	searchFields         []string
+	searchCount          bool
+	searchWorkers        int
+	searchChunkSize      int
+	searchFuzzy          bool
+	searchGlob           bool
+	searchMaxDistance    int
+	searchRank           bool
+	searchHasHeader      string
+	searchMissingHeader  string
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <har-file> [query]",
+	Short: "Search a HAR file and print matches as JSON lines",
+	Long: `Search a HAR file without the terminal UI. Each match is printed to stdout as one
+JSON object per line, with the entry index, matched field, url and status, ready for jq.
+The query may be left out with --has-header or --missing-header, which then list every
+entry that has, or lacks, the header.
+
+Examples:
+  harific search recording.har token
+  harific search recording.har 'stack ?trace' --regex --response-bodies
+  harific search recording.har example.com --field url --count
+  harific search recording.har authorzation --fuzzy
+  harific search recording.har '/api/*/users' --glob --field url
+  harific search recording.har login --rank | head -5
+  harific search recording.har secret --field request.headers,cookie | jq .url
+  harific search recording.har --has-header set-cookie
+  harific search recording.har --missing-header cache-control --count`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSearch,
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+
+	searchCmd.Flags().BoolVar(&searchRegex, "regex", false, "Treat the query as a regular expression")
+	searchCmd.Flags().BoolVar(&searchResponseBodies, "response-bodies", false, "Also search response bodies (reads every entry from disk)")
+	searchCmd.Flags().BoolVar(&searchRequestBodies, "request-bodies", true, "Search request bodies; --request-bodies=false skips them")
+	searchCmd.Flags().BoolVarP(&searchIgnoreCase, "ignore-case", "i", false, "Ignore case when matching")
+	searchCmd.Flags().BoolVar(&searchAllMatches, "all", false, "Report every match in an entry, not just the first")
+	searchCmd.Flags().StringSliceVar(&searchFields, "field", []string{}, "Restrict matching to fields: url,metadata,request.headers,query.param,cookie,request.body,response.headers,response.cookie,websocket.message,response.body,comment,redirectURL (default: all)")
+	searchCmd.Flags().BoolVar(&searchCount, "count", false, "Print only the number of matches")
+	searchCmd.Flags().BoolVar(&searchFuzzy, "fuzzy", false, "Match words within --max-distance edits of the query (urls, metadata and headers unless --field is given)")
+	searchCmd.Flags().BoolVar(&searchGlob, "glob", false, "Treat the query as a shell-style glob: * and ? within a path segment, ** across them, [...] sets")
+	searchCmd.Flags().IntVar(&searchMaxDistance, "max-distance", motor.DefaultMaxEditDistance, "Most edits a --fuzzy match may be from the query")
+	searchCmd.Flags().BoolVar(&searchRank, "rank", false, "Print the most relevant matches first (waits for the search to finish)")
+	searchCmd.Flags().StringVar(&searchHasHeader, "has-header", "", "Only entries with this request or response header (case-insensitive)")
+	searchCmd.Flags().StringVar(&searchMissingHeader, "missing-header", "", "Only entries with neither a request nor a response header of this name")
+	searchCmd.Flags().IntVar(&searchWorkers, "workers", 0, "Number of search workers (default: number of CPUs, at most 64)")
+	searchCmd.Flags().IntVar(&searchChunkSize, "chunk-size", 0, "Entries per search work batch (default: split evenly across the workers)")
+}
+
+// searchMatch is the JSON line printed for each search result
+type searchMatch struct {
+	Index    int    `json:"index"`
+	Field    string `json:"field"`
+	URL      string `json:"url"`
+	Status   int    `json:"status"`
+	Snippet  string `json:"snippet,omitempty"`
+	Distance int    `json:"distance,omitempty"` // --fuzzy only
+	Score    int    `json:"score,omitempty"`    // --rank only
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	harFile, query := args[0], ""
+	if len(args) == 2 {
+		query = args[1]
+	} else if searchHasHeader == "" && searchMissingHeader == "" {
+		return fmt.Errorf("a query is required unless --has-header or --missing-header is given")
+	}
+
+	if err := ValidateHARFile(harFile); err != nil {
+		return fmt.Errorf("invalid HAR file: %w", err)
+	}
+
+	opts, err := searchOptions()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	streamer, err := InitializeStreamer(ctx, harFile, Logger)
+	if err != nil {
+		return err
+	}
+	defer streamer.Close()
+
+	index := streamer.GetIndex()
+	reader, err := motor.NewEntryReader(index.FilePath, index) // the file actually indexed, for gzip or stdin input
+	if err != nil {
+		return fmt.Errorf("failed to create entry reader: %w", err)
+	}
+	defer reader.Close()
+
+	searcher := motor.NewSearcher(streamer, reader)
+	results, err := searcher.Search(ctx, query, opts)
+	if err != nil {
+		return fmt.Errorf("search failed: %w", err)
+	}
+	defer logSearchStats(searcher)
+
+	encoder := json.NewEncoder(os.Stdout)
+	count := 0
+	for batch := range results {
+		for _, result := range batch {
+			if result.Error != nil {
+				Logger.Warn("failed to search entry", "index", result.Index, "error", result.Error)
+				continue
+			}
+
+			count++
+			if searchCount {
+				continue
+			}
+
+			match := searchMatch{Index: result.Index, Field: result.Field, Snippet: result.Snippet, Distance: result.Distance}
+			if searchRank {
+				match.Score = result.Score
+			}
+			if result.Index >= 0 && result.Index < len(index.Entries) {
+				match.URL = index.Entries[result.Index].URL
+				match.Status = index.Entries[result.Index].StatusCode
+			}
+			if err := encoder.Encode(match); err != nil {
+				return fmt.Errorf("failed to write result: %w", err)
+			}
+		}
+	}
+
+	if searchCount {
+		fmt.Println(count)
+	}
+	return nil
+}
+
+// logSearchStats reports where a search matched and the workers and batch size it ran with, after
+// clamping, under --verbose
+func logSearchStats(searcher *motor.HARSearcher) {
+	stats := searcher.Stats()
+	Logger.Debug("search finished",
+		"entries", stats.EntriesSearched,
+		"matches", stats.MatchesFound,
+		"matches_by_field", stats.MatchesByField,
+		"duration", stats.SearchDuration,
+		"workers", stats.WorkerCount,
+		"chunk_size", stats.ChunkSize)
+}
+
+// searchOptions builds motor search options from the search flags
+func searchOptions() (motor.SearchOptions, error) {
+	opts := motor.DefaultSearchOptions
+	modes := 0
+	for _, set := range []bool{searchRegex, searchGlob, searchFuzzy} {
+		if set {
+			modes++
+		}
+	}
+	if modes > 1 {
+		return opts, fmt.Errorf("only one of --regex, --glob and --fuzzy can be given")
+	}
+	if searchRegex {
+		opts.Mode = motor.Regex
+	}
+	if searchGlob {
+		opts.Mode = motor.Glob
+	}
+	if searchFuzzy {
+		if searchMaxDistance < 0 {
+			return opts, fmt.Errorf("--max-distance must not be negative, got %d", searchMaxDistance)
+		}
+		opts.Mode = motor.Fuzzy
+		opts.MaxEditDistance = searchMaxDistance
+	}
+	opts.SearchResponseBody = searchResponseBodies
+	opts.SkipRequestBody = !searchRequestBodies
+	opts.CaseInsensitive = searchIgnoreCase
+	opts.FirstMatchOnly = !searchAllMatches
+	opts.SortByRelevance = searchRank
+	opts.HeaderExists = searchHasHeader
+	opts.HeaderMissing = searchMissingHeader
+
+	if searchWorkers < 0 {
+		return opts, fmt.Errorf("--workers must not be negative, got %d", searchWorkers)
+	}
+	if searchWorkers > 0 {
+		opts.WorkerCount = searchWorkers
+	}
+	if searchChunkSize < 0 {
+		return opts, fmt.Errorf("--chunk-size must not be negative, got %d", searchChunkSize)
+	}
+	opts.ChunkSize = searchChunkSize
+
+	for _, name := range searchFields {
+		field, err := motor.ParseSearchField(name)
+		if err != nil {
+			return opts, err
+		}
+		opts.Fields |= field
+	}
+
+	// naming a body or websocket field is asking to read them
+	if opts.Fields&motor.SearchFieldResponseBody != 0 {
+		opts.SearchResponseBody = true
+	}
+	if opts.Fields&motor.SearchFieldRequestBody != 0 {
+		opts.SkipRequestBody = false
+	}
+	if opts.Fields&motor.SearchFieldWebSockets != 0 {
+		opts.SearchWebSockets = true
+	}
+	return opts, nil
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/pb33f/harific/motor"
+	"github.com/pb33f/harific/serve"
+	"github.com/spf13/cobra"
+)
+
+var serveAddr string
+
+var serveCmd = &cobra.Command{
+	Use:   "serve <har-file>",
+	Short: "Browse a HAR file from a web browser",
+	Long: `Index a HAR file and serve it over HTTP: a page to list and search the entries, and the
+read-only JSON API behind it.
+
+  GET /api/entries?offset=&limit=   entry summaries from the index
+  GET /api/entries/{i}              one entry, without its response body text
+  GET /api/entries/{i}/body         the response body, streamed from the file
+  GET /api/search?q=&mode=&bodies=  matches, mode being plain, regex, glob or fuzzy
+
+Examples:
+  harific serve recording.har
+  harific serve recording.har --addr 127.0.0.1:9000
+  curl 'localhost:8080/api/search?q=token' | jq .matches`,
+	Args: cobra.ExactArgs(1),
+	RunE: runServe,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":8080", "Address to listen on")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	harFile := args[0]
+
+	if err := ValidateHARFile(harFile); err != nil {
+		return fmt.Errorf("invalid HAR file: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	streamer, err := InitializeStreamer(ctx, harFile, Logger)
+	if err != nil {
+		return err
+	}
+	defer streamer.Close()
+
+	index := streamer.GetIndex()
+	reader, err := motor.NewEntryReader(index.FilePath, index) // the file actually indexed, for gzip or stdin input
+	if err != nil {
+		return fmt.Errorf("failed to create entry reader: %w", err)
+	}
+	defer reader.Close()
+
+	server := &http.Server{
+		Addr:              serveAddr,
+		Handler:           serve.NewServer(streamer, reader),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errs := make(chan error, 1)
+	go func() { errs <- server.ListenAndServe() }()
+	Logger.Info("serving HAR file", "addr", serveAddr, "entries", index.TotalEntries)
+
+	select {
+	case err := <-errs:
+		return fmt.Errorf("server failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to shut down: %w", err)
+	}
+	return nil
+}
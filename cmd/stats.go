@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pb33f/harific/motor"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsJSON bool
+	statsTopN int
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats <har-file>",
+	Short: "Summarize the contents of a HAR file",
+	Long: `Print an overview of a HAR file: totals, status code, method and mime type breakdowns,
+and the slowest and largest entries. Everything comes from the index, so no bodies are read.
+
+Breakdowns show each count as a percentage of the entries. The slowest and largest lists show
+each entry's share of the total time or body bytes and the cumulative share down the list, so
+the top few requests that dominate a page stand out. The wall clock is the span from the first
+request's start to the last one's end: with requests running in parallel it is shorter than the
+total time in requests. --json keeps the raw numbers.
+
+Examples:
+  harific stats recording.har
+  harific stats recording.har --top 5
+  harific stats recording.har --json | jq .statusCodes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStats,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().BoolVar(&statsJSON, "json", false, "Print the report as JSON")
+	statsCmd.Flags().IntVar(&statsTopN, "top", motor.DefaultStatsTopN, "Number of slowest and largest entries to list")
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	harFile := args[0]
+
+	if err := ValidateHARFile(harFile); err != nil {
+		return fmt.Errorf("invalid HAR file: %w", err)
+	}
+	if statsTopN <= 0 {
+		return fmt.Errorf("--top must be positive, got %d", statsTopN)
+	}
+
+	streamer, err := InitializeStreamer(context.Background(), harFile, Logger)
+	if err != nil {
+		return err
+	}
+	defer streamer.Close()
+
+	stats := streamer.GetIndex().Stats(statsTopN)
+
+	if statsJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	}
+	return printStats(os.Stdout, stats)
+}
+
+// printStats writes the human readable report
+func printStats(out io.Writer, stats *motor.IndexStats) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	if stats.Version != "" {
+		fmt.Fprintf(w, "HAR version:\t%s\n", stats.Version)
+	}
+	fmt.Fprintf(w, "Entries:\t%d\n", stats.TotalEntries)
+	fmt.Fprintf(w, "Unique URLs:\t%d\n", stats.UniqueURLs)
+	fmt.Fprintf(w, "File size:\t%s\n", formatBytes(stats.FileSize))
+	fmt.Fprintf(w, "Request bytes:\t%s\n", formatBytes(stats.TotalRequestBytes))
+	fmt.Fprintf(w, "Response bytes:\t%s\n", formatBytes(stats.TotalResponseBytes))
+	fmt.Fprintf(w, "Response body bytes:\t%s\n", formatBytes(stats.TotalBodyBytes))
+	if !stats.Start.IsZero() {
+		fmt.Fprintf(w, "Time range:\t%s to %s (%s)\n",
+			stats.Start.Format("2006-01-02 15:04:05"), stats.End.Format("2006-01-02 15:04:05"),
+			stats.End.Sub(stats.Start).Round(time.Millisecond))
+		fmt.Fprintf(w, "Time in requests:\t%s total, %s wall clock\n",
+			milliseconds(stats.TotalDuration), milliseconds(stats.WallClock))
+	}
+
+	if stats.BadTimestamps > 0 {
+		fmt.Fprintf(w, "Warning:\t%d entries have a missing or unparseable startedDateTime and are not in the time range\n", stats.BadTimestamps)
+	}
+
+	printBuckets(w, "Status codes", stats.StatusCodes, stats.TotalEntries)
+	printBuckets(w, "Methods", stats.Methods, stats.TotalEntries)
+	printBuckets(w, "MIME types", stats.MimeTypes, stats.TotalEntries)
+
+	// each entry's share of the total, then the running share of the list so far: how much of
+	// the time or bytes the top n account for together
+	fmt.Fprintf(w, "\nSlowest entries (share of total time, cumulative)\n")
+	var cumulative float64
+	for _, entry := range stats.Slowest {
+		share := percent(max(entry.Duration, 0), stats.TotalDuration)
+		cumulative += share
+		fmt.Fprintf(w, "  #%d\t%.0fms\t%.1f%%\t%.1f%%\t%s\t%d\t%s\n",
+			entry.Index, entry.Duration, share, cumulative, entry.Method, entry.Status, entry.URL)
+	}
+
+	fmt.Fprintf(w, "\nLargest entries (share of body bytes, cumulative)\n")
+	cumulative = 0
+	for _, entry := range stats.Largest {
+		share := percent(float64(max(entry.BodySize, 0)), float64(stats.TotalBodyBytes))
+		cumulative += share
+		fmt.Fprintf(w, "  #%d\t%s\t%.1f%%\t%.1f%%\t%s\t%d\t%s\n",
+			entry.Index, formatBytes(entry.BodySize), share, cumulative, entry.Method, entry.Status, entry.URL)
+	}
+
+	return w.Flush()
+}
+
+func printBuckets(w io.Writer, title string, buckets []motor.CountBucket, total int) {
+	fmt.Fprintf(w, "\n%s\n", title)
+	for _, bucket := range buckets {
+		fmt.Fprintf(w, "  %s\t%d\t%.1f%%\n", bucket.Key, bucket.Count, percent(float64(bucket.Count), float64(total)))
+	}
+}
+
+// percent is part as a percentage of total, 0 when there is no total
+func percent(part, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return part / total * 100
+}
+
+// milliseconds converts a time in milliseconds, as the index keeps them, for printing
+func milliseconds(ms float64) time.Duration {
+	return time.Duration(ms * float64(time.Millisecond)).Round(time.Millisecond)
+}
+
+// formatBytes renders a byte count with a binary unit, e.g. "1.5 MB"
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -2,30 +2,230 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/colorprofile"
+	"github.com/pb33f/harific/hargen"
 	"github.com/pb33f/harific/tui"
 )
 
-func LaunchTUI(harFile string) error {
-	model, err := tui.NewHARViewModel(harFile)
-	if err != nil {
-		return fmt.Errorf("failed to create TUI model: %w", err)
+// TUIOptions configures optional TUI features
+type TUIOptions struct {
+	InjectionReport string        // path to an injection report written by 'harific generate --report'
+	WebSockets      bool          // index and search _webSocketMessages frames
+	DecodeBodies    bool          // show and search base64 / gzip response bodies as decoded text
+	MaxEntrySize    int64         // largest entry to load, in bytes (0 = motor.MaxEntrySize)
+	NoColor         bool          // drop colors but keep bold and faint text, for dumb terminals
+	Follow          bool          // add entries appended to the files while they are open
+	SearchDebounce  time.Duration // live search delay, overriding the view settings (0 = from the settings)
+	StartEntry      int           // entries to skip before indexing, see motor.StreamerOptions.StartEntry
+	MaxEntries      int           // most entries to index (0 = all)
+	BodyDir         string        // directory response body files are read from (empty = not read)
+}
+
+// LaunchTUI opens the HAR files in the terminal UI, one tab per file. only the first file is
+// indexed up front; the rest are indexed when first switched to.
+func LaunchTUI(harFiles []string, opts TUIOptions) error {
+	if err := loadTheme(); err != nil {
+		return err
 	}
 
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	var injections []hargen.InjectedTerm
+	if opts.InjectionReport != "" {
+		terms, err := hargen.LoadInjectionReport(opts.InjectionReport)
+		if err != nil {
+			return err
+		}
+		injections = terms
+	}
 
-	finalModel, err := p.Run()
-	if err != nil {
+	history, historyPath := loadSearchHistory()
+	settings, settingsPath := loadViewSettings()
+
+	tabs := make([]*tui.HARViewModel, 0, len(harFiles))
+	for _, harFile := range harFiles {
+		title := harFile
+		if harFile == stdinFile {
+			title = "stdin"
+		}
+		model, err := tui.NewHARViewModel(title)
+		if err != nil {
+			return fmt.Errorf("failed to create TUI model: %w", err)
+		}
+		if harFile == stdinFile {
+			// bubbletea reads keys from the terminal when stdin is a pipe
+			model.SetSource(os.Stdin)
+		}
+
+		model.SetSearchHistory(history) // shared, so a query typed in one tab is in every tab's history
+		model.SetViewSettings(settings)
+		model.SetWebSocketSupport(opts.WebSockets)
+		model.SetDecodeBodies(opts.DecodeBodies)
+		model.SetMaxEntrySize(opts.MaxEntrySize)
+		model.SetFollow(opts.Follow)
+		model.SetEntryWindow(opts.StartEntry, opts.MaxEntries)
+		model.SetExternalBodyDir(opts.BodyDir)
+		if opts.SearchDebounce > 0 {
+			model.SetSearchDebounce(opts.SearchDebounce)
+		}
+		if injections != nil {
+			model.SetInjectedTerms(injections)
+		}
+		tabs = append(tabs, model)
+	}
+
+	model := tui.NewTabsModel(tabs...)
+	p := tea.NewProgram(model, programOptions(opts.NoColor, tea.WithAltScreen())...)
+
+	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("error running TUI: %w", err)
 	}
 
 	// cleanup resources
-	if m, ok := finalModel.(*tui.HARViewModel); ok {
-		if err := m.Cleanup(); err != nil {
-			return fmt.Errorf("cleanup error: %w", err)
+	for i, tab := range model.Tabs() {
+		if model.Started(i) && harFiles[i] != stdinFile {
+			recordRecentFile(harFiles[i], tab.EntryCount())
 		}
 	}
+	saveSearchHistory(history, historyPath)
+	saveViewSettings(settings, settingsPath)
+	if err := model.Cleanup(); err != nil {
+		return fmt.Errorf("cleanup error: %w", err)
+	}
+
+	return nil
+}
+
+// LaunchRecentFiles shows the recent files launcher and returns the chosen path.
+// shown is false when there are no recent files, so the caller can fall back to help.
+func LaunchRecentFiles() (selected string, shown bool, err error) {
+	path, err := tui.DefaultRecentFilesPath()
+	if err != nil {
+		return "", false, nil
+	}
+
+	recent, err := tui.LoadRecentFiles(path, tui.DefaultRecentFilesLimit)
+	if err != nil {
+		Logger.Debug("ignoring unreadable recent files", "error", err)
+		return "", false, nil
+	}
+	if len(recent.Files) == 0 {
+		return "", false, nil
+	}
+
+	if err := loadTheme(); err != nil {
+		return "", true, err
+	}
+
+	launcher := tui.NewLauncherModel(recent)
+	if _, err := tea.NewProgram(launcher, programOptions(noColor)...).Run(); err != nil {
+		return "", true, fmt.Errorf("error running launcher: %w", err)
+	}
+
+	if launcher.Changed() {
+		if err := recent.Save(path); err != nil {
+			Logger.Debug("failed to save recent files", "error", err)
+		}
+	}
+
+	return launcher.Selected(), true, nil
+}
+
+// loadTheme applies the theme file from tui.DefaultThemePath, if there is one
+func loadTheme() error {
+	path, err := tui.DefaultThemePath()
+	if err != nil {
+		Logger.Debug("using the default theme", "error", err)
+		return nil
+	}
 
+	theme, err := tui.LoadTheme(path)
+	if err != nil {
+		return err
+	}
+	tui.ApplyTheme(theme)
 	return nil
+}
+
+// programOptions adds the options every tui program shares to extra
+func programOptions(noColor bool, extra ...tea.ProgramOption) []tea.ProgramOption {
+	if noColor {
+		// bubbletea already honours NO_COLOR; the flag does the same without the environment variable
+		extra = append(extra, tea.WithColorProfile(colorprofile.Ascii))
+	}
+	return extra
+}
+
+// loadSearchHistory reads the search history from tui.DefaultSearchHistoryPath; an unreadable
+// history starts empty, and an empty path means it cannot be saved either
+func loadSearchHistory() (*tui.SearchHistory, string) {
+	path, err := tui.DefaultSearchHistoryPath()
+	if err != nil {
+		Logger.Debug("search history disabled", "error", err)
+		return tui.NewSearchHistory(tui.DefaultSearchHistoryLimit), ""
+	}
+
+	history, err := tui.LoadSearchHistory(path, tui.DefaultSearchHistoryLimit)
+	if err != nil {
+		Logger.Debug("ignoring unreadable search history", "error", err)
+		history = tui.NewSearchHistory(tui.DefaultSearchHistoryLimit)
+	}
+	return history, path
+}
+
+// saveSearchHistory writes the session's search history back; failures are not fatal
+func saveSearchHistory(history *tui.SearchHistory, path string) {
+	if history == nil || path == "" {
+		return
+	}
+	if err := history.Save(path); err != nil {
+		Logger.Debug("failed to save search history", "error", err)
+	}
+}
+
+// loadViewSettings reads the table preferences from tui.DefaultViewSettingsPath; unreadable
+// settings fall back to the defaults, and an empty path means they cannot be saved either
+func loadViewSettings() (*tui.ViewSettings, string) {
+	path, err := tui.DefaultViewSettingsPath()
+	if err != nil {
+		Logger.Debug("view settings disabled", "error", err)
+		return &tui.ViewSettings{}, ""
+	}
+
+	settings, err := tui.LoadViewSettings(path)
+	if err != nil {
+		Logger.Debug("ignoring unreadable view settings", "error", err)
+		settings = &tui.ViewSettings{}
+	}
+	return settings, path
+}
+
+// saveViewSettings writes the table preferences the session ended with; failures are not fatal
+func saveViewSettings(settings *tui.ViewSettings, path string) {
+	if settings == nil || path == "" {
+		return
+	}
+	if err := settings.Save(path); err != nil {
+		Logger.Debug("failed to save view settings", "error", err)
+	}
+}
+
+// recordRecentFile adds a file to the recent files list; failures are not fatal
+func recordRecentFile(harFile string, entries int) {
+	path, err := tui.DefaultRecentFilesPath()
+	if err != nil {
+		return
+	}
+
+	recent, err := tui.LoadRecentFiles(path, tui.DefaultRecentFilesLimit)
+	if err != nil {
+		recent = tui.NewRecentFiles(tui.DefaultRecentFilesLimit)
+	}
+
+	recent.Add(harFile, entries, time.Now())
+	if err := recent.Save(path); err != nil {
+		Logger.Debug("failed to save recent files", "error", err)
+	}
 }
\ No newline at end of file
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/pb33f/harific/motor"
+	"github.com/spf13/cobra"
+)
+
+var validateJSON bool
+
+var validateCmd = &cobra.Command{
+	Use:   "validate <har-file>",
+	Short: "Check a HAR file for structural problems",
+	Long: `Walk a HAR file and report every problem found, not just the first: truncated or
+malformed JSON, fields the HAR 1.2 spec requires that are missing, values of the wrong type,
+unparseable timestamps and body sizes that disagree with the content. Exits non-zero if any
+errors are found; warnings alone still exit zero.
+
+Examples:
+  harific validate recording.har
+  harific validate recording.har.gz
+  harific validate recording.har --json | jq '.issues[] | select(.severity == "error")'`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true, // a failed validation is not a usage error
+	RunE:         runValidate,
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+
+	validateCmd.Flags().BoolVar(&validateJSON, "json", false, "Print the report as JSON")
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	harFile := args[0]
+
+	if err := ValidateHARFile(harFile); err != nil {
+		return fmt.Errorf("invalid HAR file: %w", err)
+	}
+
+	var report *motor.ValidationReport
+	if harFile == stdinFile {
+		report = motor.Validate(os.Stdin)
+	} else {
+		var err error
+		if report, err = motor.ValidateFile(harFile); err != nil {
+			return err
+		}
+	}
+
+	if validateJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else if err := printValidationReport(os.Stdout, harFile, report); err != nil {
+		return err
+	}
+
+	if errors := report.Errors(); errors > 0 {
+		return fmt.Errorf("%s has %d errors", harFile, errors)
+	}
+	return nil
+}
+
+// printValidationReport lists the issues in file order followed by a summary line
+func printValidationReport(out io.Writer, harFile string, report *motor.ValidationReport) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	for _, issue := range report.Issues {
+		location := "log"
+		if issue.Entry >= 0 {
+			location = "entry " + strconv.Itoa(issue.Entry)
+		}
+		path := issue.Path
+		if path == "" {
+			path = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", issue.Severity, location, path, issue.Message)
+	}
+	if len(report.Issues) > 0 {
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "%s: HAR %s, %d entries, %d errors, %d warnings\n", harFile, report.Version, report.Entries, report.Errors(), report.Warnings())
+	return w.Flush()
+}
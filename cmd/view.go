@@ -2,15 +2,24 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/pb33f/harific/motor"
 	"github.com/spf13/cobra"
 )
 
 var viewCmd = &cobra.Command{
-	Use:   "view <har-file>",
+	Use:   "view <har-file> [har-file...]",
 	Short: "View and explore HAR files in the terminal UI",
 	Long: `Open a HAR file in an interactive terminal user interface.
 Navigate through requests and responses, view headers, bodies, and metadata.
+Several files open in tabs, switched with ctrl+n and ctrl+p, each with its own
+filters, search and selection; a file is only indexed when first switched to.
+With --follow, entries a proxy appends to a file while it is open are added to
+the table as they arrive. --start-entry and --max-entries index only a window of
+the entries, so a quick look inside a huge capture does not parse all of it.
+--body-dir reads response bodies a proxy stored as files of their own
+(content._file), relative to the HAR, from within that directory.
 
 The TUI provides:
   • Table view of all HTTP transactions
@@ -18,26 +27,101 @@ The TUI provides:
   • Search functionality with live filtering
   • File type filtering
   • Syntax highlighting for JSON/YAML content`,
-	Args: cobra.ExactArgs(1),
+	Args: cobra.MinimumNArgs(1),
 	Example: `  harific view recording.har
-  harific view large-capture.har -v`,
+  harific view before-deploy.har after-deploy.har
+  harific view large-capture.har -v
+  harific view live-capture.har --follow
+  harific view huge.har --max-entries 50000
+  harific view proxy.har --body-dir ./bodies
+  harific view test.har --injections test-injections.json`,
 	RunE: runView,
 }
 
+var (
+	injectionReportFile string
+	webSocketSupport    bool
+	decodeBodies        bool
+	maxEntrySizeMB      int
+	noColor             bool
+	followFiles         bool
+	searchDebounce      time.Duration
+	startEntry          int
+	maxEntries          int
+	bodyDir             string
+)
+
 func init() {
+	viewCmd.Flags().BoolVar(&webSocketSupport, "websockets", false, "Index and search WebSocket frames (_webSocketMessages)")
+	viewCmd.Flags().BoolVar(&decodeBodies, "decode-bodies", false, "Show and search base64 encoded response bodies as decoded text")
+	viewCmd.Flags().IntVar(&maxEntrySizeMB, "max-entry-size", motor.MaxEntrySize/(1024*1024), "Largest single entry to load, in MB")
+	viewCmd.Flags().StringVar(&injectionReportFile, "injections", "", "Injection report from 'harific generate --report' to highlight injected terms")
+	viewCmd.Flags().BoolVar(&noColor, "no-color", false, "Disable colors in the terminal UI")
+	viewCmd.Flags().BoolVar(&followFiles, "follow", false, "Watch the files for entries appended while they are open")
+	viewCmd.Flags().DurationVar(&searchDebounce, "debounce", 0, "Live search delay after a keystroke, e.g. 150ms (default from view.json, else 300ms)")
+	viewCmd.Flags().IntVar(&startEntry, "start-entry", 0, "Skip this many entries without indexing them")
+	viewCmd.Flags().IntVar(&maxEntries, "max-entries", 0, "Index at most this many entries and leave the rest of the file unread (0 = all)")
+	viewCmd.Flags().StringVar(&bodyDir, "body-dir", "", "Read response bodies stored as separate files (content._file) from within this directory")
 	rootCmd.AddCommand(viewCmd)
 }
 
 func runView(cmd *cobra.Command, args []string) error {
-	harFile := args[0]
+	if err := validateTUIFiles(args); err != nil {
+		return err
+	}
 
-	if err := ValidateHARFile(harFile); err != nil {
-		return fmt.Errorf("invalid HAR file: %w", err)
+	opts, err := viewTUIOptions()
+	if err != nil {
+		return err
 	}
 
-	if err := LaunchTUI(harFile); err != nil {
+	if err := LaunchTUI(args, opts); err != nil {
 		return fmt.Errorf("failed to launch TUI: %w", err)
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// validateTUIFiles checks every file to open in the TUI; stdin can only be viewed on its own
+func validateTUIFiles(harFiles []string) error {
+	for _, harFile := range harFiles {
+		if harFile == stdinFile && len(harFiles) > 1 {
+			return fmt.Errorf("stdin (%s) cannot be viewed alongside other files", stdinFile)
+		}
+		if harFile == stdinFile && followFiles {
+			return fmt.Errorf("stdin (%s) cannot be followed", stdinFile)
+		}
+		if err := ValidateHARFile(harFile); err != nil {
+			return fmt.Errorf("invalid HAR file %s: %w", harFile, err)
+		}
+	}
+	return nil
+}
+
+// viewTUIOptions collects the TUI flags shared by the root and view commands
+func viewTUIOptions() (TUIOptions, error) {
+	if maxEntrySizeMB <= 0 {
+		return TUIOptions{}, fmt.Errorf("--max-entry-size must be positive, got %d", maxEntrySizeMB)
+	}
+	if searchDebounce < 0 {
+		return TUIOptions{}, fmt.Errorf("--debounce cannot be negative, got %s", searchDebounce)
+	}
+	if startEntry < 0 || maxEntries < 0 {
+		return TUIOptions{}, fmt.Errorf("--start-entry and --max-entries cannot be negative")
+	}
+	if followFiles && (startEntry > 0 || maxEntries > 0) {
+		return TUIOptions{}, fmt.Errorf("--follow cannot be combined with --start-entry or --max-entries")
+	}
+	return TUIOptions{
+		InjectionReport: injectionReportFile,
+		WebSockets:      webSocketSupport,
+		DecodeBodies:    decodeBodies,
+		MaxEntrySize:    int64(maxEntrySizeMB) * 1024 * 1024,
+		NoColor:         noColor,
+		Follow:          followFiles,
+		SearchDebounce:  searchDebounce,
+		StartEntry:      startEntry,
+		MaxEntries:      maxEntries,
+		BodyDir:         bodyDir,
+	}, nil
+}
@@ -0,0 +1,320 @@
+package hargen
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pb33f/harific/motor/model"
+)
+
+// body content types a generated entry can carry, see GenerateOptions.BodyContentTypes
+const (
+	BodyJSON   = "json"
+	BodyHTML   = "html"
+	BodyXML    = "xml"
+	BodyText   = "text"
+	BodyBinary = "binary"
+)
+
+// bodyMIMETypes maps each body content type to the mime type written into the har
+var bodyMIMETypes = map[string]string{
+	BodyJSON:   "application/json",
+	BodyHTML:   "text/html; charset=utf-8",
+	BodyXML:    "application/xml",
+	BodyText:   "text/plain; charset=utf-8",
+	BodyBinary: "application/octet-stream",
+}
+
+// SetBodyContentTypes picks each entry's body type from the given list instead of always
+// generating JSON. binary only applies to responses; those entries keep a JSON request body.
+func (eg *EntryGenerator) SetBodyContentTypes(types []string) error {
+	eg.bodyTypes = nil
+	for _, t := range types {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if _, ok := bodyMIMETypes[t]; !ok {
+			return fmt.Errorf("unknown body content type: %s (expected json, html, xml, text or binary)", t)
+		}
+		eg.bodyTypes = append(eg.bodyTypes, t)
+	}
+	return nil
+}
+
+// randomBodyType picks the body type for an entry. the rng is only consulted when there is a
+// choice, so json-only output for a seed is unchanged by this option.
+func (eg *EntryGenerator) randomBodyType() string {
+	switch len(eg.bodyTypes) {
+	case 0:
+		return BodyJSON
+	case 1:
+		return eg.bodyTypes[0]
+	default:
+		return eg.bodyTypes[eg.rng.Intn(len(eg.bodyTypes))]
+	}
+}
+
+// bodyTypeOf recovers the body type from a generated mime type
+func bodyTypeOf(mimeType string) string {
+	for bodyType, mime := range bodyMIMETypes {
+		if mime == mimeType {
+			return bodyType
+		}
+	}
+	return BodyJSON
+}
+
+// generateTextBody creates a non-JSON body of the given type
+func (eg *EntryGenerator) generateTextBody(bodyType string) string {
+	switch bodyType {
+	case BodyHTML:
+		return eg.generateHTML()
+	case BodyXML:
+		return eg.generateXML()
+	case BodyBinary:
+		return eg.generateBinary()
+	default:
+		return eg.generatePlainText()
+	}
+}
+
+// injectIntoTextBody inserts term into existing non-JSON content, so several terms injected into
+// the same body all survive. it returns the new content and the path of the paragraph, element,
+// line or byte offset holding the term.
+func (eg *EntryGenerator) injectIntoTextBody(bodyType, content, term string) (string, string) {
+	switch bodyType {
+	case BodyHTML:
+		return eg.injectIntoHTML(content, term)
+	case BodyXML:
+		return eg.injectIntoXML(content, term)
+	case BodyBinary:
+		return eg.injectIntoBinary(content, term)
+	default:
+		return eg.injectIntoPlainText(content, term)
+	}
+}
+
+// textResponseBody wraps generated non-JSON content; binary content is stored base64 encoded
+func textResponseBody(bodyType, content string) model.BodyResponseType {
+	body := model.BodyResponseType{
+		Size:     int64(len(content)),
+		MIMEType: bodyMIMETypes[bodyType],
+		Content:  content,
+	}
+	if bodyType == BodyBinary {
+		body.Encoding = "base64"
+		body.Size = int64(base64.StdEncoding.DecodedLen(len(content)) - strings.Count(content, "="))
+	}
+	return body
+}
+
+func (eg *EntryGenerator) sentence(count int) string {
+	return strings.Join(eg.dict.RandomWords(count, eg.rng), " ")
+}
+
+// generateHTML creates a small page with a heading, a few paragraphs and a list of links
+func (eg *EntryGenerator) generateHTML() string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html><html><head><meta charset=\"utf-8\">")
+	fmt.Fprintf(&b, "<title>%s</title></head><body>", eg.sentence(3))
+	fmt.Fprintf(&b, "<h1 class=\"%s\">%s</h1>", eg.dict.RandomWord(eg.rng), eg.sentence(4))
+
+	for i := eg.rng.Intn(4) + 2; i > 0; i-- {
+		fmt.Fprintf(&b, "<p>%s</p>", eg.sentence(eg.rng.Intn(12)+8))
+	}
+
+	b.WriteString("<ul>")
+	for i := eg.rng.Intn(4) + 2; i > 0; i-- {
+		fmt.Fprintf(&b, "<li><a href=\"/%s\">%s</a></li>", eg.dict.RandomWord(eg.rng), eg.dict.RandomWord(eg.rng))
+	}
+	b.WriteString("</ul><br></body></html>")
+	return b.String()
+}
+
+// injectIntoHTML appends term to a random paragraph, adding a paragraph if there are none
+func (eg *EntryGenerator) injectIntoHTML(content, term string) (string, string) {
+	var closes []int
+	for offset := 0; ; {
+		open := strings.Index(content[offset:], "<p>")
+		if open < 0 {
+			break
+		}
+		end := strings.Index(content[offset+open:], "</p>")
+		if end < 0 {
+			break
+		}
+		closes = append(closes, offset+open+end)
+		offset += open + end + len("</p>")
+	}
+
+	if len(closes) == 0 {
+		at := strings.LastIndex(content, "</body>")
+		if at < 0 {
+			at = len(content)
+		}
+		return content[:at] + "<p>" + term + "</p>" + content[at:], "html.body.p[0]"
+	}
+
+	target := eg.rng.Intn(len(closes))
+	at := closes[target]
+	return content[:at] + " " + term + content[at:], fmt.Sprintf("html.body.p[%d]", target)
+}
+
+// xmlElement is a generated element with either text or children
+type xmlElement struct {
+	name     string
+	text     string
+	children []*xmlElement
+}
+
+// generateXML converts a generated JSON-like object into elements under a <response> root
+func (eg *EntryGenerator) generateXML() string {
+	root := &xmlElement{name: "response", children: xmlChildren(eg.jsonGen.GenerateObject(1))}
+	if len(root.children) == 0 {
+		root.children = []*xmlElement{{name: eg.dict.RandomWord(eg.rng), text: eg.dict.RandomWord(eg.rng)}}
+	}
+
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>`)
+	writeXMLElement(&b, root)
+	return b.String()
+}
+
+// xmlChildren turns an object into elements, sorting keys so output follows the seed
+func xmlChildren(obj map[string]interface{}) []*xmlElement {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var children []*xmlElement
+	for _, key := range keys {
+		switch v := obj[key].(type) {
+		case map[string]interface{}:
+			children = append(children, &xmlElement{name: key, children: xmlChildren(v)})
+		case []interface{}:
+			for _, item := range v {
+				if nested, ok := item.(map[string]interface{}); ok {
+					children = append(children, &xmlElement{name: key, children: xmlChildren(nested)})
+				} else {
+					children = append(children, &xmlElement{name: key, text: fmt.Sprint(item)})
+				}
+			}
+		default:
+			children = append(children, &xmlElement{name: key, text: fmt.Sprint(v)})
+		}
+	}
+	return children
+}
+
+func writeXMLElement(b *strings.Builder, el *xmlElement) {
+	if len(el.children) == 0 && el.text == "" {
+		fmt.Fprintf(b, "<%s/>", el.name)
+		return
+	}
+
+	fmt.Fprintf(b, "<%s>", el.name)
+	b.WriteString(xmlEscaper.Replace(el.text))
+	for _, child := range el.children {
+		writeXMLElement(b, child)
+	}
+	fmt.Fprintf(b, "</%s>", el.name)
+}
+
+var xmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// xmlTag matches the simple open, close and self-closing tags generateXML writes
+var xmlTag = regexp.MustCompile(`<(/?)([A-Za-z_][\w.-]*)(/?)>`)
+
+// xmlLeaf is the position just before a text-only element's closing tag, and its element path
+type xmlLeaf struct {
+	at   int
+	path string
+}
+
+// injectIntoXML appends term to the text of a random leaf element
+func (eg *EntryGenerator) injectIntoXML(content, term string) (string, string) {
+	var leaves []xmlLeaf
+	var stack []string
+	lastOpen := -1 // index just past the most recent open tag, while no child has followed it
+
+	for _, loc := range xmlTag.FindAllStringSubmatchIndex(content, -1) {
+		closing := loc[3] > loc[2]
+		selfClosing := loc[7] > loc[6]
+		name := content[loc[4]:loc[5]]
+
+		switch {
+		case selfClosing:
+			lastOpen = -1
+		case closing:
+			if lastOpen >= 0 && len(stack) > 0 && stack[len(stack)-1] == name {
+				leaves = append(leaves, xmlLeaf{at: loc[0], path: strings.Join(stack, ".")})
+			}
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			lastOpen = -1
+		default:
+			stack = append(stack, name)
+			lastOpen = loc[1]
+		}
+	}
+
+	if len(leaves) == 0 {
+		at := strings.LastIndex(content, "</")
+		if at < 0 {
+			return content + "<term>" + xmlEscaper.Replace(term) + "</term>", "term"
+		}
+		return content[:at] + "<term>" + xmlEscaper.Replace(term) + "</term>" + content[at:], "term"
+	}
+
+	leaf := leaves[eg.rng.Intn(len(leaves))]
+	return content[:leaf.at] + " " + xmlEscaper.Replace(term) + content[leaf.at:], leaf.path
+}
+
+// generatePlainText creates a few lines of words
+func (eg *EntryGenerator) generatePlainText() string {
+	lines := make([]string, eg.rng.Intn(6)+3)
+	for i := range lines {
+		lines[i] = eg.sentence(eg.rng.Intn(10) + 5)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// injectIntoPlainText inserts term between two words of a random line
+func (eg *EntryGenerator) injectIntoPlainText(content, term string) (string, string) {
+	lines := strings.Split(content, "\n")
+	target := eg.rng.Intn(len(lines))
+
+	words := strings.Fields(lines[target])
+	at := eg.rng.Intn(len(words) + 1)
+	words = append(words[:at], append([]string{term}, words[at:]...)...)
+	lines[target] = strings.Join(words, " ")
+
+	return strings.Join(lines, "\n"), fmt.Sprintf("line[%d]", target)
+}
+
+// generateBinary creates random bytes, base64 encoded as a har stores binary content
+func (eg *EntryGenerator) generateBinary() string {
+	data := make([]byte, eg.rng.Intn(3584)+512)
+	eg.rng.Read(data)
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// injectIntoBinary writes term into the raw bytes, so it only matches once the body is decoded
+func (eg *EntryGenerator) injectIntoBinary(content, term string) (string, string) {
+	data, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		data = nil
+	}
+	if len(data) <= len(term) {
+		data = append(data, term...)
+		return base64.StdEncoding.EncodeToString(data), fmt.Sprintf("bytes[%d]", len(data)-len(term))
+	}
+
+	offset := eg.rng.Intn(len(data) - len(term))
+	copy(data[offset:], term)
+	return base64.StdEncoding.EncodeToString(data), fmt.Sprintf("bytes[%d]", offset)
+}
@@ -34,8 +34,31 @@ type Dictionary struct {
 	words []string
 }
 
-// LoadDictionary loads words from a dictionary file
+// NewDictionary creates a dictionary from a word list, keeping the words LoadDictionary would
+// accept from a file (alphabetic, 3-15 characters) lowercased
+func NewDictionary(words []string) (*Dictionary, error) {
+	var valid []string
+	for _, word := range words {
+		word = strings.TrimSpace(word)
+		if isDictionaryWord(word) {
+			valid = append(valid, strings.ToLower(word))
+		}
+	}
+
+	if len(valid) == 0 {
+		return nil, fmt.Errorf("no valid words found in word list")
+	}
+
+	return &Dictionary{words: valid}, nil
+}
+
+// LoadDictionary loads words from a dictionary file. an empty path, a missing file or a file
+// without usable words falls back to the built-in word list, so generation never needs host files.
 func LoadDictionary(path string) (*Dictionary, error) {
+	if path == "" {
+		return &Dictionary{words: fallbackWords}, nil
+	}
+
 	file, err := os.Open(path)
 	if err != nil {
 		// fallback to built-in word list if file doesn't exist
@@ -52,8 +75,7 @@ func LoadDictionary(path string) (*Dictionary, error) {
 	for scanner.Scan() {
 		word := strings.TrimSpace(scanner.Text())
 
-		// filter to reasonable length (3-15 chars) and alpha only
-		if len(word) >= 3 && len(word) <= 15 && isAlpha(word) {
+		if isDictionaryWord(word) {
 			words = append(words, strings.ToLower(word))
 		}
 	}
@@ -63,12 +85,17 @@ func LoadDictionary(path string) (*Dictionary, error) {
 	}
 
 	if len(words) == 0 {
-		return nil, fmt.Errorf("no valid words found in dictionary")
+		return &Dictionary{words: fallbackWords}, nil
 	}
 
 	return &Dictionary{words: words}, nil
 }
 
+// isDictionaryWord filters to reasonable length (3-15 chars) and alpha only
+func isDictionaryWord(word string) bool {
+	return len(word) >= 3 && len(word) <= 15 && isAlpha(word)
+}
+
 // isAlpha checks if a string contains only alphabetic characters
 func isAlpha(s string) bool {
 	for _, r := range s {
@@ -0,0 +1,93 @@
+package hargen
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDictionary(t *testing.T) {
+	tests := []struct {
+		name    string
+		words   []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "lowercased and trimmed", words: []string{"Alpha", " beta ", "GAMMA"}, want: []string{"alpha", "beta", "gamma"}},
+		{name: "too short or long", words: []string{"ab", "abc", "abcdefghijklmno", "abcdefghijklmnop"}, want: []string{"abc", "abcdefghijklmno"}},
+		{name: "not alphabetic", words: []string{"abc1", "a-b-c", "café", "word"}, want: []string{"word"}},
+		{name: "nothing usable", words: []string{"", "ab", "12345"}, wantErr: true},
+		{name: "empty", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dict, err := NewDictionary(tt.words)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, dict.words)
+			assert.Equal(t, len(tt.want), dict.Size())
+		})
+	}
+}
+
+func TestLoadDictionary(t *testing.T) {
+	dir := t.TempDir()
+	words := filepath.Join(dir, "words")
+	require.NoError(t, os.WriteFile(words, []byte("Apple\nbanana\nx\n42\ncherry\n"), 0644))
+	unusable := filepath.Join(dir, "unusable")
+	require.NoError(t, os.WriteFile(unusable, []byte("a\nb\n123\n"), 0644))
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{name: "file", path: words, want: []string{"apple", "banana", "cherry"}},
+		{name: "no path", path: "", want: fallbackWords},
+		{name: "missing file", path: filepath.Join(dir, "missing"), want: fallbackWords},
+		{name: "no usable words", path: unusable, want: fallbackWords},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dict, err := LoadDictionary(tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, dict.words)
+		})
+	}
+
+	_, err := LoadDictionary(dir) // a directory can be opened but not read as words
+	assert.Error(t, err)
+}
+
+func TestDictionary_RandomWords(t *testing.T) {
+	dict, err := NewDictionary([]string{"alpha", "beta", "gamma"})
+	require.NoError(t, err)
+
+	words := dict.RandomWords(50, rand.New(rand.NewSource(3)))
+	require.Len(t, words, 50)
+	for _, word := range words {
+		assert.Contains(t, dict.words, word)
+	}
+	assert.Equal(t, words, dict.RandomWords(50, rand.New(rand.NewSource(3))), "same seed, same words")
+	assert.Nil(t, dict.RandomWords(0, rand.New(rand.NewSource(3))))
+
+	assert.Equal(t, "word", (&Dictionary{}).RandomWord(rand.New(rand.NewSource(3))))
+}
+
+func TestGenerateInMemory_Words(t *testing.T) {
+	har, _, err := GenerateInMemory(GenerateOptions{EntryCount: 5, Words: []string{"zebra", "zz"}, Seed: 1})
+	require.NoError(t, err)
+	assert.Len(t, har.Log.Entries, 5)
+
+	_, _, err = GenerateInMemory(GenerateOptions{EntryCount: 5, Words: []string{"zz", "42"}, Seed: 1})
+	assert.ErrorContains(t, err, "no valid words")
+}
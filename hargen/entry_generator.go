@@ -3,7 +3,12 @@ package hargen
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pb33f/harific/motor/model"
@@ -11,10 +16,23 @@ import (
 
 // EntryGenerator creates HAR entries with optional term injection
 type EntryGenerator struct {
-	dict    *Dictionary
-	jsonGen *JSONGenerator
-	rng     *rand.Rand
-	fatMode bool
+	dict        *Dictionary
+	jsonGen     *JSONGenerator
+	rng         *rand.Rand
+	fatMode     bool
+	timings     bool             // fill in Timings, phase by phase
+	statuses    []weightedStatus // weighted status codes (nil = uniform over defaultStatuses)
+	statusTotal float64          // sum of all status weights
+	bodyTypes   []string         // body content types to pick from per entry (nil = json only)
+}
+
+// defaultStatuses are sampled uniformly when no status distribution is set
+var defaultStatuses = []int{200, 201, 204, 301, 302, 400, 401, 403, 404, 500, 502, 503}
+
+// weightedStatus is a status code with the running total of weights up to and including it
+type weightedStatus struct {
+	code       int
+	cumulative float64
 }
 
 // NewEntryGenerator creates a new entry generator
@@ -32,29 +50,161 @@ func (eg *EntryGenerator) SetFatMode(enabled bool) {
 	eg.fatMode = enabled
 }
 
+// SetTimings makes every entry carry Timings whose phases add up to its Time
+func (eg *EntryGenerator) SetTimings(enabled bool) {
+	eg.timings = enabled
+}
+
+// SetStatusDistribution makes response status codes follow the given weights, e.g.
+// {200: 95, 500: 5}. weights are relative and need not sum to 1; an empty distribution
+// restores uniform sampling of the default codes.
+func (eg *EntryGenerator) SetStatusDistribution(distribution map[int]float64) error {
+	if err := validateStatusDistribution(distribution); err != nil {
+		return err
+	}
+
+	eg.statuses = nil
+	eg.statusTotal = 0
+
+	// map iteration order is random, sort so a seed always produces the same entries
+	codes := make([]int, 0, len(distribution))
+	for code := range distribution {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+
+	for _, code := range codes {
+		weight := distribution[code]
+		if weight == 0 {
+			continue
+		}
+		eg.statusTotal += weight
+		eg.statuses = append(eg.statuses, weightedStatus{code: code, cumulative: eg.statusTotal})
+	}
+
+	return nil
+}
+
+// ParseStatusDistribution parses "code=weight" pairs such as "200=95" and "500=5"
+func ParseStatusDistribution(specs []string) (map[int]float64, error) {
+	distribution := make(map[int]float64, len(specs))
+	for _, spec := range specs {
+		codeText, weightText, ok := strings.Cut(spec, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid status weight %q (expected code=weight)", spec)
+		}
+		code, err := strconv.Atoi(strings.TrimSpace(codeText))
+		if err != nil {
+			return nil, fmt.Errorf("invalid status code in %q: %w", spec, err)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(weightText), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight in %q: %w", spec, err)
+		}
+		distribution[code] += weight
+	}
+
+	if err := validateStatusDistribution(distribution); err != nil {
+		return nil, err
+	}
+	return distribution, nil
+}
+
+func validateStatusDistribution(distribution map[int]float64) error {
+	if len(distribution) == 0 {
+		return nil
+	}
+
+	var total float64
+	for code, weight := range distribution {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("invalid status code %d in distribution (must be 100-599)", code)
+		}
+		if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return fmt.Errorf("invalid weight %v for status %d (must be a non-negative number)", weight, code)
+		}
+		total += weight
+	}
+	if total == 0 {
+		return fmt.Errorf("status distribution needs at least one positive weight")
+	}
+	return nil
+}
+
 // GenerateEntry creates a single HAR entry with optional term injection
 func (eg *EntryGenerator) GenerateEntry(index int, injectionRequests []injectionRequest, allowedLocations []InjectionLocation) (*model.Entry, []InjectedTerm) {
+	bodyType := eg.randomBodyType()
 	entry := &model.Entry{
 		Start:      time.Now().Add(-time.Duration(index) * time.Second).Format(time.RFC3339),
 		Time:       float64(eg.rng.Intn(1000)) + eg.rng.Float64(),
-		Request:    eg.generateRequest(),
-		Response:   eg.generateResponse(),
+		Request:    eg.generateRequest(bodyType),
+		Response:   eg.generateResponse(bodyType),
 		ServerIP:   eg.generateIP(),
 		Connection: fmt.Sprintf("%d", eg.rng.Intn(65535)),
 	}
 
+	if eg.timings {
+		entry.Timings = eg.generateTimings(entry.Time)
+	}
+
 	var injected []InjectedTerm
 
-	// inject terms into specified locations
+	// inject terms into specified locations. a body or url that already holds a term is
+	// extended rather than regenerated, so every term injected into an entry survives
+	seen := make(map[InjectionLocation]bool)
 	for _, req := range injectionRequests {
-		result := eg.injectIntoEntry(entry, req.term, req.location, index)
+		result := eg.injectIntoEntry(entry, req.term, req.location, index, seen[req.location])
 		injected = append(injected, result)
+		seen[req.location] = true
 	}
 
 	return entry, injected
 }
 
-func (eg *EntryGenerator) generateRequest() model.Request {
+// generateTimings splits total milliseconds into the phases of a request. about a third of
+// requests reuse a kept-alive connection, so dns, connect and ssl are -1 (not applicable) for
+// them; ssl is part of connect, as har defines it. wait takes whatever the other phases leave,
+// so the phases add up to total to within rounding.
+func (eg *EntryGenerator) generateTimings(total float64) model.Timings {
+	between := func(low, high float64) float64 {
+		return low + eg.rng.Float64()*(high-low)
+	}
+
+	// relative share of each phase, wait being the server's time and usually the largest
+	blocked := between(0, 0.1)
+	dns, connect := -1.0, -1.0
+	if eg.rng.Float64() >= 0.3 {
+		dns = between(0.02, 0.1)
+		connect = between(0.05, 0.2)
+	}
+	send := between(0.005, 0.02)
+	wait := between(0.3, 0.7)
+	receive := between(0.05, 0.3)
+
+	sum := blocked + max(dns, 0) + max(connect, 0) + send + wait + receive
+	share := func(weight float64) float64 {
+		if weight < 0 {
+			return -1
+		}
+		return math.Round(total*weight/sum*1000) / 1000
+	}
+
+	timings := model.Timings{
+		Blocked: share(blocked),
+		DNS:     share(dns),
+		Connect: share(connect),
+		Send:    share(send),
+		Receive: share(receive),
+		SSL:     -1,
+	}
+	if timings.Connect > 0 {
+		timings.SSL = math.Round(timings.Connect*between(0.4, 0.8)*1000) / 1000
+	}
+	timings.Wait = math.Round((total-timings.Blocked-max(timings.DNS, 0)-max(timings.Connect, 0)-timings.Send-timings.Receive)*1000) / 1000
+	return timings
+}
+
+func (eg *EntryGenerator) generateRequest(bodyType string) model.Request {
 	return model.Request{
 		Method:      eg.randomMethod(),
 		URL:         eg.generateURL(),
@@ -62,13 +212,13 @@ func (eg *EntryGenerator) generateRequest() model.Request {
 		Headers:     eg.generateHeaders(eg.rng.Intn(8) + 3),
 		QueryParams: eg.generateQueryParams(eg.rng.Intn(5)),
 		Cookies:     eg.generateCookies(eg.rng.Intn(3)),
-		Body:        eg.generateRequestBody(),
+		Body:        eg.generateRequestBody(bodyType),
 		HeadersSize: eg.rng.Intn(500) + 200,
-		BodySize:    eg.rng.Intn(2000) + 100,
+		BodySize:    int64(eg.rng.Intn(2000) + 100),
 	}
 }
 
-func (eg *EntryGenerator) generateResponse() model.Response {
+func (eg *EntryGenerator) generateResponse(bodyType string) model.Response {
 	status := eg.randomStatus()
 	return model.Response{
 		StatusCode:  status,
@@ -76,9 +226,9 @@ func (eg *EntryGenerator) generateResponse() model.Response {
 		HTTPVersion: "HTTP/1.1",
 		Headers:     eg.generateHeaders(eg.rng.Intn(10) + 5),
 		Cookies:     eg.generateCookies(eg.rng.Intn(2)),
-		Body:        eg.generateResponseBody(),
+		Body:        eg.generateResponseBody(bodyType),
 		HeadersSize: eg.rng.Intn(700) + 300,
-		BodySize:    eg.rng.Intn(5000) + 500,
+		BodySize:    int64(eg.rng.Intn(5000) + 500),
 	}
 }
 
@@ -88,8 +238,18 @@ func (eg *EntryGenerator) randomMethod() string {
 }
 
 func (eg *EntryGenerator) randomStatus() int {
-	statuses := []int{200, 201, 204, 301, 302, 400, 401, 403, 404, 500, 502, 503}
-	return statuses[eg.rng.Intn(len(statuses))]
+	if len(eg.statuses) == 0 {
+		return defaultStatuses[eg.rng.Intn(len(defaultStatuses))]
+	}
+
+	target := eg.rng.Float64() * eg.statusTotal
+	i := sort.Search(len(eg.statuses), func(i int) bool {
+		return eg.statuses[i].cumulative > target
+	})
+	if i == len(eg.statuses) {
+		i-- // guard against float rounding at the top of the range
+	}
+	return eg.statuses[i].code
 }
 
 func (eg *EntryGenerator) statusText(code int) string {
@@ -110,6 +270,10 @@ func (eg *EntryGenerator) statusText(code int) string {
 	if text, ok := texts[code]; ok {
 		return text
 	}
+	// codes from a custom status distribution
+	if text := http.StatusText(code); text != "" {
+		return text
+	}
 	return "Unknown"
 }
 
@@ -231,7 +395,15 @@ func (eg *EntryGenerator) generateCookies(count int) []model.Cookie {
 	return cookies
 }
 
-func (eg *EntryGenerator) generateRequestBody() model.BodyType {
+func (eg *EntryGenerator) generateRequestBody(bodyType string) model.BodyType {
+	if bodyType != BodyJSON && bodyType != BodyBinary {
+		content := eg.generateTextBody(bodyType)
+		return model.BodyType{
+			MIMEType: bodyMIMETypes[bodyType],
+			Content:  content,
+		}
+	}
+
 	obj := eg.jsonGen.GenerateObject(0)
 	content, _ := json.Marshal(obj)
 	return model.BodyType{
@@ -240,7 +412,11 @@ func (eg *EntryGenerator) generateRequestBody() model.BodyType {
 	}
 }
 
-func (eg *EntryGenerator) generateResponseBody() model.BodyResponseType {
+func (eg *EntryGenerator) generateResponseBody(bodyType string) model.BodyResponseType {
+	if bodyType != BodyJSON {
+		return textResponseBody(bodyType, eg.generateTextBody(bodyType))
+	}
+
 	var obj map[string]interface{}
 
 	if eg.fatMode {
@@ -251,13 +427,13 @@ func (eg *EntryGenerator) generateResponseBody() model.BodyResponseType {
 
 	content, _ := json.Marshal(obj)
 	return model.BodyResponseType{
-		Size:     len(content),
+		Size:     int64(len(content)),
 		MIMEType: "application/json",
 		Content:  string(content),
 	}
 }
 
-func (eg *EntryGenerator) injectIntoEntry(entry *model.Entry, term string, location InjectionLocation, entryIndex int) InjectedTerm {
+func (eg *EntryGenerator) injectIntoEntry(entry *model.Entry, term string, location InjectionLocation, entryIndex int, extend bool) InjectedTerm {
 	result := InjectedTerm{
 		Term:       term,
 		Location:   location,
@@ -266,16 +442,26 @@ func (eg *EntryGenerator) injectIntoEntry(entry *model.Entry, term string, locat
 
 	switch location {
 	case RequestBody:
-		obj, path := eg.jsonGen.InjectTermIntoNewObject(term)
-		content, _ := json.Marshal(obj)
-		entry.Request.Body.Content = string(content)
+		if bodyType := bodyTypeOf(entry.Request.Body.MIMEType); bodyType != BodyJSON {
+			content, path := eg.injectIntoTextBody(bodyType, entry.Request.Body.Content, term)
+			entry.Request.Body.Content = content
+			result.FieldPath = path
+			break
+		}
+		content, path := eg.injectIntoJSONBody(entry.Request.Body.Content, term, extend)
+		entry.Request.Body.Content = content
 		result.FieldPath = path
 
 	case ResponseBody:
-		obj, path := eg.jsonGen.InjectTermIntoNewObject(term)
-		content, _ := json.Marshal(obj)
-		entry.Response.Body.Content = string(content)
-		entry.Response.Body.Size = len(content)
+		if bodyType := bodyTypeOf(entry.Response.Body.MIMEType); bodyType != BodyJSON {
+			content, path := eg.injectIntoTextBody(bodyType, entry.Response.Body.Content, term)
+			entry.Response.Body = textResponseBody(bodyType, content)
+			result.FieldPath = path
+			break
+		}
+		content, path := eg.injectIntoJSONBody(entry.Response.Body.Content, term, extend)
+		entry.Response.Body.Content = content
+		entry.Response.Body.Size = int64(len(content))
 		result.FieldPath = path
 
 	case RequestHeader:
@@ -312,9 +498,37 @@ func (eg *EntryGenerator) injectIntoEntry(entry *model.Entry, term string, locat
 
 	case URL:
 		// inject term as a path segment
-		entry.Request.URL = "https://api.example.com/" + term + "/" + eg.dict.RandomWord(eg.rng)
+		if extend {
+			entry.Request.URL += "/" + term
+		} else {
+			entry.Request.URL = "https://api.example.com/" + term + "/" + eg.dict.RandomWord(eg.rng)
+		}
 		result.FieldPath = "path"
 	}
 
 	return result
 }
+
+// injectIntoJSONBody returns a new JSON body holding term and the path to it. when extend is set
+// the existing body already holds an injected term, so term is added under a fresh top level key
+// instead of replacing the body.
+func (eg *EntryGenerator) injectIntoJSONBody(content, term string, extend bool) (string, string) {
+	var obj map[string]interface{}
+	if !extend || json.Unmarshal([]byte(content), &obj) != nil || obj == nil {
+		obj, path := eg.jsonGen.InjectTermIntoNewObject(term)
+		newContent, _ := json.Marshal(obj)
+		return string(newContent), path
+	}
+
+	key := eg.dict.RandomWord(eg.rng)
+	for suffix := 2; ; suffix++ {
+		if _, taken := obj[key]; !taken {
+			break
+		}
+		key = fmt.Sprintf("%s%d", eg.dict.RandomWord(eg.rng), suffix)
+	}
+	obj[key] = term
+
+	newContent, _ := json.Marshal(obj)
+	return string(newContent), key
+}
@@ -0,0 +1,212 @@
+package hargen
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// generateStatuses generates count entries from the built-in words and returns their statuses
+func generateStatuses(t *testing.T, seed int64, count int, distribution map[int]float64) []int {
+	t.Helper()
+	har, _, err := GenerateInMemory(GenerateOptions{
+		EntryCount:         count,
+		Words:              fallbackWords,
+		Seed:               seed,
+		StatusDistribution: distribution,
+	})
+	require.NoError(t, err)
+
+	statuses := make([]int, len(har.Log.Entries))
+	for i, entry := range har.Log.Entries {
+		statuses[i] = entry.Response.StatusCode
+	}
+	return statuses
+}
+
+func TestStatusDistribution_Validation(t *testing.T) {
+	tests := []struct {
+		name         string
+		distribution map[int]float64
+		wantErr      string
+	}{
+		{name: "unset"},
+		{name: "weights", distribution: map[int]float64{200: 95, 500: 5}},
+		{name: "zero weight alongside a positive one", distribution: map[int]float64{200: 1, 404: 0}},
+		{name: "negative weight", distribution: map[int]float64{200: 1, 500: -1}, wantErr: "non-negative"},
+		{name: "nan weight", distribution: map[int]float64{200: math.NaN()}, wantErr: "non-negative"},
+		{name: "infinite weight", distribution: map[int]float64{200: math.Inf(1)}, wantErr: "non-negative"},
+		{name: "all zero", distribution: map[int]float64{200: 0, 500: 0}, wantErr: "at least one positive weight"},
+		{name: "code below 100", distribution: map[int]float64{99: 1}, wantErr: "must be 100-599"},
+		{name: "code above 599", distribution: map[int]float64{600: 1}, wantErr: "must be 100-599"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := GenerateInMemory(GenerateOptions{
+				EntryCount:         1,
+				Words:              fallbackWords,
+				Seed:               1,
+				StatusDistribution: tt.distribution,
+			})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestStatusDistribution_Sampling(t *testing.T) {
+	tests := []struct {
+		name         string
+		distribution map[int]float64
+		allowed      []int
+	}{
+		{name: "uniform default", allowed: defaultStatuses},
+		{name: "weighted", distribution: map[int]float64{200: 95, 500: 5}, allowed: []int{200, 500}},
+		{name: "zero weight never sampled", distribution: map[int]float64{200: 1, 404: 0}, allowed: []int{200}},
+		{name: "single code", distribution: map[int]float64{418: 1}, allowed: []int{418}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statuses := generateStatuses(t, 42, 500, tt.distribution)
+			for _, status := range statuses {
+				assert.Contains(t, tt.allowed, status)
+			}
+		})
+	}
+
+	statuses := generateStatuses(t, 42, 2000, map[int]float64{200: 95, 500: 5})
+	errors := 0
+	for _, status := range statuses {
+		if status == 500 {
+			errors++
+		}
+	}
+	assert.InDelta(t, 0.05, float64(errors)/float64(len(statuses)), 0.02, "about one entry in twenty is a 500")
+}
+
+func TestStatusDistribution_SameSeed(t *testing.T) {
+	distribution := map[int]float64{200: 50, 301: 10, 404: 20, 500: 15, 503: 5}
+
+	first := generateStatuses(t, 7, 200, distribution)
+	// the map is ranged in a different order each time, which must not change the draw
+	for range 5 {
+		assert.Equal(t, first, generateStatuses(t, 7, 200, distribution))
+	}
+	assert.NotEqual(t, first, generateStatuses(t, 8, 200, distribution))
+}
+
+func TestStatusText(t *testing.T) {
+	eg := &EntryGenerator{}
+	tests := []struct {
+		code int
+		want string
+	}{
+		{200, "OK"},
+		{503, "Service Unavailable"},
+		{418, "I'm a teapot"}, // from a custom distribution, not one of the defaults
+		{299, "Unknown"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, eg.statusText(tt.code), "status %d", tt.code)
+	}
+}
+
+func TestParseStatusDistribution(t *testing.T) {
+	tests := []struct {
+		name    string
+		specs   []string
+		want    map[int]float64
+		wantErr string
+	}{
+		{name: "pairs", specs: []string{"200=95", " 500 = 5 "}, want: map[int]float64{200: 95, 500: 5}},
+		{name: "repeated code adds up", specs: []string{"200=1", "200=2"}, want: map[int]float64{200: 3}},
+		{name: "missing weight", specs: []string{"200"}, wantErr: "expected code=weight"},
+		{name: "bad code", specs: []string{"ok=1"}, wantErr: "invalid status code"},
+		{name: "bad weight", specs: []string{"200=lots"}, wantErr: "invalid weight"},
+		{name: "negative weight", specs: []string{"200=-1"}, wantErr: "non-negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStatusDistribution(tt.specs)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGenerateEntry_Timings(t *testing.T) {
+	tests := []struct {
+		name        string
+		withTimings bool
+	}{
+		{name: "with timings", withTimings: true},
+		{name: "without timings"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			har, _, err := GenerateInMemory(GenerateOptions{
+				EntryCount:  300,
+				Words:       fallbackWords,
+				Seed:        11,
+				WithTimings: tt.withTimings,
+			})
+			require.NoError(t, err)
+
+			if !tt.withTimings {
+				for _, entry := range har.Log.Entries {
+					assert.Zero(t, entry.Timings)
+				}
+				return
+			}
+
+			reused := 0
+			for i, entry := range har.Log.Entries {
+				timings := entry.Timings
+				// dns and connect are -1 when a connection is reused and so left out of the sum;
+				// ssl is part of connect
+				sum := timings.Blocked + max(timings.DNS, 0) + max(timings.Connect, 0) + timings.Send + timings.Wait + timings.Receive
+				assert.InDelta(t, entry.Time, sum, 0.001, "entry %d", i)
+
+				assert.GreaterOrEqual(t, timings.Blocked, 0.0, "entry %d", i)
+				assert.GreaterOrEqual(t, timings.Send, 0.0, "entry %d", i)
+				assert.GreaterOrEqual(t, timings.Wait, 0.0, "entry %d", i)
+				assert.GreaterOrEqual(t, timings.Receive, 0.0, "entry %d", i)
+				if timings.Connect < 0 {
+					reused++
+					assert.Equal(t, -1.0, timings.DNS, "entry %d", i)
+					assert.Equal(t, -1.0, timings.SSL, "entry %d", i)
+				} else {
+					assert.LessOrEqual(t, timings.SSL, timings.Connect, "entry %d", i)
+				}
+			}
+			assert.InDelta(t, 0.3, float64(reused)/float64(len(har.Log.Entries)), 0.1, "about a third of connections are reused")
+		})
+	}
+
+	timingsOf := func(seed int64) []float64 {
+		har, _, err := GenerateInMemory(GenerateOptions{EntryCount: 20, Words: fallbackWords, Seed: seed, WithTimings: true})
+		require.NoError(t, err)
+		var phases []float64
+		for _, entry := range har.Log.Entries {
+			timings := entry.Timings
+			phases = append(phases, entry.Time, timings.Blocked, timings.DNS, timings.Connect, timings.SSL, timings.Send, timings.Wait, timings.Receive)
+		}
+		return phases
+	}
+	assert.Equal(t, timingsOf(5), timingsOf(5), "same seed, same timings")
+}
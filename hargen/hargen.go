@@ -1,11 +1,14 @@
 package hargen
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/pb33f/harific/motor/model"
@@ -46,12 +49,58 @@ func (il InjectionLocation) String() string {
 	}
 }
 
+// ParseInjectionLocation converts a location name (as produced by String) back into an InjectionLocation
+func ParseInjectionLocation(name string) (InjectionLocation, error) {
+	switch strings.ToLower(name) {
+	case "request.body", "requestbody":
+		return RequestBody, nil
+	case "response.body", "responsebody":
+		return ResponseBody, nil
+	case "request.header", "requestheader":
+		return RequestHeader, nil
+	case "response.header", "responseheader":
+		return ResponseHeader, nil
+	case "query.param", "queryparam":
+		return QueryParam, nil
+	case "cookie":
+		return Cookie, nil
+	case "url":
+		return URL, nil
+	default:
+		return 0, fmt.Errorf("unknown injection location: %s", name)
+	}
+}
+
+// MarshalText encodes the location by name so injection reports are human-readable
+func (il InjectionLocation) MarshalText() ([]byte, error) {
+	return []byte(il.String()), nil
+}
+
+// UnmarshalText decodes a location name written by MarshalText
+func (il *InjectionLocation) UnmarshalText(text []byte) error {
+	loc, err := ParseInjectionLocation(string(text))
+	if err != nil {
+		return err
+	}
+	*il = loc
+	return nil
+}
+
 // InjectedTerm represents a term that was injected and where
 type InjectedTerm struct {
-	Term       string            // the injected word/phrase
-	Location   InjectionLocation // where it was injected
-	EntryIndex int               // which har entry contains it
-	FieldPath  string            // for bodies: json path like "user.name"
+	Term       string            `json:"term"`                // the injected word/phrase
+	Location   InjectionLocation `json:"location"`            // where it was injected
+	EntryIndex int               `json:"entryIndex"`          // which har entry contains it
+	FieldPath  string            `json:"fieldPath,omitempty"` // for bodies: json path like "user.name"
+	File       string            `json:"file,omitempty"`      // split output: the part holding the entry, EntryIndex counting within it
+}
+
+// InjectionSpec places a term at an exact entry and location, for fixtures that need a term
+// in a known place rather than a random one
+type InjectionSpec struct {
+	Term       string
+	EntryIndex int
+	Location   InjectionLocation
 }
 
 // GenerateOptions configures har generation
@@ -64,6 +113,14 @@ type GenerateOptions struct {
 	MaxJSONNodes       int                   // max nodes per level (default: 10)
 	Seed               int64                 // random seed for reproducibility (0 = use time)
 	FatMode            bool                  // generate ~100KB per entry (huge JSON + base64 blobs)
+	FatTargetBytes     int                   // approximate response body size of a fat mode entry (default: DefaultFatTargetBytes)
+	StatusDistribution map[int]float64       // relative weight of each response status (default: uniform over common codes)
+	BodyContentTypes   []string              // body types picked per entry: json, html, xml, text, binary (default: json)
+	Words              []string              // word list to generate from instead of loading DictionaryPath
+	InjectionPlan      []InjectionSpec       // inject exactly these terms, replacing the random InjectTerms distribution
+	WithTimings        bool                  // fill in each entry's Timings (dns, connect, ssl, send, wait, receive) to add up to its Time
+	OutputFormat       OutputFormat          // how the har is written (default: FormatHAR)
+	SplitFiles         int                   // FormatSplit only: number of files to write (default: DefaultSplitFiles)
 }
 
 // DefaultGenerateOptions provides sensible defaults
@@ -73,6 +130,7 @@ var DefaultGenerateOptions = GenerateOptions{
 	MaxJSONDepth:   3,
 	MaxJSONNodes:   10,
 	Seed:           0,
+	WithTimings:    true,
 }
 
 // GenerateResult contains the generated har and injection metadata
@@ -82,45 +140,61 @@ type GenerateResult struct {
 	TotalEntries  int            // number of entries generated
 }
 
-// Generate creates a har file with injected search terms
+// Generate creates a har file with injected search terms. split output needs a path to name its
+// parts after, see GenerateToFile.
 func Generate(opts GenerateOptions) (*GenerateResult, error) {
-	// apply defaults
-	if opts.DictionaryPath == "" {
-		opts.DictionaryPath = DefaultGenerateOptions.DictionaryPath
+	if opts.OutputFormat == FormatSplit {
+		return nil, fmt.Errorf("split output needs a file path")
 	}
-	if opts.MaxJSONDepth == 0 {
-		opts.MaxJSONDepth = DefaultGenerateOptions.MaxJSONDepth
+
+	// create temp file
+	pattern := "hargen-*.har"
+	if opts.OutputFormat == FormatNDJSON {
+		pattern = "hargen-*.ndjson"
 	}
-	if opts.MaxJSONNodes == 0 {
-		opts.MaxJSONNodes = DefaultGenerateOptions.MaxJSONNodes
+	tmpFile, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
 	}
+	defer tmpFile.Close()
 
-	// generate in memory first
-	har, injected, err := GenerateInMemory(opts)
+	injected, err := GenerateTo(tmpFile, opts)
 	if err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
 		return nil, err
 	}
 
-	// create temp file
-	tmpFile, err := os.CreateTemp("", "hargen-*.har")
+	return &GenerateResult{
+		HARFilePath:   tmpFile.Name(),
+		InjectedTerms: injected,
+		TotalEntries:  max(opts.EntryCount, 0),
+	}, nil
+}
+
+// GenerateTo generates a har and writes it as indented json to w, e.g. stdout or a pipe, or as
+// one entry per line with FormatNDJSON. split output needs a path, see GenerateToFile.
+func GenerateTo(w io.Writer, opts GenerateOptions) ([]InjectedTerm, error) {
+	if opts.OutputFormat == FormatSplit {
+		return nil, fmt.Errorf("split output needs a file path")
+	}
+
+	har, injected, err := GenerateInMemory(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create temp file: %w", err)
+		return nil, err
 	}
-	defer tmpFile.Close()
 
-	// write har to file
-	encoder := json.NewEncoder(tmpFile)
+	if opts.OutputFormat == FormatNDJSON {
+		return injected, writeNDJSON(w, har)
+	}
+
+	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
 	if err := encoder.Encode(har); err != nil {
-		os.Remove(tmpFile.Name())
 		return nil, fmt.Errorf("failed to write har: %w", err)
 	}
 
-	return &GenerateResult{
-		HARFilePath:   tmpFile.Name(),
-		InjectedTerms: injected,
-		TotalEntries:  len(har.Log.Entries),
-	}, nil
+	return injected, nil
 }
 
 // GenerateInMemory creates a har structure without writing to disk
@@ -136,6 +210,13 @@ func GenerateInMemory(opts GenerateOptions) (*model.HAR, []InjectedTerm, error)
 		opts.MaxJSONNodes = DefaultGenerateOptions.MaxJSONNodes
 	}
 
+	if opts.FatTargetBytes < 0 {
+		return nil, nil, fmt.Errorf("fat target must not be negative, got %d", opts.FatTargetBytes)
+	}
+	if opts.SplitFiles < 0 {
+		return nil, nil, fmt.Errorf("split files must not be negative, got %d", opts.SplitFiles)
+	}
+
 	// create local rng (avoid mutating global rand)
 	var rng *rand.Rand
 	if opts.Seed != 0 {
@@ -144,8 +225,14 @@ func GenerateInMemory(opts GenerateOptions) (*model.HAR, []InjectedTerm, error)
 		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
 	}
 
-	// load dictionary
-	dict, err := LoadDictionary(opts.DictionaryPath)
+	// load dictionary, an inline word list skips the file entirely
+	var dict *Dictionary
+	var err error
+	if len(opts.Words) > 0 {
+		dict, err = NewDictionary(opts.Words)
+	} else {
+		dict, err = LoadDictionary(opts.DictionaryPath)
+	}
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load dictionary: %w", err)
 	}
@@ -153,11 +240,30 @@ func GenerateInMemory(opts GenerateOptions) (*model.HAR, []InjectedTerm, error)
 	// create generators with local rng
 	jsonGen := NewJSONGenerator(dict, opts.MaxJSONDepth, opts.MaxJSONNodes, rng)
 	jsonGen.SetFatMode(opts.FatMode)
+	jsonGen.SetFatTarget(opts.FatTargetBytes)
 	entryGen := NewEntryGenerator(dict, jsonGen, rng)
 	entryGen.SetFatMode(opts.FatMode)
+	entryGen.SetTimings(opts.WithTimings)
+	if err := entryGen.SetStatusDistribution(opts.StatusDistribution); err != nil {
+		return nil, nil, err
+	}
+	if err := entryGen.SetBodyContentTypes(opts.BodyContentTypes); err != nil {
+		return nil, nil, err
+	}
 
-	// distribute injection terms across entries
-	injectionPlan := createInjectionPlan(opts.InjectTerms, opts.EntryCount, opts.InjectionLocations, rng)
+	// distribute injection terms across entries, or follow the explicit plan
+	var injectionPlan map[int][]injectionRequest
+	if len(opts.InjectionPlan) > 0 {
+		if len(opts.InjectTerms) > 0 {
+			return nil, nil, fmt.Errorf("InjectTerms and InjectionPlan cannot be combined")
+		}
+		injectionPlan, err = planFromSpecs(opts.InjectionPlan, opts.EntryCount)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		injectionPlan = createInjectionPlan(opts.InjectTerms, opts.EntryCount, opts.InjectionLocations, rng)
+	}
 
 	// generate entries
 	var entries []model.Entry
@@ -206,6 +312,28 @@ func createInjectionPlan(terms []string, entryCount int, locations []InjectionLo
 	return plan
 }
 
+// planFromSpecs validates an explicit injection plan and groups it by entry, keeping spec order
+func planFromSpecs(specs []InjectionSpec, entryCount int) (map[int][]injectionRequest, error) {
+	plan := make(map[int][]injectionRequest)
+	for i, spec := range specs {
+		if spec.Term == "" {
+			return nil, fmt.Errorf("injection plan entry %d has an empty term", i)
+		}
+		if spec.EntryIndex < 0 || spec.EntryIndex >= entryCount {
+			return nil, fmt.Errorf("injection plan entry %d: entry index %d out of range (0-%d)", i, spec.EntryIndex, entryCount-1)
+		}
+		if spec.Location < RequestBody || spec.Location > URL {
+			return nil, fmt.Errorf("injection plan entry %d: unknown injection location %d", i, spec.Location)
+		}
+
+		plan[spec.EntryIndex] = append(plan[spec.EntryIndex], injectionRequest{
+			term:     spec.Term,
+			location: spec.Location,
+		})
+	}
+	return plan, nil
+}
+
 // injectionRequest represents a single term injection request
 type injectionRequest struct {
 	term     string
@@ -221,19 +349,26 @@ func randomLocation(locations []InjectionLocation, rng *rand.Rand) InjectionLoca
 	return locations[rng.Intn(len(locations))]
 }
 
-// GenerateToFile generates a har and writes it to a specific file path
+// GenerateToFile generates a har and writes it to a specific file path. split output is written
+// to the SplitPaths of path instead.
 func GenerateToFile(path string, opts GenerateOptions) ([]InjectedTerm, error) {
-	har, injected, err := GenerateInMemory(opts)
-	if err != nil {
-		return nil, err
-	}
-
 	// ensure directory exists
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create directory: %w", err)
 	}
 
+	if opts.OutputFormat == FormatSplit {
+		har, injected, err := GenerateInMemory(opts)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeSplit(SplitPaths(path, cmp.Or(opts.SplitFiles, DefaultSplitFiles)), har, injected); err != nil {
+			return nil, err
+		}
+		return injected, nil
+	}
+
 	// create file
 	file, err := os.Create(path)
 	if err != nil {
@@ -241,11 +376,44 @@ func GenerateToFile(path string, opts GenerateOptions) ([]InjectedTerm, error) {
 	}
 	defer file.Close()
 
-	// write har
+	injected, err := GenerateTo(file, opts)
+	if err != nil {
+		// don't leave a truncated har behind
+		file.Close()
+		os.Remove(path)
+		return nil, err
+	}
+
+	return injected, nil
+}
+
+// WriteInjectionReport writes the injected terms as indented json so the report can be loaded alongside the har
+func WriteInjectionReport(path string, injected []InjectedTerm) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer file.Close()
+
 	encoder := json.NewEncoder(file)
 	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(har); err != nil {
-		return nil, fmt.Errorf("failed to write har: %w", err)
+	if err := encoder.Encode(injected); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return nil
+}
+
+// LoadInjectionReport reads an injection report previously written by WriteInjectionReport
+func LoadInjectionReport(path string) ([]InjectedTerm, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report file: %w", err)
+	}
+
+	var injected []InjectedTerm
+	if err := json.Unmarshal(data, &injected); err != nil {
+		return nil, fmt.Errorf("failed to parse report: %w", err)
 	}
 
 	return injected, nil
@@ -1,18 +1,26 @@
 package hargen
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"strings"
 )
 
+// DefaultFatTargetBytes is the approximate size of a fat mode response body when no target is set
+const DefaultFatTargetBytes = 100_000
+
+// fatBlobMIMETypes are the data uri types fat mode blobs are tagged with, picked per blob
+var fatBlobMIMETypes = []string{"image/png", "image/jpeg", "application/pdf", "application/octet-stream"}
+
 // JSONGenerator creates random JSON objects with dictionary words
 type JSONGenerator struct {
-	dict     *Dictionary
-	maxDepth int
-	maxNodes int
-	rng      *rand.Rand
-	fatMode  bool
+	dict      *Dictionary
+	maxDepth  int
+	maxNodes  int
+	rng       *rand.Rand
+	fatMode   bool
+	fatTarget int // approximate bytes of a fat object, DefaultFatTargetBytes when zero
 }
 
 // NewJSONGenerator creates a new JSON generator
@@ -38,6 +46,12 @@ func (jg *JSONGenerator) SetFatMode(enabled bool) {
 	jg.fatMode = enabled
 }
 
+// SetFatTarget sets the approximate size in bytes of the objects GenerateFatObject creates
+// (0 = DefaultFatTargetBytes)
+func (jg *JSONGenerator) SetFatTarget(bytes int) {
+	jg.fatTarget = bytes
+}
+
 // GenerateObject creates a random JSON object with dictionary words
 func (jg *JSONGenerator) GenerateObject(depth int) map[string]interface{} {
 	// at max depth, just create simple key-value pair
@@ -160,43 +174,60 @@ func (jg *JSONGenerator) GenerateRealisticValue(fieldName string) string {
 	return jg.dict.RandomWord(jg.rng)
 }
 
-// GenerateFatObject creates a huge JSON object (~100KB) with base64 blobs
+// GenerateFatObject creates a huge JSON object, about the fat target in size once marshaled,
+// made of nested structure topped up with base64 blobs
 func (jg *JSONGenerator) GenerateFatObject() map[string]interface{} {
+	target := jg.fatTarget
+	if target <= 0 {
+		target = DefaultFatTargetBytes
+	}
+	// structure is sized for the default target and scaled down for smaller ones; anything above
+	// that is made up with bigger blobs
+	scale := min(float64(target)/DefaultFatTargetBytes, 1)
+
 	obj := map[string]interface{}{
 		"status":    "success",
 		"timestamp": "2025-01-01T00:00:00Z",
 		"metadata":  jg.GenerateObject(2),
 	}
 
-	// add 3-5 large base64 blob fields (~25KB each)
-	blobCount := jg.rng.Intn(3) + 3
-	for i := 0; i < blobCount; i++ {
-		blobKey := fmt.Sprintf("blob_%d", i)
-		obj[blobKey] = jg.generateBase64Blob(25000) // 25KB base64 string
-	}
-
 	// add large arrays with nested objects
 	arrayCount := jg.rng.Intn(3) + 2
 	for i := 0; i < arrayCount; i++ {
 		arrayKey := fmt.Sprintf("items_%d", i)
-		obj[arrayKey] = jg.generateLargeArray(50) // 50 items
+		obj[arrayKey] = jg.generateLargeArray(max(int(50*scale), 1))
 	}
 
 	// add deeply nested object tree
-	obj["nested_data"] = jg.generateDeepObject(5, 20) // depth 5, 20 nodes per level
+	if scale == 1 {
+		obj["nested_data"] = jg.generateDeepObject(5, 20) // depth 5, 20 nodes per level
+	}
+
+	// fill what is left with 3-5 base64 blob fields of about equal size
+	structure, _ := json.Marshal(obj)
+	remaining := target - len(structure)
+	blobCount := jg.rng.Intn(3) + 3
+	for i := 0; i < blobCount && remaining > 0; i++ {
+		blobKey := fmt.Sprintf("blob_%d", i)
+		mimeType := fatBlobMIMETypes[jg.rng.Intn(len(fatBlobMIMETypes))]
+		share := remaining / (blobCount - i)
+		overhead := len(blobKey) + len(mimeType) + len(`,"":"data:;base64,"`)
+		obj[blobKey] = jg.generateBase64Blob(share-overhead, mimeType)
+		remaining -= share
+	}
 
 	return obj
 }
 
-// generateBase64Blob creates a base64-encoded string of specified byte size
-func (jg *JSONGenerator) generateBase64Blob(sizeBytes int) string {
+// generateBase64Blob creates a base64 data uri whose encoded payload is about sizeBytes long
+func (jg *JSONGenerator) generateBase64Blob(sizeBytes int, mimeType string) string {
 	// base64 encoding is ~4/3 the size, so generate 3/4 of target
-	rawSize := (sizeBytes * 3) / 4
+	rawSize := max((sizeBytes*3)/4, 0)
 	bytes := make([]byte, rawSize)
 	jg.rng.Read(bytes)
 
 	// encode to base64
-	return fmt.Sprintf("data:image/png;base64,%s", encodeBase64(bytes))
+	return fmt.Sprintf("data:%s;base64,%s", mimeType, encodeBase64(bytes))
 }
 
 // simple base64 encoding (using standard chars)
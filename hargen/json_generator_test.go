@@ -0,0 +1,77 @@
+package hargen
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// generateFatBodies generates count fat mode entries and returns their response bodies
+func generateFatBodies(t *testing.T, seed int64, count, target int) []string {
+	t.Helper()
+	har, _, err := GenerateInMemory(GenerateOptions{
+		EntryCount:     count,
+		Words:          fallbackWords,
+		Seed:           seed,
+		FatMode:        true,
+		FatTargetBytes: target,
+	})
+	require.NoError(t, err)
+
+	bodies := make([]string, len(har.Log.Entries))
+	for i, entry := range har.Log.Entries {
+		bodies[i] = entry.Response.Body.Content
+	}
+	return bodies
+}
+
+func TestFatTargetBytes_Size(t *testing.T) {
+	tests := []struct {
+		name   string
+		target int
+		want   int
+	}{
+		{name: "default", target: 0, want: DefaultFatTargetBytes},
+		{name: "smaller than the default", target: 5_000, want: 5_000},
+		{name: "the default", target: DefaultFatTargetBytes, want: DefaultFatTargetBytes},
+		{name: "larger than the default", target: 1_000_000, want: 1_000_000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, body := range generateFatBodies(t, 3, 10, tt.target) {
+				assert.InEpsilon(t, tt.want, len(body), 0.01, "entry %d is %d bytes", i, len(body))
+				assert.True(t, json.Valid([]byte(body)), "entry %d", i)
+			}
+		})
+	}
+}
+
+func TestFatTargetBytes_Negative(t *testing.T) {
+	_, _, err := GenerateInMemory(GenerateOptions{EntryCount: 1, Words: fallbackWords, FatMode: true, FatTargetBytes: -1})
+	assert.ErrorContains(t, err, "must not be negative")
+}
+
+func TestFatTargetBytes_SameSeed(t *testing.T) {
+	first := generateFatBodies(t, 9, 3, 20_000)
+	assert.Equal(t, first, generateFatBodies(t, 9, 3, 20_000))
+	assert.NotEqual(t, first, generateFatBodies(t, 10, 3, 20_000))
+}
+
+func TestGenerateFatObject_BlobMIMETypes(t *testing.T) {
+	dataURI := regexp.MustCompile(`"data:([^;"]+);base64,`)
+	seen := make(map[string]bool)
+	for _, body := range generateFatBodies(t, 1, 20, 10_000) {
+		for _, match := range dataURI.FindAllStringSubmatch(body, -1) {
+			seen[match[1]] = true
+		}
+	}
+
+	for _, mimeType := range fatBlobMIMETypes {
+		assert.True(t, seen[mimeType], "no blob tagged %s", mimeType)
+	}
+	assert.Len(t, seen, len(fatBlobMIMETypes), "only the fat blob types are used")
+}
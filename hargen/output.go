@@ -0,0 +1,147 @@
+package hargen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pb33f/harific/motor/model"
+)
+
+// OutputFormat selects how a generated har is written
+type OutputFormat int
+
+const (
+	FormatHAR    OutputFormat = iota // one standard har document
+	FormatNDJSON                     // one entry per line, without the log around them
+	FormatSplit                      // SplitFiles standard hars of roughly equal size, see SplitPaths
+)
+
+// DefaultSplitFiles is how many files FormatSplit writes when GenerateOptions.SplitFiles is zero
+const DefaultSplitFiles = 2
+
+// String returns the name ParseOutputFormat accepts
+func (f OutputFormat) String() string {
+	switch f {
+	case FormatHAR:
+		return "har"
+	case FormatNDJSON:
+		return "ndjson"
+	case FormatSplit:
+		return "split"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseOutputFormat converts a format name (as produced by String) into an OutputFormat
+func ParseOutputFormat(name string) (OutputFormat, error) {
+	switch strings.ToLower(name) {
+	case "", "har":
+		return FormatHAR, nil
+	case "ndjson", "jsonl":
+		return FormatNDJSON, nil
+	case "split":
+		return FormatSplit, nil
+	default:
+		return 0, fmt.Errorf("unknown output format: %s (expected har, ndjson or split)", name)
+	}
+}
+
+// SplitPaths names the files FormatSplit writes for path: "capture.har" in 3 parts is
+// capture-1.har, capture-2.har and capture-3.har
+func SplitPaths(path string, parts int) []string {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	paths := make([]string, parts)
+	for i := range paths {
+		paths[i] = fmt.Sprintf("%s-%d%s", base, i+1, ext)
+	}
+	return paths
+}
+
+// writeNDJSON writes every entry of har as compact json on a line of its own
+func writeNDJSON(w io.Writer, har *model.HAR) error {
+	encoder := json.NewEncoder(w)
+	for i := range har.Log.Entries {
+		if err := encoder.Encode(&har.Log.Entries[i]); err != nil {
+			return fmt.Errorf("failed to write entry %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// writeSplit writes har as len(paths) hars of roughly equal size, entries kept in order. each
+// part carries the log's version and creator. injected entry indexes are moved to count
+// within their part, which is recorded in File.
+func writeSplit(paths []string, har *model.HAR, injected []InjectedTerm) error {
+	sizes := make([]int, len(har.Log.Entries))
+	total := 0
+	for i := range har.Log.Entries {
+		raw, err := json.Marshal(&har.Log.Entries[i])
+		if err != nil {
+			return fmt.Errorf("failed to encode entry %d: %w", i, err)
+		}
+		sizes[i] = len(raw)
+		total += len(raw)
+	}
+
+	// a part ends once the entries so far reach its share of the total
+	starts := make([]int, len(paths)+1)
+	entry, written := 0, 0
+	for part := range paths {
+		starts[part] = entry
+		target := total * (part + 1) / len(paths)
+		for entry < len(sizes) && (written < target || part == len(paths)-1) {
+			written += sizes[entry]
+			entry++
+		}
+	}
+	starts[len(paths)] = len(sizes)
+
+	for part, path := range paths {
+		piece := &model.HAR{Log: har.Log}
+		piece.Log.Entries = har.Log.Entries[starts[part]:starts[part+1]]
+		if err := writeHARFile(path, piece); err != nil {
+			for _, written := range paths[:part] {
+				os.Remove(written) // no partial set of parts
+			}
+			return err
+		}
+	}
+
+	for i := range injected {
+		for part := range paths {
+			if injected[i].EntryIndex < starts[part+1] {
+				injected[i].EntryIndex -= starts[part]
+				injected[i].File = paths[part]
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// writeHARFile writes har as indented json to path, removing the file again if that fails
+func writeHARFile(path string, har *model.HAR) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	encodeErr := encoder.Encode(har)
+	closeErr := file.Close()
+	if encodeErr != nil || closeErr != nil {
+		os.Remove(path)
+		if encodeErr != nil {
+			return fmt.Errorf("failed to write har: %w", encodeErr)
+		}
+		return fmt.Errorf("failed to write har: %w", closeErr)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package hargen
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/harific/motor/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// entryURLs lists the request urls of entries, which a seed fixes while start times follow the clock
+func entryURLs(entries []model.Entry) []string {
+	urls := make([]string, len(entries))
+	for i, entry := range entries {
+		urls[i] = entry.Request.URL
+	}
+	return urls
+}
+
+func TestGenerateTo_Formats(t *testing.T) {
+	opts := GenerateOptions{EntryCount: 25, Words: fallbackWords, Seed: 21}
+	want, _, err := GenerateInMemory(opts)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		format OutputFormat
+		parse  func(t *testing.T, out []byte) []model.Entry
+	}{
+		{
+			name:   "har",
+			format: FormatHAR,
+			parse: func(t *testing.T, out []byte) []model.Entry {
+				var har model.HAR
+				require.NoError(t, json.Unmarshal(out, &har))
+				assert.Equal(t, "1.2", har.Log.Version)
+				return har.Log.Entries
+			},
+		},
+		{
+			name:   "ndjson",
+			format: FormatNDJSON,
+			parse: func(t *testing.T, out []byte) []model.Entry {
+				var entries []model.Entry
+				scanner := bufio.NewScanner(bytes.NewReader(out))
+				scanner.Buffer(nil, 16<<20)
+				for scanner.Scan() {
+					var entry model.Entry
+					require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry), "line %d", len(entries)+1)
+					entries = append(entries, entry)
+				}
+				require.NoError(t, scanner.Err())
+				assert.Equal(t, len(entries), bytes.Count(out, []byte("\n")), "one entry per line")
+				return entries
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := opts
+			opts.OutputFormat = tt.format
+			var out bytes.Buffer
+			_, err := GenerateTo(&out, opts)
+			require.NoError(t, err)
+			assert.Equal(t, entryURLs(want.Log.Entries), entryURLs(tt.parse(t, out.Bytes())))
+		})
+	}
+}
+
+func TestGenerateToFile_Split(t *testing.T) {
+	tests := []struct {
+		name       string
+		splitFiles int
+		wantParts  int
+	}{
+		{name: "default", wantParts: DefaultSplitFiles},
+		{name: "three", splitFiles: 3, wantParts: 3},
+		{name: "one", splitFiles: 1, wantParts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := GenerateOptions{
+				EntryCount:   40,
+				Words:        fallbackWords,
+				Seed:         33,
+				InjectTerms:  []string{"needleone", "needletwo", "needlethree"},
+				OutputFormat: FormatSplit,
+				SplitFiles:   tt.splitFiles,
+			}
+			want, _, err := GenerateInMemory(opts)
+			require.NoError(t, err)
+
+			path := filepath.Join(t.TempDir(), "capture.har")
+			injected, err := GenerateToFile(path, opts)
+			require.NoError(t, err)
+			_, err = os.Stat(path)
+			assert.True(t, os.IsNotExist(err), "only the parts are written")
+
+			var all []model.Entry
+			parts := make(map[string][]model.Entry)
+			var sizes []int
+			for _, part := range SplitPaths(path, tt.wantParts) {
+				raw, err := os.ReadFile(part)
+				require.NoError(t, err)
+				var har model.HAR
+				require.NoError(t, json.Unmarshal(raw, &har), part)
+				assert.Equal(t, "1.2", har.Log.Version, part)
+				assert.Equal(t, "hargen", har.Log.Creator.Name, part)
+				assert.NotEmpty(t, har.Log.Entries, part)
+
+				parts[part] = har.Log.Entries
+				all = append(all, har.Log.Entries...)
+				sizes = append(sizes, len(raw))
+			}
+			assert.Equal(t, entryURLs(want.Log.Entries), entryURLs(all), "entries keep their order across the parts")
+			for _, size := range sizes {
+				assert.InEpsilon(t, sizes[0], size, 0.5, "parts are roughly the same size: %v", sizes)
+			}
+
+			// injected entries are counted within the part that holds them
+			require.Len(t, injected, 3)
+			for _, term := range injected {
+				entries, ok := parts[term.File]
+				require.True(t, ok, "%s is not one of the parts", term.File)
+				require.Less(t, term.EntryIndex, len(entries))
+				raw, err := json.Marshal(entries[term.EntryIndex])
+				require.NoError(t, err)
+				assert.True(t, strings.Contains(string(raw), term.Term), "%s is not in entry %d of %s", term.Term, term.EntryIndex, term.File)
+			}
+		})
+	}
+}
+
+func TestGenerate_SplitNeedsPath(t *testing.T) {
+	opts := GenerateOptions{EntryCount: 1, Words: fallbackWords, OutputFormat: FormatSplit}
+
+	_, err := GenerateTo(&bytes.Buffer{}, opts)
+	assert.ErrorContains(t, err, "needs a file path")
+	_, err = Generate(opts)
+	assert.ErrorContains(t, err, "needs a file path")
+
+	opts.SplitFiles = -1
+	_, err = GenerateToFile(filepath.Join(t.TempDir(), "capture.har"), opts)
+	assert.ErrorContains(t, err, "must not be negative")
+}
+
+func TestParseOutputFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    OutputFormat
+		wantErr bool
+	}{
+		{name: "", want: FormatHAR},
+		{name: "har", want: FormatHAR},
+		{name: "NDJSON", want: FormatNDJSON},
+		{name: "jsonl", want: FormatNDJSON},
+		{name: "split", want: FormatSplit},
+		{name: "csv", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseOutputFormat(tt.name)
+		if tt.wantErr {
+			assert.Error(t, err, tt.name)
+			continue
+		}
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, tt.want, got, tt.name)
+		if tt.name != "" && tt.name != "jsonl" {
+			assert.Equal(t, strings.ToLower(tt.name), got.String())
+		}
+	}
+}
+
+func TestSplitPaths(t *testing.T) {
+	assert.Equal(t, []string{"out/capture-1.har", "out/capture-2.har", "out/capture-3.har"}, SplitPaths("out/capture.har", 3))
+	assert.Equal(t, []string{"capture-1", "capture-2"}, SplitPaths("capture", 2))
+}
@@ -65,6 +65,116 @@ func benchmarkRandomAccess(b *testing.B, generateFunc func() (string, func(), er
 	stats := streamer.Stats()
 	b.ReportMetric(float64(stats.TotalReads), "reads")
 	b.ReportMetric(float64(stats.BytesRead)/(1024*1024), "MB_read")
+	b.ReportMetric(stats.BytesPerSecond/(1024*1024), "MB_read/s")
+	b.ReportMetric(stats.CacheHitRatio, "hit_ratio")
+}
+
+// benchmark the pooled file reader against the mmap reader on the same random access pattern
+func BenchmarkReaderRandomAccess_50MB_Pooled(b *testing.B) {
+	benchmarkReaderRandomAccess(b, func(path string, index *Index) (EntryReader, error) {
+		return NewEntryReader(path, index)
+	})
+}
+
+func BenchmarkReaderRandomAccess_50MB_Mmap(b *testing.B) {
+	benchmarkReaderRandomAccess(b, NewMmapEntryReader)
+}
+
+func benchmarkReaderRandomAccess(b *testing.B, newReader func(string, *Index) (EntryReader, error)) {
+	harFile, cleanup, err := generateMediumHAR()
+	if err != nil {
+		b.Fatalf("failed to generate test HAR: %v", err)
+	}
+	defer cleanup()
+
+	index := buildTestIndex(b, harFile)
+	if len(index.Entries) == 0 {
+		b.Skip("no entries in file")
+	}
+
+	reader, err := newReader(harFile, index)
+	if err != nil {
+		b.Fatalf("failed to create reader: %v", err)
+	}
+	defer reader.Close()
+
+	indices := make([]int, b.N)
+	for i := 0; i < b.N; i++ {
+		indices[i] = rand.Intn(len(index.Entries))
+	}
+
+	ctx := context.Background()
+	buf := make([]byte, 64*1024)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		meta := index.Entries[indices[i]]
+		req := NewReadRequestBuilder().
+			WithOffset(meta.FileOffset).
+			WithLength(meta.Length).
+			WithBuffer(&buf).
+			Build()
+		if resp := reader.Read(ctx, req); resp.GetError() != nil {
+			b.Fatalf("read failed: %v", resp.GetError())
+		}
+	}
+}
+
+// benchmark reading windows of contiguous entries one at a time against a single ReadBatch
+func BenchmarkReadWindow_50MB_PerEntry(b *testing.B) {
+	benchmarkReadWindow(b, false)
+}
+
+func BenchmarkReadWindow_50MB_Batch(b *testing.B) {
+	benchmarkReadWindow(b, true)
+}
+
+func benchmarkReadWindow(b *testing.B, batch bool) {
+	const window = 50
+
+	harFile, cleanup, err := generateMediumHAR()
+	if err != nil {
+		b.Fatalf("failed to generate test HAR: %v", err)
+	}
+	defer cleanup()
+
+	index := buildTestIndex(b, harFile)
+	if len(index.Entries) <= window {
+		b.Skip("not enough entries in file")
+	}
+
+	reader, err := NewEntryReader(harFile, index)
+	if err != nil {
+		b.Fatalf("failed to create reader: %v", err)
+	}
+	defer reader.Close()
+
+	ctx := context.Background()
+	buf := make([]byte, 64*1024)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		entries := index.Entries[rand.Intn(len(index.Entries)-window):][:window]
+		if batch {
+			offsets, lengths := batchRequests(entries)
+			for _, resp := range reader.ReadBatch(ctx, offsets, lengths) {
+				if resp.GetError() != nil {
+					b.Fatalf("read failed: %v", resp.GetError())
+				}
+			}
+			continue
+		}
+		for _, meta := range entries {
+			req := NewReadRequestBuilder().WithOffset(meta.FileOffset).WithLength(meta.Length).WithBuffer(&buf).Build()
+			if resp := reader.Read(ctx, req); resp.GetError() != nil {
+				b.Fatalf("read failed: %v", resp.GetError())
+			}
+		}
+	}
 }
 
 // benchmark sequential streaming pattern - simulates streaming chunks of entries
@@ -135,6 +245,7 @@ func benchmarkSequentialStream(b *testing.B, generateFunc func() (string, func()
 	b.ReportMetric(float64(stats.TotalReads), "reads")
 	b.ReportMetric(float64(stats.EntriesParsed), "parsed")
 	b.ReportMetric(float64(stats.AverageReadTime.Microseconds()), "avg_us")
+	b.ReportMetric(stats.EntriesPerSecond, "entries/s")
 }
 
 // benchmark index building - measures how fast we can build the index
@@ -181,6 +292,85 @@ func benchmarkIndexBuild(b *testing.B, generateFunc func() (string, func(), erro
 	}
 }
 
+// benchmarkDecoders are the decoders the Decoders benchmarks compare. both cases run
+// encoding/json: "indirection" wraps it in a DecoderFactory of another type, so it measures only
+// the overhead of going through a custom factory, not a faster decoder. a sonic or jsoniter
+// factory added here is what would show a decoding speedup.
+var benchmarkDecoders = []struct {
+	name    string
+	factory DecoderFactory
+}{
+	{"stdlib", nil},
+	{"indirection", &countingDecoderFactory{}},
+}
+
+// benchmark index build and random access with each decoder
+func BenchmarkDecoders_IndexBuild_5MB(b *testing.B) {
+	harFile, cleanup, err := generateSmallHAR()
+	if err != nil {
+		b.Fatalf("failed to generate test HAR: %v", err)
+	}
+	defer cleanup()
+
+	for _, decoder := range benchmarkDecoders {
+		b.Run(decoder.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				opts := DefaultStreamerOptions()
+				opts.Decoder = decoder.factory
+				streamer, err := NewHARStreamer(harFile, opts)
+				if err != nil {
+					b.Fatalf("failed to create streamer: %v", err)
+				}
+				if err := streamer.Initialize(context.Background()); err != nil {
+					b.Fatalf("initialize failed: %v", err)
+				}
+				streamer.Close()
+			}
+		})
+	}
+}
+
+func BenchmarkDecoders_RandomAccess_5MB(b *testing.B) {
+	harFile, cleanup, err := generateSmallHAR()
+	if err != nil {
+		b.Fatalf("failed to generate test HAR: %v", err)
+	}
+	defer cleanup()
+
+	index := buildTestIndex(b, harFile)
+	if len(index.Entries) == 0 {
+		b.Skip("no entries in file")
+	}
+
+	for _, decoder := range benchmarkDecoders {
+		b.Run(decoder.name, func(b *testing.B) {
+			reader, err := NewEntryReader(harFile, index)
+			if err != nil {
+				b.Fatalf("failed to create reader: %v", err)
+			}
+			defer reader.Close()
+			reader.SetDecoderFactory(decoder.factory)
+
+			ctx := context.Background()
+			buf := make([]byte, 64*1024)
+			b.ResetTimer()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				meta := index.Entries[rand.Intn(len(index.Entries))]
+				req := NewReadRequestBuilder().
+					WithOffset(meta.FileOffset).
+					WithLength(meta.Length).
+					WithBuffer(&buf).
+					Build()
+				if resp := reader.Read(ctx, req); resp.GetError() != nil {
+					b.Fatalf("read failed: %v", resp.GetError())
+				}
+			}
+		})
+	}
+}
+
 // benchmark metadata access - measures lightweight metadata retrieval
 func BenchmarkMetadataAccess_5MB(b *testing.B) {
 	benchmarkMetadataAccess(b, generateSmallHAR)
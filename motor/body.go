@@ -0,0 +1,57 @@
+package motor
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pb33f/harific/motor/model"
+)
+
+// response bodies are kept exactly as the HAR stores them: the indexer never looks at
+// content.encoding and the reader returns Body.Content untouched. DecodeBody is the opt-in step
+// that turns base64 (and base64 wrapped gzip) content into text for display and search.
+
+// DecodeBody returns the text of a response body. base64 content is decoded and gzip payloads
+// are inflated; anything else is returned unchanged. decoded output larger than MaxEntrySize is
+// rejected so a small compressed body cannot expand without bound.
+func DecodeBody(body *model.BodyResponseType) (string, error) {
+	if !strings.EqualFold(body.Encoding, "base64") {
+		return body.Content, nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(body.Content)
+	if err != nil {
+		return body.Content, fmt.Errorf("failed to decode base64 body: %w", err)
+	}
+	if len(decoded) > MaxEntrySize {
+		return body.Content, fmt.Errorf("decoded body size %d exceeds maximum %d", len(decoded), MaxEntrySize)
+	}
+
+	if bytes.HasPrefix(decoded, gzipMagic) {
+		return inflateBody(decoded, body.Content)
+	}
+	return string(decoded), nil
+}
+
+// inflateBody gunzips data, returning original alongside any error
+func inflateBody(data []byte, original string) (string, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return original, fmt.Errorf("failed to read gzip body: %w", err)
+	}
+	defer gz.Close()
+
+	// read one byte past the limit to tell a body of exactly MaxEntrySize from a larger one
+	inflated, err := io.ReadAll(io.LimitReader(gz, MaxEntrySize+1))
+	if err != nil {
+		return original, fmt.Errorf("failed to inflate gzip body: %w", err)
+	}
+	if len(inflated) > MaxEntrySize {
+		return original, fmt.Errorf("inflated body exceeds maximum %d", MaxEntrySize)
+	}
+	return string(inflated), nil
+}
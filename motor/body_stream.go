@@ -0,0 +1,448 @@
+package motor
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// responseBodyPath is where the response body text sits inside an entry object
+var responseBodyPath = []string{"response", "content", "text"}
+
+// errKeyNotFound is returned by findKey when the object ends without the key
+var errKeyNotFound = errors.New("key not found")
+
+// StreamResponseBody streams the response body text of the entry at offset without loading the
+// entry: it scans the entry's json up to response.content.text and decodes that string's escapes
+// as the returned reader is read, so memory use stays flat however large the body is. the text is
+// returned as stored, a base64 encoded body is not decoded. an entry without a body text streams
+// nothing, unless the reader resolves external bodies and the entry references a body file: that
+// streams as Read would store it. the caller must close the reader.
+func (r *DefaultEntryReader) StreamResponseBody(offset int64) (io.ReadCloser, error) {
+	meta, err := r.ReadMetadata(offset)
+	if err != nil {
+		return nil, err
+	}
+
+	// a handle of its own: pooled handles are shared between reads and cannot stay positioned
+	file, err := os.Open(r.filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", r.filePath, err)
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("seek failed: %w", err)
+	}
+
+	scanner := &jsonScanner{r: bufio.NewReaderSize(io.LimitReader(file, meta.Length), 64*1024)}
+	found, err := scanner.navigate(responseBodyPath)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to find the response body at offset %d: %w", offset, err)
+	}
+	if !found {
+		defer file.Close()
+		if r.external != nil {
+			return r.streamExternalBody(file, offset, meta.Length)
+		}
+		return io.NopCloser(strings.NewReader("")), nil
+	}
+
+	return &bodyStream{string: jsonStringReader{scanner: scanner}, file: file}, nil
+}
+
+// streamExternalBody streams the body file referenced by the entry at offset, or nothing when the
+// entry references none
+func (r *DefaultEntryReader) streamExternalBody(file *os.File, offset, length int64) (io.ReadCloser, error) {
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("seek failed: %w", err)
+	}
+	scanner := &jsonScanner{r: bufio.NewReader(io.LimitReader(file, length))}
+	found, err := scanner.navigate(externalBodyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the response body file at offset %d: %w", offset, err)
+	}
+	ref := ""
+	if found {
+		ref, err = scanner.readKey()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if ref == "" {
+		return io.NopCloser(strings.NewReader("")), nil
+	}
+	return r.external.stream(ref)
+}
+
+// bodyStream is a response body being decoded straight from its entry's file
+type bodyStream struct {
+	string jsonStringReader
+	file   *os.File
+}
+
+func (b *bodyStream) Read(p []byte) (int, error) {
+	return b.string.Read(p)
+}
+
+func (b *bodyStream) Close() error {
+	return b.file.Close()
+}
+
+// jsonScanner walks raw json a byte at a time, skipping values it is not after without decoding
+// or buffering them. the token decoder behind HARDecoder cannot do this: encoding/json returns a
+// string token only once the whole string is in memory. skipping checks no more than where a
+// value ends; the strings it decodes match encoding/json, and the values it captures are decoded
+// by one, so malformed json is still refused where it is read.
+type jsonScanner struct {
+	r   *bufio.Reader
+	raw *[]byte // while capturing a value, the bytes skipped so far
+}
+
+// readByte reads the next byte of a value being skipped, keeping it when capturing
+func (s *jsonScanner) readByte() (byte, error) {
+	c, err := s.r.ReadByte()
+	if err == nil && s.raw != nil {
+		*s.raw = append(*s.raw, c)
+	}
+	return c, err
+}
+
+// unreadByte puts back the byte readByte last read
+func (s *jsonScanner) unreadByte() error {
+	if s.raw != nil && len(*s.raw) > 0 {
+		*s.raw = (*s.raw)[:len(*s.raw)-1]
+	}
+	return s.r.UnreadByte()
+}
+
+// next returns the next byte that is not json whitespace
+func (s *jsonScanner) next() (byte, error) {
+	for {
+		c, err := s.r.ReadByte()
+		if err != nil {
+			return 0, unexpectedEOF(err)
+		}
+		switch c {
+		case ' ', '\t', '\n', '\r':
+		default:
+			return c, nil
+		}
+	}
+}
+
+// navigate descends through the objects named by path, starting at the entry's opening brace
+// (array separators before it are skipped). it reports false when a key is missing or the value
+// is null, and leaves the scanner just inside the opening quote of the final string value.
+func (s *jsonScanner) navigate(path []string) (bool, error) {
+	c, err := s.next()
+	for err == nil && c == ',' {
+		c, err = s.next()
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for i, key := range path {
+		if c != '{' {
+			return false, fmt.Errorf("expected an object before %q, got %q", key, c)
+		}
+		if err := s.findKey(key); errors.Is(err, errKeyNotFound) {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+
+		if c, err = s.next(); err != nil {
+			return false, err
+		}
+		if c == 'n' {
+			return false, s.skipScalar()
+		}
+		if i == len(path)-1 && c != '"' {
+			return false, fmt.Errorf("expected a string for %q, got %q", key, c)
+		}
+	}
+	return true, nil
+}
+
+// findKey reads the members of the object just opened until key (matched case-insensitively, as
+// encoding/json does), leaving the scanner after its colon
+func (s *jsonScanner) findKey(key string) error {
+	for {
+		c, err := s.next()
+		if err != nil {
+			return err
+		}
+		switch c {
+		case '}':
+			return errKeyNotFound
+		case ',':
+			continue
+		case '"':
+		default:
+			return fmt.Errorf("expected an object key, got %q", c)
+		}
+
+		name, err := s.readKey()
+		if err != nil {
+			return err
+		}
+		if c, err = s.next(); err != nil {
+			return err
+		}
+		if c != ':' {
+			return fmt.Errorf("expected ':' after %q, got %q", name, c)
+		}
+		if strings.EqualFold(name, key) {
+			return nil
+		}
+		if err := s.skipValue(); err != nil {
+			return err
+		}
+	}
+}
+
+// readKey reads an object key after its opening quote; keys are short, so they are buffered
+func (s *jsonScanner) readKey() (string, error) {
+	var key strings.Builder
+	reader := jsonStringReader{scanner: s}
+	if _, err := io.Copy(&key, &reader); err != nil {
+		return "", err
+	}
+	return key.String(), nil
+}
+
+// skipValue skips the value that starts at the next byte
+func (s *jsonScanner) skipValue() error {
+	c, err := s.next()
+	if err != nil {
+		return err
+	}
+	switch c {
+	case '"':
+		return s.skipString()
+	case '{', '[':
+		return s.skipContainer()
+	}
+	return s.skipScalar()
+}
+
+// captureValue reads the value that starts at the next byte, returning its raw json
+func (s *jsonScanner) captureValue() ([]byte, error) {
+	c, err := s.next()
+	if err != nil {
+		return nil, err
+	}
+	raw := []byte{c}
+	s.raw = &raw
+	defer func() { s.raw = nil }()
+
+	switch c {
+	case '"':
+		err = s.skipString()
+	case '{', '[':
+		err = s.skipContainer()
+	default:
+		err = s.skipScalar()
+	}
+	return raw, err
+}
+
+// skipString skips to the closing quote of a string whose opening quote was read
+func (s *jsonScanner) skipString() error {
+	for {
+		c, err := s.readByte()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		switch c {
+		case '\\':
+			if _, err := s.readByte(); err != nil {
+				return unexpectedEOF(err)
+			}
+		case '"':
+			return nil
+		}
+	}
+}
+
+// skipContainer skips to the end of an object or array whose opening bracket was read
+func (s *jsonScanner) skipContainer() error {
+	for depth := 1; depth > 0; {
+		c, err := s.readByte()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		switch c {
+		case '"':
+			if err := s.skipString(); err != nil {
+				return err
+			}
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+		}
+	}
+	return nil
+}
+
+// skipScalar skips the rest of a number, true, false or null, leaving the delimiter after it
+func (s *jsonScanner) skipScalar() error {
+	for {
+		c, err := s.readByte()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		switch c {
+		case ',', '}', ']', ' ', '\t', '\n', '\r':
+			return s.unreadByte()
+		}
+	}
+}
+
+// jsonStringReader decodes a json string as it is read, from just after its opening quote up to
+// the closing quote, which reads as io.EOF
+type jsonStringReader struct {
+	scanner *jsonScanner
+	pending []byte // decoded bytes of an escape that did not fit the last Read
+	done    bool
+}
+
+func (j *jsonStringReader) Read(p []byte) (int, error) {
+	n := copy(p, j.pending)
+	j.pending = j.pending[n:]
+
+	var encoded [utf8.UTFMax]byte
+	for n < len(p) && !j.done {
+		c, err := j.scanner.r.ReadByte()
+		if err != nil {
+			return n, unexpectedEOF(err)
+		}
+
+		var decoded []byte
+		switch {
+		case c == '"':
+			j.done = true
+		case c == '\\':
+			if decoded, err = j.unescape(encoded[:0]); err != nil {
+				return n, err
+			}
+		case c < 0x20:
+			return n, fmt.Errorf("invalid control character %q in string", c)
+		case c >= utf8.RuneSelf:
+			decoded = j.decodeRune(c, encoded[:0])
+		default:
+			p[n] = c
+			n++
+		}
+		if len(decoded) > 0 {
+			copied := copy(p[n:], decoded)
+			n += copied
+			j.pending = append(j.pending[:0], decoded[copied:]...)
+			if len(j.pending) > 0 {
+				return n, nil
+			}
+		}
+	}
+
+	if n == 0 && j.done && len(j.pending) == 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// decodeRune appends the utf-8 sequence starting with c to buf, consuming the rest of it. invalid
+// utf-8 decodes to U+FFFD a byte at a time, as with encoding/json
+func (j *jsonStringReader) decodeRune(c byte, buf []byte) []byte {
+	var sequence [utf8.UTFMax]byte
+	sequence[0] = c
+	rest, _ := j.scanner.r.Peek(utf8.UTFMax - 1) // fewer at the end of the input
+	r, size := utf8.DecodeRune(sequence[:1+copy(sequence[1:], rest)])
+	if r == utf8.RuneError && size <= 1 {
+		return utf8.AppendRune(buf, utf8.RuneError)
+	}
+	j.scanner.r.Discard(size - 1)
+	return append(buf, sequence[:size]...)
+}
+
+// unescape decodes the escape sequence after a backslash into buf
+func (j *jsonStringReader) unescape(buf []byte) ([]byte, error) {
+	c, err := j.scanner.r.ReadByte()
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+
+	switch c {
+	case '"', '\\', '/':
+		return append(buf, c), nil
+	case 'b':
+		return append(buf, '\b'), nil
+	case 'f':
+		return append(buf, '\f'), nil
+	case 'n':
+		return append(buf, '\n'), nil
+	case 'r':
+		return append(buf, '\r'), nil
+	case 't':
+		return append(buf, '\t'), nil
+	case 'u':
+		r, err := j.readHex()
+		if err != nil {
+			return nil, err
+		}
+		if utf16.IsSurrogate(r) {
+			// the second half of a pair follows as its own \u escape; a lone half decodes to
+			// U+FFFD, as with encoding/json
+			r = j.pairSurrogate(r)
+		}
+		return utf8.AppendRune(buf, r), nil
+	}
+	return nil, fmt.Errorf("invalid escape '\\%c' in string", c)
+}
+
+// readHex reads the four hex digits of a \u escape
+func (j *jsonStringReader) readHex() (rune, error) {
+	var digits [4]byte
+	if _, err := io.ReadFull(j.scanner.r, digits[:]); err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	value, err := strconv.ParseUint(string(digits[:]), 16, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid unicode escape '\\u%s' in string", digits[:])
+	}
+	return rune(value), nil
+}
+
+// pairSurrogate combines a high surrogate with the \u escape after it, consuming that escape
+// only when it is the matching low surrogate
+func (j *jsonStringReader) pairSurrogate(high rune) rune {
+	peek, err := j.scanner.r.Peek(6)
+	if err != nil || peek[0] != '\\' || peek[1] != 'u' {
+		return utf8.RuneError
+	}
+	low, err := strconv.ParseUint(string(peek[2:]), 16, 16)
+	if err != nil {
+		return utf8.RuneError
+	}
+	r := utf16.DecodeRune(high, rune(low))
+	if r != utf8.RuneError {
+		j.scanner.r.Discard(6)
+	}
+	return r
+}
+
+// unexpectedEOF reports running out of input inside a value as io.ErrUnexpectedEOF
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,187 @@
+package motor
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bodyStreamFixture is written by hand so the keys around the body can be ordered awkwardly
+const bodyStreamFixture = `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1.0"}, "entries": [
+  {"startedDateTime": "2024-01-01T00:00:00Z", "time": 1,
+   "request": {"method": "GET", "url": "https://example.com/a", "headers": [], "bodySize": 0},
+   "response": {"status": 200, "statusText": "OK", "headers": [{"name": "x-text", "value": "\"text\": {not the body}"}],
+     "content": {"size": 40, "comment": "} ] \\\" {", "mimeType": "text/plain", "extra": [1, {"text": "nested"}, null],
+       "text": "line\none \"quoted\" \\ tab\t <b> café 😀 lone \ud800 end"}, "bodySize": 40},
+   "timings": {"send": 1, "wait": 1, "receive": 1}},
+  {"startedDateTime": "2024-01-01T00:00:01Z", "time": 1,
+   "request": {"method": "GET", "url": "https://example.com/b", "headers": [], "bodySize": 0},
+   "response": {"status": 204, "statusText": "No Content", "headers": [], "content": {"size": 0, "mimeType": "x-unknown"}, "bodySize": 0},
+   "timings": {"send": 1, "wait": 1, "receive": 1}},
+  {"startedDateTime": "2024-01-01T00:00:02Z", "time": 1,
+   "request": {"method": "GET", "url": "https://example.com/c", "headers": [], "bodySize": 0},
+   "response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 0, "mimeType": "text/plain", "text": null}, "bodySize": 0},
+   "timings": {"send": 1, "wait": 1, "receive": 1}}
+]}}`
+
+func openBodyStreamReader(t *testing.T, har string) (*DefaultEntryReader, *Index) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "bodies.har")
+	require.NoError(t, os.WriteFile(path, []byte(har), 0644))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	index, err := NewIndexBuilder(path).Build(file)
+	file.Close()
+	require.NoError(t, err)
+
+	reader, err := NewEntryReader(path, index)
+	require.NoError(t, err)
+	t.Cleanup(func() { reader.Close() })
+	return reader, index
+}
+
+func streamBody(t *testing.T, reader *DefaultEntryReader, offset int64) string {
+	t.Helper()
+	body, err := reader.StreamResponseBody(offset)
+	require.NoError(t, err)
+	defer body.Close()
+
+	// one byte at a time, so escapes are split across reads
+	data, err := io.ReadAll(iotest.OneByteReader(body))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestStreamResponseBody_MatchesDecodedEntry(t *testing.T) {
+	reader, index := openBodyStreamReader(t, bodyStreamFixture)
+	require.Len(t, index.Entries, 3)
+
+	for _, meta := range index.Entries {
+		entry, err := reader.ReadAt(meta.FileOffset, meta.Length)
+		require.NoError(t, err)
+		assert.Equal(t, entry.Response.Body.Content, streamBody(t, reader, meta.FileOffset), meta.URL)
+	}
+	assert.Contains(t, streamBody(t, reader, index.Entries[0].FileOffset), "café \U0001F600 lone � end")
+	assert.Empty(t, streamBody(t, reader, index.Entries[1].FileOffset), "an entry without a body text streams nothing")
+	assert.Empty(t, streamBody(t, reader, index.Entries[2].FileOffset))
+}
+
+func TestStreamResponseBody_LargeBody(t *testing.T) {
+	large := strings.Repeat("0123456789\"\\/\n", 200_000) // ~2.8MB decoded
+	text, err := json.Marshal(large)
+	require.NoError(t, err)
+	har := strings.Replace(webSocketFixture, `"text": "{}"`, `"text": `+string(text), 1)
+
+	reader, index := openBodyStreamReader(t, har)
+	body, err := reader.StreamResponseBody(index.Entries[0].FileOffset)
+	require.NoError(t, err)
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, len(large), len(data))
+	assert.True(t, large == string(data), "streamed body differs from the original")
+}
+
+func TestStreamResponseBody_Errors(t *testing.T) {
+	reader, _ := openBodyStreamReader(t, bodyStreamFixture)
+	_, err := reader.StreamResponseBody(12345)
+	assert.Error(t, err, "offsets outside the index are rejected")
+
+	// a string cut off before its closing quote is an unexpected eof, not a short body
+	strReader := jsonStringReader{scanner: &jsonScanner{r: bufio.NewReader(strings.NewReader(`abc\n`))}}
+	_, err = io.ReadAll(&strReader)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	strReader = jsonStringReader{scanner: &jsonScanner{r: bufio.NewReader(strings.NewReader(`bad \q"`))}}
+	_, err = io.ReadAll(&strReader)
+	assert.ErrorContains(t, err, "invalid escape")
+}
+
+// the scanner decodes strings on its own, so it is checked against encoding/json: a literal
+// decodes to the same text, or fails in both
+func TestJSONStringReader_MatchesEncodingJSON(t *testing.T) {
+	literals := []string{
+		`plain ascii`,
+		``,
+		`escapes \" \\ \/ \b \f \n \r \t`,
+		`unicode é 中 \u0000`,
+		`pair 😀 end`,
+		`lone high \ud800 end`,
+		`lone high at end \ud800`,
+		`lone low \udc00 end`,
+		`high then high \ud800😀`,
+		`high then escape \ud800\n`,
+		`reversed pair \ude00\ud83d`,
+		"raw utf-8 café 😀 中",
+		"invalid utf-8 \xff\xfe end",
+		"truncated sequence \xe4\xb8 end",
+		"encoded surrogate \xed\xa0\x80",
+		"overlong \xc0\xaf",
+		"truncated at end \xf0\x9f\x98",
+		`bad escape \q`,
+		`short unicode \u12`,
+		`bad hex \u12g4`,
+		`signed hex \u+123`,
+		"raw control \x01 char",
+		"raw newline \n char",
+		"raw tab \t char",
+		"del \x7f is fine",
+	}
+
+	for _, literal := range literals {
+		var want string
+		wantErr := json.Unmarshal([]byte(`"`+literal+`"`), &want)
+
+		scanner := &jsonScanner{r: bufio.NewReader(strings.NewReader(literal + `"`))}
+		got, gotErr := io.ReadAll(iotest.OneByteReader(&jsonStringReader{scanner: scanner}))
+		if wantErr != nil {
+			assert.Error(t, gotErr, "%q: encoding/json fails with %v", literal, wantErr)
+			continue
+		}
+		if assert.NoError(t, gotErr, "%q", literal) {
+			assert.Equal(t, want, string(got), "%q", literal)
+		}
+	}
+}
+
+// values the scanner skips or captures end where encoding/json says they do
+func TestJSONScanner_CaptureValue(t *testing.T) {
+	values := []string{
+		`"text with } ] , and \" quotes"`,
+		`{"a": [1, {"b": "]}"}], "c": null}`,
+		`[[], {}, "", 0, -1.5e3, true, false, null]`,
+		`12.5`,
+		`true`,
+		`null`,
+	}
+	for _, value := range values {
+		scanner := &jsonScanner{r: bufio.NewReader(strings.NewReader(" \n" + value + `, "next"`))}
+		raw, err := scanner.captureValue()
+		require.NoError(t, err, value)
+		assert.Equal(t, value, string(raw))
+		assert.True(t, json.Valid(raw), value)
+
+		c, err := scanner.next()
+		require.NoError(t, err)
+		assert.Equal(t, byte(','), c, "%s: the scanner stops just past the value", value)
+	}
+
+	// input cut off inside a value is an unexpected eof rather than a short value
+	for _, truncated := range []string{`"open`, `{"a": [1, 2`, `{"a": "}`, `"escape \`, `tru`} {
+		scanner := &jsonScanner{r: bufio.NewReader(strings.NewReader(truncated))}
+		_, err := scanner.captureValue()
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF, truncated)
+		var decoded interface{}
+		assert.Error(t, json.Unmarshal([]byte(truncated), &decoded), truncated)
+	}
+}
@@ -0,0 +1,102 @@
+package motor
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/harific/motor/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gzipBase64(t *testing.T, text string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(text))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestDecodeBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     model.BodyResponseType
+		expected string
+	}{
+		{"plain text is untouched", model.BodyResponseType{Content: "hello"}, "hello"},
+		{"base64", model.BodyResponseType{Content: base64.StdEncoding.EncodeToString([]byte(`{"ok":true}`)), Encoding: "base64"}, `{"ok":true}`},
+		{"encoding is case insensitive", model.BodyResponseType{Content: "aGk=", Encoding: "BASE64"}, "hi"},
+		{"base64 gzip", model.BodyResponseType{Content: gzipBase64(t, "compressed text"), Encoding: "base64"}, "compressed text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := DecodeBody(&tt.body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, decoded)
+		})
+	}
+}
+
+func TestDecodeBody_Invalid(t *testing.T) {
+	body := model.BodyResponseType{Content: "not base64!", Encoding: "base64"}
+	decoded, err := DecodeBody(&body)
+	assert.Error(t, err)
+	assert.Equal(t, body.Content, decoded, "undecodable bodies fall back to the stored content")
+
+	// valid base64 with a gzip header but a corrupt stream
+	corrupt := base64.StdEncoding.EncodeToString([]byte{0x1f, 0x8b, 0x08, 0x00, 0xff})
+	body = model.BodyResponseType{Content: corrupt, Encoding: "base64"}
+	decoded, err = DecodeBody(&body)
+	assert.Error(t, err)
+	assert.Equal(t, corrupt, decoded)
+}
+
+func TestSearch_DecodeBodies(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString([]byte(`{"secret":"hiddenvalue"}`))
+	har := fmt.Sprintf(`{"log": {"version": "1.2", "creator": {"name": "test", "version": "1.0"}, "entries": [
+      {
+        "startedDateTime": "2024-01-01T00:00:00Z",
+        "time": 10,
+        "request": {"method": "GET", "url": "https://example.com/encoded", "headers": [], "bodySize": 0},
+        "response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 24, "mimeType": "application/json", "text": %q, "encoding": "base64"}, "bodySize": 24},
+        "timings": {"send": 1, "wait": 8, "receive": 1}
+      }
+    ]}}`, encoded)
+
+	path := filepath.Join(t.TempDir(), "encoded.har")
+	require.NoError(t, os.WriteFile(path, []byte(har), 0644))
+
+	streamer, err := NewHARStreamer(path, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	reader, err := NewEntryReader(path, streamer.GetIndex())
+	require.NoError(t, err)
+	defer reader.Close()
+
+	searcher := NewSearcher(streamer, reader)
+	opts := DefaultSearchOptions
+	opts.SearchResponseBody = true
+
+	resultChan, err := searcher.Search(context.Background(), "hiddenvalue", opts)
+	require.NoError(t, err)
+	assert.Empty(t, collectResults(resultChan), "encoded bodies are not decoded by default")
+
+	opts.DecodeBodies = true
+	resultChan, err = searcher.Search(context.Background(), "hiddenvalue", opts)
+	require.NoError(t, err)
+	results := collectResults(resultChan)
+	require.Len(t, results, 1)
+	assert.Equal(t, "response.body", results[0].Field)
+	assert.Contains(t, results[0].Snippet, "hiddenvalue")
+}
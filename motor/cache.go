@@ -1,6 +1,11 @@
 package motor
 
-import "github.com/pb33f/harific/motor/model"
+import (
+	"container/list"
+	"sync"
+
+	"github.com/pb33f/harific/motor/model"
+)
 
 type NoOpCache struct{}
 
@@ -19,3 +24,76 @@ func (c *NoOpCache) Clear() {}
 func (c *NoOpCache) Size() int {
 	return 0
 }
+
+// DefaultCacheSize is the number of entries kept by the LRU cache when CacheSize is not set
+const DefaultCacheSize = 128
+
+// LRUCache is a fixed-capacity, concurrency-safe cache of parsed entries with O(1) lookup and eviction
+type LRUCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List // front = most recently used
+	items      map[int]*list.Element
+}
+
+type lruItem struct {
+	index int
+	entry *model.Entry
+}
+
+// NewLRUCache creates an LRU cache holding at most maxEntries (DefaultCacheSize if <= 0)
+func NewLRUCache(maxEntries int) *LRUCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultCacheSize
+	}
+	return &LRUCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		items:      make(map[int]*list.Element, maxEntries),
+	}
+}
+
+func (c *LRUCache) Get(index int) (*model.Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[index]; ok {
+		c.order.MoveToFront(el)
+		return el.Value.(*lruItem).entry, true
+	}
+	return nil, false
+}
+
+func (c *LRUCache) Put(index int, entry *model.Entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[index]; ok {
+		el.Value.(*lruItem).entry = entry
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[index] = c.order.PushFront(&lruItem{index: index, entry: entry})
+
+	if c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruItem).index)
+	}
+}
+
+func (c *LRUCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	clear(c.items)
+}
+
+func (c *LRUCache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
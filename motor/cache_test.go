@@ -1,6 +1,8 @@
 package motor
 
 import (
+	"context"
+	"sync"
 	"testing"
 
 	"github.com/pb33f/harific/motor/model"
@@ -76,3 +78,119 @@ func TestNoOpCacheMultipleEntries(t *testing.T) {
 		t.Errorf("expected size 0, got %d", cache.Size())
 	}
 }
+
+func TestLRUCacheEviction(t *testing.T) {
+	cache := NewLRUCache(2)
+
+	cache.Put(0, &model.Entry{Time: 0})
+	cache.Put(1, &model.Entry{Time: 1})
+
+	// touch 0 so 1 becomes least recently used
+	if _, ok := cache.Get(0); !ok {
+		t.Fatal("expected hit for entry 0")
+	}
+
+	cache.Put(2, &model.Entry{Time: 2})
+
+	if cache.Size() != 2 {
+		t.Errorf("expected size 2, got %d", cache.Size())
+	}
+	if _, ok := cache.Get(1); ok {
+		t.Error("expected entry 1 to be evicted")
+	}
+	if entry, ok := cache.Get(0); !ok || entry.Time != 0 {
+		t.Error("expected entry 0 to survive eviction")
+	}
+	if entry, ok := cache.Get(2); !ok || entry.Time != 2 {
+		t.Error("expected entry 2 to be cached")
+	}
+
+	// updating an existing entry replaces it without growing
+	cache.Put(2, &model.Entry{Time: 22})
+	if entry, _ := cache.Get(2); entry.Time != 22 {
+		t.Errorf("expected updated entry, got time %v", entry.Time)
+	}
+	if cache.Size() != 2 {
+		t.Errorf("expected size 2 after update, got %d", cache.Size())
+	}
+
+	cache.Clear()
+	if cache.Size() != 0 {
+		t.Errorf("expected size 0 after clear, got %d", cache.Size())
+	}
+}
+
+func TestLRUCacheDefaultSize(t *testing.T) {
+	cache := NewLRUCache(0)
+	for i := 0; i < DefaultCacheSize+10; i++ {
+		cache.Put(i, &model.Entry{})
+	}
+	if cache.Size() != DefaultCacheSize {
+		t.Errorf("expected size %d, got %d", DefaultCacheSize, cache.Size())
+	}
+}
+
+func TestLRUCacheConcurrentAccess(t *testing.T) {
+	cache := NewLRUCache(16)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Go(func() {
+			for i := 0; i < 1000; i++ {
+				idx := (i + w) % 32
+				if _, ok := cache.Get(idx); !ok {
+					cache.Put(idx, &model.Entry{Time: float64(idx)})
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	if cache.Size() > 16 {
+		t.Errorf("cache exceeded capacity: %d", cache.Size())
+	}
+}
+
+func TestStreamerCacheStats(t *testing.T) {
+	harFile, cleanup, err := generateTinyHAR()
+	if err != nil {
+		t.Fatalf("failed to generate test HAR: %v", err)
+	}
+	defer cleanup()
+
+	opts := DefaultStreamerOptions()
+	opts.EnableCache = true
+	opts.CacheSize = 4
+
+	streamer, err := NewHARStreamer(harFile, opts)
+	if err != nil {
+		t.Fatalf("failed to create streamer: %v", err)
+	}
+	defer streamer.Close()
+
+	if err := streamer.Initialize(context.Background()); err != nil {
+		t.Fatalf("failed to initialize: %v", err)
+	}
+
+	ctx := context.Background()
+	first, err := streamer.GetEntry(ctx, 3)
+	if err != nil {
+		t.Fatalf("GetEntry failed: %v", err)
+	}
+	second, err := streamer.GetEntry(ctx, 3)
+	if err != nil {
+		t.Fatalf("GetEntry failed: %v", err)
+	}
+
+	if first != second {
+		t.Error("expected cached entry to be returned on second read")
+	}
+
+	stats := streamer.Stats()
+	if stats.CacheMisses != 1 || stats.CacheHits != 1 {
+		t.Errorf("expected 1 miss and 1 hit, got misses=%d hits=%d", stats.CacheMisses, stats.CacheHits)
+	}
+	if stats.EntriesParsed != 1 {
+		t.Errorf("expected 1 parsed entry, got %d", stats.EntriesParsed)
+	}
+}
@@ -0,0 +1,101 @@
+package motor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"sync/atomic"
+
+	"github.com/pb33f/harific/motor/model"
+)
+
+// eachBatchSize is how many entries Each reads at a time; entries next to each other in the
+// file share a read
+const eachBatchSize = 32
+
+// errStopEach ends an Each call on behalf of an All loop that stopped early
+var errStopEach = errors.New("iteration stopped")
+
+// Each calls fn with every entry in index order, which is file order, one at a time on the calling
+// goroutine. it stops at the first error fn returns and returns it; a cancelled ctx stops it with
+// ctx.Err(), and an entry that cannot be read with an error naming it. fn is never called
+// concurrently and can keep the entries it is given.
+//
+// the walk covers the entries indexed when Each is called: entries Follow appends meanwhile are
+// left for the next walk. entries are read with ReadBatch, a batch at a time into the reader's
+// pooled buffers, rather than through GetEntry, so they don't push everything else out of the cache.
+func (s *DefaultHARStreamer) Each(ctx context.Context, fn func(i int, entry *model.Entry) error) error {
+	total, ok := s.entryCount()
+	if !ok {
+		return fmt.Errorf("streamer not initialized: call Initialize() first")
+	}
+
+	offsets := make([]int64, 0, eachBatchSize)
+	lengths := make([]int64, 0, eachBatchSize)
+	for start := 0; start < total; start += eachBatchSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		offsets, lengths = offsets[:0], lengths[:0]
+		s.mu.RLock()
+		// a file Follow found rewritten may now hold fewer entries than when the walk started
+		end := min(start+eachBatchSize, total, len(s.index.Entries))
+		for _, metadata := range s.index.Entries[min(start, end):end] {
+			offsets = append(offsets, metadata.FileOffset)
+			lengths = append(lengths, metadata.Length)
+		}
+		responses := s.reader.ReadBatch(ctx, offsets, lengths)
+		s.mu.RUnlock()
+
+		if len(responses) == 0 {
+			return nil
+		}
+
+		for i, resp := range responses {
+			if err := resp.GetError(); err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return ctxErr
+				}
+				atomic.AddInt64(&s.stats.parseErrors, 1)
+				return fmt.Errorf("entry %d: %w", start+i, err)
+			}
+			atomic.AddInt64(&s.stats.totalReads, 1)
+			atomic.AddInt64(&s.stats.entriesParsed, 1)
+			atomic.AddInt64(&s.stats.bytesRead, resp.GetBytesRead())
+
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := fn(start+i, resp.GetEntry()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// All is Each as a range-over-func iterator, yielding the entries in index order:
+//
+//	for entry, err := range streamer.All(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// a failed read or a cancelled ctx is yielded once with a nil entry, and ends the iteration.
+func (s *DefaultHARStreamer) All(ctx context.Context) iter.Seq2[*model.Entry, error] {
+	return func(yield func(*model.Entry, error) bool) {
+		err := s.Each(ctx, func(_ int, entry *model.Entry) error {
+			if !yield(entry, nil) {
+				return errStopEach
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStopEach) {
+			yield(nil, err)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package motor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pb33f/harific/motor/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEachStreamer(t *testing.T) *DefaultHARStreamer {
+	t.Helper()
+	harFile, cleanup, err := generateSmallHAR()
+	require.NoError(t, err)
+	t.Cleanup(cleanup)
+
+	streamer, err := NewHARStreamer(harFile, DefaultStreamerOptions())
+	require.NoError(t, err)
+	t.Cleanup(func() { streamer.Close() })
+	require.NoError(t, streamer.Initialize(context.Background()))
+	return streamer
+}
+
+func TestEach_VisitsEveryEntryInOrder(t *testing.T) {
+	streamer := newEachStreamer(t)
+	total := streamer.GetIndex().TotalEntries
+	require.Greater(t, total, eachBatchSize, "the fixture should span several batches")
+
+	want, err := streamer.GetEntries(context.Background(), 0, total)
+	require.NoError(t, err)
+
+	var visited []int
+	err = streamer.Each(context.Background(), func(i int, entry *model.Entry) error {
+		visited = append(visited, i)
+		assert.Equal(t, want[i].Request.URL, entry.Request.URL, "entry %d", i)
+		assert.Equal(t, want[i].Response.Body.Content, entry.Response.Body.Content, "entry %d", i)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, visited, total)
+	for i, index := range visited {
+		assert.Equal(t, i, index)
+	}
+}
+
+func TestEach_StopsEarly(t *testing.T) {
+	streamer := newEachStreamer(t)
+	stop := errors.New("stop")
+
+	visited := 0
+	err := streamer.Each(context.Background(), func(i int, _ *model.Entry) error {
+		visited++
+		if i == eachBatchSize+2 {
+			return stop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, eachBatchSize+3, visited)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	visited = 0
+	err = streamer.Each(ctx, func(i int, _ *model.Entry) error {
+		visited++
+		if i == 3 {
+			cancel()
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 4, visited)
+}
+
+func TestAll(t *testing.T) {
+	streamer := newEachStreamer(t)
+
+	count := 0
+	for entry, err := range streamer.All(context.Background()) {
+		require.NoError(t, err)
+		require.NotNil(t, entry)
+		count++
+	}
+	assert.Equal(t, streamer.GetIndex().TotalEntries, count)
+
+	// breaking out is not an error, and yields nothing more
+	count = 0
+	for _, err := range streamer.All(context.Background()) {
+		require.NoError(t, err)
+		count++
+		if count == 5 {
+			break
+		}
+	}
+	assert.Equal(t, 5, count)
+
+	uninitialized, err := NewHARStreamer("missing.har", DefaultStreamerOptions())
+	require.NoError(t, err)
+	yielded := 0
+	for entry, err := range uninitialized.All(context.Background()) {
+		assert.Nil(t, entry)
+		assert.Error(t, err)
+		yielded++
+	}
+	assert.Equal(t, 1, yielded)
+}
@@ -0,0 +1,155 @@
+package motor
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// EndpointStats aggregates the entries that share a normalized url, see NormalizeURL
+type EndpointStats struct {
+	Endpoint    string        `json:"endpoint"`
+	Count       int           `json:"count"`
+	AvgDuration float64       `json:"avgDuration"` // milliseconds, over entries with a known time
+	MaxDuration float64       `json:"maxDuration"`
+	TotalBytes  int64         `json:"totalBytes"`  // response bytes, unknown sizes not counted
+	StatusCodes []CountBucket `json:"statusCodes"` // ascending by status code
+	Indices     []int         `json:"indices"`     // entries in the group, in the order given
+}
+
+// NormalizeURL reduces a url to the endpoint it calls: the query string and fragment are dropped,
+// the host is lowercased and path segments that look like ids are templatized, so
+// "https://API.example.com/users/42/orders?page=2" becomes "https://api.example.com/users/{id}/orders".
+// numbers become {id}, uuids {uuid} and long hex strings {hash}.
+func NormalizeURL(rawURL string) string {
+	if i := strings.IndexAny(rawURL, "?#"); i >= 0 {
+		rawURL = rawURL[:i]
+	}
+
+	// lowercase the scheme and host, leaving the path's case alone
+	origin, path := "", rawURL
+	if scheme := strings.Index(rawURL, "://"); scheme >= 0 {
+		hostEnd := strings.IndexByte(rawURL[scheme+3:], '/')
+		if hostEnd < 0 {
+			return strings.ToLower(rawURL)
+		}
+		origin, path = strings.ToLower(rawURL[:scheme+3+hostEnd]), rawURL[scheme+3+hostEnd:]
+	}
+	return origin + templatizePath(path)
+}
+
+// templatizePath replaces the id-like segments of a slash separated path
+func templatizePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		switch {
+		case segment == "":
+		case isNumericSegment(segment):
+			segments[i] = "{id}"
+		case isUUIDSegment(segment):
+			segments[i] = "{uuid}"
+		case len(segment) >= 16 && isHexSegment(segment):
+			segments[i] = "{hash}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func isNumericSegment(segment string) bool {
+	return strings.Trim(segment, "0123456789") == ""
+}
+
+func isUUIDSegment(segment string) bool {
+	if len(segment) != 36 {
+		return false
+	}
+	for i, c := range segment {
+		if i == 8 || i == 13 || i == 18 || i == 23 {
+			if c != '-' {
+				return false
+			}
+		} else if !isHexDigit(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func isHexSegment(segment string) bool {
+	for _, c := range segment {
+		if !isHexDigit(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func isHexDigit(c rune) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// Endpoints groups the entries at indices by NormalizeURL and aggregates each group from index
+// metadata alone, most requested endpoint first (ties by endpoint). nil indices means every entry;
+// out of range indices are ignored.
+func (idx *Index) Endpoints(indices []int) []EndpointStats {
+	if indices == nil {
+		indices = make([]int, len(idx.Entries))
+		for i := range indices {
+			indices[i] = i
+		}
+	}
+
+	groups := make(map[string]*EndpointStats)
+	statuses := make(map[string]map[int]int)
+	timed := make(map[string]int)
+	for _, i := range indices {
+		if i < 0 || i >= len(idx.Entries) {
+			continue
+		}
+		entry := idx.Entries[i]
+		endpoint := NormalizeURL(entry.URL)
+		group, ok := groups[endpoint]
+		if !ok {
+			group = &EndpointStats{Endpoint: endpoint}
+			groups[endpoint] = group
+			statuses[endpoint] = make(map[int]int)
+		}
+
+		group.Count++
+		group.Indices = append(group.Indices, i)
+		statuses[endpoint][entry.StatusCode]++
+		if entry.Duration > 0 {
+			group.AvgDuration += entry.Duration
+			group.MaxDuration = max(group.MaxDuration, entry.Duration)
+			timed[endpoint]++
+		}
+		if entry.ResponseSize > 0 {
+			group.TotalBytes += entry.ResponseSize
+		}
+	}
+
+	endpoints := make([]EndpointStats, 0, len(groups))
+	for endpoint, group := range groups {
+		if timed[endpoint] > 0 {
+			group.AvgDuration /= float64(timed[endpoint])
+		}
+		codes := make([]int, 0, len(statuses[endpoint]))
+		for code := range statuses[endpoint] {
+			codes = append(codes, code)
+		}
+		slices.Sort(codes)
+		for _, code := range codes {
+			group.StatusCodes = append(group.StatusCodes, CountBucket{Key: strconv.Itoa(code), Count: statuses[endpoint][code]})
+		}
+		endpoints = append(endpoints, *group)
+	}
+
+	slices.SortFunc(endpoints, func(a, b EndpointStats) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Endpoint, b.Endpoint)
+	})
+	return endpoints
+}
@@ -0,0 +1,58 @@
+package motor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://API.Example.com/users/42/orders?page=2", "https://api.example.com/users/{id}/orders"},
+		{"https://example.com/Assets/App.JS#top", "https://example.com/Assets/App.JS"},
+		{"https://example.com/items/3fa85f64-5717-4562-b3fc-2c963f66afa6", "https://example.com/items/{uuid}"},
+		{"https://example.com/blobs/9f86d081884c7d65/raw", "https://example.com/blobs/{hash}/raw"},
+		{"https://example.com/v2/cafe", "https://example.com/v2/cafe"},
+		{"https://example.com/", "https://example.com/"},
+		{"https://Example.com?q=1", "https://example.com"},
+		{"/relative/7?x", "/relative/{id}"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, NormalizeURL(tt.url), tt.url)
+	}
+}
+
+func TestIndexEndpoints(t *testing.T) {
+	idx := &Index{
+		Entries: []*EntryMetadata{
+			{URL: "https://example.com/users/1", StatusCode: 200, Duration: 100, ResponseSize: 500},
+			{URL: "https://example.com/users/2?full=1", StatusCode: 404, Duration: 300, ResponseSize: 200},
+			{URL: "https://example.com/health", StatusCode: 200, Duration: 5, ResponseSize: 10},
+			{URL: "https://example.com/users/3", StatusCode: 200, Duration: -1, ResponseSize: -1},
+		},
+	}
+
+	endpoints := idx.Endpoints(nil)
+	require.Len(t, endpoints, 2)
+
+	users := endpoints[0]
+	assert.Equal(t, "https://example.com/users/{id}", users.Endpoint)
+	assert.Equal(t, 3, users.Count)
+	assert.Equal(t, 200.0, users.AvgDuration, "entries without a time are left out of the average")
+	assert.Equal(t, 300.0, users.MaxDuration)
+	assert.Equal(t, int64(700), users.TotalBytes)
+	assert.Equal(t, []CountBucket{{"200", 2}, {"404", 1}}, users.StatusCodes)
+	assert.Equal(t, []int{0, 1, 3}, users.Indices)
+
+	assert.Equal(t, "https://example.com/health", endpoints[1].Endpoint)
+
+	filtered := idx.Endpoints([]int{2, 3, 99})
+	require.Len(t, filtered, 2)
+	assert.Equal(t, "https://example.com/health", filtered[0].Endpoint, "ties are ordered by endpoint")
+	assert.Equal(t, 0.0, filtered[1].AvgDuration)
+	assert.Empty(t, (&Index{}).Endpoints(nil))
+}
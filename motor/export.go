@@ -0,0 +1,207 @@
+package motor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pb33f/harific/motor/model"
+)
+
+// ExportProgress reports how many of the selected entries have been read for an export
+type ExportProgress struct {
+	EntriesDone  int
+	TotalEntries int
+}
+
+// exportProgressSteps is roughly how many progress updates an export sends
+const exportProgressSteps = 100
+
+// exportBufferPool holds the buffers entries are encoded into before they are written out
+var exportBufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// ExportHAR writes the entries at indices (nil = every entry) to w as a new har. the log keeps
+// the source file's version, creator and browser, and only the pages the exported entries
+// reference. entries are written in the order given. progressChan, if not nil, receives
+// updates while entries are read and is closed when ExportHAR returns.
+//
+// the har is streamed: entries are read and encoded one at a time, so memory stays at about one
+// entry however many are exported. the output is the same indented json as encoding the whole
+// har at once.
+func ExportHAR(ctx context.Context, streamer HARStreamer, indices []int, w io.Writer, progressChan chan<- ExportProgress) error {
+	if progressChan != nil {
+		defer close(progressChan)
+	}
+
+	index := streamer.GetIndex()
+	if index == nil {
+		return fmt.Errorf("streamer not initialized")
+	}
+
+	if indices == nil {
+		indices = make([]int, len(index.Entries))
+		for i := range indices {
+			indices[i] = i
+		}
+	}
+
+	version := index.Version
+	if version == "" {
+		version = "1.2"
+	}
+	creator := model.Creator{Name: "harific"}
+	if index.Creator != nil {
+		creator = *index.Creator
+	}
+
+	// pages are written before the entries, so the index says which ones the entries reference
+	pageRefs := make(map[string]bool)
+	for _, entryIndex := range indices {
+		metadata, err := streamer.GetMetadata(entryIndex)
+		if err != nil {
+			return fmt.Errorf("failed to read entry %d: %w", entryIndex, err)
+		}
+		if metadata.PageRef != "" {
+			pageRefs[metadata.PageRef] = true
+		}
+	}
+	var pages []model.Page
+	for _, page := range index.Pages {
+		if pageRefs[page.ID] {
+			pages = append(pages, page)
+		}
+	}
+
+	buf := exportBufferPool.Get().(*bytes.Buffer)
+	defer exportBufferPool.Put(buf)
+	out := &exportWriter{w: bufio.NewWriter(w), buf: buf}
+
+	// the same layout json.Encoder.SetIndent("", "  ") gives a model.HAR
+	out.raw("{\n  \"log\": {\n")
+	out.field("version", version)
+	out.field("creator", creator)
+	if index.Browser != nil {
+		out.field("browser", index.Browser)
+	}
+	if len(pages) > 0 {
+		out.field("pages", pages)
+	}
+	out.raw(`    "entries": [`)
+
+	every := max(len(indices)/exportProgressSteps, 1)
+	for i, entryIndex := range indices {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		entry, err := streamer.GetEntry(ctx, entryIndex)
+		if err != nil {
+			return fmt.Errorf("failed to read entry %d: %w", entryIndex, err)
+		}
+		if i > 0 {
+			out.raw(",")
+		}
+		out.raw("\n      ")
+		out.value(entry, "      ")
+		if out.err != nil {
+			return fmt.Errorf("failed to write har: %w", out.err)
+		}
+
+		if progressChan != nil && ((i+1)%every == 0 || i+1 == len(indices)) {
+			select {
+			case progressChan <- ExportProgress{EntriesDone: i + 1, TotalEntries: len(indices)}:
+			default:
+			}
+		}
+	}
+
+	if len(indices) > 0 {
+		out.raw("\n    ")
+	}
+	out.raw("]\n  }\n}\n")
+	if out.err == nil {
+		out.err = out.w.Flush()
+	}
+	if out.err != nil {
+		return fmt.Errorf("failed to write har: %w", out.err)
+	}
+	return nil
+}
+
+// exportWriter writes the pieces of an exported har, keeping the first error so the caller
+// checks once per entry rather than once per write
+type exportWriter struct {
+	w   *bufio.Writer
+	buf *bytes.Buffer // each value is encoded here before it is written
+	err error
+}
+
+func (e *exportWriter) raw(s string) {
+	if e.err == nil {
+		_, e.err = e.w.WriteString(s)
+	}
+}
+
+// field writes a member of the log object, which is never the last because entries follows
+func (e *exportWriter) field(key string, value any) {
+	e.raw(`    "` + key + `": `)
+	e.value(value, "    ")
+	e.raw(",\n")
+}
+
+// value writes v as indented json continuing a line indented by prefix
+func (e *exportWriter) value(v any, prefix string) {
+	if e.err != nil {
+		return
+	}
+	e.buf.Reset()
+	encoder := json.NewEncoder(e.buf)
+	encoder.SetIndent(prefix, "  ")
+	if e.err = encoder.Encode(v); e.err != nil {
+		return
+	}
+	_, e.err = e.w.Write(bytes.TrimSuffix(e.buf.Bytes(), []byte("\n")))
+}
+
+// ExportHARFile is ExportHAR into the file at path. the har is written to a temporary file
+// beside path and renamed into place, so a failed export never leaves a truncated har.
+func ExportHARFile(ctx context.Context, streamer HARStreamer, indices []int, path string, progressChan chan<- ExportProgress) error {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		if progressChan != nil {
+			close(progressChan)
+		}
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	tmpPath := file.Name()
+
+	if err := ExportHAR(ctx, streamer, indices, file, progressChan); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	// CreateTemp files are private; an exported har gets normal file permissions
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to save export file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to save export file: %w", err)
+	}
+	return nil
+}
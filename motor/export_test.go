@@ -0,0 +1,121 @@
+package motor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/harific/motor/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportHARFile_Subset(t *testing.T) {
+	streamer := initPagesFixture(t)
+	path := filepath.Join(t.TempDir(), "subset.har")
+
+	progressChan := make(chan ExportProgress, 10)
+	require.NoError(t, ExportHARFile(context.Background(), streamer, []int{1, 3}, path, progressChan))
+
+	var last ExportProgress
+	for progress := range progressChan {
+		last = progress
+	}
+	assert.Equal(t, ExportProgress{EntriesDone: 2, TotalEntries: 2}, last)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+
+	// the export re-opens as a har of its own
+	exported, err := NewHARStreamer(path, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer exported.Close()
+	require.NoError(t, exported.Initialize(context.Background()))
+
+	idx := exported.GetIndex()
+	assert.Equal(t, "1.2", idx.Version)
+	require.NotNil(t, idx.Creator)
+	assert.Equal(t, "test", idx.Creator.Name)
+	require.Len(t, idx.Entries, 2)
+	assert.Equal(t, "https://example.com/checkout", idx.Entries[0].URL)
+	assert.Equal(t, "https://example.com/beacon", idx.Entries[1].URL)
+
+	require.Len(t, idx.Pages, 1, "only referenced pages are kept")
+	assert.Equal(t, "page_2", idx.Pages[0].ID)
+
+	entry, err := exported.GetEntry(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 204, entry.Response.StatusCode)
+}
+
+func TestExportHARFile_All(t *testing.T) {
+	streamer := initPagesFixture(t)
+	path := filepath.Join(t.TempDir(), "all.har")
+
+	require.NoError(t, ExportHARFile(context.Background(), streamer, nil, path, nil))
+
+	exported, err := NewHARStreamer(path, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer exported.Close()
+	require.NoError(t, exported.Initialize(context.Background()))
+
+	idx := exported.GetIndex()
+	assert.Len(t, idx.Entries, 4)
+	assert.Len(t, idx.Pages, 2)
+}
+
+func TestExportHARFile_FailureLeavesNoFile(t *testing.T) {
+	streamer := initPagesFixture(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bad.har")
+
+	err := ExportHARFile(context.Background(), streamer, []int{0, 99}, path, nil)
+	require.Error(t, err)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files, "neither the export nor its temporary file remain")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, ExportHARFile(ctx, streamer, nil, path, nil), context.Canceled)
+}
+
+func TestExportHAR_MatchesWholeEncoding(t *testing.T) {
+	streamer := initPagesFixture(t)
+	index := streamer.GetIndex()
+
+	for _, indices := range [][]int{{1, 3}, {2}, {}, nil} {
+		var streamed bytes.Buffer
+		require.NoError(t, ExportHAR(context.Background(), streamer, indices, &streamed, nil))
+
+		// what encoding the whole har in one go would have written
+		har := model.HAR{Log: model.Log{Version: index.Version, Creator: *index.Creator, Browser: index.Browser, Entries: []model.Entry{}}}
+		selected := indices
+		if selected == nil {
+			selected = []int{0, 1, 2, 3}
+		}
+		refs := make(map[string]bool)
+		for _, i := range selected {
+			entry, err := streamer.GetEntry(context.Background(), i)
+			require.NoError(t, err)
+			har.Log.Entries = append(har.Log.Entries, *entry)
+			refs[entry.PageRef] = true
+		}
+		for _, page := range index.Pages {
+			if refs[page.ID] {
+				har.Log.Pages = append(har.Log.Pages, page)
+			}
+		}
+		var whole bytes.Buffer
+		encoder := json.NewEncoder(&whole)
+		encoder.SetIndent("", "  ")
+		require.NoError(t, encoder.Encode(har))
+
+		assert.Equal(t, whole.String(), streamed.String(), "indices %v", indices)
+	}
+}
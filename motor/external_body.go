@@ -0,0 +1,143 @@
+package motor
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+
+	"github.com/pb33f/harific/motor/model"
+)
+
+// externalBodyPath is where an entry references a response body stored in a file of its own
+var externalBodyPath = []string{"response", "content", "_file"}
+
+// ExternalBodies resolves response bodies that a har references by file (content._file) instead
+// of storing as text. references are relative to the har's directory and are only followed
+// within the allowed directory, symlinks included.
+type ExternalBodies struct {
+	root   *os.Root
+	harDir string
+}
+
+// NewExternalBodies resolves body references against harDir, refusing any that lead outside
+// allowedDir. Close releases the allowed directory.
+func NewExternalBodies(harDir, allowedDir string) (*ExternalBodies, error) {
+	harDir, err := filepath.Abs(harDir)
+	if err != nil {
+		return nil, err
+	}
+	allowedDir, err = filepath.Abs(allowedDir)
+	if err != nil {
+		return nil, err
+	}
+	root, err := os.OpenRoot(allowedDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open body directory: %w", err)
+	}
+	return &ExternalBodies{root: root, harDir: harDir}, nil
+}
+
+// Close releases the allowed directory
+func (e *ExternalBodies) Close() error {
+	return e.root.Close()
+}
+
+// open opens the file a body reference names
+func (e *ExternalBodies) open(ref string) (*os.File, error) {
+	path := ref
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(e.harDir, path)
+	}
+	rel, err := filepath.Rel(e.root.Name(), path)
+	if err != nil || !filepath.IsLocal(rel) {
+		return nil, fmt.Errorf("body file %s is outside %s", ref, e.root.Name())
+	}
+	file, err := e.root.Open(rel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open body file %s: %w", ref, err)
+	}
+	return file, nil
+}
+
+// load fills in the response body of an entry that references one by file and has no text. a
+// body that is not valid utf-8 is stored as base64, as a har would store it inline.
+func (e *ExternalBodies) load(entry *model.Entry, maxSize int64) error {
+	body := &entry.Response.Body
+	if body.File == "" || body.Content != "" {
+		return nil
+	}
+
+	file, err := e.open(body.File)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read body file %s: %w", body.File, err)
+	}
+	if int64(len(data)) > maxSize {
+		return fmt.Errorf("body file %s exceeds maximum allowed size %d", body.File, maxSize)
+	}
+
+	if utf8.Valid(data) {
+		body.Content, body.Encoding = string(data), ""
+	} else {
+		body.Content, body.Encoding = base64.StdEncoding.EncodeToString(data), "base64"
+	}
+	return nil
+}
+
+// stream streams a referenced body as load would store it, without holding it in memory: the
+// file is read once to tell text from binary, then again as the caller reads
+func (e *ExternalBodies) stream(ref string) (io.ReadCloser, error) {
+	file, err := e.open(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	text, err := validUTF8(bufio.NewReaderSize(file, 64*1024))
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to read body file %s: %w", ref, err)
+	}
+	if text {
+		return file, nil
+	}
+
+	encoded, writer := io.Pipe()
+	go func() {
+		defer file.Close()
+		encoder := base64.NewEncoder(base64.StdEncoding, writer)
+		_, err := io.Copy(encoder, file)
+		if err == nil {
+			err = encoder.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+	return encoded, nil
+}
+
+// validUTF8 reports whether everything left in r is valid utf-8
+func validUTF8(r *bufio.Reader) (bool, error) {
+	for {
+		c, size, err := r.ReadRune()
+		if err == io.EOF {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if c == utf8.RuneError && size == 1 {
+			return false, nil
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package motor
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// externalBodyEntry is an entry whose response body is the file ref instead of text
+func externalBodyEntry(url, ref string) string {
+	return fmt.Sprintf(`{"startedDateTime": "2024-01-01T00:00:00Z", "time": 1,
+   "request": {"method": "GET", "url": %q, "headers": [], "bodySize": 0},
+   "response": {"status": 200, "statusText": "OK", "headers": [],
+     "content": {"size": 0, "mimeType": "application/octet-stream", "_file": %q}, "bodySize": 0},
+   "timings": {"send": 1, "wait": 1, "receive": 1}}`, url, ref)
+}
+
+// openExternalBodyStreamer writes a har referencing the given body files into capture/, with
+// bodies/ inside it and secret.txt beside it, and opens it with ExternalBodyDir set to capture/
+func openExternalBodyStreamer(t *testing.T, refs ...string) *DefaultHARStreamer {
+	t.Helper()
+	base := t.TempDir()
+	capture := filepath.Join(base, "capture")
+	require.NoError(t, os.MkdirAll(filepath.Join(capture, "bodies"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(capture, "bodies", "users.json"), []byte(`{"users": ["ada"]}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(capture, "bodies", "logo.png"), []byte{0x89, 'P', 'N', 'G', 0xff, 0x00}, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(base, "secret.txt"), []byte("secret"), 0644))
+
+	entries := make([]string, len(refs))
+	for i, ref := range refs {
+		entries[i] = externalBodyEntry(fmt.Sprintf("https://example.com/%d", i), ref)
+	}
+	har := `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1.0"}, "entries": [` +
+		strings.Join(entries, ",\n") + `]}}`
+	path := filepath.Join(capture, "proxy.har")
+	require.NoError(t, os.WriteFile(path, []byte(har), 0644))
+
+	opts := DefaultStreamerOptions()
+	opts.ExternalBodyDir = capture
+	streamer, err := NewHARStreamer(path, opts)
+	require.NoError(t, err)
+	t.Cleanup(func() { streamer.Close() })
+	require.NoError(t, streamer.Initialize(context.Background()))
+	return streamer
+}
+
+func TestExternalBodies_GetEntry(t *testing.T) {
+	streamer := openExternalBodyStreamer(t, "bodies/users.json", "bodies/logo.png")
+	ctx := context.Background()
+
+	entry, err := streamer.GetEntry(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, `{"users": ["ada"]}`, entry.Response.Body.Content)
+	assert.Empty(t, entry.Response.Body.Encoding)
+
+	entry, err = streamer.GetEntry(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "base64", entry.Response.Body.Encoding, "a binary body is stored as base64")
+	decoded, err := base64.StdEncoding.DecodeString(entry.Response.Body.Content)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x89, 'P', 'N', 'G', 0xff, 0x00}, decoded)
+}
+
+func TestExternalBodies_StreamMatchesEntry(t *testing.T) {
+	streamer := openExternalBodyStreamer(t, "bodies/users.json", "bodies/logo.png")
+
+	for i := range 2 {
+		entry, err := streamer.GetEntry(context.Background(), i)
+		require.NoError(t, err)
+
+		offset := streamer.GetIndex().Entries[i].FileOffset
+		body, err := streamer.reader.StreamResponseBody(offset)
+		require.NoError(t, err)
+		data, err := io.ReadAll(body)
+		body.Close()
+		require.NoError(t, err)
+		assert.Equal(t, entry.Response.Body.Content, string(data), "entry %d", i)
+	}
+}
+
+func TestExternalBodies_RefusesEscapes(t *testing.T) {
+	streamer := openExternalBodyStreamer(t, "../secret.txt", "bodies/missing.json")
+	secret := filepath.Join(filepath.Dir(filepath.Dir(streamer.filePath)), "secret.txt")
+	link := filepath.Join(filepath.Dir(streamer.filePath), "bodies", "link.txt")
+	require.NoError(t, os.Symlink(secret, link))
+
+	_, err := streamer.GetEntry(context.Background(), 0)
+	assert.ErrorContains(t, err, "outside")
+
+	_, err = streamer.GetEntry(context.Background(), 1)
+	assert.Error(t, err, "a missing body file fails the read")
+
+	_, err = streamer.external.open("bodies/link.txt")
+	assert.Error(t, err, "a symlink out of the directory is not followed")
+}
+
+func TestExternalBodies_Disabled(t *testing.T) {
+	reader, index := openBodyStreamReader(t, `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1.0"}, "entries": [`+
+		externalBodyEntry("https://example.com/a", "bodies/users.json")+`]}}`)
+
+	entry, err := reader.ReadAt(index.Entries[0].FileOffset, index.Entries[0].Length)
+	require.NoError(t, err)
+	assert.Empty(t, entry.Response.Body.Content)
+	assert.Equal(t, "bodies/users.json", entry.Response.Body.File)
+	assert.Empty(t, streamBody(t, reader, index.Entries[0].FileOffset))
+}
+
+func TestExternalBodies_ReadBatch(t *testing.T) {
+	streamer := openExternalBodyStreamer(t, "bodies/users.json", "../secret.txt")
+	entries := streamer.GetIndex().Entries
+
+	responses := streamer.reader.ReadBatch(context.Background(),
+		[]int64{entries[0].FileOffset, entries[1].FileOffset}, []int64{entries[0].Length, entries[1].Length})
+	require.Len(t, responses, 2)
+	require.NoError(t, responses[0].GetError())
+	assert.Equal(t, `{"users": ["ada"]}`, responses[0].GetEntry().Response.Body.Content)
+	assert.ErrorContains(t, responses[1].GetError(), "outside")
+}
@@ -0,0 +1,140 @@
+package motor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+// followTailWindow is how much of the indexed part of a followed file is hashed to notice it
+// being rewritten rather than appended to
+const followTailWindow = 64 * 1024
+
+// FollowResult describes what one Follow call found in the file
+type FollowResult struct {
+	Added   int  // entries appended to the index
+	Rebuilt bool // the file was rewritten, so the index was built again and Added counts every entry
+}
+
+// followState is what a followed file looked like when it was last indexed
+type followState struct {
+	size    int64
+	modTime time.Time
+	tail    uint64 // hash of the followTailWindow bytes before Index.EntriesEnd
+}
+
+// mark records the file as just indexed
+func (f *followState) mark(file *os.File, info os.FileInfo, index *Index) error {
+	tail, err := tailHash(file, index.EntriesEnd)
+	if err != nil {
+		return err
+	}
+	f.size = info.Size()
+	f.modTime = info.ModTime()
+	f.tail = tail
+	return nil
+}
+
+// tailHash hashes the followTailWindow bytes of file that end at end
+func tailHash(file *os.File, end int64) (uint64, error) {
+	start := max(end-followTailWindow, 0)
+	hash := xxhash.New()
+	if _, err := io.Copy(hash, io.NewSectionReader(file, start, end-start)); err != nil {
+		return 0, fmt.Errorf("failed to hash %s: %w", file.Name(), err)
+	}
+	return hash.Sum64(), nil
+}
+
+// Follow brings the index of a file opened with StreamerOptions.Follow up to date with what has
+// been written since. appended entries are indexed from where the last call stopped; a file that
+// shrank, or whose indexed bytes changed, was rewritten and is indexed again from scratch. an
+// unchanged file costs a stat. entries can be read while Follow runs, but a reader made with
+// NewEntryReader over the index needs Refresh, or replacing after a rebuild, to see the new ones.
+func (s *DefaultHARStreamer) Follow(ctx context.Context) (FollowResult, error) {
+	if _, ok := s.entryCount(); !ok {
+		return FollowResult{}, fmt.Errorf("streamer not initialized: call Initialize() first")
+	}
+	if !s.options.Follow {
+		return FollowResult{}, fmt.Errorf("streamer was not opened with StreamerOptions.Follow")
+	}
+
+	file, err := os.Open(s.filePath)
+	if err != nil {
+		return FollowResult{}, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return FollowResult{}, fmt.Errorf("failed to stat file: %w", err)
+	}
+	if info.Size() == s.follow.size && info.ModTime().Equal(s.follow.modTime) {
+		return FollowResult{}, nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return FollowResult{}, ctx.Err()
+	default:
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	end := s.index.EntriesEnd
+	rewritten := info.Size() < end
+	if !rewritten {
+		tail, err := tailHash(file, end)
+		if err != nil {
+			return FollowResult{}, err
+		}
+		rewritten = tail != s.follow.tail
+	}
+	if rewritten {
+		return s.rebuild(file, info)
+	}
+
+	if _, err := file.Seek(end, io.SeekStart); err != nil {
+		return FollowResult{}, fmt.Errorf("seek failed: %w", err)
+	}
+	// searches and the tui read the index GetIndex returned without the lock, so the entries are
+	// appended to a copy that replaces it, as a rebuild replaces it
+	index := s.index.clone()
+	added, err := s.newBuilder(s.filePath).Resume(index, file)
+	if err != nil {
+		return FollowResult{Added: added}, fmt.Errorf("failed to index appended entries: %w", err)
+	}
+	index.FileSize = info.Size()
+	s.reader.RefreshIndex(index)
+	s.index = index
+
+	return FollowResult{Added: added}, s.follow.mark(file, info, s.index)
+}
+
+// rebuild indexes a rewritten file again, replacing the index and reader; the caller holds s.mu
+func (s *DefaultHARStreamer) rebuild(file *os.File, info os.FileInfo) (FollowResult, error) {
+	builder := s.newBuilder(s.filePath)
+	builder.partial = true
+	index, err := builder.Build(io.NewSectionReader(file, 0, info.Size()))
+	if err != nil {
+		return FollowResult{}, fmt.Errorf("failed to rebuild index: %w", err)
+	}
+
+	reader, err := s.newReader(s.filePath, index)
+	if err != nil {
+		return FollowResult{}, fmt.Errorf("failed to create reader: %w", err)
+	}
+
+	s.reader.Close()
+	s.index = index
+	s.reader = reader
+	if s.cache != nil {
+		s.cache.Clear() // indices now refer to different entries
+	}
+
+	return FollowResult{Added: index.TotalEntries, Rebuilt: true}, s.follow.mark(file, info, index)
+}
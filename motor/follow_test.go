@@ -0,0 +1,260 @@
+package motor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const followHead = `{"log": {"version": "1.2", "creator": {"name": "proxy", "version": "1.0"}, "entries": [`
+
+func followEntry(name string) string {
+	return fmt.Sprintf(`{"startedDateTime": "2024-01-01T00:00:00Z", "time": 1,
+ "request": {"method": "GET", "url": "https://example.com/%s", "headers": [], "bodySize": 0},
+ "response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 4, "mimeType": "text/plain", "text": "%s"}, "bodySize": 4}}`, name, name)
+}
+
+// followHAR is a har holding the named entries, closed or cut off after the last one as a
+// proxy writing it would leave it
+func followHAR(closed bool, names ...string) string {
+	entries := make([]string, len(names))
+	for i, name := range names {
+		entries[i] = followEntry(name)
+	}
+	har := followHead + strings.Join(entries, ",\n")
+	if closed {
+		har += "]}}"
+	}
+	return har
+}
+
+func openFollowed(t *testing.T, content string) (*DefaultHARStreamer, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "live.har")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	opts := DefaultStreamerOptions()
+	opts.Follow = true
+	opts.UseIndexSidecar = true // ignored while following
+	streamer, err := NewHARStreamer(path, opts)
+	require.NoError(t, err)
+	t.Cleanup(func() { streamer.Close() })
+	require.NoError(t, streamer.Initialize(context.Background()))
+	return streamer, path
+}
+
+func entryURLs(t *testing.T, streamer *DefaultHARStreamer) []string {
+	t.Helper()
+	index := streamer.GetIndex()
+	entries, err := streamer.GetEntries(context.Background(), 0, index.TotalEntries)
+	require.NoError(t, err)
+	urls := make([]string, len(entries))
+	for i, entry := range entries {
+		urls[i] = entry.Request.URL
+	}
+	return urls
+}
+
+func TestFollow_AppendedEntries(t *testing.T) {
+	streamer, path := openFollowed(t, followHAR(true, "aaaa"))
+	require.Equal(t, 1, streamer.GetIndex().TotalEntries)
+
+	// the writer replaces the closing brackets with the next entry and closes the file again
+	require.NoError(t, os.WriteFile(path, []byte(followHAR(true, "aaaa", "bbbb", "cccc")), 0644))
+
+	result, err := streamer.Follow(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, FollowResult{Added: 2}, result)
+	assert.Equal(t, []string{"https://example.com/aaaa", "https://example.com/bbbb", "https://example.com/cccc"}, entryURLs(t, streamer))
+	assert.Equal(t, 3, streamer.GetIndex().UniqueURLs)
+
+	body, err := streamer.reader.StreamResponseBody(streamer.GetIndex().Entries[2].FileOffset)
+	require.NoError(t, err)
+	defer body.Close()
+
+	// nothing new
+	result, err = streamer.Follow(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, FollowResult{}, result)
+}
+
+func TestFollow_EntryStillBeingWritten(t *testing.T) {
+	whole := followHAR(false, "aaaa", "bbbb")
+	cut := len(followHAR(false, "aaaa")) + 20 // part way into the second entry
+
+	streamer, path := openFollowed(t, whole[:cut])
+	assert.Equal(t, 1, streamer.GetIndex().TotalEntries)
+
+	result, err := streamer.Follow(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, result.Added)
+
+	require.NoError(t, os.WriteFile(path, []byte(whole), 0644))
+	result, err = streamer.Follow(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, FollowResult{Added: 1}, result)
+	assert.Equal(t, []string{"https://example.com/aaaa", "https://example.com/bbbb"}, entryURLs(t, streamer))
+}
+
+func TestFollow_EmptyEntries(t *testing.T) {
+	streamer, path := openFollowed(t, followHead)
+	assert.Zero(t, streamer.GetIndex().TotalEntries)
+
+	require.NoError(t, os.WriteFile(path, []byte(followHAR(false, "aaaa")), 0644))
+	result, err := streamer.Follow(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, FollowResult{Added: 1}, result)
+	assert.Equal(t, []string{"https://example.com/aaaa"}, entryURLs(t, streamer))
+}
+
+func TestFollow_RewrittenFile(t *testing.T) {
+	streamer, path := openFollowed(t, followHAR(true, "aaaa", "bbbb"))
+	oldReader := streamer.reader
+
+	require.NoError(t, os.WriteFile(path, []byte(followHAR(true, "xxxx", "yyyy", "zzzz")), 0644))
+	result, err := streamer.Follow(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, FollowResult{Added: 3, Rebuilt: true}, result)
+	assert.NotSame(t, oldReader, streamer.reader)
+	assert.Equal(t, []string{"https://example.com/xxxx", "https://example.com/yyyy", "https://example.com/zzzz"}, entryURLs(t, streamer))
+
+	// a truncated file is a rewrite too
+	require.NoError(t, os.WriteFile(path, []byte(followHAR(true, "xxxx")), 0644))
+	result, err = streamer.Follow(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, FollowResult{Added: 1, Rebuilt: true}, result)
+}
+
+// reads racing a rebuild see the old index or the new one; run with -race to check the locking
+func TestFollow_ReadsDuringRebuild(t *testing.T) {
+	streamer, path := openFollowed(t, followHAR(true, "aaaa", "bbbb"))
+
+	done := make(chan struct{})
+	readErrs := make(chan error, 1)
+	go func() {
+		defer close(readErrs)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if _, err := streamer.GetMetadata(0); err != nil {
+				readErrs <- err
+				return
+			}
+			if _, err := streamer.GetEntry(context.Background(), 0); err != nil {
+				readErrs <- err
+				return
+			}
+			if _, err := streamer.GetEntries(context.Background(), 0, 1); err != nil {
+				readErrs <- err
+				return
+			}
+		}
+	}()
+
+	for i := range 5 {
+		names := []string{"xxxx", "yyyy"}
+		if i%2 == 1 {
+			names = []string{"aaaa", "bbbb", "cccc"}
+		}
+		require.NoError(t, os.WriteFile(path, []byte(followHAR(true, names...)), 0644))
+		_, err := streamer.Follow(context.Background())
+		require.NoError(t, err)
+	}
+	close(done)
+	assert.NoError(t, <-readErrs)
+}
+
+// searches racing a follow that appends entries read the index they were handed while the
+// streamer swaps in the grown copy; run with -race to check none of it is written in place
+func TestFollow_SearchDuringAppend(t *testing.T) {
+	names := []string{"aaaa"}
+	streamer, path := openFollowed(t, followHAR(true, names...))
+	searcher := NewSearcher(streamer, streamer.reader)
+
+	done := make(chan struct{})
+	searchErrs := make(chan error, 1)
+	go func() {
+		defer close(searchErrs)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			resultChan, err := searcher.Search(context.Background(), "aaaa", DefaultSearchOptions)
+			if err != nil {
+				searchErrs <- err
+				return
+			}
+			for range resultChan {
+			}
+		}
+	}()
+
+	for i := range 10 {
+		names = append(names, fmt.Sprintf("n%03d", i))
+		require.NoError(t, os.WriteFile(path, []byte(followHAR(true, names...)), 0644))
+		result, err := streamer.Follow(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, FollowResult{Added: 1}, result)
+	}
+	close(done)
+	assert.NoError(t, <-searchErrs)
+	assert.Equal(t, len(names), streamer.GetIndex().TotalEntries)
+}
+
+func TestFollow_NotFollowing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "static.har")
+	require.NoError(t, os.WriteFile(path, []byte(followHAR(true, "aaaa")), 0644))
+
+	streamer, err := NewHARStreamer(path, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	_, err = streamer.Follow(context.Background())
+	assert.Error(t, err)
+
+	_, err = NewHARStreamerFromReader(strings.NewReader(followHAR(true, "aaaa")), StreamerOptions{Follow: true})
+	assert.Error(t, err)
+}
+
+func TestIndexBuilder_ResumeMatchesFullBuild(t *testing.T) {
+	first := followHAR(false, "aaaa")
+	whole := followHAR(true, "aaaa", "bbbb", "cccc")
+
+	builder := NewIndexBuilder("live.har")
+	builder.partial = true
+	index, err := builder.Build(strings.NewReader(first))
+	require.NoError(t, err)
+
+	added, err := NewIndexBuilder("live.har").Resume(index, strings.NewReader(whole[index.EntriesEnd:]))
+	require.NoError(t, err)
+	assert.Equal(t, 2, added)
+
+	full, err := NewIndexBuilder("live.har").Build(strings.NewReader(whole))
+	require.NoError(t, err)
+	require.Equal(t, full.TotalEntries, index.TotalEntries)
+	for i := range full.Entries {
+		assert.Equal(t, full.Entries[i].FileOffset, index.Entries[i].FileOffset, "entry %d", i)
+		assert.Equal(t, full.Entries[i].Length, index.Entries[i].Length, "entry %d", i)
+		assert.Equal(t, full.Entries[i].URL, index.Entries[i].URL, "entry %d", i)
+	}
+	assert.Equal(t, full.EntriesEnd, index.EntriesEnd)
+	assert.Equal(t, full.TotalResponseBytes, index.TotalResponseBytes)
+}
+
+func TestIndexBuilder_TruncatedWithoutFollowFails(t *testing.T) {
+	whole := followHAR(false, "aaaa", "bbbb")
+	_, err := NewIndexBuilder("cut.har").Build(strings.NewReader(whole[:len(whole)-10]))
+	assert.Error(t, err)
+}
@@ -0,0 +1,68 @@
+package motor
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+)
+
+// gzip-compressed HAR files (.har.gz) are decompressed once to a temporary file which is then
+// indexed and read like any other HAR. Random access into a gzip stream is not possible without
+// a block index, and decompressing up front keeps the reader's pooled ReadAt path unchanged.
+// As a result, EntryMetadata.FileOffset and Index.FilePath always refer to the decompressed file.
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// IsGzipFile reports whether the file starts with the gzip magic bytes
+func IsGzipFile(path string) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	magic := make([]byte, len(gzipMagic))
+	if _, err := io.ReadFull(file, magic); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return false, nil // too short to be gzip
+		}
+		return false, err
+	}
+
+	return magic[0] == gzipMagic[0] && magic[1] == gzipMagic[1], nil
+}
+
+// decompressToTemp inflates a gzip file into a new temporary file and returns its path
+func decompressToTemp(path string) (string, error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer src.Close()
+
+	gz, err := gzip.NewReader(bufio.NewReader(src))
+	if err != nil {
+		return "", fmt.Errorf("failed to read gzip header: %w", err)
+	}
+	defer gz.Close()
+
+	dst, err := os.CreateTemp("", "harific-*.har")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+
+	if _, err := io.Copy(dst, gz); err != nil {
+		dst.Close()
+		os.Remove(dst.Name())
+		return "", fmt.Errorf("failed to decompress file: %w", err)
+	}
+
+	if err := dst.Close(); err != nil {
+		os.Remove(dst.Name())
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	return dst.Name(), nil
+}
@@ -0,0 +1,101 @@
+package motor
+
+import (
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/harific/hargen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gzipFile writes a gzip-compressed copy of src and returns its path
+func gzipFile(t *testing.T, src string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(src)
+	require.NoError(t, err)
+
+	dst := filepath.Join(t.TempDir(), "capture.har.gz")
+	file, err := os.Create(dst)
+	require.NoError(t, err)
+
+	gz := gzip.NewWriter(file)
+	_, err = gz.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	require.NoError(t, file.Close())
+
+	return dst
+}
+
+func TestIsGzipFile(t *testing.T) {
+	harFile, cleanup, err := generateTinyHAR()
+	require.NoError(t, err)
+	defer cleanup()
+
+	gzipped, err := IsGzipFile(harFile)
+	require.NoError(t, err)
+	assert.False(t, gzipped)
+
+	gzipped, err = IsGzipFile(gzipFile(t, harFile))
+	require.NoError(t, err)
+	assert.True(t, gzipped)
+
+	empty := filepath.Join(t.TempDir(), "empty.har")
+	require.NoError(t, os.WriteFile(empty, nil, 0644))
+	gzipped, err = IsGzipFile(empty)
+	require.NoError(t, err)
+	assert.False(t, gzipped)
+}
+
+func TestStreamer_GzipHAR(t *testing.T) {
+	result, err := hargen.Generate(hargen.GenerateOptions{
+		EntryCount:  20,
+		InjectTerms: []string{"gzipterm"},
+		InjectionLocations: []hargen.InjectionLocation{
+			hargen.ResponseBody,
+		},
+		Seed: 42,
+	})
+	require.NoError(t, err)
+	defer os.Remove(result.HARFilePath)
+
+	gzPath := gzipFile(t, result.HARFilePath)
+
+	streamer, err := NewHARStreamer(gzPath, DefaultStreamerOptions())
+	require.NoError(t, err)
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	index := streamer.GetIndex()
+	assert.Equal(t, 20, index.TotalEntries)
+	assert.NotEqual(t, gzPath, index.FilePath, "offsets refer to the decompressed copy")
+
+	entry, err := streamer.GetEntry(context.Background(), 5)
+	require.NoError(t, err)
+	assert.NotEmpty(t, entry.Request.URL)
+
+	// readers opened on the original .gz path resolve to the decompressed copy
+	reader, err := NewEntryReader(gzPath, index)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	opts := DefaultSearchOptions
+	opts.SearchResponseBody = true
+
+	resultChan, err := NewSearcher(streamer, reader).Search(context.Background(), "gzipterm", opts)
+	require.NoError(t, err)
+
+	results := collectResults(resultChan)
+	require.Len(t, results, 1)
+	assert.Equal(t, result.InjectedTerms[0].EntryIndex, results[0].Index)
+
+	// closing the streamer removes the decompressed copy
+	tempPath := index.FilePath
+	require.NoError(t, streamer.Close())
+	_, err = os.Stat(tempPath)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -2,8 +2,11 @@ package motor
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cespare/xxhash/v2"
@@ -27,15 +30,24 @@ const (
 	keyMethod            = "method"
 	keyURL               = "url"
 	keyBodySize          = "bodySize"
+	keyHeaders           = "headers"
 	keyStatus            = "status"
 	keyStatusText        = "statusText"
+	keyRedirectURL       = "redirectURL"
 	keyContent           = "content"
 	keySize              = "size"
 	keyMimeType          = "mimeType"
 	keyText              = "text"
 	keyEncoding          = "encoding"
+	keyWebSocketMessages = "_webSocketMessages"
+	keyResourceType      = "_resourceType"
+	keyPriority          = "_priority"
+	keyType              = "type"
 )
 
+// DefaultHARVersion is the version assumed for a log that does not give one
+const DefaultHARVersion = "1.2"
+
 // IndexProgress represents indexing progress
 type IndexProgress struct {
 	BytesRead    int64
@@ -49,8 +61,19 @@ type DefaultIndexBuilder struct {
 	bytesRead    int64
 	totalBytes   int64
 	progressChan chan<- IndexProgress
+
+	indexWebSockets bool // count _webSocketMessages frames into metadata
+	partial         bool // a file cut off inside an entry indexes up to the last complete one
+	skipBadEntries  bool // an entry that fails to parse is recorded in Index.SkippedEntries and left out
+	startEntry      int  // entries before this position are skipped unparsed, see StreamerOptions.StartEntry
+	maxEntries      int  // stop once this many entries are indexed (0 = all), see StreamerOptions.MaxEntries
+
+	decoders DecoderFactory // creates the token decoder, nil for encoding/json
 }
 
+// errEntryLimit stops the token walk once maxEntries are indexed; the rest of the file is never read
+var errEntryLimit = errors.New("entry limit reached")
+
 func NewIndexBuilder(filePath string) *DefaultIndexBuilder {
 	return &DefaultIndexBuilder{
 		index: &Index{
@@ -82,26 +105,151 @@ func (b *DefaultIndexBuilder) BuildWithProgress(reader io.Reader, totalBytes int
 		hash:   b.hash,
 	}
 
-	if err := b.parseHAR(hashReader); err != nil {
+	err := b.parseHAR(hashReader)
+	if errors.Is(err, errEntryLimit) {
+		b.index.EntriesCutOff = true
+		err = nil
+	}
+	if err != nil && !(b.partial && isTruncated(err)) {
 		return nil, fmt.Errorf("failed to parse har file: %w", err)
 	}
+	if err := checkEntryRanges(b.index.Entries); err != nil {
+		return nil, fmt.Errorf("failed to index har file: %w", err)
+	}
 
 	b.index.FileHash = fmt.Sprintf("%x", b.hash.Sum64())
 	b.index.FileSize = hashReader.bytesRead
+	if b.index.EntriesCutOff && b.totalBytes > 0 {
+		// the hash only covers the bytes read, so such an index is never saved as a sidecar
+		b.index.FileSize = b.totalBytes
+	}
+	if b.index.Version == "" {
+		b.index.Version = DefaultHARVersion
+	}
 	b.index.BuildTime = time.Since(startTime)
 	b.index.TotalEntries = len(b.index.Entries)
+	b.index.WebSocketsIndexed = b.indexWebSockets
+	b.index.UniqueURLs = countUniqueURLs(b.index.Entries)
+
+	return b.index, nil
+}
+
+// Resume indexes the entries appended to a har since index was built or last resumed, carrying
+// on from index.EntriesEnd rather than parsing the file again; reader must start at that offset.
+// an entry still being written at the end of the input is left for the next call. it returns
+// how many entries were added. index is extended in place, so it must not be read while Resume
+// runs; DefaultHARStreamer.Follow resumes a copy of the index its callers hold.
+func (b *DefaultIndexBuilder) Resume(index *Index, reader io.Reader) (int, error) {
+	b.index = index
+	b.indexWebSockets = index.WebSocketsIndexed
+
+	// the decoder picks up inside the entries array: a synthetic "[" (and a placeholder value
+	// when entries exist, so the separator before the next one reads as valid json)
+	prefix := "["
+	if len(index.Entries) > 0 {
+		prefix = "[0"
+	}
+	decoder := withOffset(orStdlib(b.decoders).NewDecoder(io.MultiReader(strings.NewReader(prefix), reader)),
+		index.EntriesEnd-int64(len(prefix)))
+
+	for range prefix {
+		if _, err := decoder.Token(); err != nil {
+			return 0, err
+		}
+	}
+
+	// nothing is added unless every complete entry parses, so a failed call can be retried
+	var appended []*EntryMetadata
+	var ends []int64
+	var prev *EntryMetadata
+	if len(index.Entries) > 0 {
+		prev = index.Entries[len(index.Entries)-1]
+	}
+	for decoder.More() {
+		startOffset := decoder.InputOffset()
+		entryIndex := len(index.Entries) + len(appended)
+
+		metadata, err := b.parseEntryMetadata(decoder, entryIndex, startOffset)
+		if isTruncated(err) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse entry %d: %w", entryIndex, err)
+		}
+
+		endOffset := decoder.InputOffset()
+		metadata.Length = endOffset - startOffset
+		if err := checkEntryRange(entryIndex, metadata, prev); err != nil {
+			return 0, err
+		}
+		appended = append(appended, metadata)
+		ends = append(ends, endOffset)
+		prev = metadata
+	}
+
+	for i, metadata := range appended {
+		b.addEntry(metadata, ends[i])
+	}
+	if len(appended) > 0 {
+		index.TotalEntries = len(index.Entries)
+		index.UniqueURLs = countUniqueURLs(index.Entries)
+	}
+	return len(appended), nil
+}
+
+// checkEntryRanges reports an entry whose [FileOffset, FileOffset+Length) range is empty or starts
+// before the previous entry's ends. entries are indexed in file order, so either means the parser
+// lost its place on some odd input, and reads by offset would come back with the wrong entry.
+func checkEntryRanges(entries []*EntryMetadata) error {
+	var prev *EntryMetadata
+	for i, entry := range entries {
+		if err := checkEntryRange(i, entry, prev); err != nil {
+			return err
+		}
+		prev = entry
+	}
+	return nil
+}
 
+// checkEntryRange checks entry i against prev, the entry before it (nil for the first entry)
+func checkEntryRange(i int, entry, prev *EntryMetadata) error {
+	if entry.Length <= 0 {
+		return fmt.Errorf("entry %d at offset %d has length %d", i, entry.FileOffset, entry.Length)
+	}
+	if prev == nil {
+		return nil
+	}
+	if entry.FileOffset == prev.FileOffset {
+		return fmt.Errorf("entries %d and %d share offset %d", i-1, i, entry.FileOffset)
+	}
+	if prevEnd := prev.FileOffset + prev.Length; entry.FileOffset < prevEnd {
+		return fmt.Errorf("entry %d at [%d, %d) overlaps entry %d at [%d, %d)",
+			i, entry.FileOffset, entry.FileOffset+entry.Length, i-1, prev.FileOffset, prevEnd)
+	}
+	return nil
+}
+
+// isTruncated reports whether a parse failed only because the input ended, as it does part way
+// through an entry that is still being written. the decoder reports that as a syntax error when
+// the input ends between tokens.
+func isTruncated(err error) bool {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return syntaxErr.Error() == "unexpected end of JSON input"
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+func countUniqueURLs(entries []*EntryMetadata) int {
 	urlSet := make(map[string]struct{})
-	for _, entry := range b.index.Entries {
+	for _, entry := range entries {
 		urlSet[entry.URL] = struct{}{}
 	}
-	b.index.UniqueURLs = len(urlSet)
-
-	return b.index, nil
+	return len(urlSet)
 }
 
 func (b *DefaultIndexBuilder) parseHAR(reader io.Reader) error {
-	decoder := newHARDecoder(reader)
+	decoder := newHARDecoder(reader, b.decoders)
 
 	if _, err := decoder.Token(); err != nil {
 		return err
@@ -151,9 +299,11 @@ func (b *DefaultIndexBuilder) parseLog(decoder HARDecoder) error {
 
 		switch key {
 		case keyVersion:
-			if err := decoder.Decode(&b.index.Version); err != nil {
+			var version any
+			if err := decoder.Decode(&version); err != nil {
 				return err
 			}
+			b.index.Version = harVersion(version)
 		case keyCreator:
 			var creator model.Creator
 			if err := decoder.Decode(&creator); err != nil {
@@ -167,7 +317,10 @@ func (b *DefaultIndexBuilder) parseLog(decoder HARDecoder) error {
 			}
 			b.index.Browser = &browser
 		case keyPages:
-			if err := decoder.Decode(&b.index.Pages); err != nil {
+			// a badly typed field (older exporters wrote some timings as strings) is left unset
+			// rather than failing the index; the value is still consumed either way
+			var typeErr *json.UnmarshalTypeError
+			if err := decoder.Decode(&b.index.Pages); err != nil && !errors.As(err, &typeErr) {
 				return err
 			}
 		case keyEntries:
@@ -180,10 +333,22 @@ func (b *DefaultIndexBuilder) parseLog(decoder HARDecoder) error {
 			}
 		}
 	}
-
 	return nil
 }
 
+// harVersion reads log.version, which some tools write as a number (1.1) rather than a string
+func harVersion(value any) string {
+	switch version := value.(type) {
+	case string:
+		return strings.TrimSpace(version)
+	case json.Number:
+		return version.String()
+	case float64:
+		return strconv.FormatFloat(version, 'f', -1, 64)
+	}
+	return ""
+}
+
 func (b *DefaultIndexBuilder) parseEntries(decoder HARDecoder) error {
 	token, err := decoder.Token()
 	if err != nil {
@@ -193,58 +358,127 @@ func (b *DefaultIndexBuilder) parseEntries(decoder HARDecoder) error {
 		return fmt.Errorf("expected array delimiter, got %v", token)
 	}
 
-	const (
-		updateEveryNEntries = 100
-		updateEveryNBytes   = 5 * 1024 * 1024 // 5MB
-	)
-
 	// pre-compute to avoid checking in hot path
 	trackProgress := b.progressChan != nil && b.totalBytes > 0
+	updateEveryNBytes := progressInterval(b.totalBytes)
 
 	entryIndex := 0
 	lastProgressBytes := int64(0)
+	b.index.EntriesEnd = decoder.InputOffset()
+	entriesStart := b.index.EntriesEnd
+
+	resyncer, canResync := decoder.(resyncingDecoder)
+	arrayDepth := 0
+	if canResync {
+		arrayDepth = resyncer.depth()
+	}
 
 	for decoder.More() {
+		if b.maxEntries > 0 && len(b.index.Entries) >= b.maxEntries {
+			b.index.FirstEntry = b.startEntry
+			b.index.HAREntries = estimateEntries(entryIndex, entriesStart, decoder.InputOffset(), b.totalBytes)
+			return errEntryLimit
+		}
+
 		startOffset := decoder.InputOffset()
+		if entryIndex < b.startEntry {
+			if err := helper.skipValue(decoder); err != nil {
+				return fmt.Errorf("failed to skip entry %d: %w", entryIndex, err)
+			}
+			entryIndex++
+			if trackProgress {
+				b.sendProgressUpdate(decoder.InputOffset(), len(b.index.Entries), updateEveryNBytes, &lastProgressBytes)
+			}
+			continue
+		}
 
 		metadata, err := b.parseEntryMetadata(decoder, entryIndex, startOffset)
+		if err != nil && b.skipBadEntries && canResync && !isTruncated(err) {
+			b.index.SkippedEntries = append(b.index.SkippedEntries, SkippedEntry{
+				Position: entryIndex,
+				Offset:   startOffset,
+				Error:    err.Error(),
+			})
+			if err := resyncer.skipElement(arrayDepth); err != nil {
+				return fmt.Errorf("failed to skip entry %d: %w", entryIndex, err)
+			}
+			entryIndex++
+			continue
+		}
 		if err != nil {
 			return fmt.Errorf("failed to parse entry %d: %w", entryIndex, err)
 		}
 
 		endOffset := decoder.InputOffset()
 		metadata.Length = endOffset - startOffset
-
-		b.index.Entries = append(b.index.Entries, metadata)
-		b.index.TotalRequestBytes += metadata.RequestSize
-		b.index.TotalResponseBytes += metadata.ResponseSize
-
-		if b.index.TimeRange.Start.IsZero() || metadata.Timestamp.Before(b.index.TimeRange.Start) {
-			b.index.TimeRange.Start = metadata.Timestamp
-		}
-		if metadata.Timestamp.After(b.index.TimeRange.End) {
-			b.index.TimeRange.End = metadata.Timestamp
-		}
+		b.addEntry(metadata, endOffset)
 
 		entryIndex++
 
 		// send progress update after entry processed
 		if trackProgress {
-			b.sendProgressUpdate(endOffset, entryIndex, updateEveryNEntries, updateEveryNBytes, &lastProgressBytes)
+			b.sendProgressUpdate(endOffset, entryIndex, updateEveryNBytes, &lastProgressBytes)
 		}
 	}
 
 	// send final progress update
 	if trackProgress {
-		b.sendProgressUpdate(decoder.InputOffset(), entryIndex, 0, 0, &lastProgressBytes)
+		b.sendProgressUpdate(decoder.InputOffset(), entryIndex, 0, &lastProgressBytes)
 	}
 
+	if b.startEntry > 0 {
+		b.index.FirstEntry = min(b.startEntry, entryIndex)
+		b.index.HAREntries = entryIndex
+	}
 	return nil
 }
 
-func (b *DefaultIndexBuilder) sendProgressUpdate(offset int64, entries int, everyNEntries int, everyNBytes int64, lastBytes *int64) {
-	// always send if everyNEntries is 0 (final update)
-	if everyNEntries == 0 {
+// estimateEntries scales the entries seen in the first read bytes of the entries array (which
+// starts at offset start) up to the whole file, assuming the rest are the same size on average.
+// it is 0, unknown, without the file size.
+func estimateEntries(seen int, start, read, fileSize int64) int {
+	if fileSize <= 0 || read <= start {
+		return 0
+	}
+	return max(int(float64(seen)*float64(fileSize-start)/float64(read-start)), seen)
+}
+
+// addEntry appends a parsed entry to the index, ending at endOffset in the file
+func (b *DefaultIndexBuilder) addEntry(metadata *EntryMetadata, endOffset int64) {
+	b.index.Entries = append(b.index.Entries, metadata)
+	b.index.EntriesEnd = endOffset
+	b.index.TotalRequestBytes += metadata.RequestSize
+	b.index.TotalResponseBytes += metadata.ResponseSize
+
+	// a missing or unparseable startedDateTime must not drag the range back to year 1
+	if metadata.Timestamp.IsZero() {
+		b.index.BadTimestamps++
+	} else {
+		if b.index.TimeRange.Start.IsZero() || metadata.Timestamp.Before(b.index.TimeRange.Start) {
+			b.index.TimeRange.Start = metadata.Timestamp
+		}
+		if metadata.Timestamp.After(b.index.TimeRange.End) {
+			b.index.TimeRange.End = metadata.Timestamp
+		}
+	}
+}
+
+const (
+	progressSteps       = 200             // aim for an update every 0.5% of the file
+	minProgressInterval = 1 * 1024 * 1024 // 1MB
+	maxProgressInterval = 16 * 1024 * 1024
+)
+
+// progressInterval is the number of bytes between progress updates. updates are spaced by bytes,
+// not entries, so a file of many tiny entries doesn't flood the channel and a file of huge
+// entries still reports; a 2GB file sends roughly 200 updates.
+func progressInterval(totalBytes int64) int64 {
+	return min(max(totalBytes/progressSteps, minProgressInterval), maxProgressInterval)
+}
+
+func (b *DefaultIndexBuilder) sendProgressUpdate(offset int64, entries int, everyNBytes int64, lastBytes *int64) {
+	// always send if everyNBytes is 0 (final update)
+	if everyNBytes == 0 {
 		select {
 		case b.progressChan <- IndexProgress{
 			BytesRead:    offset,
@@ -256,11 +490,8 @@ func (b *DefaultIndexBuilder) sendProgressUpdate(offset int64, entries int, ever
 		return
 	}
 
-	// hybrid check: every N entries OR every N bytes
-	shouldUpdate := (entries%everyNEntries == 0) ||
-		(offset-*lastBytes >= everyNBytes)
-
-	if shouldUpdate {
+	// a dropped update (full channel) is retried after the next entry
+	if offset-*lastBytes >= everyNBytes {
 		select {
 		case b.progressChan <- IndexProgress{
 			BytesRead:    offset,
@@ -273,6 +504,32 @@ func (b *DefaultIndexBuilder) sendProgressUpdate(offset int64, entries int, ever
 	}
 }
 
+// timestampLayouts are the startedDateTime formats seen in real hars, tried in order. the spec
+// asks for ISO 8601 with a zone, but proxies also write "+0000" offsets, a space instead of the
+// T, or no zone at all (read as UTC). fractional seconds of any precision parse with each layout.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z0700",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// parseTimestamp parses a startedDateTime, returning false if no known layout matches
+func parseTimestamp(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
 // parseEntryMetadata selectively parses only metadata fields, skipping response bodies entirely
 func (b *DefaultIndexBuilder) parseEntryMetadata(decoder HARDecoder, index int, startOffset int64) (*EntryMetadata, error) {
 	metadata := &EntryMetadata{
@@ -304,9 +561,7 @@ func (b *DefaultIndexBuilder) parseEntryMetadata(decoder HARDecoder, index int,
 			if err := decoder.Decode(&startTime); err != nil {
 				return nil, err
 			}
-			if t, err := time.Parse(time.RFC3339, startTime); err == nil {
-				metadata.Timestamp = t
-			}
+			metadata.Timestamp, _ = parseTimestamp(startTime)
 
 		case keyTime:
 			if err := decoder.Decode(&metadata.Duration); err != nil {
@@ -344,6 +599,31 @@ func (b *DefaultIndexBuilder) parseEntryMetadata(decoder HARDecoder, index int,
 			}
 			metadata.Connection = b.index.Intern(connection)
 
+		case keyResourceType:
+			var resourceType string
+			if err := decoder.Decode(&resourceType); err != nil {
+				return nil, err
+			}
+			metadata.ResourceType = b.index.Intern(resourceType)
+
+		case keyPriority:
+			var priority string
+			if err := decoder.Decode(&priority); err != nil {
+				return nil, err
+			}
+			metadata.Priority = b.index.Intern(priority)
+
+		case keyWebSocketMessages:
+			if !b.indexWebSockets {
+				if err := helper.skipValue(decoder); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if err := b.parseWebSocketMessages(decoder, metadata); err != nil {
+				return nil, err
+			}
+
 		default:
 			if err := helper.skipValue(decoder); err != nil {
 				return nil, err
@@ -359,6 +639,69 @@ func (b *DefaultIndexBuilder) parseEntryMetadata(decoder HARDecoder, index int,
 	return metadata, nil
 }
 
+// parseWebSocketMessages counts frames by direction, skipping payloads
+func (b *DefaultIndexBuilder) parseWebSocketMessages(decoder HARDecoder, metadata *EntryMetadata) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != json.Delim('[') {
+		return fmt.Errorf("expected array delimiter")
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		if token != json.Delim('{') {
+			return fmt.Errorf("expected object delimiter")
+		}
+
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+
+			key, ok := token.(string)
+			if !ok {
+				continue
+			}
+
+			if key != keyType {
+				if err := helper.skipValue(decoder); err != nil {
+					return err
+				}
+				continue
+			}
+
+			var msgType string
+			if err := decoder.Decode(&msgType); err != nil {
+				return err
+			}
+			switch msgType {
+			case "send":
+				metadata.WebSocketSent++
+			case "receive":
+				metadata.WebSocketReceived++
+			}
+		}
+
+		// consume closing brace of the message
+		if _, err := decoder.Token(); err != nil {
+			return err
+		}
+	}
+
+	// consume closing bracket
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (b *DefaultIndexBuilder) parseRequest(decoder HARDecoder, metadata *EntryMetadata) error {
 	token, err := decoder.Token()
 	if err != nil {
@@ -395,11 +738,18 @@ func (b *DefaultIndexBuilder) parseRequest(decoder HARDecoder, metadata *EntryMe
 			metadata.URL = b.index.Intern(url)
 
 		case keyBodySize:
-			var size int
+			var size int64 // an int would overflow for bodies over 2GB on 32-bit platforms
 			if err := decoder.Decode(&size); err != nil {
 				return err
 			}
-			metadata.RequestSize = int64(size)
+			metadata.RequestSize = size
+
+		case keyHeaders:
+			count, err := helper.countArray(decoder)
+			if err != nil {
+				return err
+			}
+			metadata.RequestHeaders = count
 
 		default:
 			if err := helper.skipValue(decoder); err != nil {
@@ -449,12 +799,26 @@ func (b *DefaultIndexBuilder) parseResponse(decoder HARDecoder, metadata *EntryM
 			}
 			metadata.StatusText = b.index.Intern(statusText)
 
+		case keyRedirectURL:
+			var redirectURL string
+			if err := decoder.Decode(&redirectURL); err != nil {
+				return err
+			}
+			metadata.RedirectURL = b.index.Intern(redirectURL)
+
 		case keyBodySize:
-			var size int
+			var size int64
 			if err := decoder.Decode(&size); err != nil {
 				return err
 			}
-			metadata.ResponseSize = int64(size)
+			metadata.ResponseSize = size
+
+		case keyHeaders:
+			count, err := helper.countArray(decoder)
+			if err != nil {
+				return err
+			}
+			metadata.ResponseHeaders = count
 
 		case keyContent:
 			if err := b.parseResponseContent(decoder, metadata); err != nil {
@@ -499,11 +863,11 @@ func (b *DefaultIndexBuilder) parseResponseContent(decoder HARDecoder, metadata
 
 		switch key {
 		case keySize:
-			var size int
+			var size int64
 			if err := decoder.Decode(&size); err != nil {
 				return err
 			}
-			metadata.BodySize = int64(size)
+			metadata.BodySize = size
 
 		case keyMimeType:
 			var mimeType string
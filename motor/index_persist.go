@@ -0,0 +1,166 @@
+package motor
+
+import (
+	"bufio"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+// IndexSidecarSuffix is appended to a HAR path to locate its persisted index
+const IndexSidecarSuffix = ".idx"
+
+// indexFormatVersion is bumped whenever the persisted layout changes, invalidating old sidecars
+const indexFormatVersion = 8
+
+// persistedIndex is the on-disk sidecar layout
+type persistedIndex struct {
+	FormatVersion int
+	SourceSize    int64 // size of the HAR file on disk when indexed
+	Index         *Index
+}
+
+// SidecarPath returns the sidecar index path for a HAR file
+func SidecarPath(harPath string) string {
+	return harPath + IndexSidecarSuffix
+}
+
+// Save writes the index to a sidecar file
+func (idx *Index) Save(path string) error {
+	var sourceSize int64
+	if info, err := os.Stat(idx.FilePath); err == nil {
+		sourceSize = info.Size()
+	}
+
+	// write to a temp file and rename so a crash never leaves a truncated sidecar
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("failed to create index file: %w", err)
+	}
+
+	w := bufio.NewWriter(file)
+	encodeErr := gob.NewEncoder(w).Encode(persistedIndex{
+		FormatVersion: indexFormatVersion,
+		SourceSize:    sourceSize,
+		Index:         idx,
+	})
+	if encodeErr == nil {
+		encodeErr = w.Flush()
+	}
+	closeErr := file.Close()
+
+	if encodeErr != nil || closeErr != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write index file: %w", errors.Join(encodeErr, closeErr))
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write index file: %w", err)
+	}
+	return nil
+}
+
+// LoadIndex reads an index from a sidecar file. It does not check that the index
+// still matches its HAR file; see ValidateIndex.
+func LoadIndex(path string) (*Index, error) {
+	persisted, err := loadPersistedIndex(path)
+	if err != nil {
+		return nil, err
+	}
+	return persisted.Index, nil
+}
+
+func loadPersistedIndex(path string) (*persistedIndex, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index file: %w", err)
+	}
+	defer file.Close()
+
+	var persisted persistedIndex
+	if err := gob.NewDecoder(bufio.NewReader(file)).Decode(&persisted); err != nil {
+		return nil, fmt.Errorf("failed to decode index file: %w", err)
+	}
+
+	if persisted.FormatVersion != indexFormatVersion {
+		return nil, fmt.Errorf("unsupported index format version %d", persisted.FormatVersion)
+	}
+	if persisted.Index == nil || len(persisted.Index.Entries) != persisted.Index.TotalEntries {
+		return nil, fmt.Errorf("corrupt index file")
+	}
+
+	persisted.Index.reintern()
+	return &persisted, nil
+}
+
+// ValidateIndex checks that an index still describes the given HAR file by comparing
+// the file size and xxhash computed during the original build
+func ValidateIndex(idx *Index, harPath string) error {
+	file, err := os.Open(harPath)
+	if err != nil {
+		return fmt.Errorf("failed to open HAR file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat HAR file: %w", err)
+	}
+	if info.Size() < idx.FileSize {
+		return fmt.Errorf("HAR file size %d is smaller than indexed size %d", info.Size(), idx.FileSize)
+	}
+
+	// the builder hashes the bytes the decoder consumed, which may stop short of trailing whitespace
+	hash := xxhash.New()
+	if _, err := io.CopyN(hash, file, idx.FileSize); err != nil {
+		return fmt.Errorf("failed to hash HAR file: %w", err)
+	}
+	if fmt.Sprintf("%x", hash.Sum64()) != idx.FileHash {
+		return fmt.Errorf("HAR file hash does not match index")
+	}
+
+	return nil
+}
+
+// loadSidecar returns the persisted index at sidecarPath if it is present and still valid for
+// dataPath, the (possibly decompressed) file the index offsets refer to
+func loadSidecar(sidecarPath, dataPath string, dataSize int64, webSockets, skipBadEntries bool) (*Index, bool) {
+	persisted, err := loadPersistedIndex(sidecarPath)
+	if err != nil {
+		return nil, false
+	}
+	if persisted.SourceSize != dataSize || persisted.Index.WebSocketsIndexed != webSockets {
+		return nil, false
+	}
+	if len(persisted.Index.SkippedEntries) > 0 && !skipBadEntries {
+		return nil, false // a strict build has to fail on the entries this one skipped
+	}
+	if ValidateIndex(persisted.Index, dataPath) != nil {
+		return nil, false
+	}
+	if checkEntryRanges(persisted.Index.Entries) != nil {
+		return nil, false // written by a version with a parser bug; the rebuild won't have it
+	}
+
+	persisted.Index.FilePath = dataPath
+	return persisted.Index, true
+}
+
+// reintern rebuilds the string table after decoding so repeated values share memory again
+func (idx *Index) reintern() {
+	for _, entry := range idx.Entries {
+		entry.Method = idx.Intern(entry.Method)
+		entry.URL = idx.Intern(entry.URL)
+		entry.StatusText = idx.Intern(entry.StatusText)
+		entry.MimeType = idx.Intern(entry.MimeType)
+		entry.PageRef = idx.Intern(entry.PageRef)
+		entry.ServerIP = idx.Intern(entry.ServerIP)
+		entry.Connection = idx.Intern(entry.Connection)
+	}
+}
@@ -0,0 +1,128 @@
+package motor
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_SaveLoadRoundTrip(t *testing.T) {
+	harFile, cleanup, err := generateTinyHAR()
+	require.NoError(t, err)
+	defer cleanup()
+
+	streamer, err := NewHARStreamer(harFile, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	original := streamer.GetIndex()
+	sidecar := SidecarPath(harFile)
+	defer os.Remove(sidecar)
+
+	require.NoError(t, original.Save(sidecar))
+
+	loaded, err := LoadIndex(sidecar)
+	require.NoError(t, err)
+
+	assert.Equal(t, original.FileHash, loaded.FileHash)
+	assert.Equal(t, original.FileSize, loaded.FileSize)
+	assert.Equal(t, original.TotalEntries, loaded.TotalEntries)
+	require.Len(t, loaded.Entries, len(original.Entries))
+	for i := range original.Entries {
+		assert.Equal(t, *original.Entries[i], *loaded.Entries[i], "entry %d", i)
+	}
+
+	require.NoError(t, ValidateIndex(loaded, harFile))
+}
+
+func TestStreamer_UsesValidSidecar(t *testing.T) {
+	harFile, cleanup, err := generateTinyHAR()
+	require.NoError(t, err)
+	defer cleanup()
+	defer os.Remove(SidecarPath(harFile))
+
+	opts := DefaultStreamerOptions()
+	opts.UseIndexSidecar = true
+
+	// first open builds and writes the sidecar
+	first, err := NewHARStreamer(harFile, opts)
+	require.NoError(t, err)
+	require.NoError(t, first.Initialize(context.Background()))
+	firstIndex := first.GetIndex()
+	first.Close()
+
+	_, err = os.Stat(SidecarPath(harFile))
+	require.NoError(t, err, "sidecar should be written after a full build")
+
+	// second open loads it; build time is carried over from the original build
+	second, err := NewHARStreamer(harFile, opts)
+	require.NoError(t, err)
+	defer second.Close()
+	require.NoError(t, second.Initialize(context.Background()))
+
+	secondIndex := second.GetIndex()
+	assert.Equal(t, firstIndex.FileHash, secondIndex.FileHash)
+	assert.Equal(t, firstIndex.BuildTime, secondIndex.BuildTime)
+
+	// entries are still readable through the loaded index
+	entry, err := second.GetEntry(context.Background(), 0)
+	require.NoError(t, err)
+	assert.NotEmpty(t, entry.Request.URL)
+}
+
+func TestStreamer_RebuildsStaleSidecar(t *testing.T) {
+	harFile, cleanup, err := generateTinyHAR()
+	require.NoError(t, err)
+	defer cleanup()
+	defer os.Remove(SidecarPath(harFile))
+
+	opts := DefaultStreamerOptions()
+	opts.UseIndexSidecar = true
+
+	first, err := NewHARStreamer(harFile, opts)
+	require.NoError(t, err)
+	require.NoError(t, first.Initialize(context.Background()))
+	first.Close()
+
+	// replace the HAR with different content of a different size
+	other, otherCleanup, err := generateTestHAR(5, 7)
+	require.NoError(t, err)
+	defer otherCleanup()
+	data, err := os.ReadFile(other)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(harFile, data, 0644))
+
+	second, err := NewHARStreamer(harFile, opts)
+	require.NoError(t, err)
+	defer second.Close()
+	require.NoError(t, second.Initialize(context.Background()))
+
+	assert.Equal(t, 5, second.GetIndex().TotalEntries, "stale sidecar must be ignored")
+}
+
+func TestStreamer_IgnoresCorruptSidecar(t *testing.T) {
+	harFile, cleanup, err := generateTinyHAR()
+	require.NoError(t, err)
+	defer cleanup()
+	defer os.Remove(SidecarPath(harFile))
+
+	require.NoError(t, os.WriteFile(SidecarPath(harFile), []byte("garbage"), 0644))
+
+	opts := DefaultStreamerOptions()
+	opts.UseIndexSidecar = true
+
+	streamer, err := NewHARStreamer(harFile, opts)
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	assert.Equal(t, 10, streamer.GetIndex().TotalEntries)
+
+	// corrupt sidecar is replaced by a valid one
+	_, err = LoadIndex(SidecarPath(harFile))
+	assert.NoError(t, err)
+}
@@ -1,7 +1,11 @@
 package motor
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -258,3 +262,414 @@ func TestIndexBuilder_AddEntry(t *testing.T) {
 		t.Errorf("expected offset 1024, got %d", index.Entries[0].FileOffset)
 	}
 }
+
+func TestParseTimestamp(t *testing.T) {
+	want := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2024-01-02T03:04:05Z", want},
+		{"2024-01-02T03:04:05.123Z", want.Add(123 * time.Millisecond)},
+		{"2024-01-02T03:04:05.123456789Z", want.Add(123456789)},
+		{"2024-01-02T04:04:05+01:00", want},
+		{"2024-01-02T04:04:05.5+0100", want.Add(500 * time.Millisecond)},
+		{"2024-01-02T03:04:05.250", want.Add(250 * time.Millisecond)},
+		{"2024-01-02 03:04:05", want},
+		{"2024-01-02 03:04:05Z", want},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseTimestamp(tt.value)
+		if !ok {
+			t.Errorf("parseTimestamp(%q) failed", tt.value)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseTimestamp(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "  ", "yesterday", "01/02/2024"} {
+		if _, ok := parseTimestamp(value); ok {
+			t.Errorf("parseTimestamp(%q) should fail", value)
+		}
+	}
+}
+
+func TestIndexBuilder_BadTimestamps(t *testing.T) {
+	entry := `{%s"time": 10, "request": {"method": "GET", "url": "https://example.com/", "headers": [], "bodySize": 0},
+		"response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 0, "mimeType": ""}, "bodySize": 0},
+		"timings": {"send": 1, "wait": 8, "receive": 1}}`
+	har := `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1.0"}, "entries": [` +
+		strings.Join([]string{
+			fmt.Sprintf(entry, `"startedDateTime": "2024-01-02T03:04:05.123Z", `),
+			fmt.Sprintf(entry, ""),
+			fmt.Sprintf(entry, `"startedDateTime": "not a date", `),
+			fmt.Sprintf(entry, `"startedDateTime": "2024-01-02 03:05:00", `),
+		}, ",") + `]}}`
+
+	index, err := NewIndexBuilder("bad-timestamps.har").Build(strings.NewReader(har))
+	if err != nil {
+		t.Fatalf("failed to build index: %v", err)
+	}
+
+	if index.BadTimestamps != 2 {
+		t.Errorf("expected 2 bad timestamps, got %d", index.BadTimestamps)
+	}
+	if !index.TimeRange.Start.Equal(time.Date(2024, 1, 2, 3, 4, 5, 123000000, time.UTC)) {
+		t.Errorf("missing timestamps must not become the range start, got %v", index.TimeRange.Start)
+	}
+	if !index.TimeRange.End.Equal(time.Date(2024, 1, 2, 3, 5, 0, 0, time.UTC)) {
+		t.Errorf("unexpected range end %v", index.TimeRange.End)
+	}
+	if !index.Entries[1].Timestamp.IsZero() || !index.Entries[2].Timestamp.IsZero() {
+		t.Error("entries without a usable startedDateTime keep a zero Timestamp")
+	}
+}
+
+func TestIndexBuilder_HeaderCounts(t *testing.T) {
+	entry := `{"startedDateTime": "2024-01-02T03:04:05Z", "time": 10,
+		"request": {"method": "GET", "url": "https://example.com/", "headers": %s, "bodySize": 0},
+		"response": {"status": 200, "statusText": "OK", "headers": %s, "content": {"size": 0, "mimeType": ""}, "bodySize": 0}}`
+	har := `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1.0"}, "entries": [` +
+		strings.Join([]string{
+			fmt.Sprintf(entry, `[{"name": "Host", "value": "example.com"}, {"name": "Accept", "value": "*/*"}]`,
+				`[{"name": "Content-Type", "value": "text/plain"}]`),
+			fmt.Sprintf(entry, `[]`, `null`),
+		}, ",") + `]}}`
+
+	index, err := NewIndexBuilder("headers.har").Build(strings.NewReader(har))
+	if err != nil {
+		t.Fatalf("failed to build index: %v", err)
+	}
+
+	counts := [][2]int{{2, 1}, {0, 0}}
+	for i, want := range counts {
+		got := [2]int{index.Entries[i].RequestHeaders, index.Entries[i].ResponseHeaders}
+		if got != want {
+			t.Errorf("entry %d header counts = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestIndexBuilder_ChromeResourceFields(t *testing.T) {
+	entry := `{"startedDateTime": "2024-01-02T03:04:05Z", "time": 10,%s
+		"request": {"method": "GET", "url": "https://example.com/", "headers": [], "bodySize": 0},
+		"response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 0, "mimeType": ""}, "bodySize": 0}}`
+	har := `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1.0"}, "entries": [` +
+		strings.Join([]string{
+			fmt.Sprintf(entry, ` "_resourceType": "xhr", "_priority": "High",`),
+			fmt.Sprintf(entry, ""),
+		}, ",") + `]}}`
+
+	index, err := NewIndexBuilder("chrome.har").Build(strings.NewReader(har))
+	if err != nil {
+		t.Fatalf("failed to build index: %v", err)
+	}
+
+	if got := index.Entries[0]; got.ResourceType != "xhr" || got.Priority != "High" {
+		t.Errorf("resource type, priority = %q, %q, want xhr, High", got.ResourceType, got.Priority)
+	}
+	if got := index.Entries[1]; got.ResourceType != "" || got.Priority != "" {
+		t.Errorf("an entry without the extension fields should leave them empty, got %q, %q", got.ResourceType, got.Priority)
+	}
+
+	result := searchMetadata(0, index.Entries[0], compiledPattern{mode: PlainText, plainText: "xhr"}, SearchFieldMetadata)
+	if result == nil || result.Field != "resourceType" {
+		t.Errorf("expected the resource type to match from the index, got %+v", result)
+	}
+}
+
+func TestIndexBuilder_HARVersion(t *testing.T) {
+	entry := `{"startedDateTime": "2024-01-02T03:04:05Z", "time": 10,
+		"request": {"method": "GET", "url": "https://example.com/", "headers": [], "bodySize": 0},
+		"response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 0, "mimeType": ""}, "bodySize": 0}}`
+	tests := []struct {
+		name string
+		log  string
+		want string
+	}{
+		{"string", `"version": "1.2",`, "1.2"},
+		{"number", `"version": 1.1,`, "1.1"},
+		{"missing", ``, DefaultHARVersion},
+		{"1.1 page timings as strings", `"version": "1.1", "pages": [{"startedDateTime": "2024-01-02T03:04:05Z",
+			"id": "page_1", "title": "x", "pageTimings": {"onLoad": "120"}}],`, "1.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			har := `{"log": {` + tt.log + ` "creator": {"name": "test", "version": "1.0"}, "entries": [` + entry + `]}}`
+
+			index, err := NewIndexBuilder("version.har").Build(strings.NewReader(har))
+			if err != nil {
+				t.Fatalf("failed to build index: %v", err)
+			}
+			if index.Version != tt.want {
+				t.Errorf("version = %q, want %q", index.Version, tt.want)
+			}
+			if index.TotalEntries != 1 {
+				t.Errorf("expected 1 entry, got %d", index.TotalEntries)
+			}
+		})
+	}
+}
+
+func TestIndexBuilder_EntryWindow(t *testing.T) {
+	entry := `{"startedDateTime": "2024-01-02T03:04:05Z", "time": 10,
+		"request": {"method": "GET", "url": "https://example.com/%d", "headers": [], "bodySize": 0},
+		"response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 0, "mimeType": ""}, "bodySize": 0}}`
+	entries := make([]string, 10)
+	for i := range entries {
+		entries[i] = fmt.Sprintf(entry, i)
+	}
+	har := `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1.0"}, "entries": [` +
+		strings.Join(entries, ",") + `]}}`
+
+	tests := []struct {
+		name       string
+		start, max int
+		wantURLs   []int
+		cutOff     bool
+		harEntries int
+	}{
+		{"first n", 0, 3, []int{0, 1, 2}, true, 10},
+		{"skip m", 7, 0, []int{7, 8, 9}, false, 10},
+		{"window", 4, 2, []int{4, 5}, true, 10},
+		{"max past the end", 8, 5, []int{8, 9}, false, 10},
+		{"start past the end", 20, 0, nil, false, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewIndexBuilder("window.har")
+			builder.startEntry = tt.start
+			builder.maxEntries = tt.max
+			index, err := builder.BuildWithProgress(strings.NewReader(har), int64(len(har)), nil)
+			if err != nil {
+				t.Fatalf("failed to build index: %v", err)
+			}
+
+			if index.TotalEntries != len(tt.wantURLs) {
+				t.Fatalf("expected %d entries, got %d", len(tt.wantURLs), index.TotalEntries)
+			}
+			for i, want := range tt.wantURLs {
+				url := fmt.Sprintf("https://example.com/%d", want)
+				if index.Entries[i].URL != url {
+					t.Errorf("entry %d url = %q, want %q", i, index.Entries[i].URL, url)
+				}
+				// offsets still point into the whole file
+				raw := har[index.Entries[i].FileOffset : index.Entries[i].FileOffset+index.Entries[i].Length]
+				if !strings.Contains(raw, `"`+url+`"`) {
+					t.Errorf("entry %d range %q is not entry %d", i, raw, want)
+				}
+			}
+			if !index.Windowed() || index.EntriesCutOff != tt.cutOff {
+				t.Errorf("windowed, cut off = %v, %v, want true, %v", index.Windowed(), index.EntriesCutOff, tt.cutOff)
+			}
+			if index.FirstEntry != min(tt.start, 10) {
+				t.Errorf("first entry = %d, want %d", index.FirstEntry, min(tt.start, 10))
+			}
+			if !tt.cutOff && index.HAREntries != tt.harEntries {
+				t.Errorf("har entries = %d, want %d", index.HAREntries, tt.harEntries)
+			}
+			if tt.cutOff && (index.HAREntries < 8 || index.HAREntries > 12) {
+				t.Errorf("estimated har entries = %d, want about %d", index.HAREntries, tt.harEntries)
+			}
+		})
+	}
+}
+
+func TestIndexBuilder_ByteOrderMark(t *testing.T) {
+	entry := `{"startedDateTime": "2024-01-02T03:04:05Z", "time": 10,
+		"request": {"method": "GET", "url": "https://example.com/%s", "headers": [], "bodySize": 0},
+		"response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 0, "mimeType": ""}, "bodySize": 0},
+		"timings": {"send": 1, "wait": 8, "receive": 1}}`
+	har := "\xEF\xBB\xBF\r\n  \t" + `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1.0"}, "entries": [` +
+		fmt.Sprintf(entry, "first") + "," + fmt.Sprintf(entry, "second") + `]}}`
+
+	harFile := filepath.Join(t.TempDir(), "bom.har")
+	if err := os.WriteFile(harFile, []byte(har), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	index, err := NewIndexBuilder(harFile).Build(strings.NewReader(har))
+	if err != nil {
+		t.Fatalf("failed to build index: %v", err)
+	}
+	if len(index.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(index.Entries))
+	}
+	if index.FileSize != int64(len(har)) {
+		t.Errorf("file size should count the BOM, got %d want %d", index.FileSize, len(har))
+	}
+
+	// offsets are file offsets, so the reader lands on each entry despite the BOM
+	if want := int64(strings.Index(har, `{"startedDateTime"`)); index.Entries[0].FileOffset != want {
+		t.Errorf("first entry offset = %d, want %d", index.Entries[0].FileOffset, want)
+	}
+	reader, err := NewEntryReader(harFile, index)
+	if err != nil {
+		t.Fatalf("failed to create reader: %v", err)
+	}
+	defer reader.Close()
+
+	for i, name := range []string{"first", "second"} {
+		meta := index.Entries[i]
+		read, err := reader.ReadAt(meta.FileOffset, meta.Length)
+		if err != nil {
+			t.Fatalf("failed to read entry %d: %v", i, err)
+		}
+		if read.Request.URL != "https://example.com/"+name {
+			t.Errorf("entry %d read %q", i, read.Request.URL)
+		}
+	}
+
+	// the fixture leaves out required fields, but validate must get past the BOM to see that
+	for _, issue := range Validate(strings.NewReader(har)).Issues {
+		if strings.Contains(issue.Message, "malformed JSON") {
+			t.Errorf("validate should accept a BOM: %s", issue.Message)
+		}
+	}
+}
+
+func TestIndexBuilder_SkipBadEntries(t *testing.T) {
+	entry := `{"startedDateTime": "2024-01-02T03:04:05Z", "time": 10,
+		"request": {"method": "GET", "url": "https://example.com/%s", "headers": [], "bodySize": 0},
+		"response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 0, "mimeType": ""}, "bodySize": 0}}`
+	entries := []string{
+		fmt.Sprintf(entry, "first"),
+		`{"startedDateTime": "2024-01-02T03:04:05Z", "time": "slow", "request": {"method": "GET"}}`, // wrong type
+		fmt.Sprintf(entry, "third"),
+		`{"request": {"method": "GET", "url": oops}, "response": {"headers": [{"name": "}", "value": "]"}]}}`, // not json
+		fmt.Sprintf(entry, "fifth"),
+		`[1, 2]`, // not an object, and the last element
+	}
+	har := `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1.0"}, "entries": [` +
+		strings.Join(entries, ",\n") + `]}}`
+
+	harFile := filepath.Join(t.TempDir(), "bad-entries.har")
+	if err := os.WriteFile(harFile, []byte(har), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewIndexBuilder(harFile).Build(strings.NewReader(har)); err == nil {
+		t.Fatal("a strict build should fail on the first bad entry")
+	}
+
+	builder := NewIndexBuilder(harFile)
+	builder.skipBadEntries = true
+	index, err := builder.Build(strings.NewReader(har))
+	if err != nil {
+		t.Fatalf("failed to build index: %v", err)
+	}
+
+	if index.TotalEntries != 3 {
+		t.Fatalf("expected 3 entries, got %d", index.TotalEntries)
+	}
+	positions := make([]int, len(index.SkippedEntries))
+	for i, skipped := range index.SkippedEntries {
+		positions[i] = skipped.Position
+		if skipped.Error == "" {
+			t.Errorf("skipped entry %d has no error", skipped.Position)
+		}
+	}
+	if fmt.Sprint(positions) != "[1 3 5]" {
+		t.Errorf("skipped positions = %v, want [1 3 5]", positions)
+	}
+	if want := int64(strings.Index(har, `{"request": {"method": "GET", "url": oops}`)); index.SkippedEntries[1].Offset > want {
+		t.Errorf("skipped entry offset %d is past where it starts, %d", index.SkippedEntries[1].Offset, want)
+	}
+
+	if index.FileSize != int64(len(har)) {
+		t.Errorf("file size = %d, want %d", index.FileSize, len(har))
+	}
+
+	opts := DefaultStreamerOptions()
+	opts.SkipBadEntries = true
+	streamer, err := NewHARStreamer(harFile, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer streamer.Close()
+	if err := streamer.Initialize(context.Background()); err != nil {
+		t.Fatalf("streamer should skip the bad entries: %v", err)
+	}
+	if got := len(streamer.GetIndex().SkippedEntries); got != 3 {
+		t.Errorf("streamer skipped %d entries, want 3", got)
+	}
+
+	reader, err := NewEntryReader(harFile, index)
+	if err != nil {
+		t.Fatalf("failed to create reader: %v", err)
+	}
+	defer reader.Close()
+	for i, name := range []string{"first", "third", "fifth"} {
+		meta := index.Entries[i]
+		read, err := reader.ReadAt(meta.FileOffset, meta.Length)
+		if err != nil {
+			t.Fatalf("failed to read entry %d: %v", i, err)
+		}
+		if read.Request.URL != "https://example.com/"+name {
+			t.Errorf("entry %d read %q", i, read.Request.URL)
+		}
+	}
+}
+
+func TestCheckEntryRanges(t *testing.T) {
+	entries := func(ranges ...[2]int64) []*EntryMetadata {
+		metadata := make([]*EntryMetadata, len(ranges))
+		for i, r := range ranges {
+			metadata[i] = &EntryMetadata{FileOffset: r[0], Length: r[1]}
+		}
+		return metadata
+	}
+
+	tests := []struct {
+		name    string
+		entries []*EntryMetadata
+		wantErr string
+	}{
+		{"none", nil, ""},
+		{"adjacent", entries([2]int64{10, 5}, [2]int64{15, 7}, [2]int64{30, 1}), ""},
+		{"shared offset", entries([2]int64{10, 5}, [2]int64{10, 5}), "entries 0 and 1 share offset 10"},
+		{"overlap", entries([2]int64{10, 5}, [2]int64{20, 9}, [2]int64{25, 4}), "entry 2 at [25, 29) overlaps entry 1 at [20, 29)"},
+		{"out of order", entries([2]int64{50, 5}, [2]int64{10, 5}), "entry 1 at [10, 15) overlaps entry 0"},
+		{"empty", entries([2]int64{10, 0}), "entry 0 at offset 10 has length 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkEntryRanges(tt.entries)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestIndexBuilder_EntryRangesAreConsistent(t *testing.T) {
+	harFile, cleanup, err := generateSmallHAR()
+	if err != nil {
+		t.Fatalf("failed to generate test HAR: %v", err)
+	}
+	defer cleanup()
+
+	file, err := os.Open(harFile)
+	if err != nil {
+		t.Fatalf("failed to open HAR file: %v", err)
+	}
+	defer file.Close()
+
+	index, err := NewIndexBuilder(harFile).Build(file)
+	if err != nil {
+		t.Fatalf("failed to build index: %v", err)
+	}
+	if err := checkEntryRanges(index.Entries); err != nil {
+		t.Errorf("a built index should have consistent entry ranges: %v", err)
+	}
+}
@@ -18,6 +18,10 @@ type HARStreamer interface {
     // GetEntry retrieves a single entry by index with full metadata and body
     GetEntry(ctx context.Context, index int) (*model.Entry, error)
 
+    // GetEntries reads the entries in [start, end) concurrently and returns them in index order,
+    // or the first error encountered
+    GetEntries(ctx context.Context, start, end int) ([]*model.Entry, error)
+
     // StreamRange streams entries within a specific index range [start, end)
     StreamRange(ctx context.Context, start, end int) (<-chan StreamResult, error)
 
@@ -52,6 +56,17 @@ type HARDecoder interface {
 	InputOffset() int64
 }
 
+// DecoderFactory creates the HARDecoders the index builder walks a har with and the entry readers
+// decode entries with, so a faster json implementation (sonic, jsoniter) can stand in for
+// encoding/json, the default (StdlibDecoderFactory). see StreamerOptions.Decoder.
+type DecoderFactory interface {
+	// NewDecoder returns a decoder reading r. Token must return what encoding/json's does with
+	// UseNumber set (json.Delim, string, json.Number, bool or nil), InputOffset must count bytes
+	// of r, and input that ends early should fail with io.ErrUnexpectedEOF or a *json.SyntaxError
+	// so a growing file is told apart from a broken one
+	NewDecoder(r io.Reader) HARDecoder
+}
+
 // IndexBuilder builds the lightweight index of all entries in a HAR file
 type IndexBuilder interface {
     // Build constructs the index by scanning the entire HAR file
@@ -69,6 +84,9 @@ type EntryReader interface {
 	// Read reads an entry using a request message
 	Read(ctx context.Context, req ReadRequest) ReadResponse
 
+	// ReadBatch reads several entries with one response per offset/length pair, in order
+	ReadBatch(ctx context.Context, offsets []int64, lengths []int64) []ReadResponse
+
 	// ReadMetadata reads only the metadata without parsing the full entry
 	ReadMetadata(offset int64) (*EntryMetadata, error)
 
@@ -119,6 +137,12 @@ type Searcher interface {
 	// Search executes a search and returns results via channel
 	Search(ctx context.Context, pattern string, opts SearchOptions) (<-chan []SearchResult, error)
 
+	// Compile compiles a pattern once for repeated SearchCompiled calls
+	Compile(pattern string, opts SearchOptions) (*CompiledQuery, error)
+
+	// SearchCompiled executes a compiled query and returns results via channel
+	SearchCompiled(ctx context.Context, query *CompiledQuery) (<-chan []SearchResult, error)
+
 	// Stats returns current search statistics
 	Stats() SearchStats
 }
@@ -1,22 +1,41 @@
 package motor
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
+	"strings"
 )
 
+// utf8BOM is the byte order mark some windows tools write at the start of a har file
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
 type jsonHelper struct{}
 
 var helper = &jsonHelper{}
 
 // StdlibDecoder wraps encoding/json.Decoder to implement HARDecoder interface
-// to swap to sonic: create SonicDecoder implementing HARDecoder, update newHARDecoder()
+// to swap to sonic: create a DecoderFactory returning a SonicDecoder, see StreamerOptions.Decoder
 type StdlibDecoder struct {
 	decoder *json.Decoder
+	skipped int64        // bytes dropped before the decoder saw the stream, such as a BOM
+	source  io.Reader    // the stream behind the decoder's buffer, nil when it cannot resync
+	open    []json.Delim // objects and arrays opened by Token and not yet closed
 }
 
 func (s *StdlibDecoder) Token() (json.Token, error) {
-	return s.decoder.Token()
+	token, err := s.decoder.Token()
+	if delim, ok := token.(json.Delim); ok {
+		switch delim {
+		case '{', '[':
+			s.open = append(s.open, delim)
+		default:
+			s.open = s.open[:max(len(s.open)-1, 0)]
+		}
+	}
+	return token, err
 }
 
 func (s *StdlibDecoder) Decode(v interface{}) error {
@@ -28,7 +47,48 @@ func (s *StdlibDecoder) More() bool {
 }
 
 func (s *StdlibDecoder) InputOffset() int64 {
-	return s.decoder.InputOffset()
+	return s.decoder.InputOffset() + s.skipped
+}
+
+// StdlibDecoderFactory creates StdlibDecoders, the DecoderFactory used unless another is set
+type StdlibDecoderFactory struct{}
+
+func (StdlibDecoderFactory) NewDecoder(r io.Reader) HARDecoder {
+	d := json.NewDecoder(r)
+	d.UseNumber()
+	return &StdlibDecoder{decoder: d, source: r}
+}
+
+// offsetDecoder is a decoder whose input starts skipped bytes into the file, so its InputOffset
+// is shifted by that much
+type offsetDecoder struct {
+	HARDecoder
+	skipped int64
+}
+
+func (o *offsetDecoder) InputOffset() int64 {
+	return o.HARDecoder.InputOffset() + o.skipped
+}
+
+// withOffset shifts decoder's InputOffset by skipped bytes. a StdlibDecoder is shifted in place
+// so it can still resync, see skipElement
+func withOffset(decoder HARDecoder, skipped int64) HARDecoder {
+	if std, ok := decoder.(*StdlibDecoder); ok {
+		std.skipped += skipped
+		return std
+	}
+	if skipped == 0 {
+		return decoder
+	}
+	return &offsetDecoder{HARDecoder: decoder, skipped: skipped}
+}
+
+// orStdlib is factory, or StdlibDecoderFactory when nil
+func orStdlib(factory DecoderFactory) DecoderFactory {
+	if factory == nil {
+		return StdlibDecoderFactory{}
+	}
+	return factory
 }
 
 func (h *jsonHelper) skipValue(decoder HARDecoder) error {
@@ -70,8 +130,143 @@ func (h *jsonHelper) skipArray(decoder HARDecoder) error {
 	return err
 }
 
-func newHARDecoder(r io.Reader) HARDecoder {
-	d := json.NewDecoder(r)
+// countArray skips an array, returning how many elements it held; null or any other value counts as 0
+func (h *jsonHelper) countArray(decoder HARDecoder) (int, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return 0, err
+	}
+	switch token {
+	case json.Delim('['):
+	case json.Delim('{'):
+		return 0, h.skipObject(decoder)
+	default:
+		return 0, nil
+	}
+
+	count := 0
+	for decoder.More() {
+		if err := h.skipValue(decoder); err != nil {
+			return 0, err
+		}
+		count++
+	}
+	_, err = decoder.Token()
+	return count, err
+}
+
+// newHARDecoder returns a decoder from factory for a whole har file. a leading UTF-8 BOM is
+// dropped, and InputOffset still counts it so entry offsets stay file offsets; leading whitespace
+// the decoder skips on its own.
+func newHARDecoder(r io.Reader, factory DecoderFactory) HARDecoder {
+	buffered := bufio.NewReader(r)
+	skipped := 0
+	if prefix, _ := buffered.Peek(len(utf8BOM)); bytes.Equal(prefix, utf8BOM) {
+		skipped, _ = buffered.Discard(len(utf8BOM))
+	}
+
+	return withOffset(orStdlib(factory).NewDecoder(buffered), int64(skipped))
+}
+
+// resyncingDecoder is a HARDecoder that can give up on an array element that failed to parse and
+// carry on with the next one, see StreamerOptions.SkipBadEntries
+type resyncingDecoder interface {
+	HARDecoder
+
+	// depth is how many objects and arrays the decoder is inside
+	depth() int
+
+	// skipElement moves past the rest of the element being parsed in the array open at depth,
+	// to the start of the next element or the end of the array
+	skipElement(depth int) error
+}
+
+func (s *StdlibDecoder) depth() int {
+	return len(s.open)
+}
+
+// skipElement scans the raw input for the next '{' or the closing ']' of the array at depth,
+// skipping over strings and nested values, then starts a fresh decoder there: encoding/json stops
+// for good at a syntax error. the new decoder first reads a synthetic prefix that reopens the
+// objects and arrays around that point, so whoever parses the rest of the file finds it in the
+// state they left it. the scan is best-effort: a broken string can throw it off.
+func (s *StdlibDecoder) skipElement(depth int) error {
+	if s.source == nil || depth < 1 || depth > len(s.open) || s.open[depth-1] != '[' {
+		return fmt.Errorf("decoder cannot skip to the next array element")
+	}
+
+	pending, err := io.ReadAll(s.decoder.Buffered())
+	if err != nil {
+		return err
+	}
+	offset := s.InputOffset()
+	scan := bufio.NewReader(io.MultiReader(bytes.NewReader(pending), s.source))
+
+	nested := len(s.open) - depth
+	inString, escaped := false, false
+	for found := false; !found; offset++ {
+		c, err := scan.ReadByte()
+		if err != nil {
+			if err == io.EOF {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+
+		switch {
+		case inString:
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			if nested == 0 && c == '{' {
+				found = true
+				continue
+			}
+			nested++
+		case c == '}' || c == ']':
+			if nested == 0 {
+				found = c == ']' // a stray '}' is more of the broken element
+				continue
+			}
+			nested--
+		}
+	}
+	offset-- // the loop counted the byte found, which the new decoder reads again
+	if err := scan.UnreadByte(); err != nil {
+		return err
+	}
+
+	var prefix strings.Builder
+	tokens := 0
+	for _, delim := range s.open[:depth] {
+		if delim == '{' {
+			prefix.WriteString(`{"":`)
+			tokens += 2
+		} else {
+			prefix.WriteByte('[')
+			tokens++
+		}
+	}
+
+	rest, _ := scan.Peek(scan.Buffered())
+	d := json.NewDecoder(io.MultiReader(strings.NewReader(prefix.String()), bytes.NewReader(bytes.Clone(rest)), s.source))
 	d.UseNumber()
-	return &StdlibDecoder{decoder: d}
+	for range tokens {
+		if _, err := d.Token(); err != nil {
+			return err
+		}
+	}
+
+	s.decoder = d
+	s.skipped = offset - int64(prefix.Len())
+	s.open = s.open[:depth]
+	return nil
 }
@@ -0,0 +1,111 @@
+package motor
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingDecoderFactory stands in for a third-party decoder: it wraps encoding/json in a type of
+// its own, so the builder and readers see something other than a StdlibDecoder
+type countingDecoderFactory struct {
+	decoders atomic.Int64
+}
+
+type countingDecoder struct {
+	decoder *json.Decoder
+}
+
+func (f *countingDecoderFactory) NewDecoder(r io.Reader) HARDecoder {
+	f.decoders.Add(1)
+	d := json.NewDecoder(r)
+	d.UseNumber()
+	return &countingDecoder{decoder: d}
+}
+
+func (c *countingDecoder) Token() (json.Token, error) { return c.decoder.Token() }
+func (c *countingDecoder) Decode(v interface{}) error { return c.decoder.Decode(v) }
+func (c *countingDecoder) More() bool                 { return c.decoder.More() }
+func (c *countingDecoder) InputOffset() int64         { return c.decoder.InputOffset() }
+
+func openWithDecoder(t *testing.T, content string, factory DecoderFactory, follow bool) (*DefaultHARStreamer, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "capture.har")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	opts := DefaultStreamerOptions()
+	opts.Decoder = factory
+	opts.Follow = follow
+	streamer, err := NewHARStreamer(path, opts)
+	require.NoError(t, err)
+	t.Cleanup(func() { streamer.Close() })
+	require.NoError(t, streamer.Initialize(context.Background()))
+	return streamer, path
+}
+
+func TestStreamerOptions_Decoder(t *testing.T) {
+	// the bom is dropped before the decoder sees the file, so its offsets are shifted back
+	content := string(utf8BOM) + followHAR(true, "aaaa", "bbbb")
+	factory := &countingDecoderFactory{}
+	custom, _ := openWithDecoder(t, content, factory, false)
+	stdlib, _ := openWithDecoder(t, content, nil, false)
+
+	assert.Equal(t, stdlib.GetIndex().Entries, custom.GetIndex().Entries, "offsets match the stdlib decoder's")
+	built := factory.decoders.Load()
+	assert.Equal(t, int64(1), built, "the index is built with the custom decoder")
+
+	assert.Equal(t, entryURLs(t, stdlib), entryURLs(t, custom))
+	assert.Greater(t, factory.decoders.Load(), built, "entries are read with the custom decoder")
+}
+
+func TestStreamerOptions_DecoderFollow(t *testing.T) {
+	factory := &countingDecoderFactory{}
+	streamer, path := openWithDecoder(t, followHAR(false, "aaaa"), factory, true)
+	require.NoError(t, os.WriteFile(path, []byte(followHAR(true, "aaaa", "bbbb")), 0644))
+
+	built := factory.decoders.Load()
+	result, err := streamer.Follow(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, FollowResult{Added: 1}, result)
+	assert.Greater(t, factory.decoders.Load(), built, "appended entries are indexed with the custom decoder")
+	assert.Equal(t, []string{"https://example.com/aaaa", "https://example.com/bbbb"}, entryURLs(t, streamer))
+}
+
+func TestStreamerOptions_DecoderSkipBadEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.har")
+	require.NoError(t, os.WriteFile(path, []byte(followHead+followEntry("aaaa")+`, {"request": {"method": 1}}]}}`), 0644))
+
+	for _, factory := range []DecoderFactory{nil, &countingDecoderFactory{}} {
+		opts := DefaultStreamerOptions()
+		opts.Decoder = factory
+		opts.SkipBadEntries = true
+		streamer, err := NewHARStreamer(path, opts)
+		require.NoError(t, err)
+		err = streamer.Initialize(context.Background())
+		if factory == nil {
+			assert.NoError(t, err)
+			assert.Len(t, streamer.GetIndex().SkippedEntries, 1)
+		} else {
+			assert.Error(t, err, "only the stdlib decoder can skip a bad entry")
+		}
+		streamer.Close()
+	}
+}
+
+func TestWithOffset(t *testing.T) {
+	std := StdlibDecoderFactory{}.NewDecoder(strings.NewReader(""))
+	assert.Same(t, std, withOffset(std, 3), "a stdlib decoder is shifted in place")
+	assert.Equal(t, int64(3), std.InputOffset())
+
+	custom := (&countingDecoderFactory{}).NewDecoder(strings.NewReader(""))
+	assert.Same(t, custom, withOffset(custom, 0))
+	assert.Equal(t, int64(5), withOffset(custom, 5).InputOffset())
+}
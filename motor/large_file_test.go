@@ -0,0 +1,122 @@
+//go:build unix
+
+package motor
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lastEntryOffset puts the last entry past 4GB, beyond both int32 and uint32 offsets
+const lastEntryOffset = int64(5) << 30
+
+const largeFileEntry = `{"startedDateTime": "2024-01-01T00:00:00Z", "time": 1,
+ "request": {"method": "GET", "url": "https://example.com/%s", "headers": [], "bodySize": 0},
+ "response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 5000000000, "mimeType": "text/plain", "text": "body of %s"}, "bodySize": 5000000000},
+ "timings": {"send": 1, "wait": 1, "receive": 1}}`
+
+// writeSparseHAR writes a first entry at the start of a file and the last at lastEntryOffset,
+// leaving a hole between them so the multi-GB file takes a few KB of disk
+func writeSparseHAR(t *testing.T) (string, *Index) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping large file test in short mode")
+	}
+
+	path := filepath.Join(t.TempDir(), "large.har")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	first := strings.ReplaceAll(largeFileEntry, "%s", "first")
+	last := strings.ReplaceAll(largeFileEntry, "%s", "last")
+	_, err = file.WriteAt([]byte(first), 0)
+	require.NoError(t, err)
+	_, err = file.WriteAt([]byte(last), lastEntryOffset)
+	require.NoError(t, err)
+
+	info, err := file.Stat()
+	require.NoError(t, err)
+	if stat, ok := info.Sys().(*syscall.Stat_t); !ok || stat.Blocks*512 > 1<<30 {
+		t.Skip("temp directory does not support sparse files")
+	}
+
+	index := &Index{
+		FilePath: path,
+		FileSize: info.Size(),
+		Entries: []*EntryMetadata{
+			{FileOffset: 0, Length: int64(len(first)), URL: "https://example.com/first"},
+			{FileOffset: lastEntryOffset, Length: int64(len(last)), URL: "https://example.com/last"},
+		},
+		TotalEntries: 2,
+	}
+	return path, index
+}
+
+func TestLargeFile_ReadFirstAndLastEntry(t *testing.T) {
+	path, index := writeSparseHAR(t)
+
+	reader, err := NewEntryReader(path, index)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	for _, meta := range index.Entries {
+		resp := reader.Read(context.Background(), NewReadRequestBuilder().WithOffset(meta.FileOffset).WithLength(meta.Length).Build())
+		require.NoError(t, resp.GetError(), meta.URL)
+		assert.Equal(t, meta.URL, resp.GetEntry().Request.URL)
+		assert.Equal(t, int64(5000000000), resp.GetEntry().Response.BodySize)
+		assert.Equal(t, int64(5000000000), resp.GetEntry().Response.Body.Size)
+	}
+
+	batch := reader.ReadBatch(context.Background(),
+		[]int64{index.Entries[0].FileOffset, index.Entries[1].FileOffset},
+		[]int64{index.Entries[0].Length, index.Entries[1].Length})
+	require.Len(t, batch, 2)
+	for i, resp := range batch {
+		require.NoError(t, resp.GetError())
+		assert.Equal(t, index.Entries[i].URL, resp.GetEntry().Request.URL)
+	}
+
+	body, err := reader.StreamResponseBody(lastEntryOffset)
+	require.NoError(t, err)
+	defer body.Close()
+	text, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, "body of last", string(text))
+}
+
+func TestLargeFile_MmapReader(t *testing.T) {
+	path, index := writeSparseHAR(t)
+	if ^uint(0)>>32 == 0 {
+		t.Skip("a multi-GB file cannot be mapped on a 32-bit platform")
+	}
+
+	reader, err := NewMmapEntryReader(path, index)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	last := index.Entries[1]
+	resp := reader.Read(context.Background(), NewReadRequestBuilder().WithOffset(last.FileOffset).WithLength(last.Length).Build())
+	require.NoError(t, resp.GetError())
+	assert.Equal(t, last.URL, resp.GetEntry().Request.URL)
+}
+
+func TestIndexBuilder_BodySizesOver2GB(t *testing.T) {
+	har := `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1.0"}, "entries": [` +
+		strings.ReplaceAll(largeFileEntry, "%s", "huge") + `]}}`
+
+	index, err := NewIndexBuilder("huge.har").Build(strings.NewReader(har))
+	require.NoError(t, err)
+	require.Len(t, index.Entries, 1)
+	assert.Equal(t, int64(5000000000), index.Entries[0].ResponseSize)
+	assert.Equal(t, int64(5000000000), index.Entries[0].BodySize)
+	assert.Equal(t, int64(5000000000), index.TotalResponseBytes)
+}
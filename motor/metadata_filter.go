@@ -0,0 +1,54 @@
+package motor
+
+import (
+	"context"
+	"strings"
+)
+
+// MetadataFilter is a declarative filter over indexed entry metadata, for callers that can't
+// supply a Go func to StreamFiltered (the TUI and CLI). zero values leave a bound open.
+type MetadataFilter struct {
+	StatusMin   int      // minimum status code, inclusive
+	StatusMax   int      // maximum status code, inclusive
+	DurationMin float64  // minimum total time in milliseconds, inclusive
+	DurationMax float64  // maximum total time in milliseconds, inclusive
+	Methods     []string // allowed request methods, case-insensitive (empty = any)
+}
+
+// Matches returns true if the entry satisfies every bound set on the filter
+func (f MetadataFilter) Matches(metadata *EntryMetadata) bool {
+	if metadata == nil {
+		return false
+	}
+	if f.StatusMin > 0 && metadata.StatusCode < f.StatusMin {
+		return false
+	}
+	if f.StatusMax > 0 && metadata.StatusCode > f.StatusMax {
+		return false
+	}
+	if f.DurationMin > 0 && metadata.Duration < f.DurationMin {
+		return false
+	}
+	if f.DurationMax > 0 && metadata.Duration > f.DurationMax {
+		return false
+	}
+	if len(f.Methods) > 0 {
+		for _, method := range f.Methods {
+			if strings.EqualFold(method, metadata.Method) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// IsEmpty returns true if the filter has no bounds and matches every entry
+func (f MetadataFilter) IsEmpty() bool {
+	return f.StatusMin == 0 && f.StatusMax == 0 && f.DurationMin == 0 && f.DurationMax == 0 && len(f.Methods) == 0
+}
+
+// StreamFilteredBy streams entries matching a declarative metadata filter
+func (s *DefaultHARStreamer) StreamFilteredBy(ctx context.Context, filter MetadataFilter) (<-chan StreamResult, error) {
+	return s.StreamFiltered(ctx, filter.Matches)
+}
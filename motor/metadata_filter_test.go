@@ -0,0 +1,73 @@
+package motor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataFilter_Matches(t *testing.T) {
+	entry := &EntryMetadata{Method: "POST", StatusCode: 503, Duration: 750}
+
+	tests := []struct {
+		name     string
+		filter   MetadataFilter
+		expected bool
+	}{
+		{"empty", MetadataFilter{}, true},
+		{"status min", MetadataFilter{StatusMin: 400}, true},
+		{"status min excludes", MetadataFilter{StatusMin: 504}, false},
+		{"status range", MetadataFilter{StatusMin: 500, StatusMax: 599}, true},
+		{"status max excludes", MetadataFilter{StatusMax: 499}, false},
+		{"duration min", MetadataFilter{DurationMin: 500}, true},
+		{"duration max excludes", MetadataFilter{DurationMax: 500}, false},
+		{"duration bounds inclusive", MetadataFilter{DurationMin: 750, DurationMax: 750}, true},
+		{"method", MetadataFilter{Methods: []string{"GET", "post"}}, true},
+		{"method excludes", MetadataFilter{Methods: []string{"GET"}}, false},
+		{"combined", MetadataFilter{StatusMin: 500, DurationMin: 500, Methods: []string{"POST"}}, true},
+		{"combined one failing", MetadataFilter{StatusMin: 500, DurationMin: 1000, Methods: []string{"POST"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.filter.Matches(entry))
+		})
+	}
+
+	assert.False(t, MetadataFilter{}.Matches(nil))
+	assert.True(t, MetadataFilter{}.IsEmpty())
+	assert.False(t, MetadataFilter{Methods: []string{"GET"}}.IsEmpty())
+}
+
+func TestHARStreamer_StreamFilteredBy(t *testing.T) {
+	harFile, cleanup, err := generateSmallHAR()
+	require.NoError(t, err)
+	defer cleanup()
+
+	streamer, err := NewHARStreamer(harFile, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	filter := MetadataFilter{StatusMin: 400}
+
+	expected := 0
+	for _, meta := range streamer.GetIndex().Entries {
+		if meta.StatusCode >= 400 {
+			expected++
+		}
+	}
+
+	resultChan, err := streamer.StreamFilteredBy(context.Background(), filter)
+	require.NoError(t, err)
+
+	count := 0
+	for result := range resultChan {
+		require.NoError(t, result.Error)
+		assert.GreaterOrEqual(t, result.Entry.Response.StatusCode, 400)
+		count++
+	}
+	assert.Equal(t, expected, count)
+}
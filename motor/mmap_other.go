@@ -0,0 +1,14 @@
+//go:build !unix
+
+package motor
+
+import "os"
+
+// mmapFile is unavailable here; NewMmapEntryReader falls back to the pooled file reader
+func mmapFile(file *os.File, size int64) ([]byte, error) {
+	return nil, errMmapUnsupported
+}
+
+func munmapFile(data []byte) error {
+	return nil
+}
@@ -31,6 +31,15 @@ type Entry struct {
 
 	// Comment can be added by the user
 	Comment string `json:"comment,omitempty"`
+
+	// ResourceType is how the browser classified the request: document, script, xhr, ... (Chrome extension)
+	ResourceType string `json:"_resourceType,omitempty"`
+
+	// Priority is the browser's fetch priority, e.g. VeryHigh or Low (Chrome extension)
+	Priority string `json:"_priority,omitempty"`
+
+	// WebSocketMessages contains frames for WebSocket connections (Chrome extension)
+	WebSocketMessages []WebSocketMessage `json:"_webSocketMessages,omitempty"`
 }
 
 // CacheState represents the cache status before and after a request.
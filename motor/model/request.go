@@ -28,7 +28,7 @@ type Request struct {
 	HeadersSize int `json:"headersSize"`
 
 	// BodySize of the request body in bytes.
-	BodySize int `json:"bodySize"`
+	BodySize int64 `json:"bodySize"`
 
 	// Comment can be added by the user
 	Comment string `json:"comment,omitempty"`
@@ -30,7 +30,7 @@ type Response struct {
 	HeadersSize int `json:"headersSize"`
 
 	// BodySize of the response body in bytes (as sent)
-	BodySize int `json:"bodySize"`
+	BodySize int64 `json:"bodySize"`
 
 	// Comment can be added by the user
 	Comment string `json:"comment,omitempty"`
@@ -39,7 +39,7 @@ type Response struct {
 // BodyResponseType contains various information about the response body.
 type BodyResponseType struct {
 	// Size of response content in bytes (decompressed).
-	Size int `json:"size"`
+	Size int64 `json:"size"`
 	// Compression is the number of bytes saved by compression
 	Compression int `json:"compression,omitempty"`
 	// MIMEType of the body content
@@ -48,6 +48,8 @@ type BodyResponseType struct {
 	Content string `json:"text,omitempty"`
 	// Encoding used by the response.
 	Encoding string `json:"encoding,omitempty"`
+	// File references a body stored outside the HAR, which some proxies write in place of Content
+	File string `json:"_file,omitempty"`
 	// Comment can be added by the user
 	Comment string `json:"comment,omitempty"`
 }
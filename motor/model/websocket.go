@@ -0,0 +1,13 @@
+package model
+
+// WebSocketMessage is a single WebSocket frame recorded by Chrome under the `_webSocketMessages` extension.
+type WebSocketMessage struct {
+	// Type is the direction of the frame, "send" or "receive"
+	Type string `json:"type"`
+	// Time the frame was sent or received, in seconds since the epoch
+	Time float64 `json:"time"`
+	// Opcode of the frame (1 = text, 2 = binary)
+	Opcode int `json:"opcode"`
+	// Data is the frame payload
+	Data string `json:"data"`
+}
@@ -0,0 +1,36 @@
+package motor
+
+import (
+	"context"
+
+	"github.com/pb33f/harific/motor/model"
+)
+
+// Page returns the page with the given id from the har's log.pages
+func (idx *Index) Page(pageID string) (*model.Page, bool) {
+	for i := range idx.Pages {
+		if idx.Pages[i].ID == pageID {
+			return &idx.Pages[i], true
+		}
+	}
+	return nil, false
+}
+
+// EntriesForPage returns the metadata of every entry whose pageref is pageID, in file order.
+// an empty pageID returns the entries that belong to no page.
+func (idx *Index) EntriesForPage(pageID string) []*EntryMetadata {
+	var entries []*EntryMetadata
+	for _, entry := range idx.Entries {
+		if entry.PageRef == pageID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// StreamPage streams the entries belonging to a page, see Index.EntriesForPage
+func (s *DefaultHARStreamer) StreamPage(ctx context.Context, pageID string) (<-chan StreamResult, error) {
+	return s.StreamFiltered(ctx, func(metadata *EntryMetadata) bool {
+		return metadata.PageRef == pageID
+	})
+}
@@ -0,0 +1,76 @@
+package motor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pagesFixture = `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1.0"},
+  "pages": [
+    {"startedDateTime": "2024-01-01T00:00:00Z", "id": "page_1", "title": "Home", "pageTimings": {"onContentLoad": 120, "onLoad": 340}},
+    {"startedDateTime": "2024-01-01T00:00:05Z", "id": "page_2", "title": "Checkout", "pageTimings": {"onLoad": 90}}
+  ],
+  "entries": [
+    {"pageref": "page_1", "startedDateTime": "2024-01-01T00:00:00Z", "time": 10, "request": {"method": "GET", "url": "https://example.com/", "headers": [], "bodySize": 0}, "response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 0, "mimeType": "text/html"}, "bodySize": 0}, "timings": {"send": 1, "wait": 8, "receive": 1}},
+    {"pageref": "page_2", "startedDateTime": "2024-01-01T00:00:05Z", "time": 10, "request": {"method": "GET", "url": "https://example.com/checkout", "headers": [], "bodySize": 0}, "response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 0, "mimeType": "text/html"}, "bodySize": 0}, "timings": {"send": 1, "wait": 8, "receive": 1}},
+    {"pageref": "page_1", "startedDateTime": "2024-01-01T00:00:01Z", "time": 10, "request": {"method": "GET", "url": "https://example.com/app.js", "headers": [], "bodySize": 0}, "response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 0, "mimeType": "text/javascript"}, "bodySize": 0}, "timings": {"send": 1, "wait": 8, "receive": 1}},
+    {"startedDateTime": "2024-01-01T00:00:09Z", "time": 10, "request": {"method": "GET", "url": "https://example.com/beacon", "headers": [], "bodySize": 0}, "response": {"status": 204, "statusText": "No Content", "headers": [], "content": {"size": 0, "mimeType": ""}, "bodySize": 0}, "timings": {"send": 1, "wait": 8, "receive": 1}}
+  ]}}`
+
+func initPagesFixture(t *testing.T) *DefaultHARStreamer {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pages.har")
+	require.NoError(t, os.WriteFile(path, []byte(pagesFixture), 0644))
+
+	streamer, err := NewHARStreamer(path, DefaultStreamerOptions())
+	require.NoError(t, err)
+	t.Cleanup(func() { streamer.Close() })
+	require.NoError(t, streamer.Initialize(context.Background()))
+	return streamer
+}
+
+func TestIndex_Pages(t *testing.T) {
+	idx := initPagesFixture(t).GetIndex()
+
+	require.Len(t, idx.Pages, 2)
+	page, ok := idx.Page("page_1")
+	require.True(t, ok)
+	assert.Equal(t, "Home", page.Title)
+	assert.Equal(t, 120.0, page.PageTimings.OnContentLoad)
+	assert.Equal(t, 340.0, page.PageTimings.OnLoad)
+
+	_, ok = idx.Page("missing")
+	assert.False(t, ok)
+
+	home := idx.EntriesForPage("page_1")
+	require.Len(t, home, 2)
+	assert.Equal(t, "https://example.com/", home[0].URL)
+	assert.Equal(t, "https://example.com/app.js", home[1].URL)
+
+	assert.Len(t, idx.EntriesForPage("page_2"), 1)
+	unpaged := idx.EntriesForPage("")
+	require.Len(t, unpaged, 1)
+	assert.Equal(t, "https://example.com/beacon", unpaged[0].URL)
+}
+
+func TestHARStreamer_StreamPage(t *testing.T) {
+	streamer := initPagesFixture(t)
+
+	resultChan, err := streamer.StreamPage(context.Background(), "page_1")
+	require.NoError(t, err)
+
+	var indices []int
+	for result := range resultChan {
+		require.NoError(t, result.Error)
+		assert.Equal(t, "page_1", result.Entry.PageRef)
+		indices = append(indices, result.Index)
+	}
+	sort.Ints(indices)
+	assert.Equal(t, []int{0, 2}, indices)
+}
@@ -1,9 +1,9 @@
 package motor
 
 import (
+	"bufio"
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -14,9 +14,10 @@ import (
 )
 
 const (
-	// MaxEntrySize is the maximum size of a single HAR entry that can be read.
+	// MaxEntrySize is the default maximum size of a single HAR entry that can be read.
 	// This prevents OOM attacks from malicious or corrupted HAR files.
-	// 100MB should be sufficient for even very large responses.
+	// 100MB should be sufficient for even very large responses; readers can raise it
+	// with SetMaxEntrySize (or StreamerOptions.MaxEntrySize).
 	MaxEntrySize = 100 * 1024 * 1024 // 100MB
 )
 
@@ -25,8 +26,13 @@ type DefaultEntryReader struct {
 	filePool    *sync.Pool               // pool of file handles for concurrent access
 	index       *Index
 	offsetIndex map[int64]*EntryMetadata // o(1) metadata lookup by offset
+	offsetMu    sync.RWMutex             // protects offsetIndex as Refresh extends it
 	pooledFiles []*os.File               // track pooled files for cleanup
 	mu          sync.Mutex               // protects pooledFiles slice
+	bufferPool  sync.Pool                // span buffers reused across ReadBatch calls
+	maxEntry    int64                    // largest entry Read will load (default MaxEntrySize)
+	external    *ExternalBodies          // resolves bodies stored in files of their own, nil when off
+	decoders    DecoderFactory           // decodes entries, nil for encoding/json
 }
 
 // pooledFile wraps *os.File with thread-safe registration
@@ -52,18 +58,25 @@ func (pf *pooledFile) Read(p []byte) (n int, err error) {
 	return pf.file.Read(p)
 }
 
+// NewEntryReader creates a random-access reader for the entries in index. When filePath is a
+// gzip file, reads go to the decompressed copy the index was built from (index.FilePath).
 func NewEntryReader(filePath string, index *Index) (*DefaultEntryReader, error) {
-	// pre-build offset index for o(1) metadata lookups during search
-	offsetIndex := make(map[int64]*EntryMetadata, len(index.Entries))
-	for i := range index.Entries {
-		offsetIndex[index.Entries[i].FileOffset] = index.Entries[i]
+	filePath = entryDataPath(filePath, index)
+	if err := checkEntryRanges(index.Entries); err != nil {
+		return nil, fmt.Errorf("invalid index for %s: %w", filePath, err)
 	}
 
 	reader := &DefaultEntryReader{
 		filePath:    filePath,
 		index:       index,
-		offsetIndex: offsetIndex,
+		offsetIndex: buildOffsetIndex(index),
 		pooledFiles: make([]*os.File, 0, 16),
+		maxEntry:    MaxEntrySize,
+	}
+
+	reader.bufferPool.New = func() interface{} {
+		buf := make([]byte, 64*1024) // 64kb buffers, grown for larger spans
+		return &buf
 	}
 
 	reader.filePool = &sync.Pool{
@@ -85,6 +98,64 @@ func NewEntryReader(filePath string, index *Index) (*DefaultEntryReader, error)
 	return reader, nil
 }
 
+// entryDataPath returns the file the index offsets refer to: for a gzip file that is the
+// decompressed copy the index was built from (index.FilePath)
+func entryDataPath(filePath string, index *Index) string {
+	if index.FilePath != "" && index.FilePath != filePath {
+		if gzipped, err := IsGzipFile(filePath); err == nil && gzipped {
+			return index.FilePath
+		}
+	}
+	return filePath
+}
+
+// buildOffsetIndex pre-builds the offset lookup for o(1) metadata lookups during search
+func buildOffsetIndex(index *Index) map[int64]*EntryMetadata {
+	offsetIndex := make(map[int64]*EntryMetadata, len(index.Entries))
+	for i := range index.Entries {
+		offsetIndex[index.Entries[i].FileOffset] = index.Entries[i]
+	}
+	return offsetIndex
+}
+
+// decodeEntry decodes the entry at the start of r with a decoder from factory (nil for
+// encoding/json), skipping any leading array separator
+func decodeEntry(r io.Reader, factory DecoderFactory) (*model.Entry, error) {
+	decoder := orStdlib(factory).NewDecoder(&skipLeadingReader{reader: r})
+	var entry model.Entry
+	if err := decoder.Decode(&entry); err != nil {
+		return nil, fmt.Errorf("decode failed: %w", err)
+	}
+	return &entry, nil
+}
+
+// SetMaxEntrySize raises (or lowers) the size cap that guards reads against oversized entries
+func (r *DefaultEntryReader) SetMaxEntrySize(size int64) error {
+	if err := validateMaxEntrySize(size); err != nil {
+		return err
+	}
+	r.maxEntry = size
+	return nil
+}
+
+// SetExternalBodies reads response bodies the har stores in files of their own through bodies;
+// nil turns that off. the reader does not close bodies.
+func (r *DefaultEntryReader) SetExternalBodies(bodies *ExternalBodies) {
+	r.external = bodies
+}
+
+// SetDecoderFactory decodes entries with decoders from factory; nil goes back to encoding/json
+func (r *DefaultEntryReader) SetDecoderFactory(factory DecoderFactory) {
+	r.decoders = factory
+}
+
+func validateMaxEntrySize(size int64) error {
+	if size <= 0 {
+		return fmt.Errorf("max entry size must be positive, got %d", size)
+	}
+	return nil
+}
+
 func (r *DefaultEntryReader) Read(ctx context.Context, req ReadRequest) ReadResponse {
 	resp := newReadResponse()
 
@@ -97,8 +168,8 @@ func (r *DefaultEntryReader) Read(ctx context.Context, req ReadRequest) ReadResp
 	}
 
 	// Validate entry size to prevent OOM attacks
-	if req.GetLength() > MaxEntrySize {
-		resp.err = fmt.Errorf("entry size %d exceeds maximum allowed size %d", req.GetLength(), MaxEntrySize)
+	if req.GetLength() > r.maxEntry {
+		resp.err = fmt.Errorf("entry size %d exceeds maximum allowed size %d", req.GetLength(), r.maxEntry)
 		return resp
 	}
 
@@ -130,8 +201,8 @@ func (r *DefaultEntryReader) Read(ctx context.Context, req ReadRequest) ReadResp
 		// search path: use pooled buffer for maximum efficiency
 		if req.GetLength() > int64(cap(*buf)) {
 			// Double-check size limit before allocation (defense in depth)
-			if req.GetLength() > MaxEntrySize {
-				resp.err = fmt.Errorf("cannot allocate buffer: entry size %d exceeds maximum %d", req.GetLength(), MaxEntrySize)
+			if req.GetLength() > r.maxEntry {
+				resp.err = fmt.Errorf("cannot allocate buffer: entry size %d exceeds maximum %d", req.GetLength(), r.maxEntry)
 				return resp
 			}
 			*buf = make([]byte, req.GetLength())
@@ -159,26 +230,56 @@ func (r *DefaultEntryReader) Read(ctx context.Context, req ReadRequest) ReadResp
 	default:
 	}
 
-	skipReader := &skipLeadingReader{reader: jsonReader}
-	decoder := json.NewDecoder(skipReader)
-	var entry model.Entry
-	if err := decoder.Decode(&entry); err != nil {
-		resp.err = fmt.Errorf("decode failed: %w", err)
+	entry, err := decodeEntry(jsonReader, r.decoders)
+	if err == nil && r.external != nil {
+		err = r.external.load(entry, r.maxEntry)
+	}
+	if err != nil {
+		resp.err = err
 		return resp
 	}
 
-	resp.entry = &entry
+	resp.entry = entry
 	return resp
 }
 
 // fast metadata lookup without loading full entry from disk
 func (r *DefaultEntryReader) ReadMetadata(offset int64) (*EntryMetadata, error) {
-	if meta, ok := r.offsetIndex[offset]; ok {
+	r.offsetMu.RLock()
+	meta, ok := r.offsetIndex[offset]
+	r.offsetMu.RUnlock()
+	if ok {
 		return meta, nil
 	}
 	return nil, fmt.Errorf("metadata not found for offset %d", offset)
 }
 
+// Refresh makes the entries appended to the reader's index since it was created readable, for a
+// caller extending that index in place with DefaultIndexBuilder.Resume
+func (r *DefaultEntryReader) Refresh() {
+	r.offsetMu.Lock()
+	defer r.offsetMu.Unlock()
+	r.addOffsets()
+}
+
+// RefreshIndex moves the reader onto index, its index with entries appended, making the new
+// entries readable. DefaultHARStreamer.Follow swaps in such a copy rather than growing the index
+// its readers hold; pass it the streamer's GetIndex after a Follow that was not a rebuild.
+func (r *DefaultEntryReader) RefreshIndex(index *Index) {
+	r.offsetMu.Lock()
+	defer r.offsetMu.Unlock()
+	r.index = index
+	r.addOffsets()
+}
+
+// addOffsets adds the index's entries past those already looked up by offset; the caller holds
+// offsetMu
+func (r *DefaultEntryReader) addOffsets() {
+	for _, meta := range r.index.Entries[len(r.offsetIndex):] {
+		r.offsetIndex[meta.FileOffset] = meta
+	}
+}
+
 // close releases all file handles in the pool
 func (r *DefaultEntryReader) Close() error {
 	r.mu.Lock()
@@ -248,35 +349,46 @@ func (r *DefaultEntryReader) ReadAt(offset int64, length int64) (*model.Entry, e
 	return resp.GetEntry(), nil
 }
 
-// deprecated: use Read() with entry.Response.Body.Content
-func (r *DefaultEntryReader) StreamResponseBody(offset int64) (io.ReadCloser, error) {
+// ReadPartial decodes only the named top-level fields of the entry at offset: request, response,
+// timings, startedDateTime and time. the entry is scanned rather than decoded, so the fields left
+// out (a large response body, say) are skipped over without being held in memory. other names
+// are ignored, and a field the entry lacks comes back as its zero value.
+func (r *DefaultEntryReader) ReadPartial(offset int64, fields []string) (map[string]interface{}, error) {
 	meta, err := r.ReadMetadata(offset)
 	if err != nil {
 		return nil, err
 	}
 
-	entry, err := r.ReadAt(offset, meta.Length)
-	if err != nil {
-		return nil, err
+	entry := &model.Entry{}
+	targets := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		switch field {
+		case "request":
+			targets[field] = &entry.Request
+		case "response":
+			targets[field] = &entry.Response
+		case "timings":
+			targets[field] = &entry.Timings
+		case "startedDateTime":
+			targets[field] = &entry.Start
+		case "time":
+			targets[field] = &entry.Time
+		}
 	}
-
-	if entry.Response.Body.Content == "" {
-		return io.NopCloser(strings.NewReader("")), nil
+	_, withResponse := targets["response"]
+	if withResponse && meta.Length > r.maxEntry {
+		return nil, fmt.Errorf("entry size %d exceeds maximum allowed size %d", meta.Length, r.maxEntry)
 	}
 
-	return io.NopCloser(strings.NewReader(entry.Response.Body.Content)), nil
-}
-
-// deprecated: use Read() and extract fields manually
-func (r *DefaultEntryReader) ReadPartial(offset int64, fields []string) (map[string]interface{}, error) {
-	meta, err := r.ReadMetadata(offset)
-	if err != nil {
-		return nil, err
+	if len(targets) > 0 {
+		if err := r.readFields(offset, meta.Length, targets); err != nil {
+			return nil, fmt.Errorf("failed to read fields at offset %d: %w", offset, err)
+		}
 	}
-
-	entry, err := r.ReadAt(offset, meta.Length)
-	if err != nil {
-		return nil, err
+	if withResponse && r.external != nil {
+		if err := r.external.load(entry, r.maxEntry); err != nil {
+			return nil, err
+		}
 	}
 
 	result := make(map[string]interface{})
@@ -297,3 +409,82 @@ func (r *DefaultEntryReader) ReadPartial(offset int64, fields []string) (map[str
 
 	return result, nil
 }
+
+// readFields scans the entry at offset for the keys of targets (matched case-insensitively, as
+// encoding/json does), decoding each value into its target and skipping every other member
+func (r *DefaultEntryReader) readFields(offset, length int64, targets map[string]interface{}) error {
+	pooledHandle := r.filePool.Get()
+	if pooledHandle == nil {
+		return fmt.Errorf("failed to get file handle from pool")
+	}
+	pf, ok := pooledHandle.(*pooledFile)
+	if !ok || pf == nil {
+		return fmt.Errorf("invalid file handle type")
+	}
+	defer r.filePool.Put(pf)
+
+	if _, err := pf.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("seek failed: %w", err)
+	}
+	scanner := &jsonScanner{r: bufio.NewReaderSize(io.LimitReader(pf, length), 64*1024)}
+
+	c, err := scanner.next()
+	for err == nil && c == ',' {
+		c, err = scanner.next()
+	}
+	if err != nil {
+		return err
+	}
+	if c != '{' {
+		return fmt.Errorf("expected an entry object, got %q", c)
+	}
+
+	for {
+		c, err := scanner.next()
+		if err != nil {
+			return err
+		}
+		switch c {
+		case '}':
+			return nil
+		case ',':
+			continue
+		case '"':
+		default:
+			return fmt.Errorf("expected an object key, got %q", c)
+		}
+
+		name, err := scanner.readKey()
+		if err != nil {
+			return err
+		}
+		if c, err = scanner.next(); err != nil {
+			return err
+		}
+		if c != ':' {
+			return fmt.Errorf("expected ':' after %q, got %q", name, c)
+		}
+
+		var target interface{}
+		for key, t := range targets {
+			if strings.EqualFold(name, key) {
+				target = t
+				break
+			}
+		}
+		if target == nil {
+			if err := scanner.skipValue(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		raw, err := scanner.captureValue()
+		if err != nil {
+			return err
+		}
+		if err := orStdlib(r.decoders).NewDecoder(bytes.NewReader(raw)).Decode(target); err != nil {
+			return fmt.Errorf("failed to decode %s: %w", name, err)
+		}
+	}
+}
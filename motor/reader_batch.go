@@ -0,0 +1,165 @@
+package motor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+)
+
+const (
+	// maxBatchGap is the most separator bytes (commas, whitespace) allowed between two entries
+	// for them to share a read; entries further apart start a new span
+	maxBatchGap = 4 * 1024
+
+	// maxBatchSpan caps the bytes read into memory at once for one span of a batch
+	maxBatchSpan = 8 * 1024 * 1024
+)
+
+// readSpan is a run of batch requests, in request order, that lie back to back in the file
+type readSpan struct {
+	start, end int64 // file range covering every request in the span
+	requests   []int // positions in the batch
+}
+
+// planReadSpans groups contiguous requests into spans. requests that fail validation, including
+// entries larger than maxEntry, are reported in errs and never join a span, so one bad entry
+// doesn't fail its neighbours.
+func planReadSpans(offsets, lengths []int64, maxEntry int64) ([]readSpan, map[int]error) {
+	var spans []readSpan
+	errs := make(map[int]error)
+
+	for i := range offsets {
+		offset, length := offsets[i], lengths[i]
+		switch {
+		case length > maxEntry:
+			errs[i] = fmt.Errorf("entry size %d exceeds maximum allowed size %d", length, maxEntry)
+			continue
+		case offset < 0 || length < 0:
+			errs[i] = fmt.Errorf("invalid read of %d bytes at offset %d", length, offset)
+			continue
+		}
+
+		end := offset + length
+		if n := len(spans); n > 0 {
+			last := &spans[n-1]
+			if offset >= last.end && offset-last.end <= maxBatchGap && end-last.start <= maxBatchSpan {
+				last.end = end
+				last.requests = append(last.requests, i)
+				continue
+			}
+		}
+		spans = append(spans, readSpan{start: offset, end: end, requests: []int{i}})
+	}
+	return spans, errs
+}
+
+// batchErrorResponses fails every request of a batch with err
+func batchErrorResponses(n int, err error) []ReadResponse {
+	responses := make([]ReadResponse, n)
+	for i := range responses {
+		resp := newReadResponse()
+		resp.err = err
+		responses[i] = resp
+	}
+	return responses
+}
+
+// ReadBatch reads several entries, returning one response per offset/length pair in the same
+// order. entries that sit back to back in the file are read with a single seek and read, then
+// decoded one by one from memory; a bad entry only fails its own response.
+func (r *DefaultEntryReader) ReadBatch(ctx context.Context, offsets []int64, lengths []int64) []ReadResponse {
+	if len(offsets) != len(lengths) {
+		return batchErrorResponses(len(offsets), fmt.Errorf("batch has %d offsets but %d lengths", len(offsets), len(lengths)))
+	}
+
+	responses := make([]ReadResponse, len(offsets))
+	spans, errs := planReadSpans(offsets, lengths, r.maxEntry)
+	for i, err := range errs {
+		resp := newReadResponse()
+		resp.err = err
+		responses[i] = resp
+	}
+
+	buf := r.bufferPool.Get().(*[]byte)
+	defer r.bufferPool.Put(buf)
+
+	for _, span := range spans {
+		if err := ctx.Err(); err != nil {
+			for _, i := range span.requests {
+				resp := newReadResponse()
+				resp.err = err
+				responses[i] = resp
+			}
+			continue
+		}
+
+		data, err := r.readSpan(span, buf)
+		for _, i := range span.requests {
+			resp := newReadResponse()
+			responses[i] = resp
+			if err != nil {
+				resp.err = err
+				continue
+			}
+
+			// a short read at the end of the file decodes what is there, like Read
+			from := min(offsets[i]-span.start, int64(len(data)))
+			to := min(offsets[i]+lengths[i]-span.start, int64(len(data)))
+			entry, err := decodeEntry(bytes.NewReader(data[from:to]), r.decoders)
+			if err == nil && r.external != nil {
+				err = r.external.load(entry, r.maxEntry)
+			}
+			if err != nil {
+				resp.err = err
+				continue
+			}
+			resp.entry = entry
+			resp.bytesRead = to - from
+		}
+	}
+
+	return responses
+}
+
+// readSpan reads a span's file range into buf, growing it if needed
+func (r *DefaultEntryReader) readSpan(span readSpan, buf *[]byte) ([]byte, error) {
+	pooledHandle := r.filePool.Get()
+	if pooledHandle == nil {
+		return nil, fmt.Errorf("failed to get file handle from pool")
+	}
+	pf, ok := pooledHandle.(*pooledFile)
+	if !ok || pf == nil {
+		return nil, fmt.Errorf("invalid file handle type")
+	}
+	defer r.filePool.Put(pf)
+
+	if _, err := pf.Seek(span.start, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("seek failed: %w", err)
+	}
+
+	size := span.end - span.start
+	if size > int64(cap(*buf)) {
+		*buf = make([]byte, size)
+	}
+
+	n, err := io.ReadFull(pf, (*buf)[:size])
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("read failed: %w", err)
+	}
+	return (*buf)[:n], nil
+}
+
+// ReadBatch reads several entries, returning one response per offset/length pair in the same
+// order; the mapping is sliced per entry, so there is no span to plan
+func (r *MmapEntryReader) ReadBatch(ctx context.Context, offsets []int64, lengths []int64) []ReadResponse {
+	if len(offsets) != len(lengths) {
+		return batchErrorResponses(len(offsets), fmt.Errorf("batch has %d offsets but %d lengths", len(offsets), len(lengths)))
+	}
+
+	responses := make([]ReadResponse, len(offsets))
+	for i := range offsets {
+		responses[i] = r.Read(ctx, NewReadRequestBuilder().WithOffset(offsets[i]).WithLength(lengths[i]).Build())
+	}
+	return responses
+}
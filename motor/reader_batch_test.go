@@ -0,0 +1,129 @@
+package motor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func batchRequests(entries []*EntryMetadata) ([]int64, []int64) {
+	offsets := make([]int64, len(entries))
+	lengths := make([]int64, len(entries))
+	for i, meta := range entries {
+		offsets[i] = meta.FileOffset
+		lengths[i] = meta.Length
+	}
+	return offsets, lengths
+}
+
+func TestPlanReadSpans(t *testing.T) {
+	// back to back entries separated by ",\n" share a span; a jump backwards starts a new one
+	offsets := []int64{100, 152, 200, 10}
+	lengths := []int64{50, 48, 20, 30}
+
+	spans, errs := planReadSpans(offsets, lengths, MaxEntrySize)
+	assert.Empty(t, errs)
+	require.Len(t, spans, 2)
+	assert.Equal(t, readSpan{start: 100, end: 220, requests: []int{0, 1, 2}}, spans[0])
+	assert.Equal(t, readSpan{start: 10, end: 40, requests: []int{3}}, spans[1])
+
+	// far apart entries and invalid requests are split out
+	spans, errs = planReadSpans([]int64{0, 101 + maxBatchGap, 0}, []int64{100, 10, MaxEntrySize + 1}, MaxEntrySize)
+	assert.Len(t, spans, 2)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[2].Error(), "exceeds maximum")
+}
+
+func TestReadBatch_MatchesRead(t *testing.T) {
+	harFile, cleanup, err := generateSmallHAR()
+	require.NoError(t, err)
+	defer cleanup()
+
+	index := buildTestIndex(t, harFile)
+	require.Greater(t, len(index.Entries), 10)
+
+	pooled, err := NewEntryReader(harFile, index)
+	require.NoError(t, err)
+	defer pooled.Close()
+
+	mapped, err := NewMmapEntryReader(harFile, index)
+	require.NoError(t, err)
+	defer mapped.Close()
+
+	window := index.Entries[3:10]
+	offsets, lengths := batchRequests(window)
+	spans, _ := planReadSpans(offsets, lengths, MaxEntrySize)
+	assert.Len(t, spans, 1, "contiguous entries are read in one span")
+
+	for name, reader := range map[string]EntryReader{"pooled": pooled, "mmap": mapped} {
+		t.Run(name, func(t *testing.T) {
+			responses := reader.ReadBatch(context.Background(), offsets, lengths)
+			require.Len(t, responses, len(window))
+			for i, meta := range window {
+				want := pooled.Read(context.Background(), NewReadRequestBuilder().WithOffset(meta.FileOffset).WithLength(meta.Length).Build())
+				require.NoError(t, responses[i].GetError())
+				assert.Equal(t, want.GetEntry(), responses[i].GetEntry())
+				assert.Equal(t, meta.Length, responses[i].GetBytesRead())
+			}
+		})
+	}
+}
+
+func TestReadBatch_ErrorIsolation(t *testing.T) {
+	harFile, cleanup, err := generateSmallHAR()
+	require.NoError(t, err)
+	defer cleanup()
+
+	index := buildTestIndex(t, harFile)
+	reader, err := NewEntryReader(harFile, index)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	offsets, lengths := batchRequests(index.Entries[:4])
+	offsets[1] += lengths[1] / 2 // starts mid-entry, fails to decode
+	lengths[1] -= lengths[1] / 2
+	lengths[2] = MaxEntrySize + 1
+
+	responses := reader.ReadBatch(context.Background(), offsets, lengths)
+	require.Len(t, responses, 4)
+	assert.NoError(t, responses[0].GetError())
+	assert.Error(t, responses[1].GetError())
+	assert.Error(t, responses[2].GetError())
+	assert.NoError(t, responses[3].GetError())
+	assert.Equal(t, index.Entries[3].URL, responses[3].GetEntry().Request.URL)
+
+	// reverse order still returns responses in request order
+	offsets, lengths = batchRequests([]*EntryMetadata{index.Entries[5], index.Entries[2]})
+	responses = reader.ReadBatch(context.Background(), offsets, lengths)
+	require.NoError(t, responses[0].GetError())
+	require.NoError(t, responses[1].GetError())
+	assert.Equal(t, index.Entries[5].URL, responses[0].GetEntry().Request.URL)
+	assert.Equal(t, index.Entries[2].URL, responses[1].GetEntry().Request.URL)
+}
+
+func TestReadBatch_InvalidInput(t *testing.T) {
+	harFile, cleanup, err := generateSmallHAR()
+	require.NoError(t, err)
+	defer cleanup()
+
+	index := buildTestIndex(t, harFile)
+	reader, err := NewEntryReader(harFile, index)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	responses := reader.ReadBatch(context.Background(), []int64{0, 1}, []int64{10})
+	require.Len(t, responses, 2)
+	assert.Error(t, responses[0].GetError())
+	assert.Error(t, responses[1].GetError())
+
+	assert.Empty(t, reader.ReadBatch(context.Background(), nil, nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	offsets, lengths := batchRequests(index.Entries[:2])
+	for _, resp := range reader.ReadBatch(ctx, offsets, lengths) {
+		assert.ErrorIs(t, resp.GetError(), context.Canceled)
+	}
+}
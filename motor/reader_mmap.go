@@ -0,0 +1,160 @@
+package motor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// errMmapUnsupported is returned by mmapFile on platforms without memory mapping
+var errMmapUnsupported = errors.New("mmap is not supported on this platform")
+
+// MmapEntryReader serves entries straight out of a memory-mapped HAR file. a read slices the
+// mapping at the entry's offset instead of seeking and reading a pooled file handle, which
+// removes the per-entry syscalls from heavy random access.
+type MmapEntryReader struct {
+	filePath    string
+	index       *Index
+	offsetIndex map[int64]*EntryMetadata
+	data        []byte
+	mu          sync.RWMutex // reads hold the read lock so Close never unmaps memory in use
+	closed      bool
+	maxEntry    int64           // largest entry Read will load (default MaxEntrySize)
+	external    *ExternalBodies // resolves bodies stored in files of their own, nil when off
+	decoders    DecoderFactory  // decodes entries, nil for encoding/json
+}
+
+// NewMmapEntryReader creates a memory-mapped reader for the entries in index. On platforms
+// without mmap it falls back to the pooled file reader from NewEntryReader.
+func NewMmapEntryReader(filePath string, index *Index) (EntryReader, error) {
+	filePath = entryDataPath(filePath, index)
+	if err := checkEntryRanges(index.Entries); err != nil {
+		return nil, fmt.Errorf("invalid index for %s: %w", filePath, err)
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open har file: %w", err)
+	}
+	defer file.Close() // the mapping stays valid after the file is closed
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat har file: %w", err)
+	}
+
+	var data []byte
+	if info.Size() > 0 {
+		data, err = mmapFile(file, info.Size())
+		if errors.Is(err, errMmapUnsupported) {
+			return NewEntryReader(filePath, index)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to map har file: %w", err)
+		}
+	}
+
+	return &MmapEntryReader{
+		filePath:    filePath,
+		index:       index,
+		offsetIndex: buildOffsetIndex(index),
+		data:        data,
+		maxEntry:    MaxEntrySize,
+	}, nil
+}
+
+// SetMaxEntrySize raises (or lowers) the size cap that guards reads against oversized entries
+func (r *MmapEntryReader) SetMaxEntrySize(size int64) error {
+	if err := validateMaxEntrySize(size); err != nil {
+		return err
+	}
+	r.maxEntry = size
+	return nil
+}
+
+// SetExternalBodies reads response bodies the har stores in files of their own through bodies;
+// nil turns that off. the reader does not close bodies.
+func (r *MmapEntryReader) SetExternalBodies(bodies *ExternalBodies) {
+	r.external = bodies
+}
+
+// SetDecoderFactory decodes entries with decoders from factory; nil goes back to encoding/json
+func (r *MmapEntryReader) SetDecoderFactory(factory DecoderFactory) {
+	r.decoders = factory
+}
+
+func (r *MmapEntryReader) Read(ctx context.Context, req ReadRequest) ReadResponse {
+	resp := newReadResponse()
+
+	select {
+	case <-ctx.Done():
+		resp.err = ctx.Err()
+		return resp
+	default:
+	}
+
+	if req.GetLength() > r.maxEntry {
+		resp.err = fmt.Errorf("entry size %d exceeds maximum allowed size %d", req.GetLength(), r.maxEntry)
+		return resp
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.closed {
+		resp.err = fmt.Errorf("reader is closed")
+		return resp
+	}
+
+	offset, length := req.GetOffset(), req.GetLength()
+	if offset < 0 || length < 0 || offset > int64(len(r.data)) {
+		resp.err = fmt.Errorf("offset %d out of range for file of %d bytes", offset, len(r.data))
+		return resp
+	}
+	// like the pooled reader, a short read at the end of the file decodes what is there
+	end := min(offset+length, int64(len(r.data)))
+
+	// decoding copies every string out of the mapping, so the entry outlives Close.
+	// the request buffer is not needed: the mapping already is one
+	entry, err := decodeEntry(bytes.NewReader(r.data[offset:end]), r.decoders)
+	if err == nil && r.external != nil {
+		err = r.external.load(entry, r.maxEntry)
+	}
+	if err != nil {
+		resp.err = err
+		return resp
+	}
+
+	resp.entry = entry
+	resp.bytesRead = end - offset
+	return resp
+}
+
+// ReadMetadata looks up entry metadata by offset without touching the mapping
+func (r *MmapEntryReader) ReadMetadata(offset int64) (*EntryMetadata, error) {
+	if meta, ok := r.offsetIndex[offset]; ok {
+		return meta, nil
+	}
+	return nil, fmt.Errorf("metadata not found for offset %d", offset)
+}
+
+// Close unmaps the file once in-flight reads finish; later reads return an error
+func (r *MmapEntryReader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	data := r.data
+	r.data = nil
+	if data == nil {
+		return nil
+	}
+	return munmapFile(data)
+}
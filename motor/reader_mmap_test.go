@@ -0,0 +1,138 @@
+package motor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildTestIndex(t testing.TB, harFile string) *Index {
+	t.Helper()
+	file, err := os.Open(harFile)
+	require.NoError(t, err)
+	defer file.Close()
+
+	index, err := NewIndexBuilder(harFile).Build(file)
+	require.NoError(t, err)
+	return index
+}
+
+func TestMmapEntryReader_MatchesPooledReader(t *testing.T) {
+	harFile, cleanup, err := generateSmallHAR()
+	require.NoError(t, err)
+	defer cleanup()
+
+	index := buildTestIndex(t, harFile)
+
+	pooled, err := NewEntryReader(harFile, index)
+	require.NoError(t, err)
+	defer pooled.Close()
+
+	mapped, err := NewMmapEntryReader(harFile, index)
+	require.NoError(t, err)
+	defer mapped.Close()
+
+	buf := make([]byte, 64*1024)
+	for _, meta := range index.Entries {
+		req := NewReadRequestBuilder().WithOffset(meta.FileOffset).WithLength(meta.Length).Build()
+		want := pooled.Read(context.Background(), req)
+		require.NoError(t, want.GetError())
+
+		got := mapped.Read(context.Background(), req)
+		require.NoError(t, got.GetError())
+		assert.Equal(t, want.GetEntry(), got.GetEntry())
+		assert.Equal(t, meta.Length, got.GetBytesRead())
+
+		// with a search buffer the mapping is read directly
+		bufReq := NewReadRequestBuilder().WithOffset(meta.FileOffset).WithLength(meta.Length).WithBuffer(&buf).Build()
+		got = mapped.Read(context.Background(), bufReq)
+		require.NoError(t, got.GetError())
+		assert.Equal(t, want.GetEntry(), got.GetEntry())
+	}
+
+	meta, err := mapped.ReadMetadata(index.Entries[0].FileOffset)
+	require.NoError(t, err)
+	assert.Same(t, index.Entries[0], meta)
+
+	_, err = mapped.ReadMetadata(-1)
+	assert.Error(t, err)
+}
+
+func TestMmapEntryReader_Errors(t *testing.T) {
+	harFile, cleanup, err := generateSmallHAR()
+	require.NoError(t, err)
+	defer cleanup()
+
+	index := buildTestIndex(t, harFile)
+	reader, err := NewMmapEntryReader(harFile, index)
+	require.NoError(t, err)
+
+	info, err := os.Stat(harFile)
+	require.NoError(t, err)
+
+	tooLarge := NewReadRequestBuilder().WithOffset(0).WithLength(MaxEntrySize + 1).Build()
+	assert.Error(t, reader.Read(context.Background(), tooLarge).GetError())
+
+	pastEnd := NewReadRequestBuilder().WithOffset(info.Size() + 10).WithLength(10).Build()
+	assert.Error(t, reader.Read(context.Background(), pastEnd).GetError())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	meta := index.Entries[0]
+	req := NewReadRequestBuilder().WithOffset(meta.FileOffset).WithLength(meta.Length).Build()
+	assert.ErrorIs(t, reader.Read(ctx, req).GetError(), context.Canceled)
+
+	require.NoError(t, reader.Close())
+	assert.Error(t, reader.Read(context.Background(), req).GetError(), "reads after close must not touch unmapped memory")
+	assert.NoError(t, reader.Close(), "close is idempotent")
+}
+
+func TestMmapEntryReader_EmptyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.har")
+	require.NoError(t, os.WriteFile(path, nil, 0644))
+
+	reader, err := NewMmapEntryReader(path, &Index{FilePath: path})
+	require.NoError(t, err)
+	defer reader.Close()
+
+	req := NewReadRequestBuilder().WithOffset(0).WithLength(10).Build()
+	assert.Error(t, reader.Read(context.Background(), req).GetError())
+}
+
+func TestMmapEntryReader_ConcurrentReads(t *testing.T) {
+	harFile, cleanup, err := generateSmallHAR()
+	require.NoError(t, err)
+	defer cleanup()
+
+	index := buildTestIndex(t, harFile)
+	reader, err := NewMmapEntryReader(harFile, index)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < len(index.Entries); i += 8 {
+				meta := index.Entries[i]
+				req := NewReadRequestBuilder().WithOffset(meta.FileOffset).WithLength(meta.Length).Build()
+				if err := reader.Read(context.Background(), req).GetError(); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
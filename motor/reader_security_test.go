@@ -129,4 +129,72 @@ func TestRead_ValidSizeBelowLimit(t *testing.T) {
 	assert.Nil(t, resp.GetError(), "expected successful read for valid size")
 	assert.NotNil(t, resp.GetEntry(), "expected entry to be read")
 	assert.Greater(t, resp.GetBytesRead(), int64(0), "expected bytes to be read")
-}
\ No newline at end of file
+}
+// TestRead_ConfiguredEntrySizeLimit tests that SetMaxEntrySize replaces the default limit
+func TestRead_ConfiguredEntrySizeLimit(t *testing.T) {
+	harFile, cleanup, err := generateSmallHAR()
+	require.NoError(t, err)
+	defer cleanup()
+
+	index := buildTestIndex(t, harFile)
+	metadata := index.Entries[0]
+	req := NewReadRequestBuilder().WithOffset(metadata.FileOffset).WithLength(metadata.Length).Build()
+
+	pooled, err := NewEntryReader(harFile, index)
+	require.NoError(t, err)
+	defer pooled.Close()
+
+	mapped, err := NewMmapEntryReader(harFile, index)
+	require.NoError(t, err)
+	defer mapped.Close()
+
+	readers := map[string]interface {
+		EntryReader
+		SetMaxEntrySize(size int64) error
+	}{
+		"pooled": pooled,
+		"mmap":   mapped.(*MmapEntryReader),
+	}
+
+	for name, reader := range readers {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, reader.SetMaxEntrySize(0))
+			assert.Error(t, reader.SetMaxEntrySize(-1))
+
+			require.NoError(t, reader.SetMaxEntrySize(metadata.Length-1))
+			resp := reader.Read(context.Background(), req)
+			require.Error(t, resp.GetError())
+			assert.Contains(t, resp.GetError().Error(), "exceeds maximum allowed size")
+
+			batch := reader.ReadBatch(context.Background(), []int64{metadata.FileOffset}, []int64{metadata.Length})
+			assert.Error(t, batch[0].GetError())
+
+			require.NoError(t, reader.SetMaxEntrySize(metadata.Length))
+			resp = reader.Read(context.Background(), req)
+			require.NoError(t, resp.GetError())
+			assert.NotNil(t, resp.GetEntry())
+		})
+	}
+}
+
+// TestStreamerOptions_MaxEntrySize tests that the streamer's reader honours the configured limit
+func TestStreamerOptions_MaxEntrySize(t *testing.T) {
+	harFile, cleanup, err := generateSmallHAR()
+	require.NoError(t, err)
+	defer cleanup()
+
+	opts := DefaultStreamerOptions()
+	opts.MaxEntrySize = -1
+	_, err = NewHARStreamer(harFile, opts)
+	assert.Error(t, err)
+
+	opts.MaxEntrySize = 10
+	streamer, err := NewHARStreamer(harFile, opts)
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	_, err = streamer.GetEntry(context.Background(), 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds maximum allowed size 10")
+}
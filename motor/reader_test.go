@@ -1,12 +1,17 @@
 package motor
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"os"
+	"runtime"
+	"strings"
 	"sync"
 	"testing"
 
 	"github.com/pb33f/harific/hargen"
+	"github.com/pb33f/harific/motor/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -270,6 +275,101 @@ func TestEntryReader_ReadPartial(t *testing.T) {
 	t.Logf("read %d partial fields", len(partial))
 }
 
+func TestEntryReader_ReadPartialMatchesRead(t *testing.T) {
+	reader, index := openBodyStreamReader(t, bodyStreamFixture)
+	fields := []string{"request", "response", "timings", "startedDateTime", "time", "cache"}
+
+	for _, meta := range index.Entries {
+		entry, err := reader.ReadAt(meta.FileOffset, meta.Length)
+		require.NoError(t, err)
+		partial, err := reader.ReadPartial(meta.FileOffset, fields)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]interface{}{
+			"request":         entry.Request,
+			"response":        entry.Response,
+			"timings":         entry.Timings,
+			"startedDateTime": entry.Start,
+			"time":            entry.Time,
+		}, partial, "unknown fields are left out")
+	}
+}
+
+// readFields scans for the requested keys itself rather than decoding the entry; encoding/json
+// decoding the same entry bytes must fill the same fields, whatever the keys look like
+func TestEntryReader_ReadPartialMatchesEncodingJSON(t *testing.T) {
+	entries := []string{
+		// case variants and escaped keys match, as encoding/json matches them
+		`{"Request": {"method": "GET", "url": "https://example.com/a", "headers": [], "bodySize": 0}, "TIME": 1.5,
+  "startedDate\u0054ime": "2024-01-01T00:00:00Z", "\u0074imings": {"send": 1, "wait": 2, "receive": 3, "dns": -1}}`,
+		// a repeated key decodes again into the same value, members of the first kept
+		`{"request": {"method": "GET", "url": "https://example.com/b", "headers": [{"name": "a", "value": "1"}]},
+  "request": {"url": "https://example.com/b2"}, "time": 1, "time": 2, "Time": 3}`,
+		// braces, brackets and quotes inside strings of skipped and captured values
+		`{"comment": "} ] { [ \" \\", "_extra": {"nested": [{"}": "{"}], "n": null},
+  "request": {"method": "POST", "url": "https://example.com/c?q=}\"", "headers": [{"name": "x", "value": "[\"{\"]"}],
+   "postData": {"mimeType": "text/plain", "text": "caf\u00e9 \ud83d\ude00 \ud800 \n"}}, "time": 0}`,
+		// null leaves the field as it was, other whitespace and number forms decode the same
+		`{ "timings" : null , "time" : 1.25e2 , "response" :
+  {"status": 204, "statusText": "No Content", "headers": [], "content": {"size": 0, "mimeType": ""}, "bodySize": -1} }`,
+		// keys that differ by more than case are not the requested ones
+		`{"requests": {"url": "https://example.com/wrong"}, "request_": {"url": "https://example.com/wrong"},
+  "request": {"method": "GET", "url": "https://example.com/d", "headers": []}, "startedDateTimes": "no"}`,
+	}
+	reader, index := openBodyStreamReader(t, `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1.0"}, "entries": [`+
+		strings.Join(entries, ",\n")+`]}}`)
+	require.Len(t, index.Entries, len(entries))
+
+	raw, err := os.ReadFile(reader.filePath)
+	require.NoError(t, err)
+	fields := []string{"request", "response", "timings", "startedDateTime", "time"}
+	for i, meta := range index.Entries {
+		var want model.Entry
+		entryBytes := bytes.TrimLeft(raw[meta.FileOffset:meta.FileOffset+meta.Length], ", \n")
+		require.NoError(t, json.Unmarshal(entryBytes, &want), "entry %d", i)
+
+		partial, err := reader.ReadPartial(meta.FileOffset, fields)
+		require.NoError(t, err, "entry %d", i)
+		assert.Equal(t, map[string]interface{}{
+			"request":         want.Request,
+			"response":        want.Response,
+			"timings":         want.Timings,
+			"startedDateTime": want.Start,
+			"time":            want.Time,
+		}, partial, "entry %d", i)
+	}
+}
+
+func TestEntryReader_ReadPartialSkipsLargeBody(t *testing.T) {
+	body := strings.Repeat("x", 32*1024*1024)
+	reader, index := openBodyStreamReader(t, `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1.0"}, "entries": [
+  {"STARTEDDATETIME": "2024-01-01T00:00:00Z", "time": 12.5,
+   "response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 1, "mimeType": "text/plain", "text": "`+body+`"}, "bodySize": 1},
+   "request": {"method": "POST", "url": "https://example.com/upload", "headers": [{"name": "a", "value": "b, [c]"}], "bodySize": 0}}
+]}}`)
+	meta := index.Entries[0]
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	partial, err := reader.ReadPartial(meta.FileOffset, []string{"request", "startedDateTime", "time"})
+	runtime.ReadMemStats(&after)
+	require.NoError(t, err)
+
+	request := partial["request"].(model.Request)
+	assert.Equal(t, "https://example.com/upload", request.URL, "a field after the body is found")
+	assert.Equal(t, "b, [c]", request.Headers[0].Value)
+	assert.Equal(t, "2024-01-01T00:00:00Z", partial["startedDateTime"], "keys match case-insensitively")
+	assert.Equal(t, 12.5, partial["time"])
+	assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(len(body)/8), "the body is skipped, not read into memory")
+
+	require.NoError(t, reader.SetMaxEntrySize(1024))
+	_, err = reader.ReadPartial(meta.FileOffset, []string{"request"})
+	assert.NoError(t, err, "the size cap only applies when the response is decoded")
+	_, err = reader.ReadPartial(meta.FileOffset, []string{"response"})
+	assert.ErrorContains(t, err, "exceeds maximum")
+}
+
 func TestEntryReader_StreamResponseBody(t *testing.T) {
 	harFile, cleanup, err := generateSmallHAR()
 	if err != nil {
@@ -651,3 +751,26 @@ func TestClose_ClosesAllPooledFiles(t *testing.T) {
 	reader.mu.Unlock()
 }
 
+
+func TestNewEntryReader_RejectsOverlappingEntries(t *testing.T) {
+	harFile, cleanup, err := generateSmallHAR()
+	require.NoError(t, err)
+	defer cleanup()
+
+	file, err := os.Open(harFile)
+	require.NoError(t, err)
+	index, err := NewIndexBuilder(harFile).Build(file)
+	file.Close()
+	require.NoError(t, err)
+	require.Greater(t, len(index.Entries), 2)
+
+	// the second entry claims the first one's offset, as a parser that lost its place might
+	index.Entries[1].FileOffset = index.Entries[0].FileOffset
+
+	_, err = NewEntryReader(harFile, index)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "entries 0 and 1 share offset")
+
+	_, err = NewMmapEntryReader(harFile, index)
+	assert.Error(t, err)
+}
@@ -0,0 +1,97 @@
+package motor
+
+import "fmt"
+
+// CompiledQuery is a search pattern compiled once, by HARSearcher.Compile, to run any number of
+// times with SearchCompiled. a live search re-running the same regex skips recompiling it.
+type CompiledQuery struct {
+	text    string
+	opts    SearchOptions
+	query   *compiledQuery // boolean queries; nil for a single pattern
+	pattern compiledPattern
+}
+
+// Compile compiles pattern for SearchCompiled, returning the error Search would return for it
+func (s *HARSearcher) Compile(pattern string, opts SearchOptions) (*CompiledQuery, error) {
+	return compileSearch(pattern, opts)
+}
+
+// ValidatePattern returns the error Search would return for pattern with these options, without
+// searching anything
+func ValidatePattern(pattern string, opts SearchOptions) error {
+	_, err := compileSearch(pattern, opts)
+	return err
+}
+
+// compileSearch compiles the pattern once (not per entry!); boolean queries compile each of their terms
+func compileSearch(pattern string, opts SearchOptions) (*CompiledQuery, error) {
+	query, err := compileQuery(pattern, opts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	compiled := &CompiledQuery{text: pattern, opts: opts, query: query}
+	if query == nil {
+		compiled.pattern, err = compilePattern(pattern, opts)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+	}
+	return compiled, nil
+}
+
+// Pattern returns the pattern the query was compiled from
+func (q *CompiledQuery) Pattern() string {
+	return q.text
+}
+
+// Options returns the options the query searches with
+func (q *CompiledQuery) Options() SearchOptions {
+	return q.opts
+}
+
+// CompiledFor returns true if compiling pattern with opts would give this query, so it can be
+// reused whatever else (bodies, indices, limits) opts changes
+func (q *CompiledQuery) CompiledFor(pattern string, opts SearchOptions) bool {
+	return pattern == q.text && patternOptionsOf(opts) == patternOptionsOf(q.opts)
+}
+
+// WithOptions returns a copy of the query searching with opts. the options that shape the
+// compiled pattern (mode, case, whole word, snippet context, edit distance, extraction) stay as
+// compiled; check CompiledFor first when they may have changed.
+func (q *CompiledQuery) WithOptions(opts SearchOptions) *CompiledQuery {
+	copied := *q
+	copied.opts = opts
+	compiledWith := q.opts
+	copied.opts.Mode = compiledWith.Mode
+	copied.opts.CaseInsensitive = compiledWith.CaseInsensitive
+	copied.opts.WholeWord = compiledWith.WholeWord
+	copied.opts.SnippetContext = compiledWith.SnippetContext
+	copied.opts.MaxEditDistance = compiledWith.MaxEditDistance
+	copied.opts.Extract = compiledWith.Extract
+	copied.opts.CaptureGroup = compiledWith.CaptureGroup
+	return &copied
+}
+
+// patternOptions are the SearchOptions compilePattern reads
+type patternOptions struct {
+	mode            SearchMode
+	caseInsensitive bool
+	wholeWord       bool
+	snippetContext  int
+	maxEditDistance int
+	extract         bool
+	captureGroup    int
+}
+
+func patternOptionsOf(opts SearchOptions) patternOptions {
+	return patternOptions{
+		mode:            opts.Mode,
+		caseInsensitive: opts.CaseInsensitive,
+		wholeWord:       opts.WholeWord,
+		snippetContext:  opts.SnippetContext,
+		maxEditDistance: opts.MaxEditDistance,
+		extract:         opts.Extract,
+		captureGroup:    opts.CaptureGroup,
+	}
+}
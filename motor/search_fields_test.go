@@ -0,0 +1,412 @@
+package motor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/pb33f/harific/motor/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fieldsFixture places the term "needle" in every searchable location of a single entry
+const fieldsFixture = `{
+  "log": {
+    "version": "1.2",
+    "creator": {"name": "test", "version": "1.0"},
+    "entries": [
+      {
+        "startedDateTime": "2024-01-01T00:00:00Z",
+        "time": 10,
+        "request": {
+          "method": "POST",
+          "url": "https://example.com/needle?q=needle",
+          "headers": [{"name": "X-Trace", "value": "needle"}],
+          "queryString": [{"name": "q", "value": "needle"}],
+          "cookies": [{"name": "session", "value": "needle"}],
+          "postData": {"mimeType": "text/plain", "text": "needle"},
+          "bodySize": 6,
+          "comment": "needle"
+        },
+        "response": {
+          "status": 200,
+          "statusText": "needle",
+          "headers": [{"name": "X-Echo", "value": "needle"}],
+          "content": {"size": 6, "mimeType": "text/plain", "text": "needle"},
+          "bodySize": 6,
+          "comment": "needle"
+        },
+        "timings": {"send": 1, "wait": 8, "receive": 1},
+        "_webSocketMessages": [
+          {"type": "send", "time": 1704067201.123, "opcode": 1, "data": "needle"}
+        ],
+        "comment": "the needle request"
+      }
+    ]
+  }
+}`
+
+func searchFieldsFixture(t *testing.T, opts SearchOptions) []string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "fields.har")
+	require.NoError(t, os.WriteFile(path, []byte(fieldsFixture), 0644))
+
+	streamerOpts := DefaultStreamerOptions()
+	streamerOpts.IndexWebSockets = true
+	streamer, err := NewHARStreamer(path, streamerOpts)
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	reader, err := NewEntryReader(path, streamer.GetIndex())
+	require.NoError(t, err)
+	defer reader.Close()
+
+	resultChan, err := NewSearcher(streamer, reader).Search(context.Background(), "needle", opts)
+	require.NoError(t, err)
+
+	var fields []string
+	for _, result := range collectResults(resultChan) {
+		require.NoError(t, result.Error)
+		fields = append(fields, result.Field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func allFieldsOptions() SearchOptions {
+	opts := DefaultSearchOptions
+	opts.FirstMatchOnly = false
+	opts.SearchResponseBody = true
+	opts.SearchWebSockets = true
+	return opts
+}
+
+func TestSearchFields_EmptyMeansAll(t *testing.T) {
+	fields := searchFieldsFixture(t, allFieldsOptions())
+
+	assert.Equal(t, []string{
+		"comment.entry",
+		"comment.request",
+		"comment.response",
+		"cookie.session",
+		"query.param.q",
+		"request.body",
+		"request.headers.X-Trace",
+		"response.body",
+		"response.headers.X-Echo",
+		"url",
+		"websocket.message",
+	}, fields)
+
+	opts := allFieldsOptions()
+	opts.Fields = SearchFieldAll
+	assert.Equal(t, fields, searchFieldsFixture(t, opts))
+}
+
+func TestSearchFields_SingleField(t *testing.T) {
+	tests := []struct {
+		name     string
+		field    SearchField
+		expected string
+	}{
+		{"url", SearchFieldURL, "url"},
+		{"metadata", SearchFieldMetadata, "status"},
+		{"request headers", SearchFieldRequestHeaders, "request.headers.X-Trace"},
+		{"query params", SearchFieldQueryParams, "query.param.q"},
+		{"cookies", SearchFieldCookies, "cookie.session"},
+		{"request body", SearchFieldRequestBody, "request.body"},
+		{"response headers", SearchFieldResponseHeaders, "response.headers.X-Echo"},
+		{"websockets", SearchFieldWebSockets, "websocket.message"},
+		{"response body", SearchFieldResponseBody, "response.body"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := allFieldsOptions()
+			opts.Fields = tt.field
+			assert.Equal(t, []string{tt.expected}, searchFieldsFixture(t, opts))
+		})
+	}
+}
+
+func TestSearchFields_Combination(t *testing.T) {
+	opts := allFieldsOptions()
+	opts.Fields = SearchFieldCookies | SearchFieldResponseHeaders
+
+	assert.Equal(t, []string{"cookie.session", "response.headers.X-Echo"}, searchFieldsFixture(t, opts))
+}
+
+func TestSearchFields_Comments(t *testing.T) {
+	opts := allFieldsOptions()
+	opts.Fields = SearchFieldComments
+
+	fields := searchFieldsFixture(t, opts)
+	assert.Equal(t, []string{"comment.entry", "comment.request", "comment.response"}, fields)
+	assert.Equal(t, "comment", FieldCategory(fields[0]))
+
+	field, err := ParseSearchField("comment")
+	require.NoError(t, err)
+	assert.Equal(t, SearchFieldComments, field)
+}
+
+func TestSearchFields_ResponseBodyStillRequiresDeepSearch(t *testing.T) {
+	opts := allFieldsOptions()
+	opts.SearchResponseBody = false
+	opts.Fields = SearchFieldResponseBody
+
+	assert.Empty(t, searchFieldsFixture(t, opts))
+}
+
+func TestSearchFields_MetadataOnlySkipsDiskRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fields.har")
+	require.NoError(t, os.WriteFile(path, []byte(fieldsFixture), 0644))
+
+	streamer, err := NewHARStreamer(path, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	reader, err := NewEntryReader(path, streamer.GetIndex())
+	require.NoError(t, err)
+	defer reader.Close()
+
+	searcher := NewSearcher(streamer, reader)
+
+	opts := DefaultSearchOptions
+	opts.Fields = SearchFieldURL
+	pattern, err := compilePattern("nomatch", opts)
+	require.NoError(t, err)
+
+	buf := make([]byte, 64*1024)
+	results := searchEntry(context.Background(), searcher, 0, pattern, opts, &buf)
+
+	assert.Empty(t, results)
+	assert.Equal(t, int64(0), searcher.Stats().BytesSearched, "url-only search should not load the entry")
+}
+
+func TestSearchFields_RedirectURL(t *testing.T) {
+	entry := `{"startedDateTime": "2024-01-01T00:00:00Z", "time": 10,
+   "request": {"method": "GET", "url": "https://example.com/%s", "headers": [], "bodySize": 0},
+   "response": {"status": %d, "statusText": "", "headers": [], "content": {"size": 0, "mimeType": ""}, "redirectURL": %q, "bodySize": 0}}`
+	har := `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1.0"}, "entries": [` +
+		fmt.Sprintf(entry, "account", 302, "https://example.com/login?next=needle") + "," +
+		fmt.Sprintf(entry, "login", 200, "") + "," +
+		fmt.Sprintf(entry, "old", 301, "https://example.com/new") + `]}}`
+
+	path := filepath.Join(t.TempDir(), "redirects.har")
+	require.NoError(t, os.WriteFile(path, []byte(har), 0644))
+
+	streamer, err := NewHARStreamer(path, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+	assert.Equal(t, "https://example.com/login?next=needle", streamer.GetIndex().Entries[0].RedirectURL)
+
+	reader, err := NewEntryReader(path, streamer.GetIndex())
+	require.NoError(t, err)
+	defer reader.Close()
+	searcher := NewSearcher(streamer, reader)
+
+	opts := DefaultSearchOptions
+	opts.Fields = SearchFieldRedirectURL
+	resultChan, err := searcher.Search(context.Background(), "/login", opts)
+	require.NoError(t, err)
+	results := collectResults(resultChan)
+	require.Len(t, results, 1, "the login page itself is not a redirect to it")
+	assert.Equal(t, 0, results[0].Index)
+	assert.Equal(t, "redirectURL", results[0].Field)
+	assert.Equal(t, int64(0), searcher.Stats().BytesSearched, "redirectURL is matched from the index")
+
+	// an unrestricted search finds the term in the redirect too
+	resultChan, err = searcher.Search(context.Background(), "needle", DefaultSearchOptions)
+	require.NoError(t, err)
+	results = collectResults(resultChan)
+	require.Len(t, results, 1)
+	assert.Equal(t, "redirectURL", results[0].Field)
+
+	field, err := ParseSearchField("redirectURL")
+	require.NoError(t, err)
+	assert.Equal(t, SearchFieldRedirectURL, field)
+}
+
+func TestSearchFields_PostDataParams(t *testing.T) {
+	entry := &model.Entry{}
+	entry.Request.Body = model.BodyType{
+		MIMEType: "application/x-www-form-urlencoded",
+		Params: []model.PostNameValuePair{
+			{Name: "username", Value: "admin"},
+			{Name: "password", Value: "hunter2"},
+			{Name: "avatar", FileName: "hunter2.png", ContentType: "image/png"},
+		},
+	}
+
+	opts := allFieldsOptions()
+	pattern, err := compilePattern("hunter2", opts)
+	require.NoError(t, err)
+
+	results := searchEntryFields(0, entry, pattern, opts, nil)
+	require.Len(t, results, 1, "one result per location, like headers")
+	assert.Equal(t, "request.postData.password", results[0].Field)
+	assert.Equal(t, "request.postData", FieldCategory(results[0].Field))
+
+	pattern, err = compilePattern("username", opts)
+	require.NoError(t, err)
+	results = searchEntryFields(0, entry, pattern, opts, nil)
+	require.Len(t, results, 1)
+	assert.Equal(t, "request.postData.username", results[0].Field)
+
+	opts.Fields = SearchFieldRequestHeaders
+	assert.Empty(t, searchEntryFields(0, entry, pattern, opts, nil), "params are part of the request body field")
+}
+
+func TestSearchFields_ResponseCookies(t *testing.T) {
+	entry := &model.Entry{}
+	entry.Request.Cookies = []model.Cookie{{Name: "theme", Value: "dark"}}
+	entry.Response.Cookies = []model.Cookie{{Name: "session", Value: "s3cr3t", HTTPOnly: true}}
+
+	opts := allFieldsOptions()
+	pattern, err := compilePattern("s3cr3t", opts)
+	require.NoError(t, err)
+
+	results := searchEntryFields(0, entry, pattern, opts, nil)
+	require.Len(t, results, 1)
+	assert.Equal(t, "response.cookie.session", results[0].Field)
+	assert.Equal(t, "response.cookie", FieldCategory(results[0].Field))
+
+	opts.Fields = SearchFieldCookies
+	assert.Empty(t, searchEntryFields(0, entry, pattern, opts, nil), "request and response cookies are separate fields")
+
+	pattern, err = compilePattern("dark", opts)
+	require.NoError(t, err)
+	results = searchEntryFields(0, entry, pattern, opts, nil)
+	require.Len(t, results, 1)
+	assert.Equal(t, "cookie.theme", results[0].Field)
+
+	field, err := ParseSearchField("response.cookie")
+	require.NoError(t, err)
+	assert.Equal(t, SearchFieldResponseCookies, field)
+}
+
+func TestSearchFields_WholeWord(t *testing.T) {
+	opts := allFieldsOptions()
+	opts.WholeWord = true
+	assert.Len(t, searchFieldsFixture(t, opts), 11, "needle is a whole word in every location")
+
+	path := filepath.Join(t.TempDir(), "fields.har")
+	require.NoError(t, os.WriteFile(path, []byte(fieldsFixture), 0644))
+
+	streamerOpts := DefaultStreamerOptions()
+	streamerOpts.IndexWebSockets = true
+	streamer, err := NewHARStreamer(path, streamerOpts)
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	reader, err := NewEntryReader(path, streamer.GetIndex())
+	require.NoError(t, err)
+	defer reader.Close()
+
+	resultChan, err := NewSearcher(streamer, reader).Search(context.Background(), "needl", opts)
+	require.NoError(t, err)
+	assert.Empty(t, collectResults(resultChan), "a partial word matches nowhere")
+}
+
+func TestParseSearchField(t *testing.T) {
+	field, err := ParseSearchField("url")
+	require.NoError(t, err)
+	assert.Equal(t, SearchFieldURL, field)
+
+	field, err = ParseSearchField(" Response.Body ")
+	require.NoError(t, err)
+	assert.Equal(t, SearchFieldResponseBody, field)
+
+	for name, want := range searchFieldNames {
+		field, err := ParseSearchField(name)
+		require.NoError(t, err)
+		assert.Equal(t, want, field)
+	}
+
+	_, err = ParseSearchField("body")
+	assert.Error(t, err)
+}
+
+const headerPredicateFixture = `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1.0"}, "entries": [
+  {"startedDateTime": "2024-01-01T00:00:00Z", "time": 10,
+   "request": {"method": "GET", "url": "https://example.com/login", "headers": [{"name": "Cache-Control", "value": "no-cache"}], "bodySize": 0},
+   "response": {"status": 200, "statusText": "OK", "headers": [{"name": "Set-Cookie", "value": "session=abc"}], "content": {"size": 0, "mimeType": ""}, "bodySize": 0},
+   "timings": {"send": 1, "wait": 8, "receive": 1}},
+  {"startedDateTime": "2024-01-01T00:00:01Z", "time": 10,
+   "request": {"method": "GET", "url": "https://example.com/app.js", "headers": [], "bodySize": 0},
+   "response": {"status": 200, "statusText": "OK", "headers": [{"name": "set-cookie", "value": "tracking=1"}], "content": {"size": 0, "mimeType": ""}, "bodySize": 0},
+   "timings": {"send": 1, "wait": 8, "receive": 1}},
+  {"startedDateTime": "2024-01-01T00:00:02Z", "time": 10,
+   "request": {"method": "GET", "url": "https://example.com/logo.png", "headers": [], "bodySize": 0},
+   "response": {"status": 200, "statusText": "OK", "headers": [{"name": "Cache-Control", "value": "max-age=60"}], "content": {"size": 0, "mimeType": ""}, "bodySize": 0},
+   "timings": {"send": 1, "wait": 8, "receive": 1}}
+]}}`
+
+func TestSearch_HeaderPredicates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "headers.har")
+	require.NoError(t, os.WriteFile(path, []byte(headerPredicateFixture), 0644))
+
+	streamer, err := NewHARStreamer(path, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	reader, err := NewEntryReader(path, streamer.GetIndex())
+	require.NoError(t, err)
+	defer reader.Close()
+
+	search := func(pattern string, opts SearchOptions) []SearchResult {
+		t.Helper()
+		resultChan, err := NewSearcher(streamer, reader).Search(context.Background(), pattern, opts)
+		require.NoError(t, err)
+		results := collectResults(resultChan)
+		sort.Slice(results, func(i, j int) bool { return results[i].Index < results[j].Index })
+		for _, result := range results {
+			require.NoError(t, result.Error)
+		}
+		return results
+	}
+
+	opts := DefaultSearchOptions
+	opts.HeaderExists = "SET-COOKIE"
+	results := search("", opts)
+	require.Len(t, results, 2, "header names compare case-insensitively")
+	assert.Equal(t, "header.exists.set-cookie", results[0].Field)
+	assert.Equal(t, "header.exists", FieldCategory(results[0].Field))
+	assert.Equal(t, "response Set-Cookie: session=abc", results[0].Snippet)
+	assert.Equal(t, 1, results[1].Index)
+
+	// absence looks at request and response headers alike
+	opts = DefaultSearchOptions
+	opts.HeaderMissing = "cache-control"
+	results = search("", opts)
+	require.Len(t, results, 1)
+	assert.Equal(t, 1, results[0].Index)
+	assert.Equal(t, "header.missing.cache-control", results[0].Field)
+
+	opts.HeaderExists = "set-cookie"
+	results = search("", opts)
+	require.Len(t, results, 1)
+	assert.Equal(t, 1, results[0].Index)
+
+	// with a pattern the predicates only narrow which entries are searched
+	opts = DefaultSearchOptions
+	opts.HeaderExists = "set-cookie"
+	results = search("log", opts)
+	require.Len(t, results, 1)
+	assert.Equal(t, 0, results[0].Index)
+	assert.Equal(t, "url", results[0].Field)
+
+	results = search("login OR logo", opts)
+	require.Len(t, results, 1)
+	assert.Equal(t, 0, results[0].Index)
+}
@@ -0,0 +1,104 @@
+package motor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		limit    int
+		expected int
+	}{
+		{"authorization", "authorization", 2, 0},
+		{"authorization", "authorzation", 2, 1},
+		{"kitten", "sitting", 3, 3},
+		{"kitten", "sitting", 1, 2}, // gives up past the limit
+		{"", "abc", 5, 3},
+		{"café", "cafe", 2, 1}, // runes, not bytes
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, levenshtein(tt.a, tt.b, tt.limit), "%q vs %q", tt.a, tt.b)
+	}
+}
+
+func TestMatches_Fuzzy(t *testing.T) {
+	pattern, err := compilePattern("authorzation", SearchOptions{Mode: Fuzzy})
+	require.NoError(t, err)
+
+	assert.True(t, matches("Authorization", pattern))
+	assert.True(t, matches("Bearer token, authorisation pending", pattern))
+	assert.False(t, matches("authority", pattern))
+
+	result := matchResult(3, "request.headers.Authorization", "x-authorization-id", pattern)
+	assert.Equal(t, 1, result.Distance)
+	assert.Equal(t, 2, result.Offset)
+	assert.Contains(t, result.Snippet, "authorization")
+
+	// exact substrings still match, at distance 0
+	result = matchResult(3, "url", "https://example.com/authorzation/x", pattern)
+	assert.Equal(t, 0, result.Distance)
+}
+
+func TestMatches_FuzzyLimits(t *testing.T) {
+	// short patterns are capped at a third of their length, so "id" needs an exact match
+	pattern, err := compilePattern("id", SearchOptions{Mode: Fuzzy, MaxEditDistance: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 0, pattern.maxDistance)
+	assert.False(t, matches("is", pattern))
+
+	pattern, err = compilePattern("content-typo", SearchOptions{Mode: Fuzzy, MaxEditDistance: 1})
+	require.NoError(t, err)
+	assert.True(t, matches("Content-Type", pattern), "multi word patterns compare runs of words")
+
+	_, err = compilePattern("x", SearchOptions{Mode: Fuzzy, MaxEditDistance: -1})
+	assert.Error(t, err)
+}
+
+func TestSearch_FuzzyDefaultsToMetadataAndHeaders(t *testing.T) {
+	const fixture = `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1.0"}, "entries": [
+	  {"startedDateTime": "2024-01-01T00:00:00Z", "time": 10,
+	   "request": {"method": "GET", "url": "https://example.com/a", "headers": [{"name": "Authorization", "value": "Bearer x"}], "bodySize": 0},
+	   "response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 12, "mimeType": "text/plain", "text": "authorization"}, "bodySize": 12},
+	   "timings": {"send": 1, "wait": 8, "receive": 1}},
+	  {"startedDateTime": "2024-01-01T00:00:01Z", "time": 10,
+	   "request": {"method": "GET", "url": "https://example.com/b", "headers": [], "bodySize": 0},
+	   "response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 13, "mimeType": "text/plain", "text": "authorization"}, "bodySize": 13},
+	   "timings": {"send": 1, "wait": 8, "receive": 1}}
+	]}}`
+
+	path := filepath.Join(t.TempDir(), "fuzzy.har")
+	require.NoError(t, os.WriteFile(path, []byte(fixture), 0644))
+
+	streamer, err := NewHARStreamer(path, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	reader, err := NewEntryReader(path, streamer.GetIndex())
+	require.NoError(t, err)
+	defer reader.Close()
+
+	opts := DefaultSearchOptions
+	opts.Mode = Fuzzy
+	resultChan, err := NewSearcher(streamer, reader).Search(context.Background(), "authorzation", opts)
+	require.NoError(t, err)
+
+	results := collectResults(resultChan)
+	require.Len(t, results, 1, "the body match in entry 1 is skipped by default")
+	assert.Equal(t, 0, results[0].Index)
+	assert.Equal(t, "request.headers.Authorization", results[0].Field)
+	assert.Equal(t, 1, results[0].Distance)
+
+	// asking for bodies brings them back in
+	opts.SearchResponseBody = true
+	resultChan, err = NewSearcher(streamer, reader).Search(context.Background(), "authorzation", opts)
+	require.NoError(t, err)
+	assert.Len(t, collectResults(resultChan), 3)
+}
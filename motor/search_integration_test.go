@@ -3,6 +3,7 @@ package motor
 import (
 	"context"
 	"os"
+	"slices"
 	"testing"
 
 	"github.com/pb33f/harific/hargen"
@@ -221,3 +222,168 @@ func generateTerms(count int) []string {
 	}
 	return terms
 }
+
+func TestIntegration_MixedBodyContentTypes(t *testing.T) {
+	terms := []string{"quokkaone", "quokkatwo", "quokkathree", "quokkafour", "quokkafive", "quokkasix"}
+	result, err := hargen.Generate(hargen.GenerateOptions{
+		EntryCount:         40,
+		InjectTerms:        terms,
+		InjectionLocations: []hargen.InjectionLocation{hargen.RequestBody, hargen.ResponseBody},
+		BodyContentTypes:   []string{hargen.BodyHTML, hargen.BodyXML, hargen.BodyText, hargen.BodyBinary},
+		Seed:               7,
+	})
+	require.NoError(t, err)
+	defer os.Remove(result.HARFilePath)
+
+	streamer, err := NewHARStreamer(result.HARFilePath, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	reader, err := NewEntryReader(result.HARFilePath, streamer.GetIndex())
+	require.NoError(t, err)
+	defer reader.Close()
+
+	// no entry is json, every body mime type comes from the requested set
+	for _, entry := range streamer.GetIndex().Entries {
+		assert.NotContains(t, entry.MimeType, "json")
+	}
+
+	searcher := NewSearcher(streamer, reader)
+	opts := DefaultSearchOptions
+	opts.SearchResponseBody = true
+	opts.DecodeBodies = true // binary bodies only contain the term once decoded
+
+	for _, injected := range result.InjectedTerms {
+		assert.NotEmpty(t, injected.FieldPath, "term %s should report where it was injected", injected.Term)
+
+		resultChan, err := searcher.Search(context.Background(), injected.Term, opts)
+		require.NoError(t, err)
+
+		found := false
+		for _, res := range collectResults(resultChan) {
+			if res.Index == injected.EntryIndex {
+				found = true
+			}
+		}
+		assert.True(t, found, "term %s should be found in entry %d (%s)", injected.Term, injected.EntryIndex, injected.FieldPath)
+	}
+}
+
+func TestIntegration_InjectionPlan(t *testing.T) {
+	plan := []hargen.InjectionSpec{
+		{Term: "wombatbody", EntryIndex: 3, Location: hargen.ResponseBody},
+		{Term: "wombatagain", EntryIndex: 3, Location: hargen.ResponseBody},
+		{Term: "wombatpath", EntryIndex: 0, Location: hargen.URL},
+		{Term: "wombatsecond", EntryIndex: 0, Location: hargen.URL},
+		{Term: "wombatheader", EntryIndex: 9, Location: hargen.RequestHeader},
+	}
+	result, err := hargen.Generate(hargen.GenerateOptions{
+		EntryCount:    10,
+		InjectionPlan: plan,
+		Seed:          11,
+	})
+	require.NoError(t, err)
+	defer os.Remove(result.HARFilePath)
+
+	require.Len(t, result.InjectedTerms, len(plan))
+	for _, spec := range plan {
+		found := false
+		for _, injected := range result.InjectedTerms {
+			if injected.Term == spec.Term {
+				found = true
+				assert.Equal(t, spec.EntryIndex, injected.EntryIndex)
+				assert.Equal(t, spec.Location, injected.Location)
+			}
+		}
+		assert.True(t, found, "term %s should be reported", spec.Term)
+	}
+
+	streamer, err := NewHARStreamer(result.HARFilePath, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	reader, err := NewEntryReader(result.HARFilePath, streamer.GetIndex())
+	require.NoError(t, err)
+	defer reader.Close()
+
+	searcher := NewSearcher(streamer, reader)
+	opts := DefaultSearchOptions
+	opts.SearchResponseBody = true
+
+	// terms sharing a body or url must all survive
+	for _, spec := range plan {
+		resultChan, err := searcher.Search(context.Background(), spec.Term, opts)
+		require.NoError(t, err)
+		results := collectResults(resultChan)
+		require.Len(t, results, 1, "term %s should be found once", spec.Term)
+		assert.Equal(t, spec.EntryIndex, results[0].Index)
+	}
+}
+
+func TestIntegration_InjectionPlanValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		opts hargen.GenerateOptions
+	}{
+		{"index out of range", hargen.GenerateOptions{EntryCount: 5, InjectionPlan: []hargen.InjectionSpec{{Term: "x", EntryIndex: 5}}}},
+		{"negative index", hargen.GenerateOptions{EntryCount: 5, InjectionPlan: []hargen.InjectionSpec{{Term: "x", EntryIndex: -1}}}},
+		{"empty term", hargen.GenerateOptions{EntryCount: 5, InjectionPlan: []hargen.InjectionSpec{{EntryIndex: 1}}}},
+		{"unknown location", hargen.GenerateOptions{EntryCount: 5, InjectionPlan: []hargen.InjectionSpec{{Term: "x", Location: 42}}}},
+		{"combined with random terms", hargen.GenerateOptions{EntryCount: 5, InjectTerms: []string{"y"}, InjectionPlan: []hargen.InjectionSpec{{Term: "x"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := hargen.GenerateInMemory(tt.opts)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestIntegration_GlobSearch_URLPaths(t *testing.T) {
+	// a url injection makes the url https://api.example.com/<term>/<random word>
+	result, err := hargen.Generate(hargen.GenerateOptions{
+		EntryCount: 50,
+		InjectionPlan: []hargen.InjectionSpec{
+			{Term: "v1", EntryIndex: 3, Location: hargen.URL},
+			{Term: "v2", EntryIndex: 17, Location: hargen.URL},
+			{Term: "v1x", EntryIndex: 30, Location: hargen.URL},
+		},
+		Seed: 42,
+	})
+	require.NoError(t, err)
+	defer os.Remove(result.HARFilePath)
+
+	streamer, err := NewHARStreamer(result.HARFilePath, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	reader, err := NewEntryReader(result.HARFilePath, streamer.GetIndex())
+	require.NoError(t, err)
+	defer reader.Close()
+
+	searcher := NewSearcher(streamer, reader)
+	search := func(glob string) []int {
+		opts := DefaultSearchOptions
+		opts.Mode = Glob
+		opts.Fields = SearchFieldURL
+		resultChan, err := searcher.Search(context.Background(), glob, opts)
+		require.NoError(t, err)
+		var indices []int
+		for _, r := range collectResults(resultChan) {
+			indices = append(indices, r.Index)
+		}
+		slices.Sort(indices)
+		return indices
+	}
+
+	assert.Equal(t, []int{3, 17}, search("api.example.com/v?/*"))
+	assert.Equal(t, []int{3, 17, 30}, search("api.example.com/v*/*"))
+	assert.Equal(t, []int{3, 30}, search("api.example.com/v1*/"))
+	assert.Equal(t, []int{17}, search("api.example.com/v[!1]/"))
+	// the dot is literal: as a regex wildcard it would match the v1/ and v1x/ segments
+	assert.Empty(t, search("/v1.*"))
+}
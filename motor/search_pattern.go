@@ -3,51 +3,422 @@ package motor
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
+// DefaultSnippetContext is the number of bytes kept either side of a match in SearchResult.Snippet
+const DefaultSnippetContext = 40
+
 // SearchMode defines the type of search to perform
 type SearchMode int
 
 const (
 	PlainText SearchMode = iota
 	Regex
+	Fuzzy // approximate, case-insensitive plain text: words within MaxEditDistance edits match
+	Glob  // shell-style wildcards: * and ? stay within a path segment, ** crosses them, [...] is a set
 )
 
+// DefaultMaxEditDistance is the edit distance Fuzzy mode allows when SearchOptions.MaxEditDistance is 0
+const DefaultMaxEditDistance = 2
+
+// fuzzySearchFields are searched in Fuzzy mode when SearchOptions.Fields is unset; comparing
+// every word of every body against the pattern would be slow
+const fuzzySearchFields = indexedSearchFields | SearchFieldRequestHeaders | SearchFieldResponseHeaders
+
 // compiledPattern holds a compiled search pattern
 type compiledPattern struct {
-	mode      SearchMode
-	plainText string
-	regex     *regexp.Regexp
+	mode            SearchMode
+	plainText       string
+	regex           *regexp.Regexp
+	caseInsensitive bool           // plain text only; regex patterns carry the (?i) flag instead
+	foldRegex       *regexp.Regexp // locates case-insensitive plain text matches, where lowercasing may shift offsets
+	snippetContext  int            // bytes of context either side of a match in snippets
+	maxDistance     int            // fuzzy only: most edits a match may be from the pattern
+	fuzzyWords      int            // fuzzy only: number of words in the pattern
+	empty           bool           // the pattern is "", so header predicates alone select entries
+	extract         bool           // collect the captureGroup submatch of every match into Captures
+	captureGroup    int
+	statusRange     bool // plain text only: the pattern is a status range such as 4xx or 500-599
+	statusMin       int
+	statusMax       int
 }
 
 // compilePattern compiles a search pattern based on search mode
 func compilePattern(pattern string, opts SearchOptions) (compiledPattern, error) {
 	cp := compiledPattern{
-		mode: opts.Mode,
+		mode:           opts.Mode,
+		snippetContext: opts.SnippetContext,
+		empty:          pattern == "",
+	}
+	if cp.snippetContext == 0 {
+		cp.snippetContext = DefaultSnippetContext
+	}
+
+	if opts.Mode == Fuzzy {
+		if opts.MaxEditDistance < 0 {
+			return cp, fmt.Errorf("max edit distance must not be negative, got %d", opts.MaxEditDistance)
+		}
+		cp.plainText = strings.ToLower(pattern)
+		cp.foldRegex = regexp.MustCompile("(?i)" + regexp.QuoteMeta(pattern))
+		cp.maxDistance = fuzzyDistanceLimit(pattern, opts.MaxEditDistance)
+		cp.fuzzyWords = max(len(fuzzyWordSpans(pattern)), 1)
+		return cp, nil
+	}
+
+	if opts.Mode == Glob {
+		// globs become regexes; everything else about the search is then the same
+		pattern = globPattern(pattern)
+		opts.Mode = Regex
+		cp.mode = Regex
 	}
 
-	if opts.Mode == Regex {
-		// compile regex pattern
+	if opts.Mode == Regex || opts.WholeWord {
+		// compile regex pattern; whole word plain text is matched as an escaped regex
+		if opts.Mode != Regex {
+			pattern = wholeWordPattern(pattern)
+			cp.mode = Regex
+		} else if opts.WholeWord {
+			pattern = `\b(?:` + pattern + `)\b`
+		}
+		if opts.CaseInsensitive {
+			pattern = "(?i)" + pattern
+		}
 		regex, err := regexp.Compile(pattern)
 		if err != nil {
 			return cp, fmt.Errorf("invalid regex pattern: %w", err)
 		}
 		cp.regex = regex
+
+		if opts.Extract {
+			if opts.Mode != Regex {
+				return cp, fmt.Errorf("extracting captures requires regex mode")
+			}
+			if opts.CaptureGroup < 0 || opts.CaptureGroup > regex.NumSubexp() {
+				return cp, fmt.Errorf("capture group %d out of range, the pattern has %d", opts.CaptureGroup, regex.NumSubexp())
+			}
+			cp.extract = true
+			cp.captureGroup = opts.CaptureGroup
+		}
 	} else {
-		// plain text pattern
+		if opts.Extract {
+			return cp, fmt.Errorf("extracting captures requires regex mode")
+		}
+		// plain text pattern, lowercased once up front for case-insensitive matching
 		cp.plainText = pattern
+		cp.statusMin, cp.statusMax, cp.statusRange = parseStatusRange(pattern)
+		if opts.CaseInsensitive {
+			cp.plainText = strings.ToLower(pattern)
+			cp.caseInsensitive = true
+			cp.foldRegex = regexp.MustCompile("(?i)" + regexp.QuoteMeta(pattern))
+		}
 	}
 
 	return cp, nil
 }
 
+// parseStatusRange reads a status code range: a class like "4xx" (either case of x) or an
+// inclusive span like "500-599"
+func parseStatusRange(pattern string) (int, int, bool) {
+	pattern = strings.TrimSpace(pattern)
+	if len(pattern) == 3 && pattern[0] >= '1' && pattern[0] <= '5' && strings.EqualFold(pattern[1:], "xx") {
+		low := int(pattern[0]-'0') * 100
+		return low, low + 99, true
+	}
+
+	from, to, ok := strings.Cut(pattern, "-")
+	if !ok || len(from) != 3 || len(to) != 3 {
+		return 0, 0, false
+	}
+	low, err := strconv.Atoi(from)
+	if err != nil || low < 100 {
+		return 0, 0, false
+	}
+	high, err := strconv.Atoi(to)
+	if err != nil || high < low {
+		return 0, 0, false
+	}
+	return low, high, true
+}
+
+// globPattern translates a shell-style glob into an unanchored regex, escaping everything that
+// is not a wildcard so "/api/v1.2" matches only itself. * matches a run of characters other than
+// '/', ** any run at all, ? one character other than '/', and [abc], [a-z] or [!abc] one character
+// of (or not of) a set. a backslash makes the next character literal, and a [ without a closing ]
+// is literal too.
+func globPattern(glob string) string {
+	var pattern strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				pattern.WriteString(".*?")
+				i++
+			} else {
+				pattern.WriteString("[^/]*?")
+			}
+		case '?':
+			pattern.WriteString("[^/]")
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+			}
+			pattern.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		case '[':
+			set, end := globSet(glob, i)
+			if end < 0 {
+				pattern.WriteString(`\[`)
+				continue
+			}
+			pattern.WriteString(set)
+			i = end
+		default:
+			_, size := utf8.DecodeRuneInString(glob[i:])
+			pattern.WriteString(regexp.QuoteMeta(glob[i : i+size]))
+			i += size - 1
+		}
+	}
+	return pattern.String()
+}
+
+// globSet translates the [...] set starting at glob[start], returning the regex character class
+// and the index of the closing ], or -1 when the set is never closed. a ] straight after the
+// opening [ (or [!) is part of the set, as in the shell.
+func globSet(glob string, start int) (string, int) {
+	i := start + 1
+	negate := i < len(glob) && (glob[i] == '!' || glob[i] == '^')
+	if negate {
+		i++
+	}
+
+	var set strings.Builder
+	set.WriteByte('[')
+	if negate {
+		set.WriteByte('^')
+	}
+	for first := true; i < len(glob); i, first = i+1, false {
+		c := glob[i]
+		switch {
+		case c == ']' && !first:
+			set.WriteByte(']')
+			return set.String(), i
+		case c == '-':
+			set.WriteByte('-') // a range, or literal when it opens or closes the set
+		case c == '\\' || c == '[' || c == ']' || c == '^':
+			set.WriteByte('\\')
+			set.WriteByte(c)
+		default:
+			set.WriteByte(c)
+		}
+	}
+	return "", -1
+}
+
+// wholeWordPattern escapes plain text and requires a word boundary at each end that starts or
+// ends with a word character, so "id" no longer matches "width" while "-id" still matches "x-id"
+func wholeWordPattern(text string) string {
+	pattern := regexp.QuoteMeta(text)
+	if text == "" {
+		return pattern
+	}
+	if isWordByte(text[0]) {
+		pattern = `\b` + pattern
+	}
+	if isWordByte(text[len(text)-1]) {
+		pattern += `\b`
+	}
+	return pattern
+}
+
+// isWordByte reports whether c is an ascii word character, matching regexp's \b
+func isWordByte(c byte) bool {
+	return c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// fuzzyDistanceLimit is the edit distance allowed for pattern. it is capped at a third of the
+// pattern's length so short patterns like "id" don't match every short word.
+func fuzzyDistanceLimit(pattern string, maxDistance int) int {
+	if maxDistance == 0 {
+		maxDistance = DefaultMaxEditDistance
+	}
+	return min(maxDistance, utf8.RuneCountInString(pattern)/3)
+}
+
+// fuzzyWordSpans returns the byte ranges of the runs of letters and digits in s
+func fuzzyWordSpans(s string) [][2]int {
+	var spans [][2]int
+	start := -1
+	for i, r := range s {
+		word := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case word && start < 0:
+			start = i
+		case !word && start >= 0:
+			spans = append(spans, [2]int{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, [2]int{start, len(s)})
+	}
+	return spans
+}
+
+// fuzzyLocate finds the closest match of a fuzzy pattern in haystack: an exact case-insensitive
+// substring, or else the run of as many words as the pattern has with the fewest edits. it
+// returns the match's byte range and edit distance, or -1s when nothing is close enough.
+func fuzzyLocate(haystack string, pattern compiledPattern) (int, int, int) {
+	if loc := pattern.foldRegex.FindStringIndex(haystack); loc != nil {
+		return loc[0], loc[1], 0
+	}
+
+	spans := fuzzyWordSpans(haystack)
+	patternLen := utf8.RuneCountInString(pattern.plainText)
+	bestStart, bestEnd, best := -1, -1, -1
+	for i := 0; i+pattern.fuzzyWords <= len(spans); i++ {
+		start, end := spans[i][0], spans[i+pattern.fuzzyWords-1][1]
+		candidate := strings.ToLower(haystack[start:end])
+
+		// a length difference alone can rule the candidate out
+		lengthDiff := utf8.RuneCountInString(candidate) - patternLen
+		if lengthDiff > pattern.maxDistance || -lengthDiff > pattern.maxDistance {
+			continue
+		}
+
+		distance := levenshtein(candidate, pattern.plainText, pattern.maxDistance)
+		if distance <= pattern.maxDistance && (best < 0 || distance < best) {
+			bestStart, bestEnd, best = start, end, distance
+			if distance == 1 {
+				break // 0 was ruled out by the substring check
+			}
+		}
+	}
+	return bestStart, bestEnd, best
+}
+
+// levenshtein returns the edit distance between a and b, or limit+1 once it must exceed limit
+func levenshtein(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev, curr = curr, prev
+	}
+	return min(prev[len(rb)], limit+1)
+}
+
 // matches checks if haystack matches the compiled pattern
 func matches(haystack string, pattern compiledPattern) bool {
 	if pattern.mode == Regex {
 		return pattern.regex.MatchString(haystack)
 	}
 
+	if pattern.mode == Fuzzy {
+		_, _, distance := fuzzyLocate(haystack, pattern)
+		return distance >= 0
+	}
+
+	if pattern.caseInsensitive {
+		return strings.Contains(strings.ToLower(haystack), pattern.plainText)
+	}
+
 	// plain text: use strings.contains (faster than regex)
 	return strings.Contains(haystack, pattern.plainText)
 }
+
+// locate returns the byte range of the first match of pattern in haystack, or -1, -1 if there is none
+func locate(haystack string, pattern compiledPattern) (int, int) {
+	var loc []int
+	switch {
+	case pattern.mode == Regex:
+		loc = pattern.regex.FindStringIndex(haystack)
+	case pattern.caseInsensitive:
+		loc = pattern.foldRegex.FindStringIndex(haystack)
+	default:
+		if i := strings.Index(haystack, pattern.plainText); i >= 0 {
+			return i, i + len(pattern.plainText)
+		}
+	}
+
+	if loc == nil {
+		return -1, -1
+	}
+	return loc[0], loc[1]
+}
+
+// snippet returns the match at [start, end) with up to context bytes either side,
+// widened as needed so the window never splits a utf-8 sequence
+func snippet(haystack string, start, end, context int) string {
+	if context < 0 {
+		return ""
+	}
+
+	from := max(start-context, 0)
+	for from > 0 && !utf8.RuneStart(haystack[from]) {
+		from--
+	}
+
+	to := min(end+context, len(haystack))
+	for to < len(haystack) && !utf8.RuneStart(haystack[to]) {
+		to++
+	}
+
+	return haystack[from:to]
+}
+
+// matchResult builds a result for a value already known to match, recording where the match sits
+func matchResult(index int, field, value string, pattern compiledPattern) *SearchResult {
+	result := &SearchResult{Index: index, Field: field}
+
+	var start, end int
+	if pattern.mode == Fuzzy {
+		start, end, result.Distance = fuzzyLocate(value, pattern)
+	} else {
+		start, end = locate(value, pattern)
+	}
+	result.Score = relevanceScore(field, value, start, end, result.Distance)
+	if start < 0 {
+		// should not happen after matches() succeeded; keep the match without position info
+		return result
+	}
+
+	result.Offset = start
+	result.Snippet = snippet(value, start, end, pattern.snippetContext)
+	if pattern.extract {
+		result.Captures = captures(value, pattern)
+	}
+	return result
+}
+
+// captures returns the capture group of every match in value; groups that did not take part
+// in a match are skipped
+func captures(value string, pattern compiledPattern) []string {
+	var found []string
+	for _, loc := range pattern.regex.FindAllStringSubmatchIndex(value, -1) {
+		start, end := loc[2*pattern.captureGroup], loc[2*pattern.captureGroup+1]
+		if start >= 0 {
+			found = append(found, value[start:end])
+		}
+	}
+	return found
+}
@@ -107,3 +107,215 @@ func TestMatches_EmptyPattern(t *testing.T) {
 	assert.True(t, matches("anything", pattern))
 	assert.True(t, matches("", pattern))
 }
+
+func TestMatches_PlainText_CaseInsensitive(t *testing.T) {
+	opts := SearchOptions{Mode: PlainText, CaseInsensitive: true}
+	pattern, err := compilePattern("Authorization", opts)
+	require.NoError(t, err)
+
+	assert.True(t, matches("authorization", pattern))
+	assert.True(t, matches("AUTHORIZATION: Bearer", pattern))
+	assert.True(t, matches("Authorization", pattern))
+	assert.False(t, matches("auth", pattern))
+}
+
+func TestMatches_Regex_CaseInsensitive(t *testing.T) {
+	opts := SearchOptions{Mode: Regex, CaseInsensitive: true}
+	pattern, err := compilePattern(`^bearer\s+\w+`, opts)
+	require.NoError(t, err)
+
+	assert.True(t, matches("Bearer abc123", pattern))
+	assert.True(t, matches("BEARER token", pattern))
+	assert.False(t, matches("Basic abc123", pattern))
+
+	// flag off keeps the existing case-sensitive behavior
+	opts.CaseInsensitive = false
+	pattern, err = compilePattern(`^bearer`, opts)
+	require.NoError(t, err)
+	assert.False(t, matches("Bearer abc123", pattern))
+}
+
+func TestLocate(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      SearchOptions
+		pattern   string
+		haystack  string
+		wantStart int
+		wantEnd   int
+	}{
+		{"plain", SearchOptions{Mode: PlainText}, "key", `{"apikey":"x"}`, 5, 8},
+		{"plain no match", SearchOptions{Mode: PlainText}, "KEY", `{"apikey":"x"}`, -1, -1},
+		{"plain case insensitive", SearchOptions{Mode: PlainText, CaseInsensitive: true}, "KEY", `{"apikey":"x"}`, 5, 8},
+		{"case insensitive multibyte", SearchOptions{Mode: PlainText, CaseInsensitive: true}, "token", "ȺȺ TOKEN", 5, 10},
+		{"regex", SearchOptions{Mode: Regex}, `\d+`, "user123", 4, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, err := compilePattern(tt.pattern, tt.opts)
+			require.NoError(t, err)
+
+			start, end := locate(tt.haystack, pattern)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+		})
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	haystack := "0123456789match0123456789"
+
+	assert.Equal(t, "789match012", snippet(haystack, 10, 15, 3))
+	assert.Equal(t, haystack, snippet(haystack, 10, 15, 100), "window clamps to the value")
+	assert.Equal(t, "match", snippet(haystack, 10, 15, 0))
+	assert.Empty(t, snippet(haystack, 10, 15, -1), "negative context disables snippets")
+
+	// never split a multi-byte rune
+	assert.Equal(t, "éab", snippet("xéab", 3, 4, 1))
+}
+
+func TestMatchResult_OffsetAndSnippet(t *testing.T) {
+	pattern, err := compilePattern("secret", SearchOptions{Mode: PlainText, SnippetContext: 4})
+	require.NoError(t, err)
+
+	value := `{"token":"secret-value","other":1}`
+	result := matchResult(3, "response.body", value, pattern)
+
+	assert.Equal(t, 3, result.Index)
+	assert.Equal(t, "response.body", result.Field)
+	assert.Equal(t, 10, result.Offset)
+	assert.Equal(t, `n":"secret-val`, result.Snippet)
+}
+
+func TestCompilePattern_DefaultSnippetContext(t *testing.T) {
+	pattern, err := compilePattern("x", SearchOptions{Mode: PlainText})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultSnippetContext, pattern.snippetContext)
+}
+
+func TestMatches_WholeWord(t *testing.T) {
+	opts := SearchOptions{Mode: PlainText, WholeWord: true}
+	pattern, err := compilePattern("id", opts)
+	require.NoError(t, err)
+
+	assert.True(t, matches("id", pattern))
+	assert.True(t, matches(`{"id": 42}`, pattern))
+	assert.True(t, matches("user id=7", pattern))
+	assert.False(t, matches("width", pattern))
+	assert.False(t, matches("hidden", pattern))
+	assert.False(t, matches("idempotent", pattern))
+	assert.False(t, matches("ID", pattern))
+
+	start, end := locate("width and id", pattern)
+	assert.Equal(t, 10, start)
+	assert.Equal(t, 12, end)
+}
+
+func TestMatches_WholeWord_Options(t *testing.T) {
+	// case insensitive
+	pattern, err := compilePattern("id", SearchOptions{Mode: PlainText, WholeWord: true, CaseInsensitive: true})
+	require.NoError(t, err)
+	assert.True(t, matches("User-ID", pattern))
+	assert.False(t, matches("WIDTH", pattern))
+
+	// regex metacharacters in plain text are literal
+	pattern, err = compilePattern("a.b", SearchOptions{Mode: PlainText, WholeWord: true})
+	require.NoError(t, err)
+	assert.True(t, matches("x a.b y", pattern))
+	assert.False(t, matches("axb", pattern))
+
+	// edges that are not word characters need no boundary
+	pattern, err = compilePattern("-id", SearchOptions{Mode: PlainText, WholeWord: true})
+	require.NoError(t, err)
+	assert.True(t, matches("x-id", pattern))
+	assert.False(t, matches("x-idle", pattern))
+
+	// regex mode wraps the whole expression
+	pattern, err = compilePattern("id|name", SearchOptions{Mode: Regex, WholeWord: true})
+	require.NoError(t, err)
+	assert.True(t, matches("the name", pattern))
+	assert.False(t, matches("hidden rename", pattern))
+}
+
+func TestMatchResult_Captures(t *testing.T) {
+	value := `{"orders":[{"orderId":"17"},{"orderId":"42"},{"orderId":""}]}`
+
+	pattern, err := compilePattern(`"orderId":"(\d*)"`, SearchOptions{Mode: Regex, Extract: true, CaptureGroup: 1})
+	require.NoError(t, err)
+	result := matchResult(0, "response.body", value, pattern)
+	assert.Equal(t, []string{"17", "42", ""}, result.Captures)
+
+	pattern, err = compilePattern(`"orderId":"(\d+)"`, SearchOptions{Mode: Regex, Extract: true})
+	require.NoError(t, err)
+	result = matchResult(0, "response.body", value, pattern)
+	assert.Equal(t, []string{`"orderId":"17"`, `"orderId":"42"`}, result.Captures, "group 0 is the whole match")
+
+	// groups that sit out a match are skipped
+	pattern, err = compilePattern(`id=(\d+)|name=(\w+)`, SearchOptions{Mode: Regex, Extract: true, CaptureGroup: 2})
+	require.NoError(t, err)
+	result = matchResult(0, "url", "id=1&name=bob&id=2", pattern)
+	assert.Equal(t, []string{"bob"}, result.Captures)
+
+	pattern, err = compilePattern(`orderId`, SearchOptions{Mode: Regex})
+	require.NoError(t, err)
+	assert.Nil(t, matchResult(0, "response.body", value, pattern).Captures, "captures are only collected when extracting")
+}
+
+func TestCompilePattern_ExtractErrors(t *testing.T) {
+	_, err := compilePattern(`id=(\d+)`, SearchOptions{Mode: Regex, Extract: true, CaptureGroup: 2})
+	assert.ErrorContains(t, err, "capture group 2 out of range")
+
+	_, err = compilePattern("id", SearchOptions{Mode: PlainText, Extract: true})
+	assert.ErrorContains(t, err, "requires regex mode")
+
+	_, err = compilePattern("id", SearchOptions{Mode: PlainText, WholeWord: true, Extract: true})
+	assert.ErrorContains(t, err, "requires regex mode")
+}
+
+func TestGlobPattern(t *testing.T) {
+	tests := []struct {
+		glob  string
+		match []string
+		miss  []string
+	}{
+		{"/api/*/users", []string{"https://x.io/api/v1/users", "/api//users?page=2"}, []string{"/api/v1/admin/users", "/api/users"}},
+		{"/api/**/users", []string{"/api/v1/admin/users", "/api/v1/users"}, []string{"/api/users"}},
+		{"/api/v1.2", []string{"/api/v1.2/orders"}, []string{"/api/v1x2"}},
+		{"/v?/", []string{"/v1/", "/v2/items"}, []string{"/v10/", "/v//"}},
+		{"*.json", []string{"/data/report.json"}, []string{"/data/report.js"}},
+		{"/v[12]/", []string{"/v1/", "/v2/"}, []string{"/v3/"}},
+		{"/v[!12]/", []string{"/v3/"}, []string{"/v1/", "/v2/"}},
+		{"id=[0-9][0-9]", []string{"?id=42"}, []string{"?id=4x"}},
+		{"a+b(c)|d", []string{"a+b(c)|d"}, []string{"aab", "d"}},
+		{`\*literal`, []string{"*literal"}, []string{"xliteral"}},
+		{"[unclosed", []string{"[unclosed"}, []string{"u"}},
+		{"[]]", []string{"]"}, []string{"["}},
+		{"naïve/*", []string{"naïve/café"}, []string{"naive/cafe"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.glob, func(t *testing.T) {
+			pattern, err := compilePattern(tt.glob, SearchOptions{Mode: Glob})
+			require.NoError(t, err)
+			assert.Equal(t, Regex, pattern.mode)
+			for _, s := range tt.match {
+				assert.True(t, matches(s, pattern), "%q should match %q (regex %s)", tt.glob, s, pattern.regex)
+			}
+			for _, s := range tt.miss {
+				assert.False(t, matches(s, pattern), "%q should not match %q (regex %s)", tt.glob, s, pattern.regex)
+			}
+		})
+	}
+}
+
+func TestCompilePattern_GlobOptions(t *testing.T) {
+	pattern, err := compilePattern("/API/*/Users", SearchOptions{Mode: Glob, CaseInsensitive: true})
+	require.NoError(t, err)
+	assert.True(t, matches("/api/v1/users", pattern))
+
+	pattern, err = compilePattern("user*", SearchOptions{Mode: Glob, WholeWord: true})
+	require.NoError(t, err)
+	assert.True(t, matches("/users/", pattern))
+	assert.False(t, matches("/superusers/", pattern))
+}
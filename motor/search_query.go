@@ -0,0 +1,228 @@
+package motor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// queries containing the AND / OR operators are parsed into a tree of terms, e.g.
+//
+//	checkout AND error
+//	"payment failed" OR timeout AND retry
+//
+// AND binds tighter than OR. Adjacent words between operators form a single term joined by
+// spaces, and double quotes keep a phrase (including any AND / OR inside it) as one term.
+// queries without an operator are searched verbatim, quotes and all, as they always have been.
+
+type queryOp int
+
+const (
+	queryTerm queryOp = iota
+	queryAnd
+	queryOr
+)
+
+// queryNode is a node of a parsed boolean query
+type queryNode struct {
+	op       queryOp
+	term     int // index into compiledQuery.terms when op is queryTerm
+	children []*queryNode
+}
+
+// compiledQuery holds a parsed boolean query with each term compiled once
+type compiledQuery struct {
+	root  *queryNode
+	terms []compiledPattern
+}
+
+type queryTokenKind int
+
+const (
+	tokenWord queryTokenKind = iota
+	tokenPhrase
+	tokenAnd
+	tokenOr
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	text string
+}
+
+// compileQuery parses a boolean query and compiles its terms. It returns nil (and no error)
+// when the query has no operators, so callers can keep the single pattern fast path.
+func compileQuery(query string, opts SearchOptions) (*compiledQuery, error) {
+	// cheap check first so plain queries (even with a stray quote) never reach the parser
+	if !slices.ContainsFunc(strings.Fields(query), isQueryOperator) {
+		return nil, nil
+	}
+
+	tokens, err := tokenizeQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	hasOperator := false
+	for _, tok := range tokens {
+		if tok.kind == tokenAnd || tok.kind == tokenOr {
+			hasOperator = true
+			break
+		}
+	}
+	if !hasOperator {
+		return nil, nil
+	}
+
+	p := &queryParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	cq := &compiledQuery{root: root}
+	for _, term := range p.terms {
+		pattern, err := compilePattern(term, opts)
+		if err != nil {
+			return nil, err
+		}
+		cq.terms = append(cq.terms, pattern)
+	}
+
+	return cq, nil
+}
+
+func isQueryOperator(word string) bool {
+	return word == "AND" || word == "OR"
+}
+
+// tokenizeQuery splits a query into words, quoted phrases and operators
+func tokenizeQuery(query string) ([]queryToken, error) {
+	var tokens []queryToken
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		switch {
+		case unicode.IsSpace(runes[i]):
+			i++
+
+		case runes[i] == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated quote in query")
+			}
+			tokens = append(tokens, queryToken{kind: tokenPhrase, text: string(runes[i+1 : end])})
+			i = end + 1
+
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && runes[end] != '"' {
+				end++
+			}
+			word := string(runes[i:end])
+			switch word {
+			case "AND":
+				tokens = append(tokens, queryToken{kind: tokenAnd, text: word})
+			case "OR":
+				tokens = append(tokens, queryToken{kind: tokenOr, text: word})
+			default:
+				tokens = append(tokens, queryToken{kind: tokenWord, text: word})
+			}
+			i = end
+		}
+	}
+
+	return tokens, nil
+}
+
+// queryParser is a recursive descent parser over query tokens
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+	terms  []string
+}
+
+func (p *queryParser) parseOr() (*queryNode, error) {
+	return p.parseBinary(queryOr, tokenOr, p.parseAnd)
+}
+
+func (p *queryParser) parseAnd() (*queryNode, error) {
+	return p.parseBinary(queryAnd, tokenAnd, p.parseTerm)
+}
+
+// parseBinary parses operands separated by the given operator into a single n-ary node
+func (p *queryParser) parseBinary(op queryOp, kind queryTokenKind, operand func() (*queryNode, error)) (*queryNode, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &queryNode{op: op, children: []*queryNode{first}}
+	for p.pos < len(p.tokens) && p.tokens[p.pos].kind == kind {
+		p.pos++
+		next, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		node.children = append(node.children, next)
+	}
+
+	if len(node.children) == 1 {
+		return first, nil
+	}
+	return node, nil
+}
+
+// parseTerm joins consecutive words and phrases into one search term
+func (p *queryParser) parseTerm() (*queryNode, error) {
+	var parts []string
+	for p.pos < len(p.tokens) {
+		tok := p.tokens[p.pos]
+		if tok.kind != tokenWord && tok.kind != tokenPhrase {
+			break
+		}
+		parts = append(parts, tok.text)
+		p.pos++
+	}
+
+	if len(parts) == 0 {
+		if p.pos < len(p.tokens) {
+			return nil, fmt.Errorf("missing search term before %s", p.tokens[p.pos].text)
+		}
+		return nil, fmt.Errorf("missing search term after %s", p.tokens[p.pos-1].text)
+	}
+
+	term := strings.Join(parts, " ")
+	if term == "" {
+		return nil, fmt.Errorf("empty search term in query")
+	}
+
+	p.terms = append(p.terms, term)
+	return &queryNode{op: queryTerm, term: len(p.terms) - 1}, nil
+}
+
+// eval reports whether the node is satisfied given which terms matched the entry
+func (n *queryNode) eval(matched []bool) bool {
+	switch n.op {
+	case queryAnd:
+		for _, child := range n.children {
+			if !child.eval(matched) {
+				return false
+			}
+		}
+		return true
+	case queryOr:
+		for _, child := range n.children {
+			if child.eval(matched) {
+				return true
+			}
+		}
+		return false
+	default:
+		return matched[n.term]
+	}
+}
@@ -0,0 +1,164 @@
+package motor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileQuery_NoOperators(t *testing.T) {
+	for _, q := range []string{"checkout", "checkout error", `"status":"error"`, `"unterminated`, "and or", "ANDROID"} {
+		query, err := compileQuery(q, DefaultSearchOptions)
+		require.NoError(t, err, q)
+		assert.Nil(t, query, "%q should use the single pattern path", q)
+	}
+}
+
+func TestCompileQuery_Terms(t *testing.T) {
+	tests := []struct {
+		query string
+		terms []string
+	}{
+		{"checkout AND error", []string{"checkout", "error"}},
+		{"a OR b OR c", []string{"a", "b", "c"}},
+		{`"payment failed" OR timeout`, []string{"payment failed", "timeout"}},
+		{"checkout page AND error", []string{"checkout page", "error"}},
+		{`"rock AND roll" OR jazz`, []string{"rock AND roll", "jazz"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, err := compileQuery(tt.query, DefaultSearchOptions)
+			require.NoError(t, err)
+			require.NotNil(t, query)
+
+			var terms []string
+			for _, term := range query.terms {
+				terms = append(terms, term.plainText)
+			}
+			assert.Equal(t, tt.terms, terms)
+		})
+	}
+}
+
+func TestCompileQuery_Errors(t *testing.T) {
+	for _, q := range []string{"AND error", "checkout AND", "a AND OR b", `"open AND close`} {
+		_, err := compileQuery(q, DefaultSearchOptions)
+		assert.Error(t, err, q)
+	}
+
+	_, err := compileQuery("[bad AND good", SearchOptions{Mode: Regex})
+	assert.Error(t, err)
+}
+
+func TestQueryNode_Precedence(t *testing.T) {
+	// a OR b AND c == a OR (b AND c)
+	query, err := compileQuery("a OR b AND c", DefaultSearchOptions)
+	require.NoError(t, err)
+
+	tests := []struct {
+		matched  []bool
+		expected bool
+	}{
+		{[]bool{true, false, false}, true},
+		{[]bool{false, true, false}, false},
+		{[]bool{false, true, true}, true},
+		{[]bool{false, false, true}, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, query.root.eval(tt.matched), "%v", tt.matched)
+	}
+}
+
+const queryFixture = `{
+  "log": {
+    "version": "1.2",
+    "creator": {"name": "test", "version": "1.0"},
+    "entries": [
+      {
+        "startedDateTime": "2024-01-01T00:00:00Z",
+        "time": 10,
+        "request": {"method": "POST", "url": "https://shop.example.com/checkout", "headers": [], "bodySize": 0},
+        "response": {"status": 500, "statusText": "Internal Server Error", "headers": [], "content": {"size": 17, "mimeType": "application/json", "text": "{\"error\":\"boom\"}"}, "bodySize": 17},
+        "timings": {"send": 1, "wait": 8, "receive": 1}
+      },
+      {
+        "startedDateTime": "2024-01-01T00:00:01Z",
+        "time": 10,
+        "request": {"method": "POST", "url": "https://shop.example.com/checkout", "headers": [], "bodySize": 0},
+        "response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 13, "mimeType": "application/json", "text": "{\"ok\":\"yes\"}"}, "bodySize": 13},
+        "timings": {"send": 1, "wait": 8, "receive": 1}
+      },
+      {
+        "startedDateTime": "2024-01-01T00:00:02Z",
+        "time": 10,
+        "request": {"method": "GET", "url": "https://shop.example.com/cart", "headers": [], "bodySize": 0},
+        "response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 17, "mimeType": "application/json", "text": "{\"error\":\"none\"}"}, "bodySize": 17},
+        "timings": {"send": 1, "wait": 8, "receive": 1}
+      }
+    ]
+  }
+}`
+
+func searchQueryFixture(t *testing.T, query string, opts SearchOptions) map[int][]string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "query.har")
+	require.NoError(t, os.WriteFile(path, []byte(queryFixture), 0644))
+
+	streamer, err := NewHARStreamer(path, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	reader, err := NewEntryReader(path, streamer.GetIndex())
+	require.NoError(t, err)
+	defer reader.Close()
+
+	resultChan, err := NewSearcher(streamer, reader).Search(context.Background(), query, opts)
+	require.NoError(t, err)
+
+	fields := make(map[int][]string)
+	for _, result := range collectResults(resultChan) {
+		require.NoError(t, result.Error)
+		fields[result.Index] = append(fields[result.Index], result.Field)
+	}
+	return fields
+}
+
+func TestSearch_BooleanAnd(t *testing.T) {
+	opts := DefaultSearchOptions
+	opts.SearchResponseBody = true
+	opts.FirstMatchOnly = false
+
+	fields := searchQueryFixture(t, `checkout AND "error"`, opts)
+
+	// only the first entry has both terms; entry 2 has the body match but not the url
+	require.Len(t, fields, 1)
+	assert.ElementsMatch(t, []string{"url", "response.body"}, fields[0])
+}
+
+func TestSearch_BooleanOr(t *testing.T) {
+	fields := searchQueryFixture(t, "cart OR Internal", DefaultSearchOptions)
+
+	require.Len(t, fields, 2)
+	assert.Equal(t, []string{"status"}, fields[0])
+	assert.Equal(t, []string{"url"}, fields[2], "first match only reports one field per entry")
+}
+
+func TestSearch_BooleanInvalidQuery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "query.har")
+	require.NoError(t, os.WriteFile(path, []byte(queryFixture), 0644))
+
+	streamer, err := NewHARStreamer(path, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	_, err = NewSearcher(streamer, streamer.reader).Search(context.Background(), "checkout AND", DefaultSearchOptions)
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+package motor
+
+import (
+	"cmp"
+	"slices"
+)
+
+// relevance weights; the kind of match dominates, then where it was found, then how early
+const (
+	scoreExactMatch  = 3000 // the pattern is the whole value
+	scorePrefixMatch = 2000 // the value starts with the pattern
+	scoreSubstring   = 1000 // the pattern is somewhere inside the value
+	scoreFieldStep   = 100  // per fieldWeight step
+	scoreMaxOffset   = 99   // offsets beyond this all rank the same
+	scorePerEdit     = 1000 // fuzzy mode: each edit costs a match kind
+)
+
+// fieldWeight ranks the field categories, indexed fields first and deep bodies last
+func fieldWeight(field string) int {
+	switch FieldCategory(field) {
+	case "url", "method", "status", "statusCode", "mimeType", "serverIP", "redirectURL", "resourceType", "priority":
+		return 4
+	case "request.headers", "response.headers", "query.param", "cookie", "response.cookie", "request.postData",
+		"header.exists", "header.missing", "comment":
+		return 3
+	case "request.body", "websocket.message":
+		return 2
+	}
+	return 1
+}
+
+// relevanceScore scores a match of value[start:end] found in field; higher is more relevant.
+// start < 0 means the position is unknown and only the field counts.
+func relevanceScore(field, value string, start, end, distance int) int {
+	score := fieldWeight(field) * scoreFieldStep
+	if start < 0 {
+		return score
+	}
+
+	switch {
+	case start == 0 && end == len(value):
+		score += scoreExactMatch
+	case start == 0:
+		score += scorePrefixMatch
+	default:
+		score += scoreSubstring
+	}
+	return score + scoreMaxOffset - min(start, scoreMaxOffset) - distance*scorePerEdit
+}
+
+// rankResults drains every batch from in and sends them on as a single batch ordered by Score,
+// best first. ties keep entry order, and read errors go last.
+func rankResults(in <-chan []SearchResult) <-chan []SearchResult {
+	out := make(chan []SearchResult, 1)
+	go func() {
+		defer close(out)
+
+		var all []SearchResult
+		for batch := range in {
+			all = append(all, batch...)
+		}
+		if len(all) == 0 {
+			return
+		}
+
+		slices.SortStableFunc(all, compareRelevance)
+		out <- all
+	}()
+	return out
+}
+
+// compareRelevance orders results by descending Score, then entry index and offset
+func compareRelevance(a, b SearchResult) int {
+	if (a.Error != nil) != (b.Error != nil) {
+		if a.Error != nil {
+			return 1
+		}
+		return -1
+	}
+	if c := cmp.Compare(b.Score, a.Score); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Index, b.Index); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Offset, b.Offset)
+}
@@ -0,0 +1,95 @@
+package motor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelevanceScore(t *testing.T) {
+	exact := relevanceScore("method", "POST", 0, 4, 0)
+	prefix := relevanceScore("url", "https://example.com", 0, 5, 0)
+	substring := relevanceScore("url", "https://example.com", 8, 15, 0)
+	late := relevanceScore("url", "https://example.com", 12, 15, 0)
+	body := relevanceScore("response.body", "example", 0, 7, 0)
+	header := relevanceScore("request.headers.Host", "example", 0, 7, 0)
+
+	assert.Greater(t, exact, prefix)
+	assert.Greater(t, prefix, substring)
+	assert.Greater(t, substring, late, "earlier offsets rank higher")
+	assert.Greater(t, header, body, "headers outrank bodies for the same match")
+	assert.Greater(t, substring, relevanceScore("url", "https://example.com", 8, 15, 1), "fuzzy edits cost relevance")
+}
+
+func TestRankResults(t *testing.T) {
+	results := []SearchResult{
+		{Index: 4, Score: 100},
+		{Index: 1, Error: errors.New("read failed"), Score: 9000},
+		{Index: 2, Score: 3000},
+		{Index: 0, Score: 100},
+	}
+	in := make(chan []SearchResult, 2)
+	in <- results[:2]
+	in <- results[2:]
+	close(in)
+
+	batches := 0
+	var ranked []SearchResult
+	for batch := range rankResults(in) {
+		batches++
+		ranked = append(ranked, batch...)
+	}
+
+	assert.Equal(t, 1, batches, "ranked results arrive in one batch")
+	require.Len(t, ranked, 4)
+	assert.Equal(t, []int{2, 0, 4, 1}, []int{ranked[0].Index, ranked[1].Index, ranked[2].Index, ranked[3].Index})
+}
+
+func TestSearch_SortByRelevance(t *testing.T) {
+	const fixture = `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1.0"}, "entries": [
+	  {"startedDateTime": "2024-01-01T00:00:00Z", "time": 10,
+	   "request": {"method": "GET", "url": "https://example.com/a", "headers": [], "bodySize": 0},
+	   "response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 17, "mimeType": "text/plain", "text": "see login for more"}, "bodySize": 17},
+	   "timings": {"send": 1, "wait": 8, "receive": 1}},
+	  {"startedDateTime": "2024-01-01T00:00:01Z", "time": 10,
+	   "request": {"method": "GET", "url": "https://example.com/account/login", "headers": [], "bodySize": 0},
+	   "response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 2, "mimeType": "text/plain", "text": "ok"}, "bodySize": 2},
+	   "timings": {"send": 1, "wait": 8, "receive": 1}},
+	  {"startedDateTime": "2024-01-01T00:00:02Z", "time": 10,
+	   "request": {"method": "GET", "url": "https://example.com/b", "headers": [{"name": "X-Flow", "value": "login"}], "bodySize": 0},
+	   "response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 2, "mimeType": "text/plain", "text": "ok"}, "bodySize": 2},
+	   "timings": {"send": 1, "wait": 8, "receive": 1}}
+	]}}`
+
+	path := filepath.Join(t.TempDir(), "rank.har")
+	require.NoError(t, os.WriteFile(path, []byte(fixture), 0644))
+
+	streamer, err := NewHARStreamer(path, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	reader, err := NewEntryReader(path, streamer.GetIndex())
+	require.NoError(t, err)
+	defer reader.Close()
+
+	opts := DefaultSearchOptions
+	opts.SearchResponseBody = true
+	opts.SortByRelevance = true
+	opts.WorkerCount = 3
+	opts.ChunkSize = 1
+	resultChan, err := NewSearcher(streamer, reader).Search(context.Background(), "login", opts)
+	require.NoError(t, err)
+
+	results := collectResults(resultChan)
+	require.Len(t, results, 3)
+	assert.Equal(t, 2, results[0].Index, "an exact header value ranks first")
+	assert.Equal(t, 1, results[1].Index, "then the url substring")
+	assert.Equal(t, 0, results[2].Index, "the body match comes last")
+	assert.Equal(t, "response.body", results[2].Field)
+}
@@ -2,6 +2,9 @@ package motor
 
 import (
 	"context"
+	"runtime"
+	"strconv"
+	"strings"
 	"sync/atomic"
 
 	"github.com/pb33f/harific/motor/model"
@@ -9,26 +12,27 @@ import (
 
 // workBatch represents a range of entries to process
 type workBatch struct {
-	startIndex int // inclusive start
-	endIndex   int // exclusive end (go range convention)
+	startIndex int   // inclusive start
+	endIndex   int   // exclusive end (go range convention)
+	indices    []int // explicit entry indices; when set, startIndex/endIndex are ignored
 }
 
-// createWorkBatches divides entries into batches for workers
+// createWorkBatches divides entries (limited to opts.StartIndex/EndIndex) into batches for workers
 func createWorkBatches(totalEntries int, opts SearchOptions) []workBatch {
 	var batches []workBatch
 
-	chunkSize := opts.ChunkSize
-
-	// fallback: auto-partition based on worker count
-	if chunkSize == 0 {
-		chunkSize = (totalEntries + opts.WorkerCount - 1) / opts.WorkerCount
+	rangeStart, rangeEnd := searchRange(opts, totalEntries)
+	if rangeStart >= rangeEnd {
+		return batches
 	}
 
+	chunkSize := batchChunkSize(rangeEnd-rangeStart, opts)
+
 	// create batches
-	for start := 0; start < totalEntries; start += chunkSize {
+	for start := rangeStart; start < rangeEnd; start += chunkSize {
 		end := start + chunkSize
-		if end > totalEntries {
-			end = totalEntries
+		if end > rangeEnd {
+			end = rangeEnd
 		}
 
 		batches = append(batches, workBatch{
@@ -40,13 +44,52 @@ func createWorkBatches(totalEntries int, opts SearchOptions) []workBatch {
 	return batches
 }
 
+// batchChunkSize is the number of entries per batch when n entries are searched: opts.ChunkSize,
+// or when that is 0 an even split of the entries across the workers
+func batchChunkSize(n int, opts SearchOptions) int {
+	if opts.ChunkSize > 0 {
+		return opts.ChunkSize
+	}
+	return max((n+opts.WorkerCount-1)/opts.WorkerCount, 1)
+}
+
+// clampConcurrency bounds the worker count to [1, MaxSearchWorkers], defaulting 0 to the number of
+// CPUs, and turns a negative chunk size into auto-partitioning
+func clampConcurrency(opts SearchOptions) SearchOptions {
+	if opts.WorkerCount <= 0 {
+		opts.WorkerCount = runtime.NumCPU()
+	}
+	opts.WorkerCount = min(opts.WorkerCount, MaxSearchWorkers)
+	opts.ChunkSize = max(opts.ChunkSize, 0)
+	return opts
+}
+
+// createIndexBatches divides an explicit list of entry indices into batches for workers
+func createIndexBatches(indices []int, opts SearchOptions) []workBatch {
+	var batches []workBatch
+
+	chunkSize := batchChunkSize(len(indices), opts)
+	for start := 0; start < len(indices); start += chunkSize {
+		end := start + chunkSize
+		if end > len(indices) {
+			end = len(indices)
+		}
+
+		batches = append(batches, workBatch{indices: indices[start:end]})
+	}
+
+	return batches
+}
+
 // worker processes work batches and searches entries
 func worker(ctx context.Context,
 	workQueue <-chan workBatch,
 	results chan<- []SearchResult,
 	searcher *HARSearcher,
 	pattern compiledPattern,
-	opts SearchOptions) {
+	opts SearchOptions,
+	query *compiledQuery,
+	limiter *resultLimiter) {
 
 	for {
 		select {
@@ -64,9 +107,15 @@ func worker(ctx context.Context,
 			// accumulate matches (small initial capacity for common case)
 			batchResults := make([]SearchResult, 0, 8)
 
-			// process each entry in this batch
-			for i := batch.startIndex; i < batch.endIndex; i++ {
-				entryResults := searchEntry(ctx, searcher, i, pattern, opts, buf)
+			processEntry := func(i int) {
+				var entryResults []*SearchResult
+				if hasHeaderPredicate(opts) {
+					entryResults = searchEntryHeaderPredicates(ctx, searcher, i, pattern, query, opts, buf)
+				} else if query != nil {
+					entryResults = searchEntryQuery(ctx, searcher, i, query, opts, buf)
+				} else {
+					entryResults = searchEntry(ctx, searcher, i, pattern, opts, buf)
+				}
 
 				// flatten results from this entry into batch
 				for _, result := range entryResults {
@@ -76,6 +125,17 @@ func worker(ctx context.Context,
 				atomic.AddInt64(&searcher.stats.entriesSearched, 1)
 			}
 
+			// process each entry in this batch
+			if batch.indices != nil {
+				for _, i := range batch.indices {
+					processEntry(i)
+				}
+			} else {
+				for i := batch.startIndex; i < batch.endIndex; i++ {
+					processEntry(i)
+				}
+			}
+
 			// return buffer to pool immediately
 			searcher.bufferPool.Put(buf)
 
@@ -89,10 +149,32 @@ func worker(ctx context.Context,
 					}
 				}
 
-				select {
-				case results <- batchResults:
-					atomic.AddInt64(&searcher.stats.matchesFound, int64(successCount))
-				case <-ctx.Done():
+				// claim result slots; once MaxResults is full every later match is dropped
+				allowed, full := limiter.take(successCount)
+				if allowed < successCount {
+					batchResults = limitResults(batchResults, allowed)
+					successCount = allowed
+				}
+
+				fieldCounts := make(map[string]int64, 4)
+				for _, result := range batchResults {
+					if result.Error == nil {
+						fieldCounts[FieldCategory(result.Field)]++
+					}
+				}
+
+				if len(batchResults) > 0 {
+					select {
+					case results <- batchResults:
+						atomic.AddInt64(&searcher.stats.matchesFound, int64(successCount))
+						searcher.stats.addFieldCounts(fieldCounts)
+					case <-ctx.Done():
+						return
+					}
+				}
+
+				if full {
+					limiter.stop()
 					return
 				}
 			}
@@ -100,6 +182,21 @@ func worker(ctx context.Context,
 	}
 }
 
+// limitResults keeps the first n successful matches of a batch, along with any read errors before the cut
+func limitResults(batch []SearchResult, n int) []SearchResult {
+	kept := batch[:0]
+	for _, result := range batch {
+		if result.Error == nil {
+			if n == 0 {
+				break
+			}
+			n--
+		}
+		kept = append(kept, result)
+	}
+	return kept
+}
+
 // searchEntry searches a single entry for pattern matches
 // returns slice of matches (empty if no matches, or single error result)
 func searchEntry(ctx context.Context,
@@ -118,7 +215,7 @@ func searchEntry(ctx context.Context,
 	}
 
 	// search metadata fields
-	if result := searchMetadata(index, metadata, pattern); result != nil {
+	if result := searchMetadata(index, metadata, pattern, opts.Fields); result != nil {
 		results = append(results, result)
 		if opts.FirstMatchOnly && !opts.SearchResponseBody {
 			return results // early return unless deep search required
@@ -126,14 +223,28 @@ func searchEntry(ctx context.Context,
 	}
 
 	// decide whether to load full entry
-	// skip loading if: firstmatchonly=true AND already matched AND no deep search required
+	// skip loading if: firstmatchonly=true AND already matched AND no deep search required,
+	// or if none of the requested fields live outside the index
 	needsFullEntry := len(results) == 0 || !opts.FirstMatchOnly || opts.SearchResponseBody
+	if opts.Fields != 0 && opts.Fields&^indexedSearchFields == 0 {
+		needsFullEntry = false
+	}
 
 	if !needsFullEntry {
 		return results
 	}
 
 	// step 2: load full entry for header/body searches (if needed)
+	entry, err := readSearchEntry(ctx, s, metadata, buf)
+	if err != nil {
+		return []*SearchResult{{Index: index, Error: err}}
+	}
+
+	return searchEntryFields(index, entry, pattern, opts, results)
+}
+
+// readSearchEntry loads the full entry behind metadata, counting the bytes read
+func readSearchEntry(ctx context.Context, s *HARSearcher, metadata *EntryMetadata, buf *[]byte) (*model.Entry, error) {
 	req := NewReadRequestBuilder().
 		WithOffset(metadata.FileOffset).
 		WithLength(metadata.Length).
@@ -142,40 +253,126 @@ func searchEntry(ctx context.Context,
 
 	resp := s.reader.Read(ctx, req)
 	if resp.GetError() != nil {
-		return []*SearchResult{{Index: index, Error: resp.GetError()}}
+		return nil, resp.GetError()
 	}
 
-	entry := resp.GetEntry()
 	atomic.AddInt64(&s.stats.bytesSearched, resp.GetBytesRead())
+	return resp.GetEntry(), nil
+}
+
+// searchEntryQuery evaluates a boolean query against a single entry. every term is matched against
+// the whole entry and the entry is reported only if the query holds, with the matches of each term.
+func searchEntryQuery(ctx context.Context,
+	s *HARSearcher,
+	index int,
+	query *compiledQuery,
+	opts SearchOptions,
+	buf *[]byte) []*SearchResult {
+
+	metadata, err := s.streamer.GetMetadata(index)
+	if err != nil {
+		return []*SearchResult{{Index: index, Error: err}}
+	}
+
+	// terms may match anywhere, so the full entry is needed unless only indexed fields are searched
+	var entry *model.Entry
+	if opts.Fields == 0 || opts.Fields&^indexedSearchFields != 0 {
+		entry, err = readSearchEntry(ctx, s, metadata, buf)
+		if err != nil {
+			return []*SearchResult{{Index: index, Error: err}}
+		}
+	}
+
+	return evaluateQuery(index, metadata, entry, query, opts)
+}
+
+// evaluateQuery matches every term of query against an entry, whose full body may be nil when
+// only indexed fields are searched, and returns the term matches if the query holds
+func evaluateQuery(index int,
+	metadata *EntryMetadata,
+	entry *model.Entry,
+	query *compiledQuery,
+	opts SearchOptions) []*SearchResult {
+
+	termResults := make([][]*SearchResult, len(query.terms))
+	matched := make([]bool, len(query.terms))
+	for i, pattern := range query.terms {
+		var results []*SearchResult
+		if result := searchMetadata(index, metadata, pattern, opts.Fields); result != nil {
+			results = append(results, result)
+		}
+		if entry != nil && (len(results) == 0 || !opts.FirstMatchOnly || opts.SearchResponseBody) {
+			results = searchEntryFields(index, entry, pattern, opts, results)
+		}
+		termResults[i] = results
+		matched[i] = len(results) > 0
+	}
+
+	if !query.root.eval(matched) {
+		return nil
+	}
+
+	var results []*SearchResult
+	for _, termMatches := range termResults {
+		results = append(results, termMatches...)
+	}
+	if opts.FirstMatchOnly && !opts.SearchResponseBody && len(results) > 1 {
+		results = results[:1]
+	}
+	return results
+}
+
+// searchEntryFields runs the search steps that need the full entry, appending matches to results
+func searchEntryFields(index int,
+	entry *model.Entry,
+	pattern compiledPattern,
+	opts SearchOptions,
+	results []*SearchResult) []*SearchResult {
 
 	// step 3: search request headers
-	if result := searchHeaders(index, entry.Request.Headers, pattern, "request.headers."); result != nil {
-		results = append(results, result)
-		if opts.FirstMatchOnly && !opts.SearchResponseBody {
-			return results
+	if opts.Fields.has(SearchFieldRequestHeaders) {
+		if result := searchHeaders(index, entry.Request.Headers, pattern, "request.headers."); result != nil {
+			results = append(results, result)
+			if opts.FirstMatchOnly && !opts.SearchResponseBody {
+				return results
+			}
 		}
 	}
 
 	// step 4: search query params
-	if result := searchHeaders(index, entry.Request.QueryParams, pattern, "query.param."); result != nil {
-		results = append(results, result)
-		if opts.FirstMatchOnly && !opts.SearchResponseBody {
-			return results
+	if opts.Fields.has(SearchFieldQueryParams) {
+		if result := searchHeaders(index, entry.Request.QueryParams, pattern, "query.param."); result != nil {
+			results = append(results, result)
+			if opts.FirstMatchOnly && !opts.SearchResponseBody {
+				return results
+			}
 		}
 	}
 
-	// step 5: search cookies
-	if result := searchCookies(index, entry.Request.Cookies, pattern); result != nil {
-		results = append(results, result)
-		if opts.FirstMatchOnly && !opts.SearchResponseBody {
-			return results
+	// step 5: search request cookies
+	if opts.Fields.has(SearchFieldCookies) {
+		if result := searchCookies(index, entry.Request.Cookies, pattern, "cookie."); result != nil {
+			results = append(results, result)
+			if opts.FirstMatchOnly && !opts.SearchResponseBody {
+				return results
+			}
 		}
 	}
 
 	// step 6: search request body
-	if entry.Request.Body.Content != "" {
+	if !opts.SkipRequestBody && opts.Fields.has(SearchFieldRequestBody) && entry.Request.Body.Content != "" {
 		if matches(entry.Request.Body.Content, pattern) {
-			results = append(results, &SearchResult{Index: index, Field: "request.body"})
+			results = append(results, matchResult(index, "request.body", entry.Request.Body.Content, pattern))
+			if opts.FirstMatchOnly && !opts.SearchResponseBody {
+				return results
+			}
+		}
+	}
+
+	// step 6b: search url-encoded post data params, which har exporters store instead of text
+	if !opts.SkipRequestBody && opts.Fields.has(SearchFieldRequestBody) {
+		if result := searchPostData(index, entry.Request.Body.Params, pattern); result != nil {
+			results = append(results, result)
 			if opts.FirstMatchOnly && !opts.SearchResponseBody {
 				return results
 			}
@@ -183,59 +380,237 @@ func searchEntry(ctx context.Context,
 	}
 
 	// step 7: search response headers
-	if result := searchHeaders(index, entry.Response.Headers, pattern, "response.headers."); result != nil {
+	if opts.Fields.has(SearchFieldResponseHeaders) {
+		if result := searchHeaders(index, entry.Response.Headers, pattern, "response.headers."); result != nil {
+			results = append(results, result)
+			if opts.FirstMatchOnly && !opts.SearchResponseBody {
+				return results
+			}
+		}
+	}
+
+	// step 7b: search cookies the response set
+	if opts.Fields.has(SearchFieldResponseCookies) {
+		if result := searchCookies(index, entry.Response.Cookies, pattern, "response.cookie."); result != nil {
+			results = append(results, result)
+			if opts.FirstMatchOnly && !opts.SearchResponseBody {
+				return results
+			}
+		}
+	}
+
+	// step 7c: search the comments annotating the entry, its request and its response
+	if opts.Fields.has(SearchFieldComments) {
+		for _, comment := range []struct{ field, text string }{
+			{"comment.entry", entry.Comment},
+			{"comment.request", entry.Request.Comment},
+			{"comment.response", entry.Response.Comment},
+		} {
+			if comment.text != "" && matches(comment.text, pattern) {
+				results = append(results, matchResult(index, comment.field, comment.text, pattern))
+				if opts.FirstMatchOnly && !opts.SearchResponseBody {
+					return results
+				}
+			}
+		}
+	}
+
+	// step 8: search websocket frame payloads
+	if opts.SearchWebSockets && opts.Fields.has(SearchFieldWebSockets) {
+		for _, msg := range entry.WebSocketMessages {
+			if matches(msg.Data, pattern) {
+				results = append(results, matchResult(index, "websocket.message", msg.Data, pattern))
+				if opts.FirstMatchOnly && !opts.SearchResponseBody {
+					return results
+				}
+				break
+			}
+		}
+	}
+
+	// step 9: ALWAYS search response body if deep search enabled (guarantees bodies are checked)
+	if opts.SearchResponseBody && opts.Fields.has(SearchFieldResponseBody) && entry.Response.Body.Content != "" {
+		body := entry.Response.Body.Content
+		if opts.DecodeBodies {
+			// bodies that fail to decode are still searched as stored
+			body, _ = DecodeBody(&entry.Response.Body)
+		}
+		if matches(body, pattern) {
+			results = append(results, matchResult(index, "response.body", body, pattern))
+		}
+	}
+
+	return results
+}
+
+// hasHeaderPredicate reports whether HeaderExists or HeaderMissing narrows the search
+func hasHeaderPredicate(opts SearchOptions) bool {
+	return opts.HeaderExists != "" || opts.HeaderMissing != ""
+}
+
+// searchEntryHeaderPredicates searches an entry only if it meets the HeaderExists and
+// HeaderMissing predicates. an empty pattern reports the predicates themselves as matches.
+func searchEntryHeaderPredicates(ctx context.Context,
+	s *HARSearcher,
+	index int,
+	pattern compiledPattern,
+	query *compiledQuery,
+	opts SearchOptions,
+	buf *[]byte) []*SearchResult {
+
+	metadata, err := s.streamer.GetMetadata(index)
+	if err != nil {
+		return []*SearchResult{{Index: index, Error: err}}
+	}
+
+	// headers are not indexed, so the predicates always need the full entry
+	entry, err := readSearchEntry(ctx, s, metadata, buf)
+	if err != nil {
+		return []*SearchResult{{Index: index, Error: err}}
+	}
+
+	var results []*SearchResult
+	if opts.HeaderExists != "" {
+		result := searchHeaderExists(index, entry, opts.HeaderExists)
+		if result == nil {
+			return nil
+		}
 		results = append(results, result)
-		if opts.FirstMatchOnly && !opts.SearchResponseBody {
-			return results
+	}
+	if opts.HeaderMissing != "" {
+		if findHeader(entry.Request.Headers, opts.HeaderMissing) != nil || findHeader(entry.Response.Headers, opts.HeaderMissing) != nil {
+			return nil
 		}
+		name := strings.ToLower(opts.HeaderMissing)
+		results = append(results, &SearchResult{Index: index, Field: "header.missing." + name, Score: relevanceScore("header.missing."+name, "", -1, -1, 0)})
 	}
 
-	// step 8: ALWAYS search response body if deep search enabled (guarantees bodies are checked)
-	if opts.SearchResponseBody && entry.Response.Body.Content != "" {
-		if matches(entry.Response.Body.Content, pattern) {
-			results = append(results, &SearchResult{Index: index, Field: "response.body"})
+	// with a pattern the predicates only select the entry, and the pattern decides what is reported
+	if query != nil {
+		return evaluateQuery(index, metadata, entry, query, opts)
+	}
+	if !pattern.empty {
+		var matched []*SearchResult
+		if result := searchMetadata(index, metadata, pattern, opts.Fields); result != nil {
+			matched = append(matched, result)
+			if opts.FirstMatchOnly && !opts.SearchResponseBody {
+				return matched
+			}
 		}
+		return searchEntryFields(index, entry, pattern, opts, matched)
 	}
 
+	if opts.FirstMatchOnly && len(results) > 1 {
+		results = results[:1]
+	}
 	return results
 }
 
+// searchHeaderExists reports the first request, then response, header called name, or nil
+func searchHeaderExists(index int, entry *model.Entry, name string) *SearchResult {
+	side := "request"
+	header := findHeader(entry.Request.Headers, name)
+	if header == nil {
+		side = "response"
+		header = findHeader(entry.Response.Headers, name)
+	}
+	if header == nil {
+		return nil
+	}
+
+	field := "header.exists." + strings.ToLower(header.Name)
+	return &SearchResult{
+		Index:   index,
+		Field:   field,
+		Snippet: side + " " + header.Name + ": " + header.Value,
+		Score:   relevanceScore(field, "", -1, -1, 0),
+	}
+}
+
+// findHeader returns the first header called name, compared case-insensitively, or nil
+func findHeader(headers []model.NameValuePair, name string) *model.NameValuePair {
+	for i := range headers {
+		if strings.EqualFold(headers[i].Name, name) {
+			return &headers[i]
+		}
+	}
+	return nil
+}
+
 // searchHeaders checks if any header name or value matches the pattern
 func searchHeaders(index int, headers []model.NameValuePair, pattern compiledPattern, prefix string) *SearchResult {
 	for _, header := range headers {
-		if matches(header.Name, pattern) || matches(header.Value, pattern) {
-			return &SearchResult{Index: index, Field: prefix + header.Name}
+		if matches(header.Name, pattern) {
+			return matchResult(index, prefix+header.Name, header.Name, pattern)
+		}
+		if matches(header.Value, pattern) {
+			return matchResult(index, prefix+header.Name, header.Value, pattern)
 		}
 	}
 	return nil
 }
 
 // searchCookies checks if any cookie name or value matches the pattern
-func searchCookies(index int, cookies []model.Cookie, pattern compiledPattern) *SearchResult {
+func searchCookies(index int, cookies []model.Cookie, pattern compiledPattern, prefix string) *SearchResult {
 	for _, cookie := range cookies {
-		if matches(cookie.Name, pattern) || matches(cookie.Value, pattern) {
-			return &SearchResult{Index: index, Field: "cookie." + cookie.Name}
+		if matches(cookie.Name, pattern) {
+			return matchResult(index, prefix+cookie.Name, cookie.Name, pattern)
+		}
+		if matches(cookie.Value, pattern) {
+			return matchResult(index, prefix+cookie.Name, cookie.Value, pattern)
 		}
 	}
 	return nil
 }
 
-// searchMetadata checks if any metadata field matches the pattern
-func searchMetadata(index int, metadata *EntryMetadata, pattern compiledPattern) *SearchResult {
+// searchPostData checks if any post data param name, value or file name matches the pattern
+func searchPostData(index int, params []model.PostNameValuePair, pattern compiledPattern) *SearchResult {
+	for _, param := range params {
+		field := "request.postData." + param.Name
+		for _, value := range []string{param.Name, param.Value, param.FileName} {
+			if value != "" && matches(value, pattern) {
+				return matchResult(index, field, value, pattern)
+			}
+		}
+	}
+	return nil
+}
+
+// searchMetadata checks if any metadata field selected by fields matches the pattern
+func searchMetadata(index int, metadata *EntryMetadata, pattern compiledPattern, fields SearchField) *SearchResult {
+	statusCode := ""
+	if metadata.StatusCode > 0 {
+		statusCode = strconv.Itoa(metadata.StatusCode)
+	}
+	if pattern.statusRange && fields.has(SearchFieldMetadata) &&
+		metadata.StatusCode >= pattern.statusMin && metadata.StatusCode <= pattern.statusMax {
+		return &SearchResult{
+			Index:   index,
+			Field:   "statusCode",
+			Snippet: statusCode,
+			Score:   relevanceScore("statusCode", statusCode, 0, len(statusCode), 0),
+		}
+	}
+
 	metadataFields := []struct {
 		value string
 		name  string
+		field SearchField
 	}{
-		{metadata.URL, "url"},
-		{metadata.Method, "method"},
-		{metadata.StatusText, "status"},
-		{metadata.MimeType, "mimeType"},
-		{metadata.ServerIP, "serverIP"},
+		{metadata.URL, "url", SearchFieldURL},
+		{metadata.Method, "method", SearchFieldMetadata},
+		{metadata.StatusText, "status", SearchFieldMetadata},
+		{statusCode, "statusCode", SearchFieldMetadata},
+		{metadata.MimeType, "mimeType", SearchFieldMetadata},
+		{metadata.ServerIP, "serverIP", SearchFieldMetadata},
+		{metadata.ResourceType, "resourceType", SearchFieldMetadata},
+		{metadata.Priority, "priority", SearchFieldMetadata},
+		{metadata.RedirectURL, "redirectURL", SearchFieldRedirectURL},
 	}
 
 	for _, field := range metadataFields {
-		if matches(field.value, pattern) {
-			return &SearchResult{Index: index, Field: field.name}
+		if fields.has(field.field) && matches(field.value, pattern) {
+			return matchResult(index, field.name, field.value, pattern)
 		}
 	}
 	return nil
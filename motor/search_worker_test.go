@@ -82,6 +82,25 @@ func TestCreateWorkBatches_ManualChunkSize(t *testing.T) {
 	}
 }
 
+func TestCreateWorkBatches_SubRange(t *testing.T) {
+	opts := SearchOptions{
+		WorkerCount: 4,
+		StartIndex:  1000,
+		EndIndex:    2000,
+	}
+
+	batches := createWorkBatches(5000, opts)
+	require.Len(t, batches, 4)
+	assert.Equal(t, 1000, batches[0].startIndex)
+	assert.Equal(t, 2000, batches[len(batches)-1].endIndex)
+
+	totalCovered := 0
+	for _, batch := range batches {
+		totalCovered += batch.endIndex - batch.startIndex
+	}
+	assert.Equal(t, 1000, totalCovered)
+}
+
 func TestSearchMetadata_AllFields(t *testing.T) {
 	opts := SearchOptions{Mode: PlainText}
 
@@ -131,7 +150,7 @@ func TestSearchMetadata_AllFields(t *testing.T) {
 			pattern, err := compilePattern(tt.fieldToMatch, opts)
 			require.NoError(t, err)
 
-			result := searchMetadata(0, metadata, pattern)
+			result := searchMetadata(0, metadata, pattern, 0)
 			require.NotNil(t, result)
 			assert.Equal(t, 0, result.Index)
 			assert.Equal(t, tt.expectedField, result.Field)
@@ -153,10 +172,48 @@ func TestSearchMetadata_NoMatch(t *testing.T) {
 	pattern, err := compilePattern("notfound", opts)
 	require.NoError(t, err)
 
-	result := searchMetadata(0, metadata, pattern)
+	result := searchMetadata(0, metadata, pattern, 0)
 	assert.Nil(t, result)
 }
 
+func TestSearchMetadata_StatusCode(t *testing.T) {
+	metadata := &EntryMetadata{URL: "https://example.com/missing", StatusCode: 404, StatusText: "Not Found"}
+	opts := SearchOptions{Mode: PlainText}
+
+	tests := []struct {
+		pattern string
+		matches bool
+	}{
+		{"404", true},
+		{"4xx", true},
+		{"4XX", true},
+		{"400-499", true},
+		{"404-404", true},
+		{"5xx", false},
+		{"500-599", false},
+		{"499-400", false}, // backwards, so plain text that matches nothing
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			pattern, err := compilePattern(tt.pattern, opts)
+			require.NoError(t, err)
+
+			result := searchMetadata(0, metadata, pattern, 0)
+			if !tt.matches {
+				assert.Nil(t, result)
+				return
+			}
+			require.NotNil(t, result)
+			assert.Equal(t, "statusCode", result.Field)
+			assert.Equal(t, "404", result.Snippet)
+		})
+	}
+
+	pattern, err := compilePattern("4xx", SearchOptions{Mode: Regex})
+	require.NoError(t, err)
+	assert.False(t, pattern.statusRange, "ranges are a plain text shorthand only")
+}
+
 func TestSearchHeaders_RequestHeaders(t *testing.T) {
 	headers := []model.NameValuePair{
 		{Name: "Content-Type", Value: "application/json"},
@@ -188,6 +245,22 @@ func TestSearchHeaders_RequestHeaders(t *testing.T) {
 	}
 }
 
+func TestSearchHeaders_CaseInsensitive(t *testing.T) {
+	headers := []model.NameValuePair{
+		{Name: "Authorization", Value: "Bearer token123"},
+	}
+
+	pattern, err := compilePattern("authorization", SearchOptions{Mode: PlainText})
+	require.NoError(t, err)
+	assert.Nil(t, searchHeaders(0, headers, pattern, "request.headers."))
+
+	pattern, err = compilePattern("authorization", SearchOptions{Mode: PlainText, CaseInsensitive: true})
+	require.NoError(t, err)
+	result := searchHeaders(0, headers, pattern, "request.headers.")
+	require.NotNil(t, result)
+	assert.Equal(t, "request.headers.Authorization", result.Field)
+}
+
 func TestSearchHeaders_NoMatch(t *testing.T) {
 	headers := []model.NameValuePair{
 		{Name: "Content-Type", Value: "application/json"},
@@ -497,3 +570,16 @@ func TestSearchEntry_NoMatch(t *testing.T) {
 
 	assert.Empty(t, searchResults)
 }
+
+func TestClampConcurrency(t *testing.T) {
+	opts := clampConcurrency(SearchOptions{WorkerCount: -3, ChunkSize: -10})
+	assert.Equal(t, runtime.NumCPU(), opts.WorkerCount)
+	assert.Equal(t, 0, opts.ChunkSize)
+
+	opts = clampConcurrency(SearchOptions{WorkerCount: 10000, ChunkSize: 50})
+	assert.Equal(t, MaxSearchWorkers, opts.WorkerCount)
+	assert.Equal(t, 50, opts.ChunkSize)
+
+	// auto-partitioning never yields an empty chunk, even with more workers than entries
+	assert.Equal(t, 1, batchChunkSize(3, SearchOptions{WorkerCount: 8}))
+}
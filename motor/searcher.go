@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"runtime"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -11,13 +12,106 @@ import (
 
 // SearchOptions configures search behavior
 type SearchOptions struct {
-	Mode               SearchMode // plaintext or regex
-	SearchResponseBody bool       // deep search flag (default: false)
-	FirstMatchOnly     bool       // stop at first match per entry (default: true)
-	WorkerCount        int        // default: runtime.numcpu()
-	ChunkSize          int        // entries per work batch (default: 0 = auto-partition)
+	Mode               SearchMode  // plaintext, regex or fuzzy
+	SearchResponseBody bool        // deep search flag (default: false)
+	SkipRequestBody    bool        // leave request post data text and params unsearched (default: false)
+	FirstMatchOnly     bool        // stop at first match per entry (default: true)
+	WorkerCount        int         // default: runtime.numcpu(), at most MaxSearchWorkers
+	ChunkSize          int         // entries per work batch (default: 0 = auto-partition)
+	Indices            []int       // restrict search to these entry indices (default: nil = all entries)
+	StartIndex         int         // first entry to search, inclusive (default: 0)
+	EndIndex           int         // last entry to search, exclusive (default: 0 = through the last entry)
+	SearchWebSockets   bool        // search _webSocketMessages payloads (default: false)
+	CaseInsensitive    bool        // ignore case in plaintext and regex modes (default: false)
+	Fields             SearchField // restrict matching to these locations (default: 0 = all fields)
+	SnippetContext     int         // bytes either side of a match kept in Snippet (default: 0 = 40, negative disables)
+	MaxResults         int         // stop searching once this many matches are found (default: 0 = unlimited)
+	DecodeBodies       bool        // match base64 / gzip response bodies as decoded text (default: false)
+	WholeWord          bool        // only match the pattern between word boundaries (default: false = substring)
+	MaxEditDistance    int         // fuzzy mode: most edits a match may be from the pattern (default: 0 = 2)
+
+	// HeaderExists and HeaderMissing keep only entries that have, or lack, a request or response
+	// header of that name (case-insensitive). with an empty pattern every such entry is reported
+	// under a "header.exists.<name>" or "header.missing.<name>" field; otherwise the pattern's
+	// matches are reported for those entries only (default: "" = no predicate)
+	HeaderExists  string
+	HeaderMissing string
+
+	// Extract fills SearchResult.Captures with submatch CaptureGroup of every match in the matched
+	// value (0 = the whole match), for pulling values such as tokens or ids out of a har. regex
+	// mode only (default: false)
+	Extract      bool
+	CaptureGroup int
+
+	// SortByRelevance delivers every match in one batch ordered by SearchResult.Score, best first.
+	// results are held back until the search completes, so nothing streams in the meantime, and
+	// MaxResults still keeps the first matches found rather than the best ones (default: false)
+	SortByRelevance bool
+}
+
+// SearchField is a bitmask of entry locations a search may match in
+type SearchField uint16
+
+const (
+	SearchFieldURL             SearchField = 1 << iota // request url
+	SearchFieldMetadata                                // method, status text, mime type, server ip, resource type and priority
+	SearchFieldRequestHeaders                          // request header names and values
+	SearchFieldQueryParams                             // query parameter names and values
+	SearchFieldCookies                                 // request cookie names and values
+	SearchFieldRequestBody                             // request post data text and params (skipped when SkipRequestBody is set)
+	SearchFieldResponseHeaders                         // response header names and values
+	SearchFieldWebSockets                              // websocket frame payloads (also requires SearchWebSockets)
+	SearchFieldResponseBody                            // response body (also requires SearchResponseBody)
+	SearchFieldResponseCookies                         // response (Set-Cookie) cookie names and values
+	SearchFieldComments                                // entry, request and response comments
+	SearchFieldRedirectURL                             // response redirectURL, where a redirect points
+
+	// SearchFieldAll selects every location; equivalent to leaving Fields unset
+	SearchFieldAll = SearchFieldURL | SearchFieldMetadata | SearchFieldRequestHeaders |
+		SearchFieldQueryParams | SearchFieldCookies | SearchFieldRequestBody |
+		SearchFieldResponseHeaders | SearchFieldWebSockets | SearchFieldResponseBody |
+		SearchFieldResponseCookies | SearchFieldComments | SearchFieldRedirectURL
+)
+
+// indexedSearchFields are matched from the index alone, without reading entries from the file
+const indexedSearchFields = SearchFieldURL | SearchFieldMetadata | SearchFieldRedirectURL
+
+// has returns true if f selects field; an empty mask selects everything
+func (f SearchField) has(field SearchField) bool {
+	return f == 0 || f&field != 0
+}
+
+// searchFieldNames maps the names accepted by ParseSearchField to their fields
+var searchFieldNames = map[string]SearchField{
+	"url":               SearchFieldURL,
+	"metadata":          SearchFieldMetadata,
+	"request.headers":   SearchFieldRequestHeaders,
+	"query.param":       SearchFieldQueryParams,
+	"cookie":            SearchFieldCookies,
+	"request.body":      SearchFieldRequestBody,
+	"response.headers":  SearchFieldResponseHeaders,
+	"response.cookie":   SearchFieldResponseCookies,
+	"websocket.message": SearchFieldWebSockets,
+	"response.body":     SearchFieldResponseBody,
+	"comment":           SearchFieldComments,
+	"redirecturl":       SearchFieldRedirectURL,
 }
 
+// ParseSearchField parses a field name. names follow the categories FieldCategory reports, e.g.
+// "url", "request.headers" or "response.body", plus "metadata" for the method, status text, mime
+// type and server ip.
+func ParseSearchField(name string) (SearchField, error) {
+	field, ok := searchFieldNames[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return 0, fmt.Errorf("unknown search field: %s (expected url, metadata, request.headers, query.param, "+
+			"cookie, request.body, response.headers, response.cookie, websocket.message, response.body, comment or redirectURL)", name)
+	}
+	return field, nil
+}
+
+// MaxSearchWorkers caps SearchOptions.WorkerCount; searches are bound by disk reads well before this
+const MaxSearchWorkers = 64
+
 // DefaultSearchOptions provides sensible defaults
 var DefaultSearchOptions = SearchOptions{
 	Mode:               PlainText,
@@ -29,17 +123,26 @@ var DefaultSearchOptions = SearchOptions{
 
 // SearchResult represents a single match
 type SearchResult struct {
-	Index int    // entry index in har file
-	Field string // which field matched: "url", "request.body", "response.headers.content-type"
-	Error error  // non-fatal error reading this entry (search continues)
+	Index    int      // entry index in har file
+	Field    string   // which field matched: "url", "request.body", "response.headers.content-type"
+	Offset   int      // byte offset of the match within the matched value
+	Snippet  string   // the match with surrounding context from the matched value
+	Distance int      // fuzzy mode: edits between the pattern and the match (0 = exact), for ranking
+	Score    int      // relevance: exact > prefix > substring match, indexed fields over bodies, earlier offsets first
+	Captures []string // Extract only: the capture group of each match in the value, in order
+	Error    error    // non-fatal error reading this entry (search continues)
 }
 
 // SearchStats tracks search performance metrics
 type SearchStats struct {
-	EntriesSearched int64         // total entries processed
-	MatchesFound    int64         // total matches found
-	BytesSearched   int64         // total bytes read from disk
-	SearchDuration  time.Duration // total search time
+	EntriesSearched int64            // total entries processed
+	MatchesFound    int64            // total matches found
+	BytesSearched   int64            // total bytes read from disk
+	SearchDuration  time.Duration    // total search time
+	MatchesByField  map[string]int64 // matches per field category: "url", "request.headers", "response.body", ...
+	Truncated       bool             // search stopped at MaxResults; more matches may exist
+	WorkerCount     int              // workers the search actually ran, after clamping
+	ChunkSize       int              // entries per work batch actually used, after auto-partitioning
 }
 
 // searchAtomicStats holds search statistics with atomic operations
@@ -48,6 +151,12 @@ type searchAtomicStats struct {
 	matchesFound    int64
 	bytesSearched   int64
 	searchDuration  int64 // nanoseconds
+	truncated       int32 // 1 once MaxResults stopped the search
+	workerCount     int64
+	chunkSize       int64
+
+	fieldMu        sync.Mutex
+	matchesByField map[string]int64
 }
 
 // HARSearcher provides efficient search across har entries
@@ -74,41 +183,92 @@ func NewSearcher(streamer HARStreamer, reader EntryReader) *HARSearcher {
 
 // Search executes a search and streams results via channel
 func (s *HARSearcher) Search(ctx context.Context, pattern string, opts SearchOptions) (<-chan []SearchResult, error) {
-	// set defaults
-	if opts.WorkerCount == 0 {
-		opts.WorkerCount = runtime.NumCPU()
+	compiled, err := s.Compile(pattern, opts)
+	if err != nil {
+		return nil, err
 	}
+	return s.SearchCompiled(ctx, compiled)
+}
 
-	// compile pattern once (not per entry!)
-	compiledPattern, err := compilePattern(pattern, opts)
-	if err != nil {
-		return nil, fmt.Errorf("invalid pattern: %w", err)
+// SearchCompiled runs a query compiled by Compile with its options, streaming results via channel
+func (s *HARSearcher) SearchCompiled(ctx context.Context, compiled *CompiledQuery) (<-chan []SearchResult, error) {
+	// set defaults
+	opts := clampConcurrency(compiled.opts)
+	if opts.Mode == Fuzzy && opts.Fields == 0 {
+		// fuzzy matching is costly, so bodies are only compared when asked for explicitly
+		opts.Fields = fuzzySearchFields
+		if opts.SearchResponseBody {
+			opts.Fields |= SearchFieldResponseBody
+		}
 	}
+	query, compiledPattern := compiled.query, compiled.pattern
 
 	// Reset statistics for this search to avoid cumulative stats across searches
 	atomic.StoreInt64(&s.stats.entriesSearched, 0)
 	atomic.StoreInt64(&s.stats.matchesFound, 0)
 	atomic.StoreInt64(&s.stats.bytesSearched, 0)
 	atomic.StoreInt64(&s.stats.searchDuration, 0)
+	atomic.StoreInt32(&s.stats.truncated, 0)
+	atomic.StoreInt64(&s.stats.workerCount, 0)
+	atomic.StoreInt64(&s.stats.chunkSize, 0)
+	s.stats.fieldMu.Lock()
+	s.stats.matchesByField = make(map[string]int64)
+	s.stats.fieldMu.Unlock()
 
 	// get total entries
 	index := s.streamer.GetIndex()
 	totalEntries := index.TotalEntries
 
-	// handle empty har
-	if totalEntries == 0 {
+	// validate index range and whitelist before doing any work
+	if err := validateSearchRange(opts, totalEntries); err != nil {
+		return nil, err
+	}
+	for _, i := range opts.Indices {
+		if i < 0 || i >= totalEntries {
+			return nil, fmt.Errorf("search index %d out of range [0, %d)", i, totalEntries)
+		}
+	}
+	if opts.Indices != nil && (opts.StartIndex > 0 || opts.EndIndex > 0) {
+		opts.Indices = indicesInRange(opts.Indices, opts, totalEntries)
+	}
+
+	// handle empty har (or an empty whitelist)
+	if totalEntries == 0 || (opts.Indices != nil && len(opts.Indices) == 0) {
 		emptyResults := make(chan []SearchResult)
 		close(emptyResults)
 		return emptyResults, nil
 	}
 
 	// create work batches
-	batches := createWorkBatches(totalEntries, opts)
+	var batches []workBatch
+	if opts.Indices != nil {
+		batches = createIndexBatches(opts.Indices, opts)
+	} else {
+		batches = createWorkBatches(totalEntries, opts)
+	}
+
+	// there is no point running more workers than there are batches
+	if len(batches) > 0 {
+		opts.WorkerCount = min(opts.WorkerCount, len(batches))
+		size := len(batches[0].indices)
+		if opts.Indices == nil {
+			size = batches[0].endIndex - batches[0].startIndex
+		}
+		atomic.StoreInt64(&s.stats.workerCount, int64(opts.WorkerCount))
+		atomic.StoreInt64(&s.stats.chunkSize, int64(size))
+	}
 
 	// create channels
 	workQueue := make(chan workBatch, opts.WorkerCount*2)
 	results := make(chan []SearchResult, opts.WorkerCount)
 
+	// a result limit cancels the remaining batches through a derived context once it is reached
+	ctx, cancel := context.WithCancel(ctx)
+	limiter := newResultLimiter(opts.MaxResults, func() {
+		atomic.StoreInt32(&s.stats.truncated, 1)
+		cancel()
+	})
+
 	// start timer
 	startTime := time.Now()
 
@@ -118,7 +278,7 @@ func (s *HARSearcher) Search(ctx context.Context, pattern string, opts SearchOpt
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			worker(ctx, workQueue, results, s, compiledPattern, opts)
+			worker(ctx, workQueue, results, s, compiledPattern, opts, query, limiter)
 		}()
 	}
 
@@ -139,15 +299,58 @@ func (s *HARSearcher) Search(ctx context.Context, pattern string, opts SearchOpt
 	go func() {
 		wg.Wait()        // wait for all workers to finish
 		close(results)   // signal consumer: no more results
+		cancel()
 
 		// record final stats
 		duration := time.Since(startTime)
 		atomic.StoreInt64(&s.stats.searchDuration, int64(duration))
 	}()
 
+	if opts.SortByRelevance {
+		return rankResults(results), nil
+	}
 	return results, nil
 }
 
+// validateSearchRange checks StartIndex/EndIndex against the number of entries
+func validateSearchRange(opts SearchOptions, totalEntries int) error {
+	if opts.StartIndex < 0 {
+		return fmt.Errorf("start index %d must not be negative", opts.StartIndex)
+	}
+	if opts.EndIndex < 0 {
+		return fmt.Errorf("end index %d must not be negative", opts.EndIndex)
+	}
+	if opts.EndIndex > totalEntries {
+		return fmt.Errorf("end index %d exceeds entry count %d", opts.EndIndex, totalEntries)
+	}
+	start, end := searchRange(opts, totalEntries)
+	if totalEntries > 0 && start >= end {
+		return fmt.Errorf("start index %d must be less than end index %d", start, end)
+	}
+	return nil
+}
+
+// searchRange resolves StartIndex/EndIndex into a half-open range of entries
+func searchRange(opts SearchOptions, totalEntries int) (start, end int) {
+	end = totalEntries
+	if opts.EndIndex > 0 {
+		end = opts.EndIndex
+	}
+	return opts.StartIndex, end
+}
+
+// indicesInRange drops whitelisted indices that fall outside StartIndex/EndIndex
+func indicesInRange(indices []int, opts SearchOptions, totalEntries int) []int {
+	start, end := searchRange(opts, totalEntries)
+	inRange := make([]int, 0, len(indices))
+	for _, i := range indices {
+		if i >= start && i < end {
+			inRange = append(inRange, i)
+		}
+	}
+	return inRange
+}
+
 // Stats returns current search statistics
 func (s *HARSearcher) Stats() SearchStats {
 	return SearchStats{
@@ -155,5 +358,80 @@ func (s *HARSearcher) Stats() SearchStats {
 		MatchesFound:    atomic.LoadInt64(&s.stats.matchesFound),
 		BytesSearched:   atomic.LoadInt64(&s.stats.bytesSearched),
 		SearchDuration:  time.Duration(atomic.LoadInt64(&s.stats.searchDuration)),
+		MatchesByField:  s.stats.fieldCounts(),
+		Truncated:       atomic.LoadInt32(&s.stats.truncated) == 1,
+		WorkerCount:     int(atomic.LoadInt64(&s.stats.workerCount)),
+		ChunkSize:       int(atomic.LoadInt64(&s.stats.chunkSize)),
+	}
+}
+
+// resultLimiter hands out MaxResults match slots across workers
+type resultLimiter struct {
+	mu      sync.Mutex
+	max     int
+	taken   int
+	onLimit func() // called once, after the batch that fills the last slot has been sent
+}
+
+func newResultLimiter(max int, onLimit func()) *resultLimiter {
+	return &resultLimiter{max: max, onLimit: onLimit}
+}
+
+// take claims up to n slots, returning how many were granted and whether the limit is now full
+func (l *resultLimiter) take(n int) (int, bool) {
+	if l == nil || l.max <= 0 {
+		return n, false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	remaining := l.max - l.taken
+	if n > remaining {
+		n = remaining
+	}
+	l.taken += n
+	return n, n > 0 && l.taken == l.max
+}
+
+// stop signals that the limit has been reached
+func (l *resultLimiter) stop() {
+	if l != nil && l.onLimit != nil {
+		l.onLimit()
+	}
+}
+
+// addFieldCounts merges a batch's per-field match counts into the running totals
+func (st *searchAtomicStats) addFieldCounts(counts map[string]int64) {
+	st.fieldMu.Lock()
+	defer st.fieldMu.Unlock()
+	if st.matchesByField == nil {
+		st.matchesByField = make(map[string]int64)
+	}
+	for field, n := range counts {
+		st.matchesByField[field] += n
+	}
+}
+
+// fieldCounts returns a copy of the per-field match counts
+func (st *searchAtomicStats) fieldCounts() map[string]int64 {
+	st.fieldMu.Lock()
+	defer st.fieldMu.Unlock()
+	counts := make(map[string]int64, len(st.matchesByField))
+	for field, n := range st.matchesByField {
+		counts[field] = n
+	}
+	return counts
+}
+
+// FieldCategory reduces a SearchResult.Field to its category by dropping the header,
+// param or cookie name, e.g. "request.headers.content-type" -> "request.headers"
+func FieldCategory(field string) string {
+	for _, prefix := range []string{"request.headers.", "response.headers.", "query.param.", "cookie.", "response.cookie.", "request.postData.",
+		"header.exists.", "header.missing.", "comment."} {
+		if strings.HasPrefix(field, prefix) {
+			return prefix[:len(prefix)-1]
+		}
 	}
+	return field
 }
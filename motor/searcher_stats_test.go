@@ -133,3 +133,61 @@ func TestSearcher_MultipleSequentialSearches(t *testing.T) {
 			"search %d stats should not be cumulative (got %d entries)", i+1, stats.EntriesSearched)
 	}
 }
+
+// TestSearcher_MatchesByFieldSumsToTotal tests that per-field counts account for every match
+func TestSearcher_MatchesByFieldSumsToTotal(t *testing.T) {
+	harFile, cleanup, err := generateSmallHAR()
+	require.Nil(t, err)
+	defer cleanup()
+
+	streamer, err := NewHARStreamer(harFile, DefaultStreamerOptions())
+	require.Nil(t, err)
+
+	err = streamer.Initialize(context.Background())
+	require.Nil(t, err)
+	defer streamer.Close()
+
+	reader, err := NewEntryReader(harFile, streamer.GetIndex())
+	require.Nil(t, err)
+	defer reader.Close()
+
+	searcher := NewSearcher(streamer, reader)
+
+	// all matches across many fields, spread over several workers
+	opts := DefaultSearchOptions
+	opts.FirstMatchOnly = false
+	opts.SearchResponseBody = true
+	opts.WorkerCount = 4
+
+	resultsChan, err := searcher.Search(context.Background(), "a", opts)
+	require.Nil(t, err)
+
+	byField := make(map[string]int64)
+	for batch := range resultsChan {
+		for _, result := range batch {
+			if result.Error == nil {
+				byField[FieldCategory(result.Field)]++
+			}
+		}
+	}
+
+	stats := searcher.Stats()
+	require.Greater(t, stats.MatchesFound, int64(0))
+	assert.Greater(t, len(stats.MatchesByField), 1, "expected matches in more than one field category")
+
+	var sum int64
+	for _, n := range stats.MatchesByField {
+		sum += n
+	}
+	assert.Equal(t, stats.MatchesFound, sum, "per-field counts should sum to total matches")
+	assert.Equal(t, byField, stats.MatchesByField)
+}
+
+func TestFieldCategory(t *testing.T) {
+	assert.Equal(t, "request.headers", FieldCategory("request.headers.Content-Type"))
+	assert.Equal(t, "response.headers", FieldCategory("response.headers.x-id"))
+	assert.Equal(t, "query.param", FieldCategory("query.param.q"))
+	assert.Equal(t, "cookie", FieldCategory("cookie.session"))
+	assert.Equal(t, "url", FieldCategory("url"))
+	assert.Equal(t, "response.body", FieldCategory("response.body"))
+}
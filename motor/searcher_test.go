@@ -2,6 +2,7 @@ package motor
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
 	"time"
@@ -400,6 +401,7 @@ func TestSearch_CustomChunkSize(t *testing.T) {
 
 	opts := DefaultSearchOptions
 	opts.ChunkSize = 25 // custom chunk size
+	opts.WorkerCount = 16
 
 	resultChan, err := searcher.Search(context.Background(), "chunked", opts)
 	require.NoError(t, err)
@@ -409,6 +411,18 @@ func TestSearch_CustomChunkSize(t *testing.T) {
 
 	stats := searcher.Stats()
 	assert.Equal(t, int64(200), stats.EntriesSearched)
+	assert.Equal(t, 25, stats.ChunkSize)
+	assert.Equal(t, 8, stats.WorkerCount, "no more workers than the 8 batches")
+
+	// a negative chunk size falls back to auto-partitioning
+	opts.ChunkSize = -1
+	opts.WorkerCount = 4
+	resultChan, err = searcher.Search(context.Background(), "chunked", opts)
+	require.NoError(t, err)
+	_ = collectResults(resultChan)
+	stats = searcher.Stats()
+	assert.Equal(t, 50, stats.ChunkSize)
+	assert.Equal(t, 4, stats.WorkerCount)
 }
 
 func TestSearch_DeepSearchEnabled(t *testing.T) {
@@ -458,6 +472,126 @@ func TestSearch_DeepSearchEnabled(t *testing.T) {
 	assert.Equal(t, "response.body", results[0].Field)
 }
 
+func TestSearch_RequestBodyToggle(t *testing.T) {
+	result, err := hargen.Generate(hargen.GenerateOptions{
+		EntryCount: 20,
+		InjectionPlan: []hargen.InjectionSpec{
+			{Term: "payloadterm", EntryIndex: 3, Location: hargen.RequestBody},
+			{Term: "blobterm", EntryIndex: 7, Location: hargen.ResponseBody},
+		},
+		Seed: 42,
+	})
+	require.NoError(t, err)
+	defer os.Remove(result.HARFilePath)
+
+	streamer, err := NewHARStreamer(result.HARFilePath, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	reader, err := NewEntryReader(result.HARFilePath, streamer.GetIndex())
+	require.NoError(t, err)
+	defer reader.Close()
+
+	searcher := NewSearcher(streamer, reader)
+	search := func(pattern string, requestBodies, responseBodies bool) []SearchResult {
+		opts := DefaultSearchOptions
+		opts.SkipRequestBody = !requestBodies
+		opts.SearchResponseBody = responseBodies
+		resultChan, err := searcher.Search(context.Background(), pattern, opts)
+		require.NoError(t, err)
+		return collectResults(resultChan)
+	}
+
+	// request bodies are searched by default, by options built from scratch too
+	resultChan, err := searcher.Search(context.Background(), "payloadterm", SearchOptions{})
+	require.NoError(t, err)
+	results := collectResults(resultChan)
+	require.Len(t, results, 1)
+	assert.Equal(t, "request.body", results[0].Field)
+	assert.Equal(t, 3, results[0].Index)
+
+	results = search("payloadterm", true, false)
+	require.Len(t, results, 1)
+	assert.Equal(t, "request.body", results[0].Field)
+
+	assert.Empty(t, search("payloadterm", false, true), "request bodies should be skipped when disabled")
+
+	// response bodies only, the inverse
+	results = search("blobterm", false, true)
+	require.Len(t, results, 1)
+	assert.Equal(t, "response.body", results[0].Field)
+
+	// each flag on its own, set on options built from scratch
+	bare := func(pattern string, opts SearchOptions) []SearchResult {
+		resultChan, err := searcher.Search(context.Background(), pattern, opts)
+		require.NoError(t, err)
+		return collectResults(resultChan)
+	}
+	assert.Empty(t, bare("payloadterm", SearchOptions{SkipRequestBody: true}))
+	assert.Empty(t, bare("blobterm", SearchOptions{}), "response bodies are not searched by default")
+	results = bare("blobterm", SearchOptions{SearchResponseBody: true})
+	require.Len(t, results, 1)
+	assert.Equal(t, "response.body", results[0].Field)
+	results = bare("blobterm", SearchOptions{SearchResponseBody: true, SkipRequestBody: true})
+	require.Len(t, results, 1, "skipping request bodies leaves response bodies searched")
+	assert.Equal(t, "response.body", results[0].Field)
+	results = bare("payloadterm", SearchOptions{SearchResponseBody: true})
+	require.Len(t, results, 1)
+	assert.Equal(t, "request.body", results[0].Field)
+}
+
+func TestSearchCompiled(t *testing.T) {
+	result, err := hargen.Generate(hargen.GenerateOptions{
+		EntryCount: 20,
+		InjectionPlan: []hargen.InjectionSpec{
+			{Term: "blobterm", EntryIndex: 7, Location: hargen.ResponseBody},
+		},
+		Seed: 42,
+	})
+	require.NoError(t, err)
+	defer os.Remove(result.HARFilePath)
+
+	streamer, err := NewHARStreamer(result.HARFilePath, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	reader, err := NewEntryReader(result.HARFilePath, streamer.GetIndex())
+	require.NoError(t, err)
+	defer reader.Close()
+	searcher := NewSearcher(streamer, reader)
+
+	opts := DefaultSearchOptions
+	opts.Mode = Regex
+	compiled, err := searcher.Compile("blob[a-z]+", opts)
+	require.NoError(t, err)
+	assert.Equal(t, "blob[a-z]+", compiled.Pattern())
+
+	// bodies are not searched as compiled, but are once the options change
+	resultChan, err := searcher.SearchCompiled(context.Background(), compiled)
+	require.NoError(t, err)
+	assert.Empty(t, collectResults(resultChan))
+
+	withBodies := opts
+	withBodies.SearchResponseBody = true
+	require.True(t, compiled.CompiledFor("blob[a-z]+", withBodies), "bodies do not change the compiled pattern")
+	resultChan, err = searcher.SearchCompiled(context.Background(), compiled.WithOptions(withBodies))
+	require.NoError(t, err)
+	results := collectResults(resultChan)
+	require.Len(t, results, 1)
+	assert.Equal(t, 7, results[0].Index)
+
+	ignoreCase := opts
+	ignoreCase.CaseInsensitive = true
+	assert.False(t, compiled.CompiledFor("blob[a-z]+", ignoreCase), "case folding is part of the compiled pattern")
+	assert.False(t, compiled.CompiledFor("blob", opts))
+
+	_, err = searcher.Compile("[", opts)
+	assert.ErrorContains(t, err, "invalid pattern")
+	assert.Error(t, ValidatePattern("[", opts))
+}
+
 func TestSearch_NoMatches(t *testing.T) {
 	result, err := hargen.Generate(hargen.GenerateOptions{
 		EntryCount: 30,
@@ -568,6 +702,201 @@ func TestSearch_MultipleInjectionLocations(t *testing.T) {
 	assert.Len(t, results, 1, "should find the single injected term")
 }
 
+func TestSearch_RestrictedIndices(t *testing.T) {
+	result, err := hargen.Generate(hargen.GenerateOptions{
+		EntryCount:  40,
+		InjectTerms: []string{"whitelisted"},
+		InjectionLocations: []hargen.InjectionLocation{
+			hargen.URL,
+		},
+		Seed: 42,
+	})
+	require.NoError(t, err)
+	defer os.Remove(result.HARFilePath)
+	require.Len(t, result.InjectedTerms, 1)
+	injectedIndex := result.InjectedTerms[0].EntryIndex
+
+	streamer, err := NewHARStreamer(result.HARFilePath, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+
+	err = streamer.Initialize(context.Background())
+	require.NoError(t, err)
+
+	index := streamer.GetIndex()
+	reader, err := NewEntryReader(result.HARFilePath, index)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	searcher := NewSearcher(streamer, reader)
+
+	// whitelist every other entry, excluding the injected one
+	var excluded []int
+	for i := 0; i < 40; i += 2 {
+		if i != injectedIndex {
+			excluded = append(excluded, i)
+		}
+	}
+
+	opts := DefaultSearchOptions
+	opts.Indices = excluded
+
+	resultChan, err := searcher.Search(context.Background(), "whitelisted", opts)
+	require.NoError(t, err)
+
+	results := collectResults(resultChan)
+	assert.Empty(t, results, "injected entry is outside the whitelist")
+	assert.Equal(t, int64(len(excluded)), searcher.Stats().EntriesSearched)
+
+	// whitelist including the injected entry
+	opts.Indices = []int{injectedIndex, (injectedIndex + 1) % 40, (injectedIndex + 2) % 40}
+
+	resultChan, err = searcher.Search(context.Background(), "whitelisted", opts)
+	require.NoError(t, err)
+
+	results = collectResults(resultChan)
+	require.Len(t, results, 1)
+	assert.Equal(t, injectedIndex, results[0].Index)
+	assert.Equal(t, int64(3), searcher.Stats().EntriesSearched)
+
+	// empty whitelist searches nothing
+	opts.Indices = []int{}
+	resultChan, err = searcher.Search(context.Background(), "whitelisted", opts)
+	require.NoError(t, err)
+	assert.Empty(t, collectResults(resultChan))
+
+	// out-of-range indices are rejected up front
+	opts.Indices = []int{0, 40}
+	_, err = searcher.Search(context.Background(), "whitelisted", opts)
+	assert.Error(t, err)
+}
+
+func TestSearch_IndexRange(t *testing.T) {
+	result, err := hargen.Generate(hargen.GenerateOptions{
+		EntryCount:  100,
+		InjectTerms: []string{"rangeterm"},
+		InjectionLocations: []hargen.InjectionLocation{
+			hargen.URL,
+		},
+		Seed: 42,
+	})
+	require.NoError(t, err)
+	defer os.Remove(result.HARFilePath)
+	require.Len(t, result.InjectedTerms, 1)
+	injectedIndex := result.InjectedTerms[0].EntryIndex
+
+	streamer, err := NewHARStreamer(result.HARFilePath, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+
+	err = streamer.Initialize(context.Background())
+	require.NoError(t, err)
+
+	index := streamer.GetIndex()
+	reader, err := NewEntryReader(result.HARFilePath, index)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	searcher := NewSearcher(streamer, reader)
+
+	// range that excludes the injected entry
+	opts := DefaultSearchOptions
+	if injectedIndex < 50 {
+		opts.StartIndex, opts.EndIndex = 50, 100
+	} else {
+		opts.StartIndex, opts.EndIndex = 0, 50
+	}
+
+	resultChan, err := searcher.Search(context.Background(), "rangeterm", opts)
+	require.NoError(t, err)
+	assert.Empty(t, collectResults(resultChan), "match outside the range must not be returned")
+	assert.Equal(t, int64(50), searcher.Stats().EntriesSearched)
+
+	// range that covers only the injected entry
+	opts.StartIndex, opts.EndIndex = injectedIndex, injectedIndex+1
+
+	resultChan, err = searcher.Search(context.Background(), "rangeterm", opts)
+	require.NoError(t, err)
+	results := collectResults(resultChan)
+	require.Len(t, results, 1)
+	assert.Equal(t, injectedIndex, results[0].Index)
+	assert.Equal(t, int64(1), searcher.Stats().EntriesSearched)
+
+	// invalid bounds
+	for _, bad := range []SearchOptions{
+		{StartIndex: -1},
+		{EndIndex: 101},
+		{StartIndex: 60, EndIndex: 40},
+		{StartIndex: 100},
+	} {
+		_, err := searcher.Search(context.Background(), "rangeterm", bad)
+		assert.Error(t, err, "start=%d end=%d", bad.StartIndex, bad.EndIndex)
+	}
+}
+
+func TestSearch_MaxResults(t *testing.T) {
+	harFile, cleanup, err := generateTestHAR(200, 42)
+	require.NoError(t, err)
+	defer cleanup()
+
+	streamer, err := NewHARStreamer(harFile, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+
+	err = streamer.Initialize(context.Background())
+	require.NoError(t, err)
+
+	reader, err := NewEntryReader(harFile, streamer.GetIndex())
+	require.NoError(t, err)
+	defer reader.Close()
+
+	searcher := NewSearcher(streamer, reader)
+
+	// every generated url matches, so the limit is always hit
+	for _, workers := range []int{1, 4} {
+		opts := DefaultSearchOptions
+		opts.WorkerCount = workers
+		opts.ChunkSize = 7
+		opts.MaxResults = 25
+
+		resultChan, err := searcher.Search(context.Background(), "http", opts)
+		require.NoError(t, err)
+
+		results := collectResults(resultChan)
+		assert.Len(t, results, 25, "workers=%d", workers)
+
+		stats := searcher.Stats()
+		assert.Equal(t, int64(25), stats.MatchesFound)
+		assert.True(t, stats.Truncated)
+		assert.Less(t, stats.EntriesSearched, int64(200), "remaining batches should be cancelled")
+	}
+
+	// zero means unlimited
+	opts := DefaultSearchOptions
+	resultChan, err := searcher.Search(context.Background(), "http", opts)
+	require.NoError(t, err)
+	assert.Len(t, collectResults(resultChan), 200)
+	assert.False(t, searcher.Stats().Truncated)
+}
+
+func TestLimitResults(t *testing.T) {
+	readErr := errors.New("read failed")
+	batch := []SearchResult{
+		{Index: 0, Field: "url"},
+		{Index: 1, Error: readErr},
+		{Index: 2, Field: "url"},
+		{Index: 3, Field: "url"},
+	}
+
+	kept := limitResults(batch, 2)
+	require.Len(t, kept, 3)
+	assert.Equal(t, 0, kept[0].Index)
+	assert.Equal(t, readErr, kept[1].Error)
+	assert.Equal(t, 2, kept[2].Index)
+
+	assert.Empty(t, limitResults([]SearchResult{{Index: 0}}, 0))
+}
+
 // collectResults is a helper to collect all search results from a channel
 func collectResults(ch <-chan []SearchResult) []SearchResult {
 	var all []SearchResult
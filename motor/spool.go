@@ -0,0 +1,45 @@
+package motor
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// a HAR that arrives on a pipe or socket can't be read by offset, so it is copied to a temporary
+// file first. The index and reader then work on the copy exactly as they would on a file given by
+// path, and the copy is removed when the streamer is closed.
+
+// seekableFile returns the path of r if it is a regular file that can be opened again by name
+func seekableFile(r io.Reader) (string, bool) {
+	file, ok := r.(*os.File)
+	if !ok {
+		return "", false
+	}
+	info, err := file.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		return "", false
+	}
+	return file.Name(), true
+}
+
+// spoolToTemp copies r to a new temporary file and returns its path
+func spoolToTemp(r io.Reader) (string, error) {
+	dst, err := os.CreateTemp("", "harific-stream-*.har")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+
+	if _, err := io.Copy(dst, r); err != nil {
+		dst.Close()
+		os.Remove(dst.Name())
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+
+	if err := dst.Close(); err != nil {
+		os.Remove(dst.Name())
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	return dst.Name(), nil
+}
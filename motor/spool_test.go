@@ -0,0 +1,72 @@
+package motor
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHARStreamerFromReader_Spools(t *testing.T) {
+	harFile, cleanup, err := generateSmallHAR()
+	require.NoError(t, err)
+	defer cleanup()
+
+	data, err := os.ReadFile(harFile)
+	require.NoError(t, err)
+
+	// a bytes.Reader stands in for a pipe: it can't be reopened by name
+	streamer, err := NewHARStreamerFromReader(bytes.NewReader(data), DefaultStreamerOptions())
+	require.NoError(t, err)
+	spoolPath := streamer.spoolPath
+	require.NotEmpty(t, spoolPath)
+	assert.False(t, streamer.options.UseIndexSidecar, "no sidecar for a spooled copy")
+
+	require.NoError(t, streamer.Initialize(context.Background()))
+	assert.Equal(t, 67, streamer.GetIndex().TotalEntries)
+
+	entry, err := streamer.GetEntry(context.Background(), 66)
+	require.NoError(t, err)
+	assert.NotEmpty(t, entry.Request.URL)
+
+	require.NoError(t, streamer.Close())
+	_, err = os.Stat(spoolPath)
+	assert.True(t, os.IsNotExist(err), "spooled copy removed on close")
+}
+
+func TestNewHARStreamerFromReader_RegularFile(t *testing.T) {
+	harFile, cleanup, err := generateSmallHAR()
+	require.NoError(t, err)
+	defer cleanup()
+
+	file, err := os.Open(harFile)
+	require.NoError(t, err)
+	defer file.Close()
+
+	streamer, err := NewHARStreamerFromReader(file, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+
+	assert.Empty(t, streamer.spoolPath, "a regular file is read in place")
+	assert.Equal(t, harFile, streamer.filePath)
+}
+
+func TestNewHARStreamerFromReader_Gzip(t *testing.T) {
+	harFile, cleanup, err := generateSmallHAR()
+	require.NoError(t, err)
+	defer cleanup()
+
+	gzPath := gzipFile(t, harFile)
+	data, err := os.ReadFile(gzPath)
+	require.NoError(t, err)
+
+	streamer, err := NewHARStreamerFromReader(bytes.NewReader(data), DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+
+	require.NoError(t, streamer.Initialize(context.Background()))
+	assert.Equal(t, 67, streamer.GetIndex().TotalEntries)
+}
@@ -0,0 +1,186 @@
+package motor
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// DefaultStatsTopN is the number of slowest and largest entries Index.Stats keeps by default
+const DefaultStatsTopN = 10
+
+// IndexStats summarizes a har file from its index alone; no entry is read from disk
+type IndexStats struct {
+	Version            string        `json:"version"` // log.version, DefaultHARVersion when the log has none
+	TotalEntries       int           `json:"totalEntries"`
+	UniqueURLs         int           `json:"uniqueUrls"`
+	FileSize           int64         `json:"fileSize"`
+	TotalRequestBytes  int64         `json:"totalRequestBytes"`
+	TotalResponseBytes int64         `json:"totalResponseBytes"`
+	TotalBodyBytes     int64         `json:"totalBodyBytes"`
+	TotalDuration      float64       `json:"totalDuration"` // milliseconds, over entries with a known time
+	WallClock          float64       `json:"wallClock"`     // milliseconds from the first start to the last end, see WallClock
+	Start              time.Time     `json:"start"`
+	End                time.Time     `json:"end"`
+	BadTimestamps      int           `json:"badTimestamps"` // entries left out of Start/End
+	StatusCodes        []CountBucket `json:"statusCodes"`   // ascending by status code
+	Methods            []CountBucket `json:"methods"`       // most common first
+	MimeTypes          []CountBucket `json:"mimeTypes"`     // most common first
+	Slowest            []RankedEntry `json:"slowest"`       // longest Duration first
+	Largest            []RankedEntry `json:"largest"`       // biggest BodySize first
+}
+
+// CountBucket is the number of entries sharing a status code, method or mime type
+type CountBucket struct {
+	Key   string `json:"key"`
+	Count int    `json:"count"`
+}
+
+// RankedEntry is an entry picked for a top N list, with its index in the har
+type RankedEntry struct {
+	Index    int     `json:"index"`
+	Method   string  `json:"method"`
+	URL      string  `json:"url"`
+	Status   int     `json:"status"`
+	Duration float64 `json:"duration"`
+	BodySize int64   `json:"bodySize"`
+}
+
+// Stats aggregates the index into histograms and top N lists (topN <= 0 uses DefaultStatsTopN).
+// entries without a mime type are counted under "(none)".
+func (idx *Index) Stats(topN int) *IndexStats {
+	stats := &IndexStats{
+		Version:            idx.Version,
+		TotalEntries:       len(idx.Entries),
+		UniqueURLs:         idx.UniqueURLs,
+		FileSize:           idx.FileSize,
+		TotalRequestBytes:  idx.TotalRequestBytes,
+		TotalResponseBytes: idx.TotalResponseBytes,
+		Start:              idx.TimeRange.Start,
+		End:                idx.TimeRange.End,
+		BadTimestamps:      idx.BadTimestamps,
+	}
+
+	indices := make([]int, len(idx.Entries))
+	for i := range indices {
+		indices[i] = i
+	}
+	idx.aggregate(stats, indices, topN)
+	return stats
+}
+
+// StatsFor aggregates only the entries at indices, e.g. the rows a filter left visible. the
+// totals, unique urls and time range cover just those entries, and FileSize is left at zero.
+// out of range indices are ignored.
+func (idx *Index) StatsFor(indices []int, topN int) *IndexStats {
+	selected := make([]int, 0, len(indices))
+	for _, i := range indices {
+		if i >= 0 && i < len(idx.Entries) {
+			selected = append(selected, i)
+		}
+	}
+
+	stats := &IndexStats{TotalEntries: len(selected)}
+	urls := make(map[string]struct{}, len(selected))
+	for _, i := range selected {
+		entry := idx.Entries[i]
+		urls[entry.URL] = struct{}{}
+		stats.TotalRequestBytes += entry.RequestSize
+		stats.TotalResponseBytes += entry.ResponseSize
+		if entry.Timestamp.IsZero() {
+			stats.BadTimestamps++
+			continue
+		}
+		if stats.Start.IsZero() || entry.Timestamp.Before(stats.Start) {
+			stats.Start = entry.Timestamp
+		}
+		if entry.Timestamp.After(stats.End) {
+			stats.End = entry.Timestamp
+		}
+	}
+	stats.UniqueURLs = len(urls)
+
+	idx.aggregate(stats, selected, topN)
+	return stats
+}
+
+// aggregate fills in the histograms, body and duration totals, wall clock and top N lists for indices
+func (idx *Index) aggregate(stats *IndexStats, indices []int, topN int) {
+	if topN <= 0 {
+		topN = DefaultStatsTopN
+	}
+
+	statuses := make(map[int]int)
+	methods := make(map[string]int)
+	mimeTypes := make(map[string]int)
+	for _, i := range indices {
+		entry := idx.Entries[i]
+		statuses[entry.StatusCode]++
+		methods[entry.Method]++
+		mimeType := entry.MimeType
+		if mimeType == "" {
+			mimeType = "(none)"
+		}
+		mimeTypes[mimeType]++
+		if entry.BodySize > 0 {
+			stats.TotalBodyBytes += entry.BodySize
+		}
+		if entry.Duration > 0 {
+			stats.TotalDuration += entry.Duration
+		}
+	}
+
+	codes := make([]int, 0, len(statuses))
+	for code := range statuses {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	for _, code := range codes {
+		stats.StatusCodes = append(stats.StatusCodes, CountBucket{Key: strconv.Itoa(code), Count: statuses[code]})
+	}
+
+	stats.WallClock = float64(WallClock(idx.timelineOf(indices))) / float64(time.Millisecond)
+
+	stats.Methods = sortedBuckets(methods)
+	stats.MimeTypes = sortedBuckets(mimeTypes)
+	stats.Slowest = idx.topEntries(indices, topN, func(a, b *EntryMetadata) int { return cmp.Compare(b.Duration, a.Duration) })
+	stats.Largest = idx.topEntries(indices, topN, func(a, b *EntryMetadata) int { return cmp.Compare(b.BodySize, a.BodySize) })
+}
+
+// sortedBuckets orders counts most common first, breaking ties by key
+func sortedBuckets(counts map[string]int) []CountBucket {
+	buckets := make([]CountBucket, 0, len(counts))
+	for key, count := range counts {
+		buckets = append(buckets, CountBucket{Key: key, Count: count})
+	}
+	slices.SortFunc(buckets, func(a, b CountBucket) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return buckets
+}
+
+// topEntries returns the first n of indices under order; ties keep the order of indices
+func (idx *Index) topEntries(indices []int, n int, order func(a, b *EntryMetadata) int) []RankedEntry {
+	indices = slices.Clone(indices)
+	slices.SortStableFunc(indices, func(a, b int) int {
+		return order(idx.Entries[a], idx.Entries[b])
+	})
+
+	ranked := make([]RankedEntry, 0, min(n, len(indices)))
+	for _, i := range indices[:min(n, len(indices))] {
+		entry := idx.Entries[i]
+		ranked = append(ranked, RankedEntry{
+			Index:    i,
+			Method:   entry.Method,
+			URL:      entry.URL,
+			Status:   entry.StatusCode,
+			Duration: entry.Duration,
+			BodySize: entry.BodySize,
+		})
+	}
+	return ranked
+}
@@ -0,0 +1,92 @@
+package motor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexStats(t *testing.T) {
+	idx := &Index{
+		Entries: []*EntryMetadata{
+			{Method: "GET", URL: "https://example.com/a", StatusCode: 200, MimeType: "application/json", Duration: 50, BodySize: 300},
+			{Method: "POST", URL: "https://example.com/b", StatusCode: 500, MimeType: "text/html", Duration: 900, BodySize: 100},
+			{Method: "GET", URL: "https://example.com/c", StatusCode: 200, MimeType: "application/json", Duration: 50, BodySize: -1},
+			{Method: "GET", URL: "https://example.com/d", StatusCode: 404, Duration: 10, BodySize: 2000},
+		},
+		UniqueURLs:         4,
+		TotalRequestBytes:  1000,
+		TotalResponseBytes: 5000,
+	}
+
+	stats := idx.Stats(2)
+
+	assert.Equal(t, 4, stats.TotalEntries)
+	assert.Equal(t, 4, stats.UniqueURLs)
+	assert.Equal(t, int64(2400), stats.TotalBodyBytes, "unknown body sizes (-1) are not counted")
+	assert.Equal(t, []CountBucket{{"200", 2}, {"404", 1}, {"500", 1}}, stats.StatusCodes)
+	assert.Equal(t, []CountBucket{{"GET", 3}, {"POST", 1}}, stats.Methods)
+	assert.Equal(t, []CountBucket{{"application/json", 2}, {"(none)", 1}, {"text/html", 1}}, stats.MimeTypes)
+
+	require.Len(t, stats.Slowest, 2)
+	assert.Equal(t, 1, stats.Slowest[0].Index)
+	assert.Equal(t, 0, stats.Slowest[1].Index, "ties keep file order")
+
+	require.Len(t, stats.Largest, 2)
+	assert.Equal(t, 3, stats.Largest[0].Index)
+	assert.Equal(t, int64(2000), stats.Largest[0].BodySize)
+	assert.Equal(t, 0, stats.Largest[1].Index)
+}
+
+func TestIndexStats_DefaultTopN(t *testing.T) {
+	idx := &Index{}
+	for i := 0; i < DefaultStatsTopN+5; i++ {
+		idx.Entries = append(idx.Entries, &EntryMetadata{Method: "GET", Duration: float64(i)})
+	}
+
+	stats := idx.Stats(0)
+	assert.Len(t, stats.Slowest, DefaultStatsTopN)
+	assert.Equal(t, DefaultStatsTopN+4, stats.Slowest[0].Index)
+
+	stats = (&Index{}).Stats(5)
+	assert.Empty(t, stats.Slowest)
+	assert.Empty(t, stats.StatusCodes)
+}
+
+func TestIndexStatsFor(t *testing.T) {
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	idx := &Index{
+		Entries: []*EntryMetadata{
+			{Method: "GET", URL: "https://example.com/a", StatusCode: 200, Duration: 50, BodySize: 300, RequestSize: 10, Timestamp: start},
+			{Method: "POST", URL: "https://example.com/b", StatusCode: 500, Duration: 900, BodySize: 100, RequestSize: 20, Timestamp: start.Add(time.Second)},
+			{Method: "GET", URL: "https://example.com/a", StatusCode: 200, Duration: 70, BodySize: 2000, RequestSize: 30, Timestamp: start.Add(5 * time.Second)},
+			{Method: "GET", URL: "https://example.com/c", StatusCode: 404, Duration: -1, BodySize: -1},
+		},
+		UniqueURLs: 3,
+		FileSize:   4096,
+	}
+
+	stats := idx.StatsFor([]int{0, 2, 3, 99}, 1)
+
+	assert.Equal(t, 3, stats.TotalEntries, "out of range indices are ignored")
+	assert.Equal(t, 2, stats.UniqueURLs)
+	assert.Equal(t, int64(0), stats.FileSize)
+	assert.Equal(t, int64(40), stats.TotalRequestBytes)
+	assert.Equal(t, int64(2300), stats.TotalBodyBytes)
+	assert.Equal(t, 120.0, stats.TotalDuration, "unknown durations (-1) are not counted")
+	assert.Equal(t, 5070.0, stats.WallClock)
+	assert.Equal(t, start, stats.Start)
+	assert.Equal(t, start.Add(5*time.Second), stats.End)
+	assert.Equal(t, 1, stats.BadTimestamps)
+	assert.Equal(t, []CountBucket{{"200", 2}, {"404", 1}}, stats.StatusCodes)
+	assert.Equal(t, []CountBucket{{"GET", 3}}, stats.Methods)
+
+	require.Len(t, stats.Slowest, 1)
+	assert.Equal(t, 2, stats.Slowest[0].Index, "ranked entries keep their index in the har")
+	require.Len(t, stats.Largest, 1)
+	assert.Equal(t, 2, stats.Largest[0].Index)
+
+	assert.Zero(t, idx.StatsFor(nil, 5).TotalEntries)
+}
@@ -3,7 +3,9 @@ package motor
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -12,12 +14,19 @@ import (
 )
 
 type DefaultHARStreamer struct {
-	filePath string
-	options  StreamerOptions
-	index    *Index
-	reader   *DefaultEntryReader
-	cache    Cache
-	stats    atomicStats
+	filePath  string
+	options   StreamerOptions
+	index     *Index
+	reader    *DefaultEntryReader
+	cache     Cache
+	stats     atomicStats
+	started   time.Time       // when the streamer was created, the base of the rates in Stats
+	external  *ExternalBodies // response bodies stored in files of their own, nil unless ExternalBodyDir is set
+	tempPath  string          // decompressed copy of a gzip HAR, removed on Close
+	spoolPath string          // copy of a HAR read from a non-seekable reader, removed on Close
+
+	mu     sync.RWMutex // held by Follow while it extends or replaces the index and reader
+	follow followState
 }
 
 type atomicStats struct {
@@ -31,13 +40,57 @@ type atomicStats struct {
 }
 
 func NewHARStreamer(filePath string, options StreamerOptions) (*DefaultHARStreamer, error) {
+	if options.MaxEntrySize < 0 {
+		return nil, fmt.Errorf("max entry size must be positive, got %d", options.MaxEntrySize)
+	}
+	if options.StartEntry < 0 || options.MaxEntries < 0 {
+		return nil, fmt.Errorf("entry window must not be negative, got start %d and max %d", options.StartEntry, options.MaxEntries)
+	}
+	if options.Follow && (options.StartEntry > 0 || options.MaxEntries > 0) {
+		return nil, fmt.Errorf("a followed har cannot be indexed in a window")
+	}
+
 	streamer := &DefaultHARStreamer{
 		filePath: filePath,
 		options:  options,
+		started:  time.Now(),
+	}
+
+	if options.EnableCache {
+		streamer.cache = NewLRUCache(options.CacheSize)
+	}
+
+	return streamer, nil
+}
+
+// NewHARStreamerFromReader creates a streamer for a har read from r, such as stdin. a regular
+// file is used in place; anything else is read to EOF into a temporary file, which then behaves
+// like any other har and is removed on Close. gzip input is detected as usual. no index sidecar
+// is written for a spooled copy, since nothing could ever find it again.
+func NewHARStreamerFromReader(r io.Reader, options StreamerOptions) (*DefaultHARStreamer, error) {
+	if path, ok := seekableFile(r); ok {
+		return NewHARStreamer(path, options)
 	}
 
-	// cache not implemented yet - EnableCache option reserved for future use
+	if options.MaxEntrySize < 0 {
+		return nil, fmt.Errorf("max entry size must be positive, got %d", options.MaxEntrySize)
+	}
+	if options.Follow {
+		return nil, fmt.Errorf("only a har file on disk can be followed")
+	}
+
+	spoolPath, err := spoolToTemp(r)
+	if err != nil {
+		return nil, err
+	}
 
+	options.UseIndexSidecar = false
+	streamer, err := NewHARStreamer(spoolPath, options)
+	if err != nil {
+		os.Remove(spoolPath)
+		return nil, err
+	}
+	streamer.spoolPath = spoolPath
 	return streamer, nil
 }
 
@@ -68,7 +121,38 @@ func (s *DefaultHARStreamer) InitializeWithProgress(ctx context.Context, progres
 	default:
 	}
 
-	file, err := os.Open(s.filePath)
+	if s.options.ExternalBodyDir != "" {
+		// references are relative to the har; a spooled copy has no directory of its own
+		harDir := filepath.Dir(s.filePath)
+		if s.spoolPath != "" {
+			harDir = s.options.ExternalBodyDir
+		}
+		external, err := NewExternalBodies(harDir, s.options.ExternalBodyDir)
+		if err != nil {
+			return err
+		}
+		s.external = external
+	}
+
+	// gzip files are decompressed once; all offsets then refer to the decompressed copy
+	dataPath := s.filePath
+	gzipped, err := IsGzipFile(s.filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	if gzipped && s.options.Follow {
+		return fmt.Errorf("a gzip har cannot be followed")
+	}
+	if gzipped {
+		tempPath, err := decompressToTemp(s.filePath)
+		if err != nil {
+			return err
+		}
+		s.tempPath = tempPath
+		dataPath = tempPath
+	}
+
+	file, err := os.Open(dataPath)
 	if err != nil {
 		return fmt.Errorf("failed to open file: %w", err)
 	}
@@ -81,12 +165,34 @@ func (s *DefaultHARStreamer) InitializeWithProgress(ctx context.Context, progres
 	}
 	fileSize := fileInfo.Size()
 
-	builder := NewIndexBuilder(s.filePath)
-	// BuildWithProgress will ALWAYS close the channel (via defer), even on error
-	channelNeedsClosing = false // BuildWithProgress takes ownership
-	index, err := builder.BuildWithProgress(file, fileSize, progressChan)
-	if err != nil {
-		return fmt.Errorf("failed to build index: %w", err)
+	// a valid sidecar skips the full parse; stale or corrupt ones are silently rebuilt
+	index, fromSidecar := (*Index)(nil), false
+	windowed := s.options.StartEntry > 0 || s.options.MaxEntries > 0
+	useSidecar := s.options.UseIndexSidecar && !s.options.Follow && !windowed
+	if useSidecar {
+		index, fromSidecar = loadSidecar(SidecarPath(s.filePath), dataPath, fileSize, s.options.IndexWebSockets, s.options.SkipBadEntries)
+	}
+
+	if !fromSidecar {
+		builder := s.newBuilder(dataPath)
+		builder.partial = s.options.Follow
+		builder.skipBadEntries = s.options.SkipBadEntries
+		builder.startEntry = s.options.StartEntry
+		builder.maxEntries = s.options.MaxEntries
+		// BuildWithProgress will ALWAYS close the channel (via defer), even on error
+		channelNeedsClosing = false // BuildWithProgress takes ownership
+		index, err = builder.BuildWithProgress(file, fileSize, progressChan)
+		if err != nil {
+			return fmt.Errorf("failed to build index: %w", err)
+		}
+
+		if useSidecar {
+			// best effort - a read-only directory just means no sidecar next time
+			_ = index.Save(SidecarPath(s.filePath))
+		}
+	} else if s.options.DisableInterning {
+		// loading re-shared the sidecar's strings; only the table is let go
+		index.disableInterning()
 	}
 
 	select {
@@ -97,7 +203,7 @@ func (s *DefaultHARStreamer) InitializeWithProgress(ctx context.Context, progres
 
 	s.index = index
 
-	reader, err := NewEntryReader(s.filePath, s.index)
+	reader, err := s.newReader(dataPath, s.index)
 	if err != nil {
 		// Note: channel was already closed by BuildWithProgress
 		return fmt.Errorf("failed to create reader: %w", err)
@@ -105,10 +211,48 @@ func (s *DefaultHARStreamer) InitializeWithProgress(ctx context.Context, progres
 
 	s.reader = reader
 
+	if s.options.Follow {
+		return s.follow.mark(file, fileInfo, index)
+	}
 	return nil
 }
 
+// newBuilder creates an index builder with the streamer's websocket, interning and decoder options
+func (s *DefaultHARStreamer) newBuilder(dataPath string) *DefaultIndexBuilder {
+	builder := NewIndexBuilder(dataPath)
+	builder.indexWebSockets = s.options.IndexWebSockets
+	builder.decoders = s.options.Decoder
+	if s.options.DisableInterning {
+		builder.index.disableInterning()
+	}
+	return builder
+}
+
+// newReader creates a reader over index with the streamer's entry size cap, body files and decoder
+func (s *DefaultHARStreamer) newReader(dataPath string, index *Index) (*DefaultEntryReader, error) {
+	reader, err := NewEntryReader(dataPath, index)
+	if err != nil {
+		return nil, err
+	}
+	if s.options.MaxEntrySize > 0 {
+		reader.maxEntry = s.options.MaxEntrySize
+	}
+	reader.external = s.external
+	reader.decoders = s.options.Decoder
+	return reader, nil
+}
+
+// ExternalBodies resolves the response bodies this har stores in files of their own, for readers
+// created outside the streamer; nil unless StreamerOptions.ExternalBodyDir is set. it is closed
+// with the streamer.
+func (s *DefaultHARStreamer) ExternalBodies() *ExternalBodies {
+	return s.external
+}
+
 func (s *DefaultHARStreamer) GetEntry(ctx context.Context, index int) (*model.Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	if s.index == nil || s.reader == nil {
 		return nil, fmt.Errorf("streamer not initialized: call Initialize() first")
 	}
@@ -158,15 +302,61 @@ func (s *DefaultHARStreamer) GetEntry(ctx context.Context, index int) (*model.En
 	return entry, nil
 }
 
+// GetEntries is StreamRange collected into a slice: entries are read by the worker pool and
+// returned in index order. the first failed read cancels the rest and is returned.
+func (s *DefaultHARStreamer) GetEntries(ctx context.Context, start, end int) ([]*model.Entry, error) {
+	total, ok := s.entryCount()
+	if !ok {
+		return nil, fmt.Errorf("streamer not initialized: call Initialize() first")
+	}
+
+	if start < 0 || start > total {
+		return nil, fmt.Errorf("start index %d out of range", start)
+	}
+	if end < start || end > total {
+		return nil, fmt.Errorf("end index %d out of range", end)
+	}
+
+	entries := make([]*model.Entry, end-start)
+	if start == end {
+		return entries, nil
+	}
+
+	rangeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var firstErr error
+	for result := range s.streamRange(rangeCtx, start, end) {
+		if result.Error != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("entry %d: %w", result.Index, result.Error)
+				cancel()
+			}
+			continue
+		}
+		entries[result.Index-start] = result.Entry
+	}
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	// a cancelled stream stops early without reporting an error
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
 func (s *DefaultHARStreamer) StreamRange(ctx context.Context, start, end int) (<-chan StreamResult, error) {
-	if s.index == nil || s.reader == nil {
+	total, ok := s.entryCount()
+	if !ok {
 		return nil, fmt.Errorf("streamer not initialized: call Initialize() first")
 	}
 
-	if start < 0 || start >= s.index.TotalEntries {
+	if start < 0 || start >= total {
 		return nil, fmt.Errorf("start index %d out of range", start)
 	}
-	if end < start || end > s.index.TotalEntries {
+	if end < start || end > total {
 		return nil, fmt.Errorf("end index %d out of range", end)
 	}
 
@@ -174,16 +364,18 @@ func (s *DefaultHARStreamer) StreamRange(ctx context.Context, start, end int) (<
 }
 
 func (s *DefaultHARStreamer) StreamFiltered(ctx context.Context, filter func(*EntryMetadata) bool) (<-chan StreamResult, error) {
+	var matchingIndices []int
+	s.mu.RLock()
 	if s.index == nil || s.reader == nil {
+		s.mu.RUnlock()
 		return nil, fmt.Errorf("streamer not initialized: call Initialize() first")
 	}
-
-	var matchingIndices []int
 	for i, metadata := range s.index.Entries {
 		if filter(metadata) {
 			matchingIndices = append(matchingIndices, i)
 		}
 	}
+	s.mu.RUnlock()
 
 	return s.streamIndices(ctx, matchingIndices), nil
 }
@@ -293,6 +485,9 @@ func (s *DefaultHARStreamer) streamIndices(ctx context.Context, indices []int) <
 }
 
 func (s *DefaultHARStreamer) GetMetadata(index int) (*EntryMetadata, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	if s.index == nil {
 		return nil, fmt.Errorf("streamer not initialized: call Initialize() first")
 	}
@@ -304,15 +499,45 @@ func (s *DefaultHARStreamer) GetMetadata(index int) (*EntryMetadata, error) {
 	return s.index.Entries[index], nil
 }
 
+// entryCount is how many entries are indexed, read under s.mu as Follow swaps the index and
+// reader when it rebuilds; ok is false until Initialize has set both
+func (s *DefaultHARStreamer) entryCount() (count int, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.index == nil || s.reader == nil {
+		return 0, false
+	}
+	return s.index.TotalEntries, true
+}
+
 func (s *DefaultHARStreamer) GetIndex() *Index {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.index
 }
 
 func (s *DefaultHARStreamer) Close() error {
+	if s.cache != nil {
+		s.cache.Clear()
+	}
+
+	var err error
 	if s.reader != nil {
-		return s.reader.Close()
+		err = s.reader.Close()
 	}
-	return nil
+	if s.external != nil {
+		s.external.Close()
+		s.external = nil
+	}
+	if s.tempPath != "" {
+		os.Remove(s.tempPath)
+		s.tempPath = ""
+	}
+	if s.spoolPath != "" {
+		os.Remove(s.spoolPath)
+		s.spoolPath = ""
+	}
+	return err
 }
 
 func (s *DefaultHARStreamer) Stats() StreamerStats {
@@ -324,7 +549,7 @@ func (s *DefaultHARStreamer) Stats() StreamerStats {
 		avgTime = time.Duration(totalTimeNs / totalReads)
 	}
 
-	return StreamerStats{
+	stats := StreamerStats{
 		TotalReads:      totalReads,
 		CacheHits:       atomic.LoadInt64(&s.stats.cacheHits),
 		CacheMisses:     atomic.LoadInt64(&s.stats.cacheMisses),
@@ -332,5 +557,15 @@ func (s *DefaultHARStreamer) Stats() StreamerStats {
 		EntriesParsed:   atomic.LoadInt64(&s.stats.entriesParsed),
 		ParseErrors:     atomic.LoadInt64(&s.stats.parseErrors),
 		AverageReadTime: avgTime,
+		Uptime:          time.Since(s.started),
+	}
+
+	if seconds := stats.Uptime.Seconds(); seconds > 0 {
+		stats.BytesPerSecond = float64(stats.BytesRead) / seconds
+		stats.EntriesPerSecond = float64(stats.TotalReads) / seconds
+	}
+	if lookups := stats.CacheHits + stats.CacheMisses; lookups > 0 {
+		stats.CacheHitRatio = float64(stats.CacheHits) / float64(lookups)
 	}
+	return stats
 }
@@ -120,3 +120,28 @@ func TestInitializedStreamer_WorksNormally(t *testing.T) {
 	}
 	assert.Equal(t, 5, count)
 }
+
+func TestStreamer_DisableInterning(t *testing.T) {
+	harFile, cleanup, err := generateSmallHAR()
+	require.Nil(t, err)
+	defer cleanup()
+
+	opts := DefaultStreamerOptions()
+	opts.DisableInterning = true
+	streamer, err := NewHARStreamer(harFile, opts)
+	require.Nil(t, err)
+	defer streamer.Close()
+	require.Nil(t, streamer.Initialize(context.Background()))
+
+	stats := streamer.GetIndex().InternStats()
+	assert.True(t, stats.Disabled)
+	assert.Zero(t, stats.Unique)
+	assert.Equal(t, 67, streamer.GetIndex().TotalEntries)
+
+	// interning stays on by default
+	enabled, err := NewHARStreamer(harFile, DefaultStreamerOptions())
+	require.Nil(t, err)
+	defer enabled.Close()
+	require.Nil(t, enabled.Initialize(context.Background()))
+	assert.NotZero(t, enabled.GetIndex().InternStats().Unique)
+}
@@ -171,4 +171,41 @@ func TestInitializeWithProgress_NilChannel(t *testing.T) {
 
 	// Verify streamer is usable
 	assert.NotNil(t, streamer.GetIndex())
-}
\ No newline at end of file
+}
+func TestProgressInterval(t *testing.T) {
+	assert.Equal(t, int64(minProgressInterval), progressInterval(10*1024))
+	assert.Equal(t, int64(10*1024*1024), progressInterval(2000*1024*1024))
+	assert.Equal(t, int64(maxProgressInterval), progressInterval(100*1024*1024*1024))
+}
+
+// TestBuildWithProgress_Cadence tests that updates are spaced by bytes rather than sent per entry
+func TestBuildWithProgress_Cadence(t *testing.T) {
+	harFile, cleanup, err := generateTestHAR(2000, 7)
+	require.NoError(t, err)
+	defer cleanup()
+
+	info, err := os.Stat(harFile)
+	require.NoError(t, err)
+	file, err := os.Open(harFile)
+	require.NoError(t, err)
+	defer file.Close()
+
+	progressChan := make(chan IndexProgress, 1000)
+	index, err := NewIndexBuilder(harFile).BuildWithProgress(file, info.Size(), progressChan)
+	require.NoError(t, err)
+
+	var updates []IndexProgress
+	for update := range progressChan {
+		updates = append(updates, update)
+	}
+
+	require.NotEmpty(t, updates)
+	assert.LessOrEqual(t, len(updates), int(info.Size()/progressInterval(info.Size()))+1)
+	for i := 1; i < len(updates)-1; i++ {
+		assert.GreaterOrEqual(t, updates[i].BytesRead-updates[i-1].BytesRead, progressInterval(info.Size()))
+	}
+
+	final := updates[len(updates)-1]
+	assert.Equal(t, index.TotalEntries, final.EntriesSoFar)
+	assert.Equal(t, info.Size(), final.TotalBytes)
+}
@@ -2,6 +2,7 @@ package motor
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 )
@@ -361,6 +362,54 @@ func TestHARStreamer_Stats(t *testing.T) {
 		stats.TotalReads, stats.EntriesParsed, stats.AverageReadTime)
 }
 
+func TestHARStreamer_StatsRates(t *testing.T) {
+	harFile, cleanup, err := generateSmallHAR()
+	if err != nil {
+		t.Fatalf("failed to generate test HAR: %v", err)
+	}
+	defer cleanup()
+
+	opts := DefaultStreamerOptions()
+	opts.EnableCache = true
+	streamer, err := NewHARStreamer(harFile, opts)
+	if err != nil {
+		t.Fatalf("failed to create streamer: %v", err)
+	}
+	defer streamer.Close()
+
+	ctx := context.Background()
+	if err := streamer.Initialize(ctx); err != nil {
+		t.Fatalf("failed to initialize: %v", err)
+	}
+
+	stats := streamer.Stats()
+	if stats.CacheHitRatio != 0 || stats.BytesPerSecond != 0 || stats.EntriesPerSecond != 0 {
+		t.Errorf("expected zero rates before any read, got %+v", stats)
+	}
+
+	// one miss, then one hit
+	for range 2 {
+		if _, err := streamer.GetEntry(ctx, 0); err != nil {
+			t.Fatalf("failed to get entry: %v", err)
+		}
+	}
+
+	stats = streamer.Stats()
+	if stats.CacheHitRatio != 0.5 {
+		t.Errorf("expected a cache hit ratio of 0.5, got %v", stats.CacheHitRatio)
+	}
+	if stats.Uptime <= 0 {
+		t.Errorf("expected a positive uptime, got %v", stats.Uptime)
+	}
+	if stats.BytesPerSecond <= 0 || stats.EntriesPerSecond <= 0 {
+		t.Errorf("expected positive rates, got %v bytes/s and %v entries/s", stats.BytesPerSecond, stats.EntriesPerSecond)
+	}
+	want := float64(stats.TotalReads) / stats.Uptime.Seconds()
+	if stats.EntriesPerSecond < want*0.5 || stats.EntriesPerSecond > want*2 {
+		t.Errorf("entries/s %v is not reads over uptime (~%v)", stats.EntriesPerSecond, want)
+	}
+}
+
 func TestHARStreamer_GetIndex(t *testing.T) {
 	harFile, cleanup, err := generateSmallHAR()
 	if err != nil {
@@ -417,3 +466,52 @@ func TestHARStreamer_WithTimeout(t *testing.T) {
 		t.Fatalf("failed to get entry: %v", err)
 	}
 }
+
+func TestHARStreamer_GetEntries(t *testing.T) {
+	harFile, cleanup, err := generateSmallHAR()
+	if err != nil {
+		t.Fatalf("failed to generate test HAR: %v", err)
+	}
+	defer cleanup()
+
+	streamer, err := NewHARStreamer(harFile, DefaultStreamerOptions())
+	if err != nil {
+		t.Fatalf("failed to create streamer: %v", err)
+	}
+	defer streamer.Close()
+
+	ctx := context.Background()
+	if err := streamer.Initialize(ctx); err != nil {
+		t.Fatalf("failed to initialize: %v", err)
+	}
+
+	entries, err := streamer.GetEntries(ctx, 5, 25)
+	if err != nil {
+		t.Fatalf("failed to get entries: %v", err)
+	}
+	if len(entries) != 20 {
+		t.Fatalf("expected 20 entries, got %d", len(entries))
+	}
+
+	// results come back in index order whatever order the workers finish in
+	for i, entry := range entries {
+		if entry.Request.URL != streamer.index.Entries[5+i].URL {
+			t.Errorf("entry %d out of order: got %s, want %s", 5+i, entry.Request.URL, streamer.index.Entries[5+i].URL)
+		}
+	}
+
+	empty, err := streamer.GetEntries(ctx, 10, 10)
+	if err != nil || len(empty) != 0 {
+		t.Errorf("empty range: got %d entries, err %v", len(empty), err)
+	}
+
+	if _, err := streamer.GetEntries(ctx, 0, streamer.index.TotalEntries+1); err == nil {
+		t.Error("expected error for end past the last entry")
+	}
+
+	// a broken entry fails the whole batch
+	streamer.index.Entries[12].Length = 3
+	if _, err := streamer.GetEntries(ctx, 10, 15); err == nil || !strings.Contains(err.Error(), "entry 12") {
+		t.Errorf("expected error naming entry 12, got %v", err)
+	}
+}
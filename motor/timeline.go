@@ -0,0 +1,93 @@
+package motor
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"time"
+)
+
+// TimelineEntry places an entry on the capture's shared timeline, measured from the earliest
+// entry's start
+type TimelineEntry struct {
+	Index       int           `json:"index"`       // position in Index.Entries
+	StartOffset time.Duration `json:"startOffset"` // since the capture started
+	EndOffset   time.Duration `json:"endOffset"`   // StartOffset plus the entry's duration
+	Concurrent  int           `json:"concurrent"`  // other entries in flight at some point during this one
+}
+
+// Timeline lays every entry with a timestamp out on one timeline, in start order (ties in file
+// order), with the number of entries each one overlaps. entries without a timestamp are left
+// out; those without a time count as instantaneous.
+func (idx *Index) Timeline() []TimelineEntry {
+	indices := make([]int, len(idx.Entries))
+	for i := range indices {
+		indices[i] = i
+	}
+	return idx.timelineOf(indices)
+}
+
+// WallClock is the span of a timeline, from the first start to the last end. unlike the sum of
+// durations it counts time in which requests overlapped only once.
+func WallClock(timeline []TimelineEntry) time.Duration {
+	var end time.Duration
+	for _, entry := range timeline {
+		end = max(end, entry.EndOffset)
+	}
+	return end
+}
+
+// timelineOf lays the entries at indices out on a timeline starting at the earliest of them
+func (idx *Index) timelineOf(indices []int) []TimelineEntry {
+	timeline := make([]TimelineEntry, 0, len(indices))
+	var start time.Time
+	for _, i := range indices {
+		entry := idx.Entries[i]
+		if entry.Timestamp.IsZero() {
+			continue
+		}
+		if start.IsZero() || entry.Timestamp.Before(start) {
+			start = entry.Timestamp
+		}
+		timeline = append(timeline, TimelineEntry{Index: i})
+	}
+
+	var starts, ends, instants []time.Duration
+	for n := range timeline {
+		entry := idx.Entries[timeline[n].Index]
+		begin := entry.Timestamp.Sub(start)
+		end := begin + time.Duration(max(entry.Duration, 0)*float64(time.Millisecond))
+		timeline[n].StartOffset, timeline[n].EndOffset = begin, end
+		starts, ends = append(starts, begin), append(ends, end)
+		if end == begin {
+			instants = append(instants, begin)
+		}
+	}
+	slices.Sort(starts)
+	slices.Sort(ends)
+	slices.Sort(instants)
+	below := func(offsets []time.Duration, limit time.Duration) int { // offsets before limit
+		return sort.Search(len(offsets), func(k int) bool { return offsets[k] >= limit })
+	}
+	upTo := func(offsets []time.Duration, limit time.Duration) int { // offsets at or before limit
+		return sort.Search(len(offsets), func(k int) bool { return offsets[k] > limit })
+	}
+
+	// an entry overlaps [s, e) when it starts before e and ends after s: those starting before e,
+	// less those ended by s. an instant overlaps an entry when it falls inside it, so one at
+	// exactly s is added back, and an instantaneous entry counts those in flight at s instead.
+	for n := range timeline {
+		s, e := timeline[n].StartOffset, timeline[n].EndOffset
+		instantsAtStart := upTo(instants, s) - below(instants, s)
+		var overlapping int
+		if e > s {
+			overlapping = below(starts, e) - upTo(ends, s) + instantsAtStart
+		} else {
+			overlapping = upTo(starts, s) - upTo(ends, s) + instantsAtStart
+		}
+		timeline[n].Concurrent = overlapping - 1 // the entry itself
+	}
+
+	slices.SortStableFunc(timeline, func(a, b TimelineEntry) int { return cmp.Compare(a.StartOffset, b.StartOffset) })
+	return timeline
+}
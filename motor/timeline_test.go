@@ -0,0 +1,63 @@
+package motor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexTimeline(t *testing.T) {
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	idx := &Index{
+		Entries: []*EntryMetadata{
+			{Timestamp: start.Add(100 * time.Millisecond), Duration: 1000}, // 100ms - 1.1s
+			{Timestamp: start, Duration: 200},                              // 0 - 200ms
+			{Timestamp: start.Add(500 * time.Millisecond)},                 // an instant inside entry 0
+			{Duration: 50}, // no timestamp
+			{Timestamp: start.Add(1100 * time.Millisecond), Duration: 100}, // starts as entry 0 ends
+			{Timestamp: start.Add(500 * time.Millisecond), Duration: -1},   // unknown time, same instant as entry 2
+		},
+	}
+
+	timeline := idx.Timeline()
+	require.Len(t, timeline, 5, "entries without a timestamp are left out")
+
+	want := []TimelineEntry{
+		{Index: 1, StartOffset: 0, EndOffset: 200 * time.Millisecond, Concurrent: 1},
+		{Index: 0, StartOffset: 100 * time.Millisecond, EndOffset: 1100 * time.Millisecond, Concurrent: 3},
+		{Index: 2, StartOffset: 500 * time.Millisecond, EndOffset: 500 * time.Millisecond, Concurrent: 2},
+		{Index: 5, StartOffset: 500 * time.Millisecond, EndOffset: 500 * time.Millisecond, Concurrent: 2},
+		{Index: 4, StartOffset: 1100 * time.Millisecond, EndOffset: 1200 * time.Millisecond, Concurrent: 0},
+	}
+	assert.Equal(t, want, timeline)
+	assert.Equal(t, 1200*time.Millisecond, WallClock(timeline))
+
+	stats := idx.Stats(1)
+	assert.Equal(t, 1200.0, stats.WallClock)
+	assert.Equal(t, 1350.0, stats.TotalDuration, "overlapping requests count more than once in the total")
+}
+
+func TestIndexTimeline_Empty(t *testing.T) {
+	idx := &Index{Entries: []*EntryMetadata{{Duration: 10}}}
+	assert.Empty(t, idx.Timeline())
+	assert.Zero(t, WallClock(nil))
+}
+
+func TestIndexTimeline_InstantAtStart(t *testing.T) {
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	idx := &Index{
+		Entries: []*EntryMetadata{
+			{Timestamp: start, Duration: 100},
+			{Timestamp: start},
+			{Timestamp: start.Add(100 * time.Millisecond)}, // as entry 0 ends
+		},
+	}
+
+	timeline := idx.Timeline()
+	require.Len(t, timeline, 3)
+	assert.Equal(t, 1, timeline[0].Concurrent, "an instant at the start falls inside the entry")
+	assert.Equal(t, 1, timeline[1].Concurrent)
+	assert.Equal(t, 0, timeline[2].Concurrent, "an entry is over by its end")
+}
@@ -1,7 +1,9 @@
 package motor
 
 import (
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cespare/xxhash/v2"
@@ -15,6 +17,7 @@ type EntryMetadata struct {
 	URL          string
 	StatusCode   int
 	StatusText   string
+	RedirectURL  string
 	MimeType     string
 	Timestamp    time.Time
 	Duration     float64
@@ -24,6 +27,18 @@ type EntryMetadata struct {
 	PageRef      string
 	ServerIP     string
 	Connection   string
+
+	// chrome's _resourceType (document, script, xhr, ...) and _priority, empty when absent
+	ResourceType string
+	Priority     string
+
+	// number of request and response headers
+	RequestHeaders  int
+	ResponseHeaders int
+
+	// populated only when StreamerOptions.IndexWebSockets is enabled
+	WebSocketSent     int
+	WebSocketReceived int
 }
 
 type Index struct {
@@ -39,11 +54,73 @@ type Index struct {
 	TotalEntries       int
 	stringShards       [256]*stringTableShard
 	shardInitMu        sync.Mutex
+	internDisabled     bool         // Intern returns its argument unchanged
+	internCalls        atomic.Int64 // non-empty strings passed to Intern
+	internHits         atomic.Int64 // calls that found the string already in the table
+	internBytesSaved   atomic.Int64 // bytes of the duplicates that hits let go
 	TotalRequestBytes  int64
 	TotalResponseBytes int64
 	TimeRange          TimeRange
 	UniqueURLs         int
 	BuildTime          time.Duration
+	WebSocketsIndexed  bool           // websocket frame counts were collected into entry metadata
+	BadTimestamps      int            // entries with a missing or unparseable startedDateTime (zero Timestamp)
+	EntriesEnd         int64          // offset just past the last indexed entry, where DefaultIndexBuilder.Resume carries on
+	SkippedEntries     []SkippedEntry // entries left out because they failed to parse, see StreamerOptions.SkipBadEntries
+	FirstEntry         int            // position in the har's entries array of Entries[0], see StreamerOptions.StartEntry
+	EntriesCutOff      bool           // StreamerOptions.MaxEntries stopped indexing before the end of the entries array
+	HAREntries         int            // windowed only: entries in the whole array, estimated from the bytes read when EntriesCutOff (0 = unknown)
+}
+
+// clone copies the index so entries can be appended to the copy while the original is read
+// without a lock. the copy has its own Entries slice and shares the metadata and string table.
+func (idx *Index) clone() *Index {
+	next := &Index{
+		FilePath:           idx.FilePath,
+		FileSize:           idx.FileSize,
+		FileHash:           idx.FileHash,
+		IndexVersion:       idx.IndexVersion,
+		Version:            idx.Version,
+		Creator:            idx.Creator,
+		Browser:            idx.Browser,
+		Pages:              slices.Clone(idx.Pages),
+		Entries:            slices.Clone(idx.Entries),
+		TotalEntries:       idx.TotalEntries,
+		TotalRequestBytes:  idx.TotalRequestBytes,
+		TotalResponseBytes: idx.TotalResponseBytes,
+		TimeRange:          idx.TimeRange,
+		UniqueURLs:         idx.UniqueURLs,
+		BuildTime:          idx.BuildTime,
+		WebSocketsIndexed:  idx.WebSocketsIndexed,
+		BadTimestamps:      idx.BadTimestamps,
+		EntriesEnd:         idx.EntriesEnd,
+		SkippedEntries:     slices.Clone(idx.SkippedEntries),
+		FirstEntry:         idx.FirstEntry,
+		EntriesCutOff:      idx.EntriesCutOff,
+		HAREntries:         idx.HAREntries,
+	}
+
+	idx.shardInitMu.Lock()
+	next.stringShards = idx.stringShards
+	next.internDisabled = idx.internDisabled
+	idx.shardInitMu.Unlock()
+	next.internCalls.Store(idx.internCalls.Load())
+	next.internHits.Store(idx.internHits.Load())
+	next.internBytesSaved.Store(idx.internBytesSaved.Load())
+	return next
+}
+
+// Windowed reports whether StreamerOptions.StartEntry or MaxEntries left some of the har's
+// entries out of the index
+func (idx *Index) Windowed() bool {
+	return idx.FirstEntry > 0 || idx.EntriesCutOff
+}
+
+// SkippedEntry is an entry StreamerOptions.SkipBadEntries left out of the index
+type SkippedEntry struct {
+	Position int    // position of the entry in the har's entries array, counting skipped ones
+	Offset   int64  // file offset where the entry started
+	Error    string // why it failed to parse
 }
 
 type stringTableShard struct {
@@ -56,11 +133,55 @@ type TimeRange struct {
 	End   time.Time
 }
 
+// InternStats describes how much the string table is saving, see Index.InternStats
+type InternStats struct {
+	Disabled   bool  // interning was turned off with StreamerOptions.DisableInterning
+	Interned   int64 // non-empty strings passed to Intern
+	Unique     int   // distinct strings held in the table
+	BytesSaved int64 // bytes of duplicate strings that now share one copy
+	TableBytes int64 // estimated size of the table itself, excluding the strings it shares
+}
+
+// internEntryBytes estimates what one table entry costs: key and value string headers plus
+// map overhead at a typical load factor
+const internEntryBytes = 40
+
+// InternStats reports the string table's effect on memory. when TableBytes exceeds BytesSaved,
+// as with millions of unique urls, DisableInterning is the cheaper choice.
+func (idx *Index) InternStats() InternStats {
+	stats := InternStats{
+		Disabled:   idx.internDisabled,
+		Interned:   idx.internCalls.Load(),
+		BytesSaved: idx.internBytesSaved.Load(),
+	}
+	for _, shard := range idx.stringShards {
+		if shard == nil {
+			continue
+		}
+		shard.mu.RLock()
+		stats.Unique += len(shard.table)
+		shard.mu.RUnlock()
+	}
+	stats.TableBytes = int64(stats.Unique) * internEntryBytes
+	return stats
+}
+
+// disableInterning makes Intern a pass-through and releases any table built so far; strings
+// already shared stay shared
+func (idx *Index) disableInterning() {
+	idx.shardInitMu.Lock()
+	defer idx.shardInitMu.Unlock()
+
+	idx.internDisabled = true
+	idx.stringShards = [256]*stringTableShard{}
+}
+
 // uses 256 shards with xxhash distribution to minimize lock contention during concurrent index building
 func (idx *Index) Intern(s string) string {
-	if s == "" {
-		return ""
+	if s == "" || idx.internDisabled {
+		return s
 	}
+	idx.internCalls.Add(1)
 
 	h := xxhash.Sum64String(s)
 	shardIdx := h % 256
@@ -74,6 +195,7 @@ func (idx *Index) Intern(s string) string {
 	shard.mu.RLock()
 	if interned, exists := shard.table[s]; exists {
 		shard.mu.RUnlock()
+		idx.recordInternHit(s)
 		return interned
 	}
 	shard.mu.RUnlock()
@@ -83,6 +205,7 @@ func (idx *Index) Intern(s string) string {
 	defer shard.mu.Unlock()
 
 	if interned, exists := shard.table[s]; exists {
+		idx.recordInternHit(s)
 		return interned
 	}
 
@@ -90,6 +213,11 @@ func (idx *Index) Intern(s string) string {
 	return s
 }
 
+func (idx *Index) recordInternHit(s string) {
+	idx.internHits.Add(1)
+	idx.internBytesSaved.Add(int64(len(s)))
+}
+
 func (idx *Index) initShard(shardIdx uint64) {
 	idx.shardInitMu.Lock()
 	defer idx.shardInitMu.Unlock()
@@ -115,14 +243,56 @@ type StreamerStats struct {
 	EntriesParsed   int64
 	ParseErrors     int64
 	AverageReadTime time.Duration
+
+	// rates over Uptime, the time since the streamer was created. EntriesPerSecond counts
+	// every read, cache hits included.
+	Uptime           time.Duration
+	BytesPerSecond   float64
+	EntriesPerSecond float64
+	CacheHitRatio    float64 // CacheHits of all cache lookups, 0 before the first lookup
 }
 
 type StreamerOptions struct {
 	// WorkerCount specifies the number of concurrent workers for streaming operations.
 	WorkerCount int
-	// EnableCache is reserved for future implementation.
-	// TODO: Implement LRU cache for frequently accessed entries to improve performance.
-	// EnableCache bool
+	// IndexWebSockets counts `_webSocketMessages` frames per entry while indexing (off by default).
+	IndexWebSockets bool
+	// UseIndexSidecar loads a persisted index from <file>.idx when it still matches the file,
+	// and writes one after a full build.
+	UseIndexSidecar bool
+	// EnableCache keeps recently read entries in an LRU cache so repeated GetEntry calls skip disk and decode.
+	EnableCache bool
+	// CacheSize is the maximum number of cached entries (0 = DefaultCacheSize).
+	CacheSize int
+	// MaxEntrySize is the largest entry, in bytes, the reader will load (0 = MaxEntrySize).
+	MaxEntrySize int64
+	// DisableInterning skips deduplicating urls, methods and other index strings. for captures of
+	// mostly unique urls the intern table costs more memory than it saves; see Index.InternStats.
+	DisableInterning bool
+	// Follow opens a file that is still being written: an entry cut off at the end of the file
+	// is left out instead of failing the build, and DefaultHARStreamer.Follow picks up the entries
+	// appended later. no index sidecar is read or written for a growing file.
+	Follow bool
+	// SkipBadEntries leaves out an entry that fails to parse instead of failing the whole build:
+	// the error is recorded in Index.SkippedEntries and indexing carries on with the next entry.
+	// finding the next entry after a syntax error is best-effort. entries appended to a followed
+	// file are still parsed strictly.
+	SkipBadEntries bool
+	// StartEntry skips the entries before this position in the entries array without parsing
+	// them; the index then starts at that entry (Index.FirstEntry).
+	StartEntry int
+	// MaxEntries stops indexing once this many entries are indexed (0 = all), so the rest of a
+	// huge file is never read; see Index.EntriesCutOff. a windowed index is not saved as a sidecar.
+	MaxEntries int
+	// ExternalBodyDir reads a response body stored in a file of its own: an entry whose content
+	// has no text but a _file reference gets the file's contents, resolved against the har's
+	// directory. references leading outside this directory are refused. empty leaves such
+	// bodies empty.
+	ExternalBodyDir string
+	// Decoder creates the json decoders for indexing and reading entries (nil = encoding/json),
+	// to plug in a faster implementation such as sonic. SkipBadEntries can only resync the
+	// encoding/json decoder; with another, the first bad entry fails the build.
+	Decoder DecoderFactory
 }
 
 func DefaultStreamerOptions() StreamerOptions {
@@ -167,3 +167,45 @@ func TestStreamerStats(t *testing.T) {
 		t.Errorf("expected hit rate %.2f, got %.2f", expectedHitRate, hitRate)
 	}
 }
+
+func TestIndex_InternStats(t *testing.T) {
+	idx := &Index{}
+	idx.Intern("https://example.com/a")
+	idx.Intern("https://example.com/a")
+	idx.Intern("GET")
+	idx.Intern("GET")
+	idx.Intern("GET")
+	idx.Intern("")
+
+	stats := idx.InternStats()
+	if stats.Interned != 5 {
+		t.Errorf("expected 5 interned strings, got %d", stats.Interned)
+	}
+	if stats.Unique != 2 {
+		t.Errorf("expected 2 unique strings, got %d", stats.Unique)
+	}
+	if want := int64(len("https://example.com/a") + 2*len("GET")); stats.BytesSaved != want {
+		t.Errorf("expected %d bytes saved, got %d", want, stats.BytesSaved)
+	}
+	if stats.TableBytes != 2*internEntryBytes {
+		t.Errorf("expected table estimate %d, got %d", 2*internEntryBytes, stats.TableBytes)
+	}
+}
+
+func TestIndex_DisableInterning(t *testing.T) {
+	idx := &Index{}
+	idx.Intern("kept")
+	idx.disableInterning()
+
+	if s := idx.Intern("hello"); s != "hello" {
+		t.Errorf("expected pass-through, got %q", s)
+	}
+
+	stats := idx.InternStats()
+	if !stats.Disabled {
+		t.Error("expected stats to report interning disabled")
+	}
+	if stats.Unique != 0 || stats.Interned != 1 {
+		t.Errorf("expected the table released and no new calls counted, got %+v", stats)
+	}
+}
@@ -0,0 +1,441 @@
+package motor
+
+import (
+	"bufio"
+	"cmp"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/pb33f/harific/motor/model"
+)
+
+// IssueSeverity says whether a validation issue breaks the har or is only suspicious
+type IssueSeverity string
+
+const (
+	SeverityError   IssueSeverity = "error"
+	SeverityWarning IssueSeverity = "warning"
+)
+
+// ValidationIssue is one problem found in a har file. Entry is the index of the entry it was
+// found in, or -1 for problems outside log.entries.
+type ValidationIssue struct {
+	Severity IssueSeverity `json:"severity"`
+	Entry    int           `json:"entry"`
+	Path     string        `json:"path"`
+	Message  string        `json:"message"`
+}
+
+// ValidationReport collects every issue found while validating a har
+type ValidationReport struct {
+	Version string            `json:"version"` // log.version, DefaultHARVersion when the log has none
+	Entries int               `json:"entries"` // entries read before the end of the file or a fatal syntax error
+	Issues  []ValidationIssue `json:"issues"`
+}
+
+// Errors counts the issues that make the file an invalid har
+func (r *ValidationReport) Errors() int {
+	return r.count(SeverityError)
+}
+
+// Warnings counts the issues that are suspicious but still readable
+func (r *ValidationReport) Warnings() int {
+	return r.count(SeverityWarning)
+}
+
+func (r *ValidationReport) count(severity IssueSeverity) int {
+	n := 0
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// required fields per the har 1.2 spec, by object. a missing log.version is only a warning, since
+// readers assume DefaultHARVersion
+var (
+	requiredLogFields      = []string{keyCreator, keyEntries}
+	requiredCreatorFields  = []string{"name", keyVersion}
+	requiredPageFields     = []string{keyStartedDateTime, "id", "title", "pageTimings"}
+	requiredEntryFields    = []string{keyStartedDateTime, keyTime, keyRequest, keyResponse, "cache", "timings"}
+	requiredRequestFields  = []string{keyMethod, keyURL, "httpVersion", "cookies", "headers", "queryString", "headersSize", keyBodySize}
+	requiredResponseFields = []string{keyStatus, keyStatusText, "httpVersion", "cookies", "headers", keyContent, keyRedirectURL, "headersSize", keyBodySize}
+	requiredContentFields  = []string{keySize, keyMimeType}
+	requiredTimingsFields  = []string{"send", "wait", "receive"}
+
+	// 1.1 logs are checked with these instead: pageTimings and cache are optional there, so
+	// captures from older exporters validate
+	requiredPageFields11  = []string{keyStartedDateTime, "id", "title"}
+	requiredEntryFields11 = []string{keyStartedDateTime, keyTime, keyRequest, keyResponse, "timings"}
+)
+
+// knownHARVersions are the versions the required fields above describe
+var knownHARVersions = []string{"1.1", "1.2"}
+
+// pageRef is an entry's pageref, kept until the whole log has been read
+type pageRef struct {
+	entry int
+	ref   string
+}
+
+// harValidator walks a har with the same token decoder the index builder uses, decoding each
+// entry on its own so one bad entry doesn't stop the rest from being checked
+type harValidator struct {
+	decoder  HARDecoder
+	report   *ValidationReport
+	pageIDs  map[string]bool
+	pageRefs []pageRef // checked once every page is known
+	maxEntry int64
+	version  string // log.version so far; it normally comes before pages and entries
+}
+
+// Validate reads a har from reader and reports every structural problem it finds: missing
+// required fields, values of the wrong type, unparseable timestamps, body sizes that disagree
+// with the content, and entries too large for the reader. only a JSON syntax error (such as a
+// truncated file) stops the walk early, since nothing after it can be located.
+func Validate(reader io.Reader) *ValidationReport {
+	v := &harValidator{
+		decoder:  newHARDecoder(reader, nil),
+		report:   &ValidationReport{Issues: make([]ValidationIssue, 0)},
+		pageIDs:  make(map[string]bool),
+		maxEntry: MaxEntrySize,
+	}
+	v.validateHAR()
+	v.report.Version = cmp.Or(v.version, DefaultHARVersion)
+	return v.report
+}
+
+// ValidateFile validates the har at path, decompressing gzip files on the fly
+func ValidateFile(path string) (*ValidationReport, error) {
+	gzipped, err := IsGzipFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	var reader io.Reader = bufio.NewReader(file)
+	if gzipped {
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read gzip header: %w", err)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	return Validate(reader), nil
+}
+
+func (v *harValidator) addIssue(severity IssueSeverity, entry int, path, format string, args ...any) {
+	v.report.Issues = append(v.report.Issues, ValidationIssue{
+		Severity: severity,
+		Entry:    entry,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// syntaxError records a decoder failure; the walk can't continue past one
+func (v *harValidator) syntaxError(entry int, path string, err error) {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		v.addIssue(SeverityError, entry, path, "file ends unexpectedly at byte %d (truncated?)", v.decoder.InputOffset())
+		return
+	}
+	v.addIssue(SeverityError, entry, path, "malformed JSON at byte %d: %v", v.decoder.InputOffset(), err)
+}
+
+func (v *harValidator) validateHAR() {
+	token, err := v.decoder.Token()
+	if err != nil {
+		v.syntaxError(-1, "", err)
+		return
+	}
+	if token != json.Delim('{') {
+		v.addIssue(SeverityError, -1, "", "top level value is not a JSON object")
+		return
+	}
+
+	sawLog := false
+	for v.decoder.More() {
+		token, err := v.decoder.Token()
+		if err != nil {
+			v.syntaxError(-1, "", err)
+			return
+		}
+
+		if token == keyLog {
+			sawLog = true
+			if !v.validateLog() {
+				return
+			}
+			continue
+		}
+		if err := helper.skipValue(v.decoder); err != nil {
+			v.syntaxError(-1, fmt.Sprint(token), err)
+			return
+		}
+	}
+
+	if !sawLog {
+		v.addIssue(SeverityError, -1, keyLog, "missing required field")
+		return
+	}
+
+	for _, ref := range v.pageRefs {
+		if !v.pageIDs[ref.ref] {
+			v.addIssue(SeverityWarning, ref.entry, keyPageRef, "references page %q, which is not in log.pages", ref.ref)
+		}
+	}
+}
+
+// validateLog checks the log object, returning false on a syntax error
+func (v *harValidator) validateLog() bool {
+	token, err := v.decoder.Token()
+	if err != nil {
+		v.syntaxError(-1, keyLog, err)
+		return false
+	}
+	if token != json.Delim('{') {
+		v.addIssue(SeverityError, -1, keyLog, "is not a JSON object")
+		return true
+	}
+
+	seen := make(map[string]bool)
+	for v.decoder.More() {
+		token, err := v.decoder.Token()
+		if err != nil {
+			v.syntaxError(-1, keyLog, err)
+			return false
+		}
+		key, _ := token.(string)
+		seen[key] = true
+		path := keyLog + "." + key
+
+		if key == keyEntries {
+			if !v.validateEntries() {
+				return false
+			}
+			continue
+		}
+
+		var raw json.RawMessage
+		if err := v.decoder.Decode(&raw); err != nil {
+			v.syntaxError(-1, path, err)
+			return false
+		}
+
+		switch key {
+		case keyVersion:
+			var version any
+			json.Unmarshal(raw, &version)
+			if _, ok := version.(string); !ok {
+				v.addIssue(SeverityError, -1, path, "is not a string")
+			}
+			v.version = harVersion(version)
+			if v.version != "" && !slices.Contains(knownHARVersions, v.version) {
+				v.addIssue(SeverityWarning, -1, path, "unrecognised version %q, checked as %s", v.version, DefaultHARVersion)
+			}
+		case keyCreator, keyBrowser:
+			v.checkObject(-1, path, raw, requiredCreatorFields)
+		case keyPages:
+			v.validatePages(raw)
+		}
+	}
+	// consume the closing brace
+	if _, err := v.decoder.Token(); err != nil {
+		v.syntaxError(-1, keyLog, err)
+		return false
+	}
+
+	for _, field := range requiredLogFields {
+		if !seen[field] {
+			v.addIssue(SeverityError, -1, keyLog+"."+field, "missing required field")
+		}
+	}
+	if !seen[keyVersion] {
+		v.addIssue(SeverityWarning, -1, keyLog+"."+keyVersion, "missing required field, assuming %s", DefaultHARVersion)
+	}
+	return true
+}
+
+func (v *harValidator) validatePages(raw json.RawMessage) {
+	var pages []json.RawMessage
+	if err := json.Unmarshal(raw, &pages); err != nil {
+		v.addIssue(SeverityError, -1, "log.pages", "is not an array")
+		return
+	}
+
+	for i, page := range pages {
+		path := fmt.Sprintf("log.pages[%d]", i)
+		required := requiredPageFields
+		if v.version == "1.1" {
+			required = requiredPageFields11
+		}
+		fields, ok := v.checkObject(-1, path, page, required)
+		if !ok {
+			continue
+		}
+		var id string
+		if err := json.Unmarshal(fields["id"], &id); err == nil {
+			v.pageIDs[id] = true
+		}
+		var started string
+		if err := json.Unmarshal(fields[keyStartedDateTime], &started); err == nil {
+			if _, ok := parseTimestamp(started); !ok {
+				v.addIssue(SeverityWarning, -1, path+"."+keyStartedDateTime, "unrecognised timestamp %q", started)
+			}
+		}
+	}
+}
+
+// validateEntries checks every entry of log.entries, returning false on a syntax error
+func (v *harValidator) validateEntries() bool {
+	token, err := v.decoder.Token()
+	if err != nil {
+		v.syntaxError(-1, "log.entries", err)
+		return false
+	}
+	if token != json.Delim('[') {
+		v.addIssue(SeverityError, -1, "log.entries", "is not an array")
+		if token == json.Delim('{') {
+			if err := helper.skipObject(v.decoder); err != nil {
+				v.syntaxError(-1, "log.entries", err)
+				return false
+			}
+		}
+		return true
+	}
+
+	for index := 0; v.decoder.More(); index++ {
+		// offsets are taken the same way the index builder takes them
+		start := v.decoder.InputOffset()
+		var raw json.RawMessage
+		if err := v.decoder.Decode(&raw); err != nil {
+			v.syntaxError(index, "", err)
+			return false
+		}
+		length := v.decoder.InputOffset() - start
+
+		v.report.Entries++
+		if length > v.maxEntry {
+			v.addIssue(SeverityWarning, index, "", "entry is %d bytes, over the %d byte limit entries are read with", length, v.maxEntry)
+		}
+		v.validateEntry(index, raw)
+	}
+
+	if _, err := v.decoder.Token(); err != nil {
+		v.syntaxError(-1, "log.entries", err)
+		return false
+	}
+	return true
+}
+
+func (v *harValidator) validateEntry(index int, raw json.RawMessage) {
+	required := requiredEntryFields
+	if v.version == "1.1" {
+		required = requiredEntryFields11
+	}
+	fields, ok := v.checkObject(index, "", raw, required)
+	if !ok {
+		return
+	}
+
+	// type mismatches don't stop Unmarshal, so the first one is reported and the rest still decode
+	var entry model.Entry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			v.addIssue(SeverityError, index, typeErr.Field, "has the wrong type (JSON %s, expected %s)", typeErr.Value, typeErr.Type)
+		} else {
+			v.addIssue(SeverityError, index, "", "failed to decode: %v", err)
+		}
+	}
+
+	var started string
+	if err := json.Unmarshal(fields[keyStartedDateTime], &started); err == nil {
+		if _, ok := parseTimestamp(started); !ok {
+			v.addIssue(SeverityError, index, keyStartedDateTime, "unrecognised timestamp %q", started)
+		}
+	}
+	if entry.PageRef != "" {
+		v.pageRefs = append(v.pageRefs, pageRef{entry: index, ref: entry.PageRef})
+	}
+
+	if request, ok := fields[keyRequest]; ok {
+		v.checkObject(index, keyRequest, request, requiredRequestFields)
+	}
+	if response, ok := fields[keyResponse]; ok {
+		if responseFields, ok := v.checkObject(index, keyResponse, response, requiredResponseFields); ok {
+			if content, ok := responseFields[keyContent]; ok {
+				v.checkObject(index, "response.content", content, requiredContentFields)
+			}
+		}
+	}
+	if timings, ok := fields["timings"]; ok {
+		v.checkObject(index, "timings", timings, requiredTimingsFields)
+	}
+
+	v.checkBodySizes(index, &entry)
+}
+
+// checkBodySizes compares the sizes an entry declares with the bodies it actually carries
+func (v *harValidator) checkBodySizes(index int, entry *model.Entry) {
+	if text := entry.Request.Body.Content; text != "" && entry.Request.BodySize > 0 && int64(len(text)) != entry.Request.BodySize {
+		v.addIssue(SeverityWarning, index, "request.bodySize", "is %d but postData.text is %d bytes", entry.Request.BodySize, len(text))
+	}
+
+	content := entry.Response.Body
+	if content.Content == "" || content.Size < 0 {
+		return
+	}
+	actual := len(content.Content)
+	switch content.Encoding {
+	case "":
+	case "base64":
+		decoded, err := base64.StdEncoding.DecodeString(content.Content)
+		if err != nil {
+			v.addIssue(SeverityError, index, "response.content.text", "is not valid base64: %v", err)
+			return
+		}
+		actual = len(decoded)
+	default:
+		return // other encodings can't be measured without decoding them
+	}
+	if int64(actual) != content.Size {
+		v.addIssue(SeverityWarning, index, "response.content.size", "is %d but the body is %d bytes", content.Size, actual)
+	}
+}
+
+// checkObject reports a value that isn't an object or lacks required fields, returning the
+// object's fields when it is one
+func (v *harValidator) checkObject(entry int, path string, raw json.RawMessage, required []string) (map[string]json.RawMessage, bool) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil || fields == nil {
+		v.addIssue(SeverityError, entry, path, "is not a JSON object")
+		return nil, false
+	}
+
+	for _, field := range required {
+		if _, ok := fields[field]; !ok {
+			fieldPath := field
+			if path != "" {
+				fieldPath = path + "." + field
+			}
+			v.addIssue(SeverityError, entry, fieldPath, "missing required field")
+		}
+	}
+	return fields, true
+}
@@ -0,0 +1,172 @@
+package motor
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validEntryJSON = `{
+	"startedDateTime": "2025-03-01T10:00:00.000Z",
+	"time": 12,
+	"request": {"method": "GET", "url": "https://example.com/", "httpVersion": "HTTP/1.1",
+		"cookies": [], "headers": [], "queryString": [], "headersSize": -1, "bodySize": 0},
+	"response": {"status": 200, "statusText": "OK", "httpVersion": "HTTP/1.1", "cookies": [],
+		"headers": [], "content": {"size": 5, "mimeType": "text/plain", "text": "hello"},
+		"redirectURL": "", "headersSize": -1, "bodySize": 5},
+	"cache": {},
+	"timings": {"send": 1, "wait": 10, "receive": 1}
+}`
+
+func validationHAR(entries ...string) string {
+	return `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1"}, "entries": [` +
+		strings.Join(entries, ",") + `]}}`
+}
+
+// issueAt returns the issue reported for entry at path, if any
+func issueAt(report *ValidationReport, entry int, path string) (ValidationIssue, bool) {
+	for _, issue := range report.Issues {
+		if issue.Entry == entry && issue.Path == path {
+			return issue, true
+		}
+	}
+	return ValidationIssue{}, false
+}
+
+func TestValidate_ValidHAR(t *testing.T) {
+	report := Validate(strings.NewReader(validationHAR(validEntryJSON, validEntryJSON)))
+
+	assert.Equal(t, 2, report.Entries)
+	assert.Empty(t, report.Issues)
+}
+
+func TestValidate_CollectsEveryProblem(t *testing.T) {
+	missingFields := strings.Replace(validEntryJSON, `"cache": {},`, "", 1)
+	missingFields = strings.Replace(missingFields, `"method": "GET", `, "", 1)
+	wrongType := strings.Replace(validEntryJSON, `"bodySize": 0`, `"bodySize": "zero"`, 1)
+	badTime := strings.Replace(validEntryJSON, "2025-03-01T10:00:00.000Z", "yesterday", 1)
+	badSize := strings.Replace(validEntryJSON, `"size": 5`, `"size": 500`, 1)
+
+	report := Validate(strings.NewReader(validationHAR(validEntryJSON, missingFields, wrongType, badTime, badSize)))
+
+	assert.Equal(t, 5, report.Entries, "a bad entry doesn't stop the walk")
+
+	_, ok := issueAt(report, 1, "cache")
+	assert.True(t, ok, "missing cache reported")
+	_, ok = issueAt(report, 1, "request.method")
+	assert.True(t, ok, "missing request.method reported")
+
+	issue, ok := issueAt(report, 2, "request.bodySize")
+	require.True(t, ok, "wrong type reported")
+	assert.Equal(t, SeverityError, issue.Severity)
+
+	_, ok = issueAt(report, 3, "startedDateTime")
+	assert.True(t, ok, "bad timestamp reported")
+
+	issue, ok = issueAt(report, 4, "response.content.size")
+	require.True(t, ok, "size mismatch reported")
+	assert.Equal(t, SeverityWarning, issue.Severity)
+
+	_, ok = issueAt(report, 0, "")
+	assert.False(t, ok, "the valid entry has no issues")
+	assert.Equal(t, 4, report.Errors())
+	assert.Equal(t, 1, report.Warnings())
+}
+
+func TestValidate_Truncated(t *testing.T) {
+	har := validationHAR(validEntryJSON, validEntryJSON)
+	report := Validate(strings.NewReader(har[:len(har)-len(validEntryJSON)/2]))
+
+	assert.Equal(t, 1, report.Entries)
+	require.NotEmpty(t, report.Issues)
+	last := report.Issues[len(report.Issues)-1]
+	assert.Equal(t, 1, last.Entry)
+	assert.Contains(t, last.Message, "ends unexpectedly")
+}
+
+func TestValidate_MissingLogFields(t *testing.T) {
+	report := Validate(strings.NewReader(`{"log": {"version": "1.2"}}`))
+
+	_, ok := issueAt(report, -1, "log.creator")
+	assert.True(t, ok)
+	_, ok = issueAt(report, -1, "log.entries")
+	assert.True(t, ok)
+
+	report = Validate(strings.NewReader(`{"notlog": {}}`))
+	_, ok = issueAt(report, -1, "log")
+	assert.True(t, ok)
+}
+
+func TestValidate_HARVersion(t *testing.T) {
+	report := Validate(strings.NewReader(`{"log": {"creator": {"name": "test", "version": "1"}, "entries": []}}`))
+	assert.Equal(t, DefaultHARVersion, report.Version)
+	issue, ok := issueAt(report, -1, "log.version")
+	require.True(t, ok)
+	assert.Equal(t, SeverityWarning, issue.Severity, "a missing version is assumed, not fatal")
+	assert.Zero(t, report.Errors())
+
+	report = Validate(strings.NewReader(strings.Replace(validationHAR(), `"1.2"`, `"2.0"`, 1)))
+	assert.Equal(t, "2.0", report.Version)
+	issue, ok = issueAt(report, -1, "log.version")
+	require.True(t, ok)
+	assert.Equal(t, SeverityWarning, issue.Severity)
+}
+
+func TestValidate_HAR11(t *testing.T) {
+	entry := strings.Replace(validEntryJSON, `"cache": {},`, "", 1)
+	har := `{"log": {"version": "1.1", "creator": {"name": "test", "version": "1"}, "entries": [` + entry + `],
+		"pages": [{"startedDateTime": "2025-03-01T10:00:00.000Z", "id": "page_1", "title": "x"}]}}`
+
+	report := Validate(strings.NewReader(har))
+
+	assert.Equal(t, "1.1", report.Version)
+	assert.Empty(t, report.Issues, "cache and pageTimings are optional in a 1.1 log")
+
+	report = Validate(strings.NewReader(strings.Replace(har, `"1.1"`, `"1.2"`, 1)))
+	_, ok := issueAt(report, 0, "cache")
+	assert.True(t, ok, "a 1.2 log still requires them")
+}
+
+func TestValidate_PageRefs(t *testing.T) {
+	entry := strings.Replace(validEntryJSON, `"time": 12,`, `"time": 12, "pageref": "page_2",`, 1)
+	har := `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1"}, "entries": [` + entry + `],
+		"pages": [{"startedDateTime": "2025-03-01T10:00:00.000Z", "id": "page_1", "title": "x", "pageTimings": {}}]}}`
+
+	report := Validate(strings.NewReader(har))
+
+	issue, ok := issueAt(report, 0, "pageref")
+	require.True(t, ok, "pages listed after entries are still checked")
+	assert.Contains(t, issue.Message, "page_2")
+}
+
+func TestValidate_Base64Content(t *testing.T) {
+	entry := strings.Replace(validEntryJSON, `"text": "hello"`, `"text": "aGVsbG8=", "encoding": "base64"`, 1)
+	report := Validate(strings.NewReader(validationHAR(entry)))
+	assert.Empty(t, report.Issues, "size is compared with the decoded body")
+
+	entry = strings.Replace(validEntryJSON, `"text": "hello"`, `"text": "!!!", "encoding": "base64"`, 1)
+	report = Validate(strings.NewReader(validationHAR(entry)))
+	_, ok := issueAt(report, 0, "response.content.text")
+	assert.True(t, ok)
+}
+
+func TestValidateFile_Gzip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.har.gz")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	gz := gzip.NewWriter(file)
+	_, err = gz.Write([]byte(validationHAR(validEntryJSON)))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	require.NoError(t, file.Close())
+
+	report, err := ValidateFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Entries)
+	assert.Empty(t, report.Issues)
+}
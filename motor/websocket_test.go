@@ -0,0 +1,118 @@
+package motor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const webSocketFixture = `{
+  "log": {
+    "version": "1.2",
+    "creator": {"name": "test", "version": "1.0"},
+    "entries": [
+      {
+        "startedDateTime": "2024-01-01T00:00:00Z",
+        "time": 10,
+        "request": {"method": "GET", "url": "https://example.com/plain", "headers": [], "bodySize": 0},
+        "response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 2, "mimeType": "application/json", "text": "{}"}, "bodySize": 2},
+        "timings": {"send": 1, "wait": 8, "receive": 1}
+      },
+      {
+        "startedDateTime": "2024-01-01T00:00:01Z",
+        "time": 5000,
+        "request": {"method": "GET", "url": "wss://example.com/socket", "headers": [], "bodySize": 0},
+        "response": {"status": 101, "statusText": "Switching Protocols", "headers": [], "content": {"size": 0, "mimeType": "x-unknown"}, "bodySize": 0},
+        "timings": {"send": 1, "wait": 8, "receive": 1},
+        "_webSocketMessages": [
+          {"type": "send", "time": 1704067201.123, "opcode": 1, "data": "{\"subscribe\":\"ticker\"}"},
+          {"type": "receive", "time": 1704067201.456, "opcode": 1, "data": "{\"price\":\"realtimequote\"}"},
+          {"type": "receive", "time": 1704067202.789, "opcode": 1, "data": "{\"price\":42}"}
+        ]
+      }
+    ]
+  }
+}`
+
+func writeWebSocketFixture(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "websocket.har")
+	require.NoError(t, os.WriteFile(path, []byte(webSocketFixture), 0644))
+	return path
+}
+
+func TestWebSocketMessages_Indexed(t *testing.T) {
+	harFile := writeWebSocketFixture(t)
+
+	opts := DefaultStreamerOptions()
+	opts.IndexWebSockets = true
+
+	streamer, err := NewHARStreamer(harFile, opts)
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	index := streamer.GetIndex()
+	require.Equal(t, 2, index.TotalEntries)
+
+	assert.Equal(t, 0, index.Entries[0].WebSocketSent)
+	assert.Equal(t, 1, index.Entries[1].WebSocketSent)
+	assert.Equal(t, 2, index.Entries[1].WebSocketReceived)
+
+	// full entry exposes the frames
+	entry, err := streamer.GetEntry(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, entry.WebSocketMessages, 3)
+	assert.Equal(t, "send", entry.WebSocketMessages[0].Type)
+}
+
+func TestWebSocketMessages_NotCountedByDefault(t *testing.T) {
+	harFile := writeWebSocketFixture(t)
+
+	streamer, err := NewHARStreamer(harFile, DefaultStreamerOptions())
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	index := streamer.GetIndex()
+	require.Equal(t, 2, index.TotalEntries)
+	assert.Equal(t, 0, index.Entries[1].WebSocketSent+index.Entries[1].WebSocketReceived)
+}
+
+func TestWebSocketMessages_Searchable(t *testing.T) {
+	harFile := writeWebSocketFixture(t)
+
+	opts := DefaultStreamerOptions()
+	opts.IndexWebSockets = true
+
+	streamer, err := NewHARStreamer(harFile, opts)
+	require.NoError(t, err)
+	defer streamer.Close()
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	reader, err := NewEntryReader(harFile, streamer.GetIndex())
+	require.NoError(t, err)
+	defer reader.Close()
+
+	searcher := NewSearcher(streamer, reader)
+
+	// frames are not searched unless enabled
+	resultChan, err := searcher.Search(context.Background(), "realtimequote", DefaultSearchOptions)
+	require.NoError(t, err)
+	assert.Empty(t, collectResults(resultChan))
+
+	searchOpts := DefaultSearchOptions
+	searchOpts.SearchWebSockets = true
+
+	resultChan, err = searcher.Search(context.Background(), "realtimequote", searchOpts)
+	require.NoError(t, err)
+
+	results := collectResults(resultChan)
+	require.Len(t, results, 1)
+	assert.Equal(t, 1, results[0].Index)
+	assert.Equal(t, "websocket.message", results[0].Field)
+}
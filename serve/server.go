@@ -0,0 +1,330 @@
+// Package serve exposes an indexed HAR file over HTTP: a small read-only JSON API and a static
+// page to page through and search it from a browser.
+package serve
+
+import (
+	"cmp"
+	"context"
+	_ "embed"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pb33f/harific/motor"
+)
+
+const (
+	// DefaultPageSize is how many entries /api/entries returns without a limit
+	DefaultPageSize = 100
+
+	// MaxPageSize caps the limit of /api/entries
+	MaxPageSize = 1000
+
+	// DefaultSearchLimit is how many matches /api/search returns without a limit
+	DefaultSearchLimit = 100
+
+	// MaxSearchLimit caps the limit of /api/search
+	MaxSearchLimit = 10000
+)
+
+//go:embed index.html
+var indexPage []byte
+
+// BodyStreamer is implemented by readers that stream a response body without loading its entry,
+// such as motor.DefaultEntryReader
+type BodyStreamer interface {
+	StreamResponseBody(offset int64) (io.ReadCloser, error)
+}
+
+// Server serves one indexed HAR file. it never writes to the file, so it can be shared read-only.
+type Server struct {
+	streamer motor.HARStreamer
+	reader   motor.EntryReader
+	bodies   BodyStreamer
+	mux      *http.ServeMux
+}
+
+// EntrySummary is an entry as listed by /api/entries, taken from the index without reading the file
+type EntrySummary struct {
+	Index       int       `json:"index"`
+	Method      string    `json:"method"`
+	URL         string    `json:"url"`
+	Status      int       `json:"status"`
+	StatusText  string    `json:"statusText,omitempty"`
+	RedirectURL string    `json:"redirectURL,omitempty"`
+	MimeType    string    `json:"mimeType,omitempty"`
+	Size        int64     `json:"size"`
+	Duration    float64   `json:"time"`
+	Started     time.Time `json:"startedDateTime"`
+}
+
+// EntryPage is the response of /api/entries
+type EntryPage struct {
+	Total   int            `json:"total"`
+	Offset  int            `json:"offset"`
+	Entries []EntrySummary `json:"entries"`
+}
+
+// SearchMatch is one match in the response of /api/search
+type SearchMatch struct {
+	Index   int    `json:"index"`
+	Field   string `json:"field"`
+	URL     string `json:"url"`
+	Status  int    `json:"status"`
+	Snippet string `json:"snippet,omitempty"`
+}
+
+// SearchResponse is the response of /api/search
+type SearchResponse struct {
+	Query     string        `json:"query"`
+	Matches   []SearchMatch `json:"matches"`
+	Truncated bool          `json:"truncated"` // the limit was reached; more matches may exist
+}
+
+// NewServer serves the entries of an initialized streamer. reader is searched and, when it is a
+// BodyStreamer, response bodies are streamed from it rather than loaded with their entry.
+func NewServer(streamer motor.HARStreamer, reader motor.EntryReader) *Server {
+	s := &Server{streamer: streamer, reader: reader, mux: http.NewServeMux()}
+	s.bodies, _ = reader.(BodyStreamer)
+
+	s.mux.HandleFunc("GET /{$}", s.handleIndex)
+	s.mux.HandleFunc("GET /api/entries", s.handleEntries)
+	s.mux.HandleFunc("GET /api/entries/{index}", s.handleEntry)
+	s.mux.HandleFunc("GET /api/entries/{index}/body", s.handleBody)
+	s.mux.HandleFunc("GET /api/search", s.handleSearch)
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(indexPage)
+}
+
+// handleEntries lists entries [offset, offset+limit) from the index
+func (s *Server) handleEntries(w http.ResponseWriter, r *http.Request) {
+	offset, err := intParam(r, "offset", 0, 0)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	limit, err := intParam(r, "limit", DefaultPageSize, MaxPageSize)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	index := s.streamer.GetIndex()
+	total := index.TotalEntries
+	page := EntryPage{Total: total, Offset: offset, Entries: []EntrySummary{}}
+	for i := offset; i < min(offset+limit, total); i++ {
+		metadata, err := s.streamer.GetMetadata(i)
+		if err != nil {
+			break // the index shrank under us
+		}
+		page.Entries = append(page.Entries, summarize(i, metadata))
+	}
+	writeJSON(w, page)
+}
+
+// handleEntry returns a whole entry, leaving out the response body text: the page fetches that
+// from /api/entries/{index}/body when it is wanted
+func (s *Server) handleEntry(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.entryIndex(w, r)
+	if !ok {
+		return
+	}
+
+	entry, err := s.streamer.GetEntry(r.Context(), i)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	copied := *entry // the streamer may cache the entry it returned
+	copied.Response.Body.Content = ""
+	writeJSON(w, copied)
+}
+
+// handleBody streams the response body text of an entry. ?encoding=base64 decodes a body stored
+// as base64 and serves it as its mime type; anything else is served as the stored text.
+func (s *Server) handleBody(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.entryIndex(w, r)
+	if !ok {
+		return
+	}
+	metadata, err := s.streamer.GetMetadata(i)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	var body io.ReadCloser
+	if s.bodies != nil {
+		body, err = s.bodies.StreamResponseBody(metadata.FileOffset)
+	} else {
+		body, err = s.loadBody(r.Context(), i)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	defer body.Close()
+
+	var content io.Reader = body
+	contentType := "text/plain; charset=utf-8"
+	if r.URL.Query().Get("encoding") == "base64" {
+		content = base64.NewDecoder(base64.StdEncoding, body)
+		contentType = cmp.Or(metadata.MimeType, "application/octet-stream")
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "sandbox") // a captured html or svg body must not run scripts here
+
+	// too late for an error status once the body has started
+	io.Copy(w, content)
+}
+
+// loadBody is the body of a reader that cannot stream one, read with its entry
+func (s *Server) loadBody(ctx context.Context, i int) (io.ReadCloser, error) {
+	entry, err := s.streamer.GetEntry(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(strings.NewReader(entry.Response.Body.Content)), nil
+}
+
+// handleSearch runs a search: q is the query, mode one of plain (the default), regex, glob or
+// fuzzy, bodies=true searches response bodies too, requestBodies=false skips request bodies and
+// limit, at least 1, caps the matches returned
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("missing query parameter q"))
+		return
+	}
+	limit, err := intParam(r, "limit", DefaultSearchLimit, MaxSearchLimit)
+	if err == nil && limit == 0 {
+		// MaxResults of 0 is unlimited, which would get round MaxSearchLimit
+		err = fmt.Errorf("invalid limit %q (must be at least 1)", r.URL.Query().Get("limit"))
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	opts := motor.DefaultSearchOptions
+	switch mode := r.URL.Query().Get("mode"); mode {
+	case "", "plain":
+	case "regex":
+		opts.Mode = motor.Regex
+	case "glob":
+		opts.Mode = motor.Glob
+	case "fuzzy":
+		opts.Mode = motor.Fuzzy
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unknown search mode %q (expected plain, regex, glob or fuzzy)", mode))
+		return
+	}
+	opts.SearchResponseBody = r.URL.Query().Get("bodies") == "true"
+	opts.SkipRequestBody = r.URL.Query().Get("requestBodies") == "false"
+	opts.CaseInsensitive = r.URL.Query().Get("ignoreCase") == "true"
+	opts.MaxResults = limit
+
+	searcher := motor.NewSearcher(s.streamer, s.reader)
+	results, err := searcher.Search(r.Context(), query, opts)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	response := SearchResponse{Query: query, Matches: []SearchMatch{}}
+	for batch := range results {
+		for _, result := range batch {
+			if result.Error != nil {
+				continue
+			}
+			match := SearchMatch{Index: result.Index, Field: result.Field, Snippet: result.Snippet}
+			if metadata, err := s.streamer.GetMetadata(result.Index); err == nil {
+				match.URL = metadata.URL
+				match.Status = metadata.StatusCode
+			}
+			response.Matches = append(response.Matches, match)
+		}
+	}
+	if err := r.Context().Err(); err != nil {
+		return // the client went away
+	}
+
+	// workers finish out of order; the page lists matches in file order
+	slices.SortStableFunc(response.Matches, func(a, b SearchMatch) int { return cmp.Compare(a.Index, b.Index) })
+	response.Truncated = searcher.Stats().Truncated
+	writeJSON(w, response)
+}
+
+// entryIndex reads the {index} path value, answering 400 or 404 itself when it is not an entry
+func (s *Server) entryIndex(w http.ResponseWriter, r *http.Request) (int, bool) {
+	i, err := strconv.Atoi(r.PathValue("index"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid entry index %q", r.PathValue("index")))
+		return 0, false
+	}
+	if total := s.streamer.GetIndex().TotalEntries; i < 0 || i >= total {
+		writeError(w, http.StatusNotFound, fmt.Errorf("entry %d out of range [0, %d)", i, total))
+		return 0, false
+	}
+	return i, true
+}
+
+func summarize(i int, metadata *motor.EntryMetadata) EntrySummary {
+	return EntrySummary{
+		Index:       i,
+		Method:      metadata.Method,
+		URL:         metadata.URL,
+		Status:      metadata.StatusCode,
+		StatusText:  metadata.StatusText,
+		RedirectURL: metadata.RedirectURL,
+		MimeType:    metadata.MimeType,
+		Size:        metadata.ResponseSize,
+		Duration:    metadata.Duration,
+		Started:     metadata.Timestamp,
+	}
+}
+
+// intParam reads a non-negative integer query parameter, fallback when absent and clamped to
+// limit when limit is above 0
+func intParam(r *http.Request, name string, fallback, limit int) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return fallback, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid %s %q", name, raw)
+	}
+	if limit > 0 {
+		value = min(value, limit)
+	}
+	return value, nil
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
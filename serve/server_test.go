@@ -0,0 +1,170 @@
+package serve
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/harific/motor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEntry(path string, status int, mimeType, text, encoding string) string {
+	return fmt.Sprintf(`{"startedDateTime": "2024-01-01T00:00:00Z", "time": 12,
+ "request": {"method": "GET", "url": "https://example.com/%s", "headers": [], "bodySize": 0},
+ "response": {"status": %d, "statusText": "", "headers": [], "content": {"size": %d, "mimeType": %q, "text": %q, "encoding": %q}, "bodySize": %d}}`,
+		path, status, len(text), mimeType, text, encoding, len(text))
+}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	entries := []string{
+		testEntry("users", 200, "application/json", `{"name": "alice"}`, ""),
+		testEntry("missing", 404, "text/plain", "not found", ""),
+		testEntry("logo.png", 200, "image/png", base64.StdEncoding.EncodeToString([]byte("\x89PNG")), "base64"),
+	}
+	har := `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1.0"}, "entries": [` +
+		strings.Join(entries, ",") + `]}}`
+	path := filepath.Join(t.TempDir(), "serve.har")
+	require.NoError(t, os.WriteFile(path, []byte(har), 0644))
+
+	streamer, err := motor.NewHARStreamer(path, motor.DefaultStreamerOptions())
+	require.NoError(t, err)
+	t.Cleanup(func() { streamer.Close() })
+	require.NoError(t, streamer.Initialize(context.Background()))
+
+	reader, err := motor.NewEntryReader(path, streamer.GetIndex())
+	require.NoError(t, err)
+	t.Cleanup(func() { reader.Close() })
+
+	server := httptest.NewServer(NewServer(streamer, reader))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func get(t *testing.T, server *httptest.Server, path string) (*http.Response, []byte) {
+	t.Helper()
+	resp, err := http.Get(server.URL + path)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, body
+}
+
+func TestServer_Entries(t *testing.T) {
+	server := newTestServer(t)
+
+	resp, body := get(t, server, "/api/entries?offset=1&limit=5")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var page EntryPage
+	require.NoError(t, json.Unmarshal(body, &page))
+	assert.Equal(t, 3, page.Total)
+	assert.Equal(t, 1, page.Offset)
+	require.Len(t, page.Entries, 2)
+	assert.Equal(t, 1, page.Entries[0].Index)
+	assert.Equal(t, "https://example.com/missing", page.Entries[0].URL)
+	assert.Equal(t, 404, page.Entries[0].Status)
+
+	resp, _ = get(t, server, "/api/entries?limit=lots")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestServer_Entry(t *testing.T) {
+	server := newTestServer(t)
+
+	resp, body := get(t, server, "/api/entries/0")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var entry struct {
+		Request  struct{ URL string }
+		Response struct {
+			Content struct {
+				Size int64
+				Text string
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(body, &entry))
+	assert.Equal(t, "https://example.com/users", entry.Request.URL)
+	assert.Equal(t, int64(17), entry.Response.Content.Size)
+	assert.Empty(t, entry.Response.Content.Text, "the body text is left to the body endpoint")
+
+	for path, status := range map[string]int{"/api/entries/3": http.StatusNotFound, "/api/entries/x": http.StatusBadRequest} {
+		resp, _ := get(t, server, path)
+		assert.Equal(t, status, resp.StatusCode, path)
+	}
+}
+
+func TestServer_Body(t *testing.T) {
+	server := newTestServer(t)
+
+	resp, body := get(t, server, "/api/entries/0/body")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `{"name": "alice"}`, string(body))
+	assert.Equal(t, "text/plain; charset=utf-8", resp.Header.Get("Content-Type"))
+
+	resp, body = get(t, server, "/api/entries/2/body?encoding=base64")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "\x89PNG", string(body))
+	assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "sandbox", resp.Header.Get("Content-Security-Policy"))
+}
+
+func TestServer_Search(t *testing.T) {
+	server := newTestServer(t)
+
+	resp, body := get(t, server, "/api/search?q=example.com")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result SearchResponse
+	require.NoError(t, json.Unmarshal(body, &result))
+	require.Len(t, result.Matches, 3)
+	for i, match := range result.Matches {
+		assert.Equal(t, i, match.Index, "matches are in file order")
+	}
+
+	_, body = get(t, server, "/api/search?q=alice&bodies=true")
+	require.NoError(t, json.Unmarshal(body, &result))
+	require.Len(t, result.Matches, 1)
+	assert.Equal(t, 0, result.Matches[0].Index)
+
+	_, body = get(t, server, "/api/search?q=m*ing&mode=glob")
+	require.NoError(t, json.Unmarshal(body, &result))
+	require.Len(t, result.Matches, 1)
+	assert.Equal(t, "https://example.com/missing", result.Matches[0].URL)
+
+	_, body = get(t, server, "/api/search?q=example.com&limit=1")
+	require.NoError(t, json.Unmarshal(body, &result))
+	assert.Len(t, result.Matches, 1)
+	assert.True(t, result.Truncated)
+
+	// a limit of 0 would be unlimited, past MaxSearchLimit
+	resp, body = get(t, server, "/api/search?q=example.com&limit=0")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Contains(t, string(body), "at least 1")
+
+	for _, path := range []string{"/api/search", "/api/search?q=x&mode=sql", "/api/search?q=(&mode=regex", "/api/search?q=x&limit=-1"} {
+		resp, _ := get(t, server, path)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, path)
+	}
+}
+
+func TestServer_Page(t *testing.T) {
+	server := newTestServer(t)
+
+	resp, body := get(t, server, "/")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/html")
+	assert.Contains(t, string(body), "/api/entries")
+
+	resp, _ = get(t, server, "/nothing-here")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -17,7 +17,12 @@ var (
     renderedDELETE string
 )
 
-func init() {
+// selectedLineMarker is the escape sequence the table's selected row starts with, derived from
+// the theme so the selected row is still recognised after ApplyTheme
+var selectedLineMarker string
+
+// prerenderMethods renders the method strings with the current method styles
+func prerenderMethods() {
     renderedGET = StyleMethodGreen.Render("GET")
     renderedQUERY = StyleMethodGreen.Render("QUERY")
     renderedPATCH = StyleMethodYellow.Render("PATCH")
@@ -36,8 +41,6 @@ func ColorizeHARTableOutput(tableView string, cursor int, rows []table.Row) stri
         selectedIdentifier = rows[cursor][0] + rows[cursor][1] + rows[cursor][2] + rows[cursor][3]
     }
 
-    // ANSI escape sequence for pink background (matches table selected style from styles.go)
-    selectedLineMarker := "\x1b[1;38;5;201;48;2;42;26;42m"
 
     var result strings.Builder
     // estimate output size: input + ANSI overhead per line (~40 bytes per colorized line)
@@ -45,7 +48,7 @@ func ColorizeHARTableOutput(tableView string, cursor int, rows []table.Row) stri
     result.Grow(estimatedSize)
 
     for i, line := range lines {
-        isSelectedLine := strings.Contains(line, selectedLineMarker) ||
+        isSelectedLine := (selectedLineMarker != "" && strings.Contains(line, selectedLineMarker)) ||
             (selectedIdentifier != "" && strings.Contains(line, selectedIdentifier))
 
         // skip header row (i=0) and selected rows (already styled by table)
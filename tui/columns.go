@@ -0,0 +1,214 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/v2/table"
+	"github.com/pb33f/harific/motor"
+)
+
+// ColumnPreset is the set of columns the entry table shows, cycled with C
+type ColumnPreset int
+
+const (
+	ColumnsDefault     ColumnPreset = iota // Method, URL, Status, Size, Duration
+	ColumnsContentType                     // adds the response mime type
+	ColumnsHeaders                         // adds the request / response header counts
+	ColumnsAll                             // adds both
+	ColumnsResource                        // adds chrome's resource type and priority
+	ColumnsTimeline                        // adds the start offset and overlapping request count
+)
+
+// columnPresetNames are how presets are written to the view settings file, in preset order
+var columnPresetNames = []string{"default", "type", "headers", "all", "resource", "timeline"}
+
+// columnSpec describes one table column and how an entry fills it
+type columnSpec struct {
+	title string
+	sort  SortColumn // the sort this column shows the indicator for; its title comes from columnTitles
+	width int        // 0 for the url column, which takes the width the others leave
+
+	// value renders the cell; urlWidth is the text width the url column has
+	value func(entry *motor.EntryMetadata, urlWidth int) string
+
+	// timed renders the cell from the entry's place on the capture timeline, in place of value
+	timed func(timeline motor.TimelineEntry) string
+}
+
+var (
+	methodColumn = columnSpec{sort: SortMethod, width: methodColumnWidth,
+		value: func(e *motor.EntryMetadata, _ int) string { return formatMethod(e.Method) }}
+	urlColumn = columnSpec{sort: SortURL,
+		value: func(e *motor.EntryMetadata, urlWidth int) string { return formatURL(e.URL, urlWidth) }}
+	statusColumn = columnSpec{sort: SortStatus, width: statusColumnWidth,
+		value: func(e *motor.EntryMetadata, _ int) string { return formatStatus(e.StatusCode, e.StatusText) }}
+	typeColumn = columnSpec{title: "Type", width: typeColumnWidth,
+		value: func(e *motor.EntryMetadata, _ int) string { return formatMimeType(e.MimeType) }}
+	headersColumn = columnSpec{title: "Headers", width: headersColumnWidth,
+		value: func(e *motor.EntryMetadata, _ int) string {
+			return fmt.Sprintf("%d/%d", e.RequestHeaders, e.ResponseHeaders)
+		}}
+	resourceColumn = columnSpec{title: "Resource", width: resourceColumnWidth,
+		value: func(e *motor.EntryMetadata, _ int) string { return e.ResourceType }}
+	priorityColumn = columnSpec{title: "Priority", width: priorityColumnWidth,
+		value: func(e *motor.EntryMetadata, _ int) string { return e.Priority }}
+	sizeColumn = columnSpec{sort: SortSize, width: sizeColumnWidth,
+		value: func(e *motor.EntryMetadata, _ int) string { return formatSize(e.ResponseSize) }}
+	durationColumn = columnSpec{sort: SortDuration, width: durationColumnWidth,
+		value: func(e *motor.EntryMetadata, _ int) string { return formatDuration(e.Duration) }}
+	startColumn = columnSpec{title: "Start", width: startColumnWidth,
+		timed: func(t motor.TimelineEntry) string { return formatStartOffset(t.StartOffset) }}
+	overlapColumn = columnSpec{title: "Overlap", width: overlapColumnWidth,
+		timed: func(t motor.TimelineEntry) string { return fmt.Sprintf("%d", t.Concurrent) }}
+)
+
+// ParseColumnPreset reads a preset name as written by String
+func ParseColumnPreset(name string) (ColumnPreset, error) {
+	for i, presetName := range columnPresetNames {
+		if strings.EqualFold(name, presetName) {
+			return ColumnPreset(i), nil
+		}
+	}
+	return ColumnsDefault, fmt.Errorf("unknown column preset %q (expected one of %s)", name, strings.Join(columnPresetNames, ", "))
+}
+
+func (p ColumnPreset) String() string {
+	if p < 0 || int(p) >= len(columnPresetNames) {
+		return columnPresetNames[ColumnsDefault]
+	}
+	return columnPresetNames[p]
+}
+
+// Next cycles to the next preset, wrapping back to the default columns
+func (p ColumnPreset) Next() ColumnPreset {
+	return (p + 1) % ColumnPreset(len(columnPresetNames))
+}
+
+// MarshalText writes the preset by name
+func (p ColumnPreset) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText reads a preset name
+func (p *ColumnPreset) UnmarshalText(text []byte) error {
+	preset, err := ParseColumnPreset(string(text))
+	if err != nil {
+		return err
+	}
+	*p = preset
+	return nil
+}
+
+// specs returns the preset's columns in table order. url stays second, so the injection marker
+// and colorizer find it where they expect, and duration stays last for the colorizer.
+func (p ColumnPreset) specs() []columnSpec {
+	switch p {
+	case ColumnsContentType:
+		return []columnSpec{methodColumn, urlColumn, statusColumn, typeColumn, sizeColumn, durationColumn}
+	case ColumnsHeaders:
+		return []columnSpec{methodColumn, urlColumn, statusColumn, headersColumn, sizeColumn, durationColumn}
+	case ColumnsAll:
+		return []columnSpec{methodColumn, urlColumn, statusColumn, typeColumn, headersColumn, sizeColumn, durationColumn}
+	case ColumnsResource:
+		return []columnSpec{methodColumn, urlColumn, statusColumn, resourceColumn, priorityColumn, sizeColumn, durationColumn}
+	case ColumnsTimeline:
+		return []columnSpec{methodColumn, urlColumn, statusColumn, startColumn, overlapColumn, sizeColumn, durationColumn}
+	default:
+		return []columnSpec{methodColumn, urlColumn, statusColumn, sizeColumn, durationColumn}
+	}
+}
+
+// fixedWidth is the width taken by every column but url. columns past the default five also
+// count their cell padding, which borderPadding only allows for the default set.
+func (p ColumnPreset) fixedWidth() int {
+	specs := p.specs()
+	width := 2 * (len(specs) - len(columnTitles))
+	for _, spec := range specs {
+		width += spec.width
+	}
+	return width
+}
+
+// urlColumnWidth is the width of the url column on a terminal this wide
+func (p ColumnPreset) urlColumnWidth(terminalWidth int) int {
+	return max(terminalWidth-p.fixedWidth()-borderPadding, minURLColumnWidth)
+}
+
+// urlTextWidth is how much of a url is shown before it is cut short with "..."
+func (p ColumnPreset) urlTextWidth(terminalWidth int) int {
+	return max(terminalWidth-p.fixedWidth()-borderPadding-6, minURLColumnWidth)
+}
+
+// columns returns the table headers and widths for a terminal this wide, marking the sorted column
+func (p ColumnPreset) columns(terminalWidth int, sort TableSort) []table.Column {
+	specs := p.specs()
+	columns := make([]table.Column, len(specs))
+	for i, spec := range specs {
+		columns[i] = table.Column{Title: spec.title, Width: spec.width}
+		if spec.sort != SortNone {
+			columns[i].Title = sort.ColumnTitle(int(spec.sort) - 1)
+		}
+		if spec.width == 0 {
+			columns[i].Width = p.urlColumnWidth(terminalWidth)
+		}
+	}
+	return columns
+}
+
+// row renders an entry's cells for the preset's columns, leaving timeline columns empty
+func (p ColumnPreset) row(entry *motor.EntryMetadata, terminalWidth int) table.Row {
+	return p.timedRow(entry, nil, terminalWidth)
+}
+
+// timedRow renders an entry's cells, filling timeline columns from timeline ("---" when nil)
+func (p ColumnPreset) timedRow(entry *motor.EntryMetadata, timeline *motor.TimelineEntry, terminalWidth int) table.Row {
+	urlWidth := p.urlTextWidth(terminalWidth)
+	specs := p.specs()
+	row := make(table.Row, len(specs))
+	for i, spec := range specs {
+		switch {
+		case spec.timed == nil:
+			row[i] = spec.value(entry, urlWidth)
+		case timeline != nil:
+			row[i] = spec.timed(*timeline)
+		default:
+			row[i] = "---"
+		}
+	}
+	return row
+}
+
+// usesTimeline reports whether any of the preset's columns come from the capture timeline
+func (p ColumnPreset) usesTimeline() bool {
+	for _, spec := range p.specs() {
+		if spec.timed != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// formatStartOffset renders an offset from the capture start, e.g. +250ms, +1.25s or +2m05s
+func formatStartOffset(offset time.Duration) string {
+	switch {
+	case offset < time.Second:
+		return fmt.Sprintf("+%dms", offset.Milliseconds())
+	case offset < time.Minute:
+		return fmt.Sprintf("+%.2fs", offset.Seconds())
+	default:
+		return fmt.Sprintf("+%dm%02ds", int(offset.Minutes()), int(offset.Seconds())%60)
+	}
+}
+
+// formatMimeType drops parameters such as charset, leaving "application/json" of
+// "application/json; charset=utf-8"
+func formatMimeType(mimeType string) string {
+	mediaType, _, _ := strings.Cut(mimeType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	if mediaType == "" {
+		return "---"
+	}
+	return mediaType
+}
@@ -0,0 +1,192 @@
+package tui
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/v2/table"
+	"github.com/pb33f/harific/motor"
+)
+
+func TestColumnPreset_Rows(t *testing.T) {
+	entry := &motor.EntryMetadata{Method: "GET", URL: "https://example.com/api", StatusCode: 200,
+		MimeType: "application/json; charset=utf-8", RequestHeaders: 3, ResponseHeaders: 5, ResponseSize: 512, Duration: 20,
+		ResourceType: "fetch", Priority: "High"}
+
+	tests := []struct {
+		preset ColumnPreset
+		titles []string
+	}{
+		{ColumnsDefault, []string{"Method", "URL", "Status", "Size", "Duration"}},
+		{ColumnsContentType, []string{"Method", "URL", "Status", "Type", "Size", "Duration"}},
+		{ColumnsHeaders, []string{"Method", "URL", "Status", "Headers", "Size", "Duration"}},
+		{ColumnsAll, []string{"Method", "URL", "Status", "Type", "Headers", "Size", "Duration"}},
+		{ColumnsResource, []string{"Method", "URL", "Status", "Resource", "Priority", "Size", "Duration"}},
+	}
+
+	for _, tt := range tests {
+		columns := tt.preset.columns(120, TableSort{})
+		titles := make([]string, len(columns))
+		width := 0
+		for i, column := range columns {
+			titles[i] = column.Title
+			width += column.Width
+		}
+		if !slices.Equal(titles, tt.titles) {
+			t.Errorf("%s columns = %v, want %v", tt.preset, titles, tt.titles)
+		}
+		if want := 120 - borderPadding - 2*(len(columns)-len(columnTitles)); width != want {
+			t.Errorf("%s columns are %d wide, want %d", tt.preset, width, want)
+		}
+
+		row := tt.preset.row(entry, 120)
+		if len(row) != len(columns) {
+			t.Fatalf("%s row has %d cells for %d columns", tt.preset, len(row), len(columns))
+		}
+		if i := slices.Index(titles, "Type"); i >= 0 && row[i] != "application/json" {
+			t.Errorf("type cell = %q", row[i])
+		}
+		if i := slices.Index(titles, "Headers"); i >= 0 && row[i] != "3/5" {
+			t.Errorf("headers cell = %q", row[i])
+		}
+		if i := slices.Index(titles, "Resource"); i >= 0 && (row[i] != "fetch" || row[i+1] != "High") {
+			t.Errorf("resource cells = %q, %q", row[i], row[i+1])
+		}
+	}
+}
+
+func TestColumnPreset_Names(t *testing.T) {
+	for preset := ColumnsDefault; preset <= ColumnsTimeline; preset++ {
+		parsed, err := ParseColumnPreset(preset.String())
+		if err != nil || parsed != preset {
+			t.Errorf("ParseColumnPreset(%q) = %v, %v", preset.String(), parsed, err)
+		}
+	}
+	if _, err := ParseColumnPreset("wide"); err == nil {
+		t.Error("expected an error for an unknown preset")
+	}
+	if ColumnsTimeline.Next() != ColumnsDefault {
+		t.Error("Next should wrap back to the default columns")
+	}
+}
+
+func TestCycleColumnPreset(t *testing.T) {
+	entries, rows := sortTestEntries()
+	settings := &ViewSettings{}
+	m := &HARViewModel{
+		allEntries:     entries,
+		rows:           rows,
+		width:          120,
+		columns:        ColumnsDefault.columns(120, TableSort{}),
+		filterChain:    NewFilterChain(),
+		searchFilter:   NewSearchFilter(),
+		fileTypeFilter: NewFileTypeFilter(),
+		methodFilter:   NewMethodFilter(),
+		statusFilter:   NewStatusClassFilter(),
+		timeFilter:     NewTimeRangeFilter(),
+	}
+	m.SetViewSettings(settings)
+	m.table = table.New(table.WithColumns(m.columns), table.WithRows(rows), table.WithHeight(10))
+	m.filteredIndices = []int{0, 1, 2}
+	m.table.SetCursor(2)
+	m.selectedIndex = 2
+
+	// all the way round, through the widest presets back to the narrowest
+	for _, want := range []ColumnPreset{ColumnsContentType, ColumnsHeaders, ColumnsAll, ColumnsResource, ColumnsTimeline, ColumnsDefault} {
+		m.cycleColumnPreset()
+		if m.columnPreset != want || settings.Columns != want {
+			t.Fatalf("preset = %s, settings = %s, want %s", m.columnPreset, settings.Columns, want)
+		}
+		if len(m.table.Rows()[0]) != len(m.table.Columns()) {
+			t.Fatalf("%s: rows have %d cells for %d columns", want, len(m.table.Rows()[0]), len(m.table.Columns()))
+		}
+		if m.selectedEntryIndex() != 2 {
+			t.Errorf("%s: selected entry = %d, want 2", want, m.selectedEntryIndex())
+		}
+		m.table.View()
+	}
+}
+
+func TestViewSettingsSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "harific", "view.json")
+
+	settings, err := LoadViewSettings(path)
+	if err != nil {
+		t.Fatalf("missing file should load the defaults: %v", err)
+	}
+	if settings.Columns != ColumnsDefault {
+		t.Errorf("default columns = %s", settings.Columns)
+	}
+
+	settings.Columns = ColumnsHeaders
+	if err := settings.Save(path); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	loaded, err := LoadViewSettings(path)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if loaded.Columns != ColumnsHeaders {
+		t.Errorf("loaded columns = %s, want headers", loaded.Columns)
+	}
+}
+
+func TestViewSettings_SearchPreferences(t *testing.T) {
+	m, err := NewHARViewModel("test.har")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.searchDebounce != 300*time.Millisecond || m.detailDebounce != 200*time.Millisecond {
+		t.Errorf("default debounce = %s / %s, want 300ms / 200ms", m.searchDebounce, m.detailDebounce)
+	}
+	m.toggleSearchView()
+	if !m.searchOptions[3] {
+		t.Error("live search should be on by default")
+	}
+
+	m.SetViewSettings(&ViewSettings{SearchDebounceMS: 120, DisableLiveSearch: true})
+	if m.searchDebounce != 120*time.Millisecond || m.detailDebounce != 200*time.Millisecond {
+		t.Errorf("debounce = %s / %s, want 120ms / 200ms", m.searchDebounce, m.detailDebounce)
+	}
+	m.toggleSearchView()
+	if m.searchOptions[3] {
+		t.Error("live search should start off when the settings disable it")
+	}
+
+	m.SetSearchDebounce(50 * time.Millisecond)
+	if m.searchDebounce != 50*time.Millisecond {
+		t.Errorf("flag override = %s, want 50ms", m.searchDebounce)
+	}
+}
+
+func TestColumnPreset_Timeline(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	entries := []*motor.EntryMetadata{
+		{Method: "GET", URL: "https://example.com/a", StatusCode: 200, Timestamp: start, Duration: 1000},
+		{Method: "GET", URL: "https://example.com/b", StatusCode: 200, Timestamp: start.Add(1250 * time.Millisecond), Duration: 10},
+		{Method: "GET", URL: "https://example.com/c", StatusCode: 200, Timestamp: start.Add(500 * time.Millisecond), Duration: 10},
+		{Method: "GET", URL: "https://example.com/d", StatusCode: 200},
+	}
+	m := &HARViewModel{index: &motor.Index{Entries: entries}, allEntries: entries, columnPreset: ColumnsTimeline}
+
+	want := [][2]string{{"+0ms", "1"}, {"+1.25s", "0"}, {"+500ms", "1"}, {"---", "---"}}
+	for i, cells := range want {
+		row := m.entryRow(i, 160)
+		if row[3] != cells[0] || row[4] != cells[1] {
+			t.Errorf("entry %d: start %q, overlap %q, want %q, %q", i, row[3], row[4], cells[0], cells[1])
+		}
+	}
+	if m.timeline == nil {
+		t.Error("the timeline should be laid out for the timeline columns")
+	}
+
+	m.timeline = nil
+	m.columnPreset = ColumnsDefault
+	m.entryRow(0, 160)
+	if m.timeline != nil {
+		t.Error("presets without timeline columns should not lay out the timeline")
+	}
+}
@@ -11,6 +11,12 @@ const (
 	statusColumnWidth   = 10
 	sizeColumnWidth     = 10
 	durationColumnWidth = 11
+	typeColumnWidth     = 24
+	headersColumnWidth  = 8
+	resourceColumnWidth = 12
+	priorityColumnWidth = 10
+	startColumnWidth    = 10
+	overlapColumnWidth  = 8
 
 	// Search panel dimensions
 	searchPanelHeightRatio = 0.3  // 30% of vertical space
@@ -23,5 +29,17 @@ const (
 	searchCursorOpt2  = 2
 	searchCursorOpt3  = 3
 	searchCursorOpt4  = 4
-	searchCursorCount = 5
+	searchCursorOpt5  = 5
+	searchCursorOpt6  = 6
+	searchCursorOpt7  = 7
+	searchCursorOpt8  = 8
+	searchCursorOpt9  = 9
+	searchCursorCount = 10
+
+	// Debounce delays in milliseconds, unless the view settings or --debounce say otherwise
+	defaultSearchDebounceMS = 300 // gives users time to finish typing
+	defaultDetailDebounceMS = 200
+
+	// maxSearchResults caps matches collected per search so broad queries on huge files stay bounded
+	maxSearchResults = 10000
 )
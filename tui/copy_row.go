@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/pb33f/harific/motor"
+)
+
+// selectedMetadata is the index entry under the cursor, nil on a page header or an empty table
+func (m *HARViewModel) selectedMetadata() *motor.EntryMetadata {
+	index := m.selectedEntryIndex()
+	if index < 0 || index >= len(m.allEntries) {
+		return nil
+	}
+	return m.allEntries[index]
+}
+
+// copySelectedURL copies the selected entry's URL to the clipboard
+func (m *HARViewModel) copySelectedURL() tea.Cmd {
+	metadata := m.selectedMetadata()
+	if metadata == nil {
+		return nil
+	}
+	return copyToClipboard(metadata.URL, "Copied URL")
+}
+
+// copySelectedRow copies the selected entry's row to the clipboard as tab separated values
+func (m *HARViewModel) copySelectedRow() tea.Cmd {
+	metadata := m.selectedMetadata()
+	if metadata == nil {
+		return nil
+	}
+	return copyToClipboard(entryTSV(metadata), "Copied row as TSV")
+}
+
+// entryTSV is one line of tab separated values: method, url, status, mime type, response size,
+// time in milliseconds and start time. raw values rather than the table's formatted cells, so
+// the line pastes into a spreadsheet as numbers.
+func entryTSV(metadata *motor.EntryMetadata) string {
+	started := ""
+	if !metadata.Timestamp.IsZero() {
+		started = metadata.Timestamp.Format(time.RFC3339Nano)
+	}
+	fields := []string{
+		metadata.Method,
+		metadata.URL,
+		strconv.Itoa(metadata.StatusCode),
+		metadata.MimeType,
+		strconv.FormatInt(metadata.ResponseSize, 10),
+		strconv.FormatFloat(metadata.Duration, 'f', -1, 64),
+		started,
+	}
+	for i, field := range fields {
+		fields[i] = tsvReplacer.Replace(field)
+	}
+	return strings.Join(fields, "\t")
+}
+
+// tsvReplacer keeps a value in its own cell
+var tsvReplacer = strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
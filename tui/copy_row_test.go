@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pb33f/harific/motor"
+)
+
+func TestEntryTSV(t *testing.T) {
+	entry := &motor.EntryMetadata{Method: "POST", URL: "https://example.com/a\tb", StatusCode: 201,
+		MimeType: "application/json", ResponseSize: 512, Duration: 12.5,
+		Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+	want := "POST\thttps://example.com/a b\t201\tapplication/json\t512\t12.5\t2024-01-02T03:04:05Z"
+	if got := entryTSV(entry); got != want {
+		t.Errorf("entryTSV = %q, want %q", got, want)
+	}
+
+	if got := entryTSV(&motor.EntryMetadata{Method: "GET"}); got != "GET\t\t0\t\t0\t0\t" {
+		t.Errorf("empty entry = %q", got)
+	}
+}
+
+func TestSelectedMetadata_Filtered(t *testing.T) {
+	m := newJumpTestModel(10)
+	m.allEntries[4].URL = "https://example.com/four"
+	m.filteredIndices = []int{pageHeaderIndex, 8, 4}
+
+	m.selectedIndex = 2
+	if got := m.selectedMetadata(); got == nil || got.URL != "https://example.com/four" {
+		t.Errorf("selected metadata = %+v, want entry 4", got)
+	}
+
+	m.selectedIndex = 0
+	if got := m.selectedMetadata(); got != nil {
+		t.Errorf("a page header should have no metadata, got %+v", got)
+	}
+	if cmd := m.copySelectedURL(); cmd != nil {
+		t.Error("copying on a page header should do nothing")
+	}
+}
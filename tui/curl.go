@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/pb33f/harific/motor/model"
+)
+
+// statusMessageDuration is how long a transient status bar message stays visible
+const statusMessageDuration = 2 * time.Second
+
+// statusMessageMsg shows a transient message in the status bar
+type statusMessageMsg struct {
+	text string
+}
+
+// clearStatusMessageMsg clears the status bar message if no newer one replaced it
+type clearStatusMessageMsg struct {
+	id int64
+}
+
+// curlSkippedHeaders are recomputed by curl or only meaningful to the original connection
+var curlSkippedHeaders = map[string]bool{
+	"content-length":    true,
+	"host":              true,
+	"connection":        true,
+	"transfer-encoding": true,
+}
+
+// BuildCurlCommand renders a request as a curl command that reproduces it
+func BuildCurlCommand(req *model.Request) string {
+	var parts []string
+	parts = append(parts, "curl")
+
+	method := strings.ToUpper(req.Method)
+	if method == "" {
+		method = "GET"
+	}
+	hasBody := req.Body.Content != "" || len(req.Body.Params) > 0
+	// curl defaults to GET, or POST once --data is given
+	if !(method == "GET" && !hasBody) && !(method == "POST" && hasBody) {
+		parts = append(parts, "-X", method)
+	}
+
+	parts = append(parts, shellQuote(curlURL(req)))
+
+	for _, header := range req.Headers {
+		// http/2 pseudo headers (:authority, :path, ...) are not real request headers
+		if strings.HasPrefix(header.Name, ":") || curlSkippedHeaders[strings.ToLower(header.Name)] {
+			continue
+		}
+		parts = append(parts, "-H", shellQuote(header.Name+": "+header.Value))
+	}
+
+	switch {
+	case req.Body.Content != "":
+		parts = append(parts, "--data-raw", shellQuote(req.Body.Content))
+	case len(req.Body.Params) > 0:
+		for _, param := range req.Body.Params {
+			parts = append(parts, "--data-urlencode", shellQuote(param.Name+"="+param.Value))
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// curlURL returns the request URL, rebuilding the query string from QueryParams when the URL lacks one
+func curlURL(req *model.Request) string {
+	if len(req.QueryParams) == 0 || strings.Contains(req.URL, "?") {
+		return req.URL
+	}
+
+	values := url.Values{}
+	for _, param := range req.QueryParams {
+		values.Add(param.Name, param.Value)
+	}
+	return req.URL + "?" + values.Encode()
+}
+
+// shellQuote wraps s in single quotes for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// copyToClipboard writes text to the system clipboard, falling back to the terminal's
+// OSC 52 clipboard (which also works over ssh) when no native clipboard is available
+func copyToClipboard(text, confirmation string) tea.Cmd {
+	if err := clipboard.WriteAll(text); err != nil {
+		return tea.Batch(tea.SetClipboard(text), showStatusMessage(confirmation))
+	}
+	return showStatusMessage(confirmation)
+}
+
+func showStatusMessage(text string) tea.Cmd {
+	return func() tea.Msg {
+		return statusMessageMsg{text: text}
+	}
+}
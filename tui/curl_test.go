@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pb33f/harific/motor/model"
+)
+
+func TestBuildCurlCommand_SimpleGet(t *testing.T) {
+	req := &model.Request{Method: "GET", URL: "https://example.com/api"}
+
+	got := BuildCurlCommand(req)
+	want := "curl 'https://example.com/api'"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestBuildCurlCommand_HeadersAndBody(t *testing.T) {
+	req := &model.Request{
+		Method: "POST",
+		URL:    "https://example.com/api",
+		Headers: []model.NameValuePair{
+			{Name: ":authority", Value: "example.com"},
+			{Name: "Content-Type", Value: "application/json"},
+			{Name: "Content-Length", Value: "15"},
+			{Name: "X-Note", Value: "it's here"},
+		},
+		Body: model.BodyType{MIMEType: "application/json", Content: `{"name":"bob"}`},
+	}
+
+	got := BuildCurlCommand(req)
+	want := `curl 'https://example.com/api' -H 'Content-Type: application/json' -H 'X-Note: it'\''s here' --data-raw '{"name":"bob"}'`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestBuildCurlCommand_MethodFlag(t *testing.T) {
+	tests := []struct {
+		method  string
+		body    string
+		wantArg bool
+	}{
+		{"GET", "", false},
+		{"POST", "x", false},
+		{"POST", "", true},
+		{"PUT", "x", true},
+		{"DELETE", "", true},
+		{"GET", "x", true},
+	}
+
+	for _, tt := range tests {
+		req := &model.Request{Method: tt.method, URL: "https://example.com", Body: model.BodyType{Content: tt.body}}
+		got := BuildCurlCommand(req)
+		hasArg := strings.Contains(got, "-X "+tt.method)
+		if hasArg != tt.wantArg {
+			t.Errorf("%s body=%q: -X present=%v, want %v (%s)", tt.method, tt.body, hasArg, tt.wantArg, got)
+		}
+	}
+}
+
+func TestBuildCurlCommand_QueryParams(t *testing.T) {
+	params := []model.NameValuePair{{Name: "q", Value: "a b"}, {Name: "page", Value: "2"}}
+
+	req := &model.Request{Method: "GET", URL: "https://example.com/search", QueryParams: params}
+	if got, want := BuildCurlCommand(req), "curl 'https://example.com/search?page=2&q=a+b'"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// an existing query string is left alone rather than duplicated
+	req.URL = "https://example.com/search?q=a+b&page=2"
+	if got, want := BuildCurlCommand(req), "curl 'https://example.com/search?q=a+b&page=2'"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestBuildCurlCommand_FormParams(t *testing.T) {
+	req := &model.Request{
+		Method: "POST",
+		URL:    "https://example.com/login",
+		Body: model.BodyType{
+			MIMEType: "application/x-www-form-urlencoded",
+			Params:   []model.PostNameValuePair{{Name: "user", Value: "bob"}, {Name: "pass", Value: "p&w"}},
+		},
+	}
+
+	got := BuildCurlCommand(req)
+	want := "curl 'https://example.com/login' --data-urlencode 'user=bob' --data-urlencode 'pass=p&w'"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
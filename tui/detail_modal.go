@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/bubbles/v2/viewport"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/pb33f/harific/motor"
 )
 
 // updateDetailContent updates the detail modal viewport content (e.g., after search changes)
@@ -38,15 +39,22 @@ func (m *HARViewModel) formatRequestFullWithSearch(width int) string {
 		return "No request data"
 	}
 
-	sections := buildRequestSections(&m.selectedEntry.Request)
+	sections := prependEntryComment(buildRequestSections(&m.selectedEntry.Request), m.selectedEntry)
 
 	opts := RenderOptions{
-		Width:    width,
-		Truncate: false,
+		Width:     width,
+		Truncate:  false,
+		WrapValue: true,
 	}
 
-	// Use search-aware rendering if search is active
-	if m.detailSearchState.active {
+	// binary bodies are shown as a placeholder or hex dump, never searched or highlighted
+	body := &m.selectedEntry.Request.Body
+	if binarySections, ok := applyBinaryBody(sections, decodeBody(body.Content, ""), body.MIMEType, m.detailHexView); ok {
+		return renderSections(binarySections, opts)
+	}
+
+	// Use search-aware rendering if search is active or the JSON is being folded
+	if m.detailSearchState.active || m.detailSearchState.Folding() {
 		// Don't apply syntax highlighting - let search renderer handle the JSON
 		return renderSectionsWithSearch(sections, opts, m.detailSearchState)
 	}
@@ -63,14 +71,25 @@ func (m *HARViewModel) formatResponseFullWithSearch(width int) string {
 	}
 
 	sections := buildResponseSections(&m.selectedEntry.Response, &m.selectedEntry.Timings)
+	sections = appendWebSocketSection(sections, m.selectedEntry)
 
 	opts := RenderOptions{
-		Width:    width,
-		Truncate: false,
+		Width:     width,
+		Truncate:  false,
+		WrapValue: true,
 	}
 
-	// Use search-aware rendering if search is active
-	if m.detailSearchState.active {
+	body := &m.selectedEntry.Response.Body
+	data := m.responseBodyData()
+	if binarySections, ok := applyBinaryBody(sections, data, body.MIMEType, m.detailHexView); ok {
+		return renderSections(binarySections, opts)
+	}
+	if m.decodeBodies {
+		sections = replaceBodyContent(sections, string(data))
+	}
+
+	// Use search-aware rendering if search is active or the JSON is being folded
+	if m.detailSearchState.active || m.detailSearchState.Folding() {
 		// Don't apply syntax highlighting - let search renderer handle the JSON
 		return renderSectionsWithSearch(sections, opts, m.detailSearchState)
 	}
@@ -89,17 +108,36 @@ func (m *HARViewModel) renderDetailSearchBar() string {
 	searchStyle := lipgloss.NewStyle().Foreground(RGBPink).Bold(true)
 	parts = append(parts, searchStyle.Render("Search: ") + searchState.searchInput.View())
 
-	// Key search checkbox
-	checkbox := "[ ]"
-	if searchState.keySearchOnly {
-		checkbox = "[x]"
+	// Search mode checkboxes, the focused one highlighted
+	focusedStyle := lipgloss.NewStyle().Background(RGBSubtlePink).Foreground(RGBPink).Bold(true)
+	checkboxes := []struct {
+		label   string
+		checked bool
+	}{
+		{"Keys Only", searchState.keySearchOnly},
+		{"Regex", searchState.regex},
+		{"Match Case", searchState.caseSensitive},
+	}
+	for i, option := range checkboxes {
+		checkbox := "[ ]"
+		if option.checked {
+			checkbox = "[x]"
+		}
+		label := checkbox + " " + option.label
+		if searchState.cursor == i+1 {
+			label = focusedStyle.Render(label)
+		}
+		parts = append(parts, label)
 	}
-	parts = append(parts, checkbox + " Keys Only")
 
 	// Match count
-	if searchState.query != "" && searchState.renderer != nil {
+	if searchState.queryErr != "" {
+		parts = append(parts, lipgloss.NewStyle().Foreground(RGBRed).Render(searchState.queryErr))
+	} else if searchState.query != "" && searchState.renderer != nil {
 		matchCount := searchState.renderer.GetMatchCount()
-		if matchCount > 0 {
+		if matchCount > 0 && searchState.currentMatch >= 0 {
+			parts = append(parts, fmt.Sprintf("match %d/%d", searchState.currentMatch+1, searchState.MatchLineCount()))
+		} else if matchCount > 0 {
 			parts = append(parts, fmt.Sprintf("%d matches", matchCount))
 		} else {
 			parts = append(parts, "no matches")
@@ -107,7 +145,10 @@ func (m *HARViewModel) renderDetailSearchBar() string {
 	}
 
 	// Help
-	helpParts := []string{"Tab: Toggle checkbox"}
+	helpParts := []string{"Tab: Next option", "Space: Toggle"}
+	if searchState.MatchLineCount() > 0 {
+		helpParts = append(helpParts, "Ctrl+N/P: Next/Prev")
+	}
 	if searchState.filtered {
 		helpParts = append(helpParts, "Enter: Show all")
 	} else if len(searchState.matches) > 0 {
@@ -176,10 +217,34 @@ func (m *HARViewModel) renderDetailModal() string {
 	modal.WriteString("\n")
 
 	// Show search controls if search is active, otherwise show normal help
-	if m.detailSearchState.active {
+	if status, ok := m.renderBodySaveStatus(); ok {
+		modal.WriteString(lipgloss.NewStyle().Foreground(RGBPink).Render(status))
+	} else if m.detailSearchState.active {
 		modal.WriteString(m.renderDetailSearchBar())
 	} else {
-		modal.WriteString(helpStyle.Render("↑/↓: Scroll | PgUp/PgDn: Page | Ctrl+F: Search | Esc: Close"))
+		helpParts := []string{"↑/↓: Scroll", "PgUp/PgDn: Page", "Ctrl+F: Search"}
+		if m.detailBodyIsBinary() {
+			if m.detailHexView {
+				helpParts[2] = "x: Hide Hex"
+			} else {
+				helpParts[2] = "x: Hex View"
+			}
+		}
+		if m.activeModal == ModalResponseFull && m.responseBodyIsEncoded() {
+			if m.decodeBodies {
+				helpParts = append(helpParts, "d: Show Encoded")
+			} else {
+				helpParts = append(helpParts, "d: Decode Body")
+			}
+		}
+		if m.detailBodyIsJSON() {
+			helpParts = append(helpParts, "c/C: Fold/All")
+		}
+		if m.activeModal == ModalResponseFull {
+			helpParts = append(helpParts, "W: Save Body")
+		}
+		helpParts = append(helpParts, "Esc: Close")
+		modal.WriteString(helpStyle.Render(strings.Join(helpParts, " | ")))
 	}
 
 	return modalStyle.Render(modal.String())
@@ -191,14 +256,15 @@ func (m *HARViewModel) formatRequestFull(width int) string {
 		return "No request data"
 	}
 
-	sections := buildRequestSections(&m.selectedEntry.Request)
+	sections := prependEntryComment(buildRequestSections(&m.selectedEntry.Request), m.selectedEntry)
 
 	// apply syntax highlighting to body content
 	sections = m.highlightBodyInSections(sections, m.selectedEntry.Request.Body.MIMEType)
 
 	opts := RenderOptions{
-		Width:    width,
-		Truncate: false, // NO truncation in modal
+		Width:     width,
+		Truncate:  false, // NO truncation in modal
+		WrapValue: true,
 	}
 
 	return renderSections(sections, opts)
@@ -211,13 +277,15 @@ func (m *HARViewModel) formatResponseFull(width int) string {
 	}
 
 	sections := buildResponseSections(&m.selectedEntry.Response, &m.selectedEntry.Timings)
+	sections = appendWebSocketSection(sections, m.selectedEntry)
 
 	// apply syntax highlighting to body content
 	sections = m.highlightBodyInSections(sections, m.selectedEntry.Response.Body.MIMEType)
 
 	opts := RenderOptions{
-		Width:    width,
-		Truncate: false, // NO truncation in modal
+		Width:     width,
+		Truncate:  false, // NO truncation in modal
+		WrapValue: true,
 	}
 
 	return renderSections(sections, opts)
@@ -234,14 +302,15 @@ func (m *HARViewModel) highlightBodyInSections(sections []Section, mimeType stri
 				if pair.Key == "Content" {
 					content := pair.Value
 
-					// pretty print JSON before highlighting
-					if contentType == "json" {
-						content = prettyPrintJSON(content)
-					}
-
-					// apply syntax highlighting
-					if contentType != "plain" {
-						content = applySyntaxHighlightingToContent(content, contentType == "yaml")
+					switch contentType {
+					case "json":
+						// pretty print JSON before highlighting
+						content = applySyntaxHighlightingToContent(prettyPrintJSON(content), false)
+					case "yaml":
+						content = applySyntaxHighlightingToContent(content, true)
+					case "xml", "html":
+						html := contentType == "html"
+						content = applyMarkupHighlighting(prettyPrintMarkup(content, html), html)
 					}
 
 					sections[i].Pairs[j].Value = content
@@ -253,7 +322,7 @@ func (m *HARViewModel) highlightBodyInSections(sections []Section, mimeType stri
 	return sections
 }
 
-// detectContentType determines if content is JSON, YAML, or plain text
+// detectContentType determines if content is JSON, YAML, HTML, XML, or plain text
 func detectContentType(mimeType string) string {
 	lower := strings.ToLower(mimeType)
 
@@ -263,6 +332,13 @@ func detectContentType(mimeType string) string {
 	if strings.Contains(lower, "yaml") || strings.Contains(lower, "yml") {
 		return "yaml"
 	}
+	// checked before xml so application/xhtml+xml gets the lenient html handling
+	if strings.Contains(lower, "html") {
+		return "html"
+	}
+	if strings.Contains(lower, "xml") {
+		return "xml"
+	}
 
 	return "plain"
 }
@@ -307,6 +383,45 @@ func applySyntaxHighlightingToContent(content string, isYAML bool) string {
 	return highlighted.String()
 }
 
+// detailBodyIsBinary reports whether the body shown in the detail modal is binary
+func (m *HARViewModel) detailBodyIsBinary() bool {
+	if m.selectedEntry == nil {
+		return false
+	}
+	if m.activeModal == ModalRequestFull {
+		body := &m.selectedEntry.Request.Body
+		return isBinaryBody(decodeBody(body.Content, ""), body.MIMEType)
+	}
+	return isBinaryBody(m.responseBodyData(), m.selectedEntry.Response.Body.MIMEType)
+}
+
+// responseBodyData returns the response body bytes to show. with body decoding enabled, base64 and
+// gzip content is decoded to text; otherwise base64 is only decoded to detect binary bodies.
+func (m *HARViewModel) responseBodyData() []byte {
+	body := &m.selectedEntry.Response.Body
+	if m.decodeBodies {
+		if text, err := motor.DecodeBody(body); err == nil {
+			return []byte(text)
+		}
+	}
+	return decodeBody(body.Content, body.Encoding)
+}
+
+// responseBodyText returns the response body as the detail modal shows and searches it
+func (m *HARViewModel) responseBodyText() string {
+	if m.decodeBodies {
+		if text, err := motor.DecodeBody(&m.selectedEntry.Response.Body); err == nil {
+			return text
+		}
+	}
+	return m.selectedEntry.Response.Body.Content
+}
+
+// responseBodyIsEncoded reports whether the selected response body is stored base64 encoded
+func (m *HARViewModel) responseBodyIsEncoded() bool {
+	return m.selectedEntry != nil && strings.EqualFold(m.selectedEntry.Response.Body.Encoding, "base64")
+}
+
 // handleDetailModalKeys handles key events when detail modal is open
 func (m *HARViewModel) handleDetailModalKeys(key string) (bool, tea.Cmd) {
 	if m.activeModal != ModalRequestFull && m.activeModal != ModalResponseFull {
@@ -322,9 +437,13 @@ func (m *HARViewModel) handleDetailModalKeys(key string) (bool, tea.Cmd) {
 			m.updateDetailContent() // Update to show cleared search
 			return true, nil
 
-		case "tab":
-			// Toggle between search input and checkbox
-			m.detailSearchState.MoveCursor(1)
+		case "tab", "shift+tab":
+			// Move between the search input and the checkboxes
+			direction := 1
+			if key == "shift+tab" {
+				direction = -1
+			}
+			m.detailSearchState.MoveCursor(direction)
 			if m.detailSearchState.cursor == 0 {
 				return true, m.detailSearchState.searchInput.Focus()
 			} else {
@@ -340,11 +459,33 @@ func (m *HARViewModel) handleDetailModalKeys(key string) (bool, tea.Cmd) {
 					m.updateDetailContent()
 				}
 			} else {
-				// On checkbox - toggle key search mode
-				m.detailSearchState.ToggleKeySearchOnly()
+				// On a checkbox - toggle that search option
+				m.detailSearchState.ToggleFocusedCheckbox()
 				m.updateDetailContent()
 			}
 			return true, nil
+
+		case "ctrl+n", "ctrl+p":
+			m.jumpToDetailMatch(key == "ctrl+n")
+			return true, nil
+
+		case "ctrl+y":
+			return true, m.copyFilteredJSON()
+
+		case "ctrl+s":
+			return true, m.saveFilteredJSON()
+
+		case "n", "N":
+			// plain n/N would otherwise be typed into the focused search input
+			if m.detailSearchState.cursor != 0 {
+				m.jumpToDetailMatch(key == "n")
+				return true, nil
+			}
+
+		case "c", "C":
+			if m.detailSearchState.cursor != 0 {
+				return true, m.toggleDetailFold(key == "C")
+			}
 		}
 
 		// Let other keys fall through for search input handling
@@ -356,16 +497,8 @@ func (m *HARViewModel) handleDetailModalKeys(key string) (bool, tea.Cmd) {
 		// Activate search and initialize with JSON content if available
 		m.detailSearchState.Activate()
 
-		// Get the JSON body content to search
-		var jsonContent string
-		if m.activeModal == ModalRequestFull && m.selectedEntry != nil {
-			jsonContent = m.selectedEntry.Request.Body.Content
-		} else if m.activeModal == ModalResponseFull && m.selectedEntry != nil {
-			jsonContent = m.selectedEntry.Response.Body.Content
-		}
-
 		// Initialize the search state with the JSON content
-		if jsonContent != "" && isValidJSON(jsonContent) {
+		if jsonContent := m.detailBodyText(); jsonContent != "" && isValidJSON(jsonContent) {
 			modalWidth := int(float64(m.width) * 0.9)
 			m.detailSearchState.SetContent(jsonContent, modalWidth-4)
 		}
@@ -373,9 +506,36 @@ func (m *HARViewModel) handleDetailModalKeys(key string) (bool, tea.Cmd) {
 		m.updateDetailContent()
 		return true, m.detailSearchState.searchInput.Focus()
 
+	case "x":
+		if !m.detailSearchState.active && m.detailBodyIsBinary() {
+			m.detailHexView = !m.detailHexView
+			m.detailViewport.GotoTop()
+			m.updateDetailContent()
+			return true, nil
+		}
+
+	case "d":
+		if !m.detailSearchState.active && m.activeModal == ModalResponseFull && m.responseBodyIsEncoded() {
+			m.decodeBodies = !m.decodeBodies
+			m.detailViewport.GotoTop()
+			m.updateDetailContent()
+			return true, nil
+		}
+
+	case "W":
+		if !m.detailSearchState.active && m.activeModal == ModalResponseFull {
+			return true, m.openBodySavePrompt()
+		}
+
+	case "c", "C":
+		if !m.detailSearchState.active {
+			return true, m.toggleDetailFold(key == "C")
+		}
+
 	case "esc":
 		m.activeModal = ModalNone
 		m.detailSearchState.Deactivate() // Also deactivate search when closing
+		m.detailHexView = false
 		return true, nil
 
 	case "up":
@@ -397,3 +557,67 @@ func (m *HARViewModel) handleDetailModalKeys(key string) (bool, tea.Cmd) {
 
 	return false, nil
 }
+
+// detailBodyText returns the body shown in the detail modal, as it is searched
+func (m *HARViewModel) detailBodyText() string {
+	if m.selectedEntry == nil {
+		return ""
+	}
+	if m.activeModal == ModalRequestFull {
+		return m.selectedEntry.Request.Body.Content
+	}
+	return m.responseBodyText()
+}
+
+// detailBodyIsJSON reports whether the detail modal's body is labelled as json, without parsing it
+func (m *HARViewModel) detailBodyIsJSON() bool {
+	if m.selectedEntry == nil {
+		return false
+	}
+	mimeType := m.selectedEntry.Response.Body.MIMEType
+	if m.activeModal == ModalRequestFull {
+		mimeType = m.selectedEntry.Request.Body.MIMEType
+	}
+	return detectContentType(mimeType) == "json"
+}
+
+// toggleDetailFold folds or unfolds the JSON object or array at the top of the detail viewport.
+// with all set it folds every one of them, or unfolds them all when any is folded.
+func (m *HARViewModel) toggleDetailFold(all bool) tea.Cmd {
+	state := m.detailSearchState
+	if !state.Folding() {
+		modalWidth := int(float64(m.width) * 0.9)
+		if err := state.StartFolding(m.detailBodyText(), modalWidth-4); err != nil {
+			return showStatusMessage("Only JSON bodies can be folded")
+		}
+		m.updateDetailContent() // the renderer's layout, so viewport rows map onto its lines
+	}
+
+	if all {
+		state.ToggleFoldAll()
+		m.detailViewport.GotoTop()
+		m.updateDetailContent()
+		return nil
+	}
+
+	path, ok := state.ToggleFoldAt(m.detailViewport.YOffset)
+	if !ok {
+		return showStatusMessage("Scroll an object or array to the top to fold it")
+	}
+	m.updateDetailContent()
+	if row, ok := state.FoldRow(path); ok {
+		m.detailViewport.SetYOffset(row)
+	}
+	return nil
+}
+
+// jumpToDetailMatch scrolls the detail viewport to the next or previous search match, wrapping around
+func (m *HARViewModel) jumpToDetailMatch(forward bool) {
+	direction := 1
+	if !forward {
+		direction = -1
+	}
+	if line, ok := m.detailSearchState.StepMatch(direction); ok {
+		m.detailViewport.SetYOffset(line)
+	}
+}
@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/table"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pb33f/harific/motor"
+)
+
+const (
+	endpointCountWidth  = 7
+	endpointStatusWidth = 24
+)
+
+// endpointColumns lays out the endpoints table, the endpoint taking whatever the numbers leave
+func endpointColumns(width int) []table.Column {
+	endpointWidth := width - endpointCountWidth - 2*durationColumnWidth - endpointStatusWidth - sizeColumnWidth - borderPadding
+	if endpointWidth < minURLColumnWidth {
+		endpointWidth = minURLColumnWidth
+	}
+	return []table.Column{
+		{Title: "Endpoint", Width: endpointWidth},
+		{Title: "Count", Width: endpointCountWidth},
+		{Title: "Avg", Width: durationColumnWidth},
+		{Title: "Max", Width: durationColumnWidth},
+		{Title: "Statuses", Width: endpointStatusWidth},
+		{Title: "Size", Width: sizeColumnWidth},
+	}
+}
+
+// endpointRow formats one endpoint group; statuses read e.g. "200×12 404×1"
+func endpointRow(endpoint motor.EndpointStats, endpointWidth int) table.Row {
+	statuses := make([]string, 0, len(endpoint.StatusCodes))
+	for _, bucket := range endpoint.StatusCodes {
+		code := bucket.Key
+		if code == "0" {
+			code = "---"
+		}
+		statuses = append(statuses, fmt.Sprintf("%s×%d", code, bucket.Count))
+	}
+
+	return table.Row{
+		ansi.Truncate(endpoint.Endpoint, endpointWidth, "..."),
+		fmt.Sprintf("%d", endpoint.Count),
+		formatDuration(endpoint.AvgDuration),
+		formatDuration(endpoint.MaxDuration),
+		ansi.Truncate(strings.Join(statuses, " "), endpointStatusWidth, "…"),
+		formatSize(endpoint.TotalBytes),
+	}
+}
+
+// openEndpointsView groups the entries passing the current filters by endpoint; Esc returns to
+// the view it was opened from
+func (m *HARViewModel) openEndpointsView() tea.Cmd {
+	if m.index == nil {
+		return nil
+	}
+	if m.endpointFilter.IsActive() {
+		// already in an endpoint's entries: go back up to the view
+		m.leaveEndpoint()
+		return nil
+	}
+	m.endpointsReturnMode = m.viewMode
+	m.showEndpoints()
+	m.endpointTable.SetCursor(0)
+	if len(m.endpoints) == 0 {
+		m.viewMode = m.endpointsReturnMode
+		return showStatusMessage("No entries to group")
+	}
+	return nil
+}
+
+// showEndpoints regroups the entries passing the other filters and shows the endpoints table
+func (m *HARViewModel) showEndpoints() {
+	indices := PassingIndices(m.allEntries, m.searchFilter, m.fileTypeFilter, m.methodFilter, m.statusFilter, m.timeFilter, m.sizeFilter)
+	if indices == nil {
+		indices = make([]int, len(m.allEntries))
+		for i := range indices {
+			indices[i] = i
+		}
+	}
+	m.endpoints = m.index.Endpoints(indices)
+	m.viewMode = ViewModeEndpoints
+	m.updateEndpointTable()
+}
+
+// updateEndpointTable rebuilds the endpoints table for the current groups and terminal size
+func (m *HARViewModel) updateEndpointTable() {
+	columns := endpointColumns(m.width)
+	rows := make([]table.Row, 0, len(m.endpoints))
+	for _, endpoint := range m.endpoints {
+		rows = append(rows, endpointRow(endpoint, columns[0].Width))
+	}
+
+	cursor := m.endpointTable.Cursor()
+	m.endpointTable = table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(m.calculateTableHeight()),
+		table.WithWidth(m.width),
+	)
+	m.endpointTable = ApplyTableStyles(m.endpointTable)
+	m.endpointTable.SetCursor(cursor)
+}
+
+// handleEndpointsKeys drives the endpoints view: Enter shows the selected endpoint's entries,
+// Esc or u goes back. it keeps every key but ctrl+c while the view is open.
+func (m *HARViewModel) handleEndpointsKeys(msg tea.KeyPressMsg) (bool, tea.Cmd) {
+	if m.viewMode != ViewModeEndpoints {
+		return false, nil
+	}
+
+	switch msg.String() {
+	case "ctrl+c":
+		return false, nil
+	case "enter", "return":
+		m.drillIntoEndpoint()
+	case "esc", "u":
+		m.viewMode = m.endpointsReturnMode
+		m.endpoints = nil
+		m.updateTableDimensions()
+	default:
+		var cmd tea.Cmd
+		m.endpointTable, cmd = m.endpointTable.Update(msg)
+		return true, cmd
+	}
+	return true, nil
+}
+
+// drillIntoEndpoint filters the table down to the selected endpoint's entries
+func (m *HARViewModel) drillIntoEndpoint() {
+	cursor := m.endpointTable.Cursor()
+	if cursor < 0 || cursor >= len(m.endpoints) {
+		return
+	}
+
+	m.endpointFilter.SetEndpoint(m.endpoints[cursor])
+	m.applyFilters()
+	m.viewMode = ViewModeTableFiltered
+	m.selectedIndex = 0
+	m.updateTableDimensions()
+	m.table.SetCursor(0)
+}
+
+// leaveEndpoint clears the endpoint filter and returns to the endpoints view, on the same group
+func (m *HARViewModel) leaveEndpoint() {
+	endpoint := m.endpointFilter.Endpoint()
+	m.endpointFilter.Clear()
+	m.applyFilters()
+
+	m.showEndpoints()
+	for i, group := range m.endpoints {
+		if group.Endpoint == endpoint {
+			m.endpointTable.SetCursor(i)
+			break
+		}
+	}
+}
+
+func (m *HARViewModel) renderEndpointsView() string {
+	var builder strings.Builder
+
+	builder.WriteString(m.renderTitle())
+	builder.WriteString("\n")
+	builder.WriteString(m.endpointTable.View())
+	builder.WriteString("\n")
+	builder.WriteString(m.renderStatusBar())
+
+	return builder.String()
+}
@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/v2/table"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pb33f/harific/motor"
+)
+
+func TestEndpointsView(t *testing.T) {
+	entries := []*motor.EntryMetadata{
+		{Method: "GET", URL: "https://example.com/users/1", StatusCode: 200, Duration: 40, ResponseSize: 100},
+		{Method: "GET", URL: "https://example.com/health", StatusCode: 200, Duration: 5, ResponseSize: 10},
+		{Method: "GET", URL: "https://example.com/users/2?full=1", StatusCode: 404, Duration: 20, ResponseSize: 50},
+		{Method: "POST", URL: "https://example.com/users/3", StatusCode: 500, Duration: 900, ResponseSize: 300},
+	}
+	rows := make([]table.Row, len(entries))
+	for i, entry := range entries {
+		rows[i] = ColumnsDefault.row(entry, 120)
+	}
+
+	m := &HARViewModel{
+		index:          &motor.Index{Entries: entries},
+		allEntries:     entries,
+		rows:           rows,
+		columns:        []table.Column{{Title: "Method"}, {Title: "URL"}, {Title: "Status"}, {Title: "Size"}, {Title: "Duration"}},
+		filterChain:    NewFilterChain(),
+		searchFilter:   NewSearchFilter(),
+		fileTypeFilter: NewFileTypeFilter(),
+		methodFilter:   NewMethodFilter(),
+		statusFilter:   NewStatusClassFilter(),
+		timeFilter:     NewTimeRangeFilter(),
+		endpointFilter: NewEndpointFilter(),
+		viewMode:       ViewModeTable,
+		width:          120,
+		height:         30,
+	}
+	m.table = table.New(table.WithColumns(m.columns), table.WithRows(rows))
+
+	// the other filters apply before grouping
+	m.methodFilter.ToggleMethod("POST", false)
+	m.applyFilters()
+
+	m.openEndpointsView()
+	if m.viewMode != ViewModeEndpoints {
+		t.Fatalf("view mode = %v, want the endpoints view", m.viewMode)
+	}
+	if len(m.endpoints) != 2 || m.endpoints[0].Endpoint != "https://example.com/users/{id}" || m.endpoints[0].Count != 2 {
+		t.Fatalf("unexpected endpoints %+v", m.endpoints)
+	}
+
+	view := ansi.Strip(m.renderEndpointsView())
+	for _, want := range []string{"https://example.com/users/{id}", "200×1 404×1", "30ms", "Endpoint 1/2"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("endpoints view missing %q", want)
+		}
+	}
+
+	// Enter drills into the selected endpoint's entries
+	m.handleEndpointsKeys(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.viewMode != ViewModeTableFiltered {
+		t.Fatalf("view mode = %v after Enter, want filtered", m.viewMode)
+	}
+	if len(m.filteredIndices) != 2 || m.filteredIndices[0] != 0 || m.filteredIndices[1] != 2 {
+		t.Errorf("filtered indices = %v, want [0 2]", m.filteredIndices)
+	}
+
+	// leaving the endpoint returns to the view on the same group, and Esc there goes back to the table
+	m.leaveEndpoint()
+	if m.viewMode != ViewModeEndpoints || m.endpointFilter.IsActive() {
+		t.Fatal("leaving an endpoint should clear its filter and show the endpoints view")
+	}
+	if m.endpointTable.Cursor() != 0 {
+		t.Errorf("cursor = %d, want the group drilled into", m.endpointTable.Cursor())
+	}
+	m.handleEndpointsKeys(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if m.viewMode != ViewModeTable || len(m.filteredIndices) != 3 {
+		t.Errorf("esc should return to the table with %d rows, got mode %v and %d rows", 3, m.viewMode, len(m.filteredIndices))
+	}
+}
@@ -0,0 +1,339 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/viewport"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pb33f/harific/motor/model"
+)
+
+// diffMaxLines caps the lines compared per entry; the line diff is quadratic, so huge bodies
+// are cut off rather than stalling the ui
+const diffMaxLines = 2000
+
+// diffKind says how a diff row differs between the baseline and the compared entry
+type diffKind int
+
+const (
+	diffSame diffKind = iota
+	diffChanged
+	diffRemoved // only in the baseline
+	diffAdded   // only in the compared entry
+)
+
+// diffRow is one side by side row; Left comes from the baseline, Right from the compared entry
+type diffRow struct {
+	Kind  diffKind
+	Left  string
+	Right string
+}
+
+// entryDiffLines flattens the comparable parts of an entry into lines: the request line, status,
+// headers, parameters, cookies and body. timings are left out since they always differ, and
+// JSON bodies are pretty-printed so the body diffs line by line.
+func entryDiffLines(entry *model.Entry) []string {
+	var lines []string
+	add := func(prefix string, sections []Section) {
+		for _, section := range sections {
+			lines = append(lines, "── "+prefix+" "+section.Title)
+			for _, pair := range section.Pairs {
+				if pair.Key != "Content" {
+					lines = append(lines, pair.Key+": "+pair.Value)
+					continue
+				}
+				content := pair.Value
+				if isValidJSON(content) {
+					content = prettyPrintJSON(content)
+				}
+				lines = append(lines, strings.Split(strings.TrimRight(content, "\n"), "\n")...)
+			}
+		}
+	}
+
+	add("Request", buildRequestSections(&entry.Request))
+	add("Response", buildResponseSections(&entry.Response, nil))
+
+	if len(lines) > diffMaxLines {
+		omitted := len(lines) - diffMaxLines
+		lines = append(lines[:diffMaxLines], fmt.Sprintf("... %d more lines not compared", omitted))
+	}
+	return lines
+}
+
+// diffLines aligns a and b on their longest common subsequence, pairing each run of removed
+// lines with the added lines that replace it as changed rows
+func diffLines(a, b []string) []diffRow {
+	// lcs[i][j] is the common subsequence length of a[i:] and b[j:]
+	lcs := make([][]int32, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	rows := make([]diffRow, 0, max(len(a), len(b)))
+	var removed, added []string
+	flush := func() {
+		for k := 0; k < max(len(removed), len(added)); k++ {
+			switch {
+			case k < len(removed) && k < len(added):
+				rows = append(rows, diffRow{Kind: diffChanged, Left: removed[k], Right: added[k]})
+			case k < len(removed):
+				rows = append(rows, diffRow{Kind: diffRemoved, Left: removed[k]})
+			default:
+				rows = append(rows, diffRow{Kind: diffAdded, Right: added[k]})
+			}
+		}
+		removed, added = removed[:0], added[:0]
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			flush()
+			rows = append(rows, diffRow{Kind: diffSame, Left: a[i], Right: b[j]})
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			removed = append(removed, a[i])
+			i++
+		default:
+			added = append(added, b[j])
+			j++
+		}
+	}
+	flush()
+	return rows
+}
+
+// markDiffBaseline remembers the selected entry as the one later entries are compared against
+func (m *HARViewModel) markDiffBaseline() tea.Cmd {
+	if _, onHeader := m.selectedPageHeader(); onHeader || len(m.allEntries) == 0 {
+		return nil
+	}
+	m.diffBaseline = m.selectedEntryIndex()
+	m.diffBaselineSet = true
+	return showStatusMessage(fmt.Sprintf("Baseline #%d marked, select another entry and press d to compare", m.diffBaseline))
+}
+
+// openEntryDiff compares the selected entry against the baseline marked with b
+func (m *HARViewModel) openEntryDiff() tea.Cmd {
+	if !m.diffBaselineSet {
+		return showStatusMessage("Mark a baseline entry with b first")
+	}
+	if _, onHeader := m.selectedPageHeader(); onHeader {
+		return nil
+	}
+	target := m.selectedEntryIndex()
+	if target == m.diffBaseline {
+		return showStatusMessage("Select a different entry to compare with the baseline")
+	}
+
+	ctx := context.Background()
+	baseline, err := m.streamer.GetEntry(ctx, m.diffBaseline)
+	if err != nil {
+		return showStatusMessage(fmt.Sprintf("Failed to read baseline: %v", err))
+	}
+	compared, err := m.streamer.GetEntry(ctx, target)
+	if err != nil {
+		return showStatusMessage(fmt.Sprintf("Failed to read entry: %v", err))
+	}
+
+	m.diffTarget = target
+	m.diffRows = diffLines(entryDiffLines(baseline), entryDiffLines(compared))
+	m.diffRendered = ""
+	m.diffViewport.GotoTop()
+	m.activeModal = ModalEntryDiff
+	return nil
+}
+
+// handleEntryDiffModalKeys scrolls the diff and jumps between changes; it keeps every key but
+// ctrl+c while the modal is open
+func (m *HARViewModel) handleEntryDiffModalKeys(key string) (bool, tea.Cmd) {
+	if m.activeModal != ModalEntryDiff {
+		return false, nil
+	}
+
+	switch key {
+	case "ctrl+c":
+		return false, nil
+	case "esc", "q", "d":
+		m.activeModal = ModalNone
+		m.diffRows = nil
+		m.diffRendered = ""
+	case "up":
+		m.diffViewport.LineUp(1)
+	case "down":
+		m.diffViewport.LineDown(1)
+	case "pgup":
+		m.diffViewport.ViewUp()
+	case "pgdown":
+		m.diffViewport.ViewDown()
+	case "n", "N":
+		if row, ok := m.nextDiffChange(m.diffViewport.YOffset, key == "n"); ok {
+			m.diffViewport.SetYOffset(row)
+		}
+	}
+	return true, nil
+}
+
+// nextDiffChange returns the first row of the next (or previous) block of differing rows after
+// row, wrapping around at either end
+func (m *HARViewModel) nextDiffChange(row int, forward bool) (int, bool) {
+	var starts []int
+	for i, r := range m.diffRows {
+		if r.Kind != diffSame && (i == 0 || m.diffRows[i-1].Kind == diffSame) {
+			starts = append(starts, i)
+		}
+	}
+	if len(starts) == 0 {
+		return 0, false
+	}
+
+	if forward {
+		for _, start := range starts {
+			if start > row {
+				return start, true
+			}
+		}
+		return starts[0], true
+	}
+	for i := len(starts) - 1; i >= 0; i-- {
+		if starts[i] < row {
+			return starts[i], true
+		}
+	}
+	return starts[len(starts)-1], true
+}
+
+// renderDiffRows renders the rows as two columns of width each, baseline on the left
+func renderDiffRows(rows []diffRow, width int) string {
+	removedStyle := lipgloss.NewStyle().Foreground(RGBRed)
+	addedStyle := lipgloss.NewStyle().Foreground(RGBGreen)
+	changedStyle := lipgloss.NewStyle().Foreground(RGBYellow)
+	separator := lipgloss.NewStyle().Foreground(RGBGrey).Render(" │ ")
+
+	cell := func(marker, text string, style *lipgloss.Style) string {
+		if marker == "" {
+			return strings.Repeat(" ", width)
+		}
+		text = ansi.Truncate(marker+" "+text, width, "…")
+		text += strings.Repeat(" ", width-ansi.StringWidth(text))
+		if style != nil {
+			return style.Render(text)
+		}
+		return text
+	}
+
+	var out strings.Builder
+	for i, row := range rows {
+		if i > 0 {
+			out.WriteString("\n")
+		}
+		switch row.Kind {
+		case diffSame:
+			out.WriteString(cell(" ", row.Left, nil) + separator + cell(" ", row.Right, nil))
+		case diffChanged:
+			out.WriteString(cell("~", row.Left, &changedStyle) + separator + cell("~", row.Right, &changedStyle))
+		case diffRemoved:
+			out.WriteString(cell("-", row.Left, &removedStyle) + separator + cell("", "", nil))
+		case diffAdded:
+			out.WriteString(cell("", "", nil) + separator + cell("+", row.Right, &addedStyle))
+		}
+	}
+	return out.String()
+}
+
+// diffSummary counts the rows of each kind, e.g. "3 changed, 1 removed, 2 added"
+func diffSummary(rows []diffRow) string {
+	var changed, removed, added int
+	for _, row := range rows {
+		switch row.Kind {
+		case diffChanged:
+			changed++
+		case diffRemoved:
+			removed++
+		case diffAdded:
+			added++
+		}
+	}
+	if changed+removed+added == 0 {
+		return "no differences"
+	}
+	return fmt.Sprintf("%d changed, %d removed, %d added", changed, removed, added)
+}
+
+// renderEntryDiffModal renders the side by side diff in a 90x90 modal, like the detail modal
+func (m *HARViewModel) renderEntryDiffModal() string {
+	modalWidth := int(float64(m.width) * 0.9)
+	modalHeight := int(float64(m.height) * 0.9)
+	innerWidth := modalWidth - 4
+	columnWidth := max(1, (innerWidth-3)/2)
+
+	if m.diffViewport.Width() == 0 {
+		m.diffViewport = viewport.New(
+			viewport.WithWidth(innerWidth),
+			viewport.WithHeight(modalHeight-5),
+		)
+	}
+	if m.diffRendered == "" || m.diffRenderedWidth != columnWidth {
+		m.diffRendered = renderDiffRows(m.diffRows, columnWidth)
+		m.diffRenderedWidth = columnWidth
+		m.diffViewport.SetContent(m.diffRendered)
+	}
+
+	modalStyle := lipgloss.NewStyle().
+		Width(modalWidth).
+		Height(modalHeight).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(RGBBlue).
+		Padding(1)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(RGBBlue)
+
+	headingStyle := lipgloss.NewStyle().Bold(true).Foreground(RGBPink).Width(columnWidth)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(RGBGrey).
+		Faint(true).
+		Width(innerWidth).
+		Align(lipgloss.Center)
+
+	var modal strings.Builder
+	modal.WriteString(titleStyle.Render(fmt.Sprintf("Diff #%d → #%d", m.diffBaseline, m.diffTarget)))
+	modal.WriteString(helpStyle.UnsetWidth().UnsetAlign().Render("  " + diffSummary(m.diffRows)))
+	modal.WriteString("\n")
+	modal.WriteString(headingStyle.Render(ansi.Truncate(m.diffEntryLabel("Baseline", m.diffBaseline), columnWidth, "…")))
+	modal.WriteString("   ")
+	modal.WriteString(headingStyle.Render(ansi.Truncate(m.diffEntryLabel("Compared", m.diffTarget), columnWidth, "…")))
+	modal.WriteString("\n")
+	modal.WriteString(m.diffViewport.View())
+	modal.WriteString("\n")
+	modal.WriteString(helpStyle.Render("↑/↓: Scroll | PgUp/PgDn: Page | n/N: Next/Prev change | Esc: Close"))
+
+	return modalStyle.Render(modal.String())
+}
+
+// diffEntryLabel names a side of the diff, e.g. "Baseline #3 GET https://example.com/api"
+func (m *HARViewModel) diffEntryLabel(label string, index int) string {
+	if index < 0 || index >= len(m.allEntries) {
+		return fmt.Sprintf("%s #%d", label, index)
+	}
+	entry := m.allEntries[index]
+	return fmt.Sprintf("%s #%d %s %s", label, index, formatMethod(entry.Method), entry.URL)
+}
@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pb33f/harific/motor/model"
+)
+
+func TestDiffLines(t *testing.T) {
+	a := []string{"same", "old", "kept", "gone"}
+	b := []string{"same", "new", "kept", "extra", "more"}
+
+	rows := diffLines(a, b)
+	want := []diffRow{
+		{Kind: diffSame, Left: "same", Right: "same"},
+		{Kind: diffChanged, Left: "old", Right: "new"},
+		{Kind: diffSame, Left: "kept", Right: "kept"},
+		{Kind: diffChanged, Left: "gone", Right: "extra"},
+		{Kind: diffAdded, Right: "more"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, got %d: %+v", len(want), len(rows), rows)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+
+	if got := diffSummary(rows); got != "2 changed, 0 removed, 1 added" {
+		t.Errorf("unexpected summary %q", got)
+	}
+	if got := diffSummary(diffLines(a, a)); got != "no differences" {
+		t.Errorf("identical lines should have no differences, got %q", got)
+	}
+}
+
+func TestEntryDiffLines(t *testing.T) {
+	entry := func(status int, auth, body string) *model.Entry {
+		return &model.Entry{
+			Request: model.Request{
+				Method:  "GET",
+				URL:     "https://example.com/api",
+				Headers: []model.NameValuePair{{Name: "Authorization", Value: auth}},
+			},
+			Response: model.Response{
+				StatusCode: status,
+				Body:       model.BodyResponseType{Content: body, MIMEType: "application/json"},
+			},
+			Timings: model.Timings{Wait: float64(status)},
+		}
+	}
+
+	baseline := entryDiffLines(entry(200, "Bearer a", `{"id":1,"name":"x"}`))
+	compared := entryDiffLines(entry(500, "Bearer b", `{"id":2,"name":"x"}`))
+
+	var changed []string
+	for _, row := range diffLines(baseline, compared) {
+		switch row.Kind {
+		case diffChanged:
+			changed = append(changed, row.Left+" => "+row.Right)
+		case diffRemoved, diffAdded:
+			t.Errorf("unexpected %v row %+v", row.Kind, row)
+		}
+	}
+
+	want := []string{
+		"Authorization: Bearer a => Authorization: Bearer b",
+		"Status: 200  => Status: 500 ",
+		`  "id": 1, =>   "id": 2,`,
+	}
+	if strings.Join(changed, "\n") != strings.Join(want, "\n") {
+		t.Errorf("expected changes %q, got %q", want, changed)
+	}
+	for _, line := range baseline {
+		if strings.Contains(line, "Timings") {
+			t.Error("timings always differ and should not be compared")
+		}
+	}
+}
+
+func TestRenderDiffRows(t *testing.T) {
+	rows := []diffRow{
+		{Kind: diffSame, Left: "same", Right: "same"},
+		{Kind: diffRemoved, Left: strings.Repeat("long ", 20)},
+		{Kind: diffAdded, Right: "added"},
+	}
+
+	lines := strings.Split(renderDiffRows(rows, 20), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		if w := ansi.StringWidth(line); w != 43 {
+			t.Errorf("line %d is %d cells wide, want 43: %q", i, w, ansi.Strip(line))
+		}
+	}
+	if plain := ansi.Strip(lines[2]); !strings.HasPrefix(plain, strings.Repeat(" ", 20)) || !strings.Contains(plain, "+ added") {
+		t.Errorf("added line should only fill the right column: %q", plain)
+	}
+}
+
+func TestNextDiffChange(t *testing.T) {
+	m := &HARViewModel{diffRows: []diffRow{
+		{Kind: diffSame}, {Kind: diffChanged}, {Kind: diffAdded}, {Kind: diffSame}, {Kind: diffRemoved},
+	}}
+
+	steps := []struct {
+		row     int
+		forward bool
+		want    int
+	}{
+		{0, true, 1},
+		{1, true, 4},
+		{4, true, 1},
+		{4, false, 1},
+		{1, false, 4},
+	}
+	for _, step := range steps {
+		if got, ok := m.nextDiffChange(step.row, step.forward); !ok || got != step.want {
+			t.Errorf("nextDiffChange(%d, %v) = %d, want %d", step.row, step.forward, got, step.want)
+		}
+	}
+
+	m.activeModal = ModalEntryDiff
+	if handled, _ := m.handleEntryDiffModalKeys("esc"); !handled || m.activeModal != ModalNone {
+		t.Error("esc should close the diff modal")
+	}
+}
@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/pb33f/harific/motor"
+)
+
+// exportProgressMsg reports progress of a running export
+type exportProgressMsg struct {
+	progress motor.ExportProgress
+}
+
+// exportCompleteMsg ends an export; err is nil on success
+type exportCompleteMsg struct {
+	path    string
+	entries int
+	err     error
+}
+
+// defaultExportPath suggests a file beside the source har, e.g. capture.har -> capture-filtered.har
+func defaultExportPath(fileName string, filtered bool) string {
+	base := strings.TrimSuffix(fileName, ".gz") // exports are written uncompressed
+	ext := filepath.Ext(base)
+	base = strings.TrimSuffix(base, ext)
+	if ext == "" {
+		ext = ".har"
+	}
+	if filtered {
+		return base + "-filtered" + ext
+	}
+	return base + "-export" + ext
+}
+
+// exportIndices returns the entries the active filters select in file order, or nil (every
+// entry) when no filter is active. page grouping and collapsed pages don't change the selection.
+func (m *HARViewModel) exportIndices() []int {
+	if m.filterChain == nil || !m.filterChain.HasActiveFilters() {
+		return nil
+	}
+	_, indices := m.filterChain.BuildFilteredRows(m.allEntries, m.rows)
+	indices = slices.Clone(indices)
+	slices.Sort(indices)
+	return indices
+}
+
+// openExportPrompt asks where to write the filtered entries
+func (m *HARViewModel) openExportPrompt() tea.Cmd {
+	if m.exporting {
+		return showStatusMessage("Export already running")
+	}
+
+	input := textinput.New()
+	input.Prompt = "Export to: "
+	input.CharLimit = 1024
+	input.SetValue(defaultExportPath(m.fileName, m.filterChain != nil && m.filterChain.HasActiveFilters()))
+	input.CursorEnd()
+	m.exportInput = input
+	m.exportPrompt = true
+	return m.exportInput.Focus()
+}
+
+// handleExportPromptKey edits the export path; Enter starts the export and Esc cancels
+func (m *HARViewModel) handleExportPromptKey(msg tea.KeyPressMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.exportPrompt = false
+		m.exportInput.Blur()
+		return nil
+
+	case "enter", "return":
+		path := strings.TrimSpace(m.exportInput.Value())
+		if path == "" {
+			return showStatusMessage("Enter a file name to export to")
+		}
+		m.exportPrompt = false
+		m.exportInput.Blur()
+		return m.startExport(path)
+	}
+
+	var cmd tea.Cmd
+	m.exportInput, cmd = m.exportInput.Update(msg)
+	return cmd
+}
+
+// startExport writes the selected entries to path in the background, reporting progress
+func (m *HARViewModel) startExport(path string) tea.Cmd {
+	if m.streamer == nil {
+		return showStatusMessage("Nothing to export")
+	}
+
+	indices := m.exportIndices()
+	total := len(indices)
+	if indices == nil {
+		total = len(m.allEntries)
+	}
+	if total == 0 {
+		return showStatusMessage("No entries to export")
+	}
+
+	m.exporting = true
+	m.exportProgress = motor.ExportProgress{TotalEntries: total}
+	m.exportChan = make(chan motor.ExportProgress, 10)
+
+	streamer := m.streamer
+	progressChan := m.exportChan
+	exportCmd := func() tea.Msg {
+		err := motor.ExportHARFile(context.Background(), streamer, indices, path, progressChan)
+		return exportCompleteMsg{path: path, entries: total, err: err}
+	}
+	return tea.Batch(exportCmd, m.listenForExportProgress())
+}
+
+func (m *HARViewModel) listenForExportProgress() tea.Cmd {
+	progressChan := m.exportChan
+	return func() tea.Msg {
+		progress, ok := <-progressChan
+		if !ok {
+			return nil // export finished
+		}
+		return exportProgressMsg{progress: progress}
+	}
+}
+
+// finishExport reports the outcome of an export in the status bar
+func (m *HARViewModel) finishExport(msg exportCompleteMsg) tea.Cmd {
+	m.exporting = false
+	if msg.err != nil {
+		return showStatusMessage(fmt.Sprintf("Export failed: %v", msg.err))
+	}
+	return showStatusMessage(fmt.Sprintf("Exported %d entries to %s", msg.entries, msg.path))
+}
+
+// renderExportStatus is the status bar while the export prompt is open or an export runs
+func (m *HARViewModel) renderExportStatus() (string, bool) {
+	switch {
+	case m.exportPrompt:
+		return m.exportInput.View() + "  (Enter: Save | Esc: Cancel)", true
+	case m.exporting:
+		return fmt.Sprintf("Exporting %d/%d entries...", m.exportProgress.EntriesDone, m.exportProgress.TotalEntries), true
+	}
+	return "", false
+}
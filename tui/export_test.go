@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/v2/table"
+	"github.com/pb33f/harific/motor"
+)
+
+func TestDefaultExportPath(t *testing.T) {
+	tests := []struct {
+		fileName string
+		filtered bool
+		want     string
+	}{
+		{"capture.har", true, "capture-filtered.har"},
+		{"/tmp/capture.har", false, "/tmp/capture-export.har"},
+		{"capture.har.gz", true, "capture-filtered.har"},
+		{"capture", true, "capture-filtered.har"},
+	}
+
+	for _, tt := range tests {
+		if got := defaultExportPath(tt.fileName, tt.filtered); got != tt.want {
+			t.Errorf("defaultExportPath(%q, %v) = %q, want %q", tt.fileName, tt.filtered, got, tt.want)
+		}
+	}
+}
+
+func TestExportIndices(t *testing.T) {
+	entries := []*motor.EntryMetadata{
+		{Method: "GET", URL: "https://example.com/a", PageRef: "page_1", Duration: 5},
+		{Method: "POST", URL: "https://example.com/b"},
+		{Method: "GET", URL: "https://example.com/c", PageRef: "page_1", Duration: 50},
+	}
+	rows := make([]table.Row, len(entries))
+	for i, entry := range entries {
+		rows[i] = ColumnsDefault.row(entry, 120)
+	}
+
+	m := &HARViewModel{
+		allEntries:     entries,
+		rows:           rows,
+		searchFilter:   NewSearchFilter(),
+		fileTypeFilter: NewFileTypeFilter(),
+		methodFilter:   NewMethodFilter(),
+		statusFilter:   NewStatusClassFilter(),
+		timeFilter:     NewTimeRangeFilter(),
+		filterChain:    NewFilterChain(),
+		groupByPage:    true,
+		collapsedPages: map[string]bool{"page_1": true},
+		tableSort:      TableSort{Column: SortDuration, Descending: true},
+	}
+	m.applyFilters()
+
+	if got := m.exportIndices(); got != nil {
+		t.Errorf("without filters every entry is exported, got %v", got)
+	}
+
+	m.methodFilter.ToggleMethod("POST", false)
+	m.applyFilters()
+
+	// sorted, grouped and collapsed in the table, but exported in file order
+	if got := m.exportIndices(); !slices.Equal(got, []int{0, 2}) {
+		t.Errorf("exportIndices() = %v, want [0 2]", got)
+	}
+}
@@ -11,6 +11,12 @@ import (
 var fileTypeCategories = []string{"Graphics", "JS", "CSS", "Fonts", "Markup", "All Files"}
 
 func (m *HARViewModel) renderFilterModal() string {
+	return renderCheckboxModal("File Type Filters", fileTypeCategories, m.filterCheckboxes[:], m.filterCursor)
+}
+
+// renderCheckboxModal renders a filter modal of labelled checkboxes followed by a reset option;
+// cursor == len(labels) focuses the reset option
+func renderCheckboxModal(title string, labels []string, checked []bool, cursor int) string {
 	// Fixed modal width for consistent appearance
 	modalWidth := 30
 
@@ -31,24 +37,24 @@ func (m *HARViewModel) renderFilterModal() string {
 
 	var content strings.Builder
 
-	content.WriteString(titleStyle.Render("File Type Filters"))
+	content.WriteString(titleStyle.Render(title))
 	content.WriteString("\n\n")
 
 	// Always use single column layout for clean appearance
-	for i, category := range fileTypeCategories {
-		cursor := " "
-		if m.filterCursor == i {
-			cursor = ">"
+	for i, label := range labels {
+		marker := " "
+		if cursor == i {
+			marker = ">"
 		}
 
 		checkbox := "[x]"
-		if !m.filterCheckboxes[i] {
+		if !checked[i] {
 			checkbox = "[ ]"
 		}
 
-		line := fmt.Sprintf("%s %s %-12s", cursor, checkbox, category)
+		line := fmt.Sprintf("%s %s %-12s", marker, checkbox, label)
 
-		if m.filterCursor == i {
+		if cursor == i {
 			line = highlightStyle.Render(line)
 		}
 
@@ -59,7 +65,7 @@ func (m *HARViewModel) renderFilterModal() string {
 	// add Reset option
 	content.WriteString("\n")
 	resetLine := " [ ] Reset All Filters"
-	if m.filterCursor == len(fileTypeCategories) {
+	if cursor == len(labels) {
 		resetLine = highlightStyle.Render("> [*] Reset All Filters")
 	}
 	content.WriteString(resetLine)
@@ -72,6 +78,18 @@ func (m *HARViewModel) renderFilterModal() string {
 	return modalStyle.Render(content.String())
 }
 
+// moveCheckboxCursor moves a checkbox modal cursor over count checkboxes plus the reset option, wrapping
+func moveCheckboxCursor(cursor, delta, count int) int {
+	cursor += delta
+	if cursor < 0 {
+		return count // wrap to Reset option
+	}
+	if cursor > count {
+		return 0 // wrap to first checkbox
+	}
+	return cursor
+}
+
 func (m *HARViewModel) toggleFilterCheckbox() {
 	if m.filterCursor < len(fileTypeCategories) {
 		m.filterCheckboxes[m.filterCursor] = !m.filterCheckboxes[m.filterCursor]
@@ -110,17 +128,11 @@ func (m *HARViewModel) handleFilterModalKeys(key string) (bool, tea.Cmd) {
 		return true, nil
 
 	case "up":
-		m.filterCursor--
-		if m.filterCursor < 0 {
-			m.filterCursor = len(fileTypeCategories) // wrap to Reset option
-		}
+		m.filterCursor = moveCheckboxCursor(m.filterCursor, -1, len(fileTypeCategories))
 		return true, nil
 
 	case "down":
-		m.filterCursor++
-		if m.filterCursor > len(fileTypeCategories) {
-			m.filterCursor = 0 // wrap to first checkbox
-		}
+		m.filterCursor = moveCheckboxCursor(m.filterCursor, 1, len(fileTypeCategories))
 		return true, nil
 
 	case " ", "space", "enter":
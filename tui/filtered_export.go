@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// filteredJSONSavedMsg ends a write of the filtered JSON subtree; err is nil on success
+type filteredJSONSavedMsg struct {
+	path string
+	err  error
+}
+
+// FilteredJSON is the subtree the filtered view shows, the matched paths and their parents, as
+// indented JSON
+func (s *ViewportSearchState) FilteredJSON() (string, error) {
+	if s.renderer == nil || !s.filtered || len(s.matches) == 0 {
+		return "", fmt.Errorf("no filtered view to export")
+	}
+	filtered, err := s.renderer.searchEngine.FilterJSON(true)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(filtered, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// filteredJSONFileName names an exported subtree after the entry's default body file name,
+// e.g. users-filtered.json
+func filteredJSONFileName(rawURL string) string {
+	name := defaultBodyFileName(rawURL, "application/json")
+	return strings.TrimSuffix(name, filepath.Ext(name)) + "-filtered.json"
+}
+
+// copyFilteredJSON copies the detail modal's filtered JSON subtree to the clipboard
+func (m *HARViewModel) copyFilteredJSON() tea.Cmd {
+	content, err := m.detailSearchState.FilteredJSON()
+	if err != nil {
+		m.detailSearchState.notice = err.Error()
+		return nil
+	}
+	m.detailSearchState.notice = fmt.Sprintf("copied %s", formatSize(int64(len(content))))
+	if err := clipboard.WriteAll(content); err != nil {
+		return tea.SetClipboard(content)
+	}
+	return nil
+}
+
+// saveFilteredJSON writes the detail modal's filtered JSON subtree to a file in the working
+// directory in the background
+func (m *HARViewModel) saveFilteredJSON() tea.Cmd {
+	content, err := m.detailSearchState.FilteredJSON()
+	if err != nil {
+		m.detailSearchState.notice = err.Error()
+		return nil
+	}
+	rawURL := ""
+	if m.selectedEntry != nil {
+		rawURL = m.selectedEntry.Request.URL
+	}
+	path := filteredJSONFileName(rawURL)
+	m.detailSearchState.notice = "saving " + path
+	return func() tea.Msg {
+		return filteredJSONSavedMsg{path: path, err: os.WriteFile(path, []byte(content+"\n"), 0644)}
+	}
+}
+
+// finishFilteredJSONSave confirms a filtered JSON write in the search bar
+func (m *HARViewModel) finishFilteredJSONSave(msg filteredJSONSavedMsg) {
+	if msg.err != nil {
+		m.detailSearchState.notice = "save failed: " + msg.err.Error()
+		return
+	}
+	m.detailSearchState.notice = "saved " + msg.path
+}
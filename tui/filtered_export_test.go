@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/harific/motor/model"
+)
+
+func TestViewportSearchFilteredJSON(t *testing.T) {
+	state := newMatchNavigationState(t)
+	if _, err := state.FilteredJSON(); err == nil {
+		t.Error("expected an error before the view is filtered")
+	}
+
+	state.ToggleFiltered()
+	content, err := state.FilteredJSON()
+	if err != nil {
+		t.Fatalf("FilteredJSON failed: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal([]byte(content), &got); err != nil {
+		t.Fatalf("export is not JSON: %v\n%s", err, content)
+	}
+	if _, ok := got["b"]; ok || got["a"] == nil || got["c"] == nil {
+		t.Errorf("expected only the paths holding an id, got %s", content)
+	}
+}
+
+func TestSaveFilteredJSON(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	m := &HARViewModel{
+		detailSearchState: newMatchNavigationState(t),
+		selectedEntry:     &model.Entry{Request: model.Request{URL: "https://example.com/api/users?page=2"}},
+	}
+	m.detailSearchState.ToggleFiltered()
+
+	cmd := m.saveFilteredJSON()
+	if cmd == nil {
+		t.Fatal("expected a save command")
+	}
+	m.finishFilteredJSONSave(cmd().(filteredJSONSavedMsg))
+
+	if m.detailSearchState.notice != "saved users-filtered.json" {
+		t.Errorf("notice = %q", m.detailSearchState.notice)
+	}
+	if _, err := os.Stat(filepath.Join(".", "users-filtered.json")); err != nil {
+		t.Errorf("expected the file to be written: %v", err)
+	}
+}
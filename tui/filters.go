@@ -3,6 +3,7 @@ package tui
 import (
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/v2/table"
 	"github.com/pb33f/harific/motor"
@@ -131,7 +132,38 @@ func (fc *FilterChain) BuildFilteredRows(allEntries []*motor.EntryMetadata, allR
 	return filtered, indices
 }
 
-// FileTypeFilter filters entries based on file extensions
+// PassingIndices returns the indices of entries that pass all active filters,
+// or nil when none of the filters are active (meaning every entry passes)
+func PassingIndices(allEntries []*motor.EntryMetadata, filters ...EntryFilter) []int {
+	active := make([]EntryFilter, 0, len(filters))
+	for _, filter := range filters {
+		if filter != nil && filter.IsActive() {
+			active = append(active, filter)
+		}
+	}
+	if len(active) == 0 {
+		return nil
+	}
+
+	indices := make([]int, 0, len(allEntries))
+	for i, entry := range allEntries {
+		passesAll := true
+		for _, filter := range active {
+			if !filter.ShouldShow(i, entry) {
+				passesAll = false
+				break
+			}
+		}
+		if passesAll {
+			indices = append(indices, i)
+		}
+	}
+
+	return indices
+}
+
+// FileTypeFilter filters entries based on their resource type, or their file extension when the
+// har has no _resourceType
 type FileTypeFilter struct {
 	excludedCategories map[string]bool
 }
@@ -146,6 +178,12 @@ var extensionToCategory = map[string]string{
 	".html": "Markup", ".htm": "Markup", ".xml": "Markup", ".yaml": "Markup", ".yml": "Markup",
 }
 
+// chrome _resourceType to category mapping; api calls have no category and are always shown
+var resourceTypeToCategory = map[string]string{
+	"image": "Graphics", "script": "JS", "stylesheet": "CSS", "font": "Fonts", "document": "Markup",
+	"xhr": "", "fetch": "", "websocket": "", "eventsource": "", "preflight": "",
+}
+
 // NewFileTypeFilter creates a new file type filter
 func NewFileTypeFilter() *FileTypeFilter {
 	return &FileTypeFilter{
@@ -153,8 +191,16 @@ func NewFileTypeFilter() *FileTypeFilter {
 	}
 }
 
-// ShouldShow returns true if the entry URL is not an excluded file type
+// ShouldShow returns true if the entry is not an excluded file type
 func (f *FileTypeFilter) ShouldShow(index int, metadata *motor.EntryMetadata) bool {
+	if metadata.ResourceType != "" {
+		category, known := resourceTypeToCategory[strings.ToLower(metadata.ResourceType)]
+		if !known {
+			category = "All Files" // media, manifest, other, ...
+		}
+		return category == "" || !f.excludedCategories[category]
+	}
+
 	// extract extension from URL
 	ext := strings.ToLower(filepath.Ext(metadata.URL))
 	if ext == "" {
@@ -200,3 +246,238 @@ func (f *FileTypeFilter) ToggleCategory(category string, include bool) {
 	}
 }
 
+
+// MethodFilter filters entries based on their request method
+type MethodFilter struct {
+	excludedMethods map[string]bool
+}
+
+// NewMethodFilter creates a new method filter
+func NewMethodFilter() *MethodFilter {
+	return &MethodFilter{
+		excludedMethods: make(map[string]bool),
+	}
+}
+
+// ShouldShow returns true if the entry's method is not excluded
+func (f *MethodFilter) ShouldShow(index int, metadata *motor.EntryMetadata) bool {
+	return !f.excludedMethods[methodCategory(metadata.Method)]
+}
+
+// IsActive returns true if any methods are excluded
+func (f *MethodFilter) IsActive() bool {
+	return len(f.excludedMethods) > 0
+}
+
+// ToggleMethod includes or excludes a method category
+func (f *MethodFilter) ToggleMethod(method string, include bool) {
+	if include {
+		delete(f.excludedMethods, method)
+	} else {
+		f.excludedMethods[method] = true
+	}
+}
+
+// Clear removes all exclusions
+func (f *MethodFilter) Clear() {
+	f.excludedMethods = make(map[string]bool)
+}
+
+// methodCategory maps a request method onto one of methodCategories
+func methodCategory(method string) string {
+	method = strings.ToUpper(method)
+	if method == "" {
+		return "GET" // matches how the table displays a missing method
+	}
+	for _, known := range methodCategories[:len(methodCategories)-1] {
+		if method == known {
+			return method
+		}
+	}
+	return "Other"
+}
+
+// StatusClassFilter filters entries based on their response status class (2xx, 3xx, ...)
+type StatusClassFilter struct {
+	excludedClasses map[string]bool
+}
+
+// NewStatusClassFilter creates a new status class filter
+func NewStatusClassFilter() *StatusClassFilter {
+	return &StatusClassFilter{
+		excludedClasses: make(map[string]bool),
+	}
+}
+
+// ShouldShow returns true if the entry's status class is not excluded
+func (f *StatusClassFilter) ShouldShow(index int, metadata *motor.EntryMetadata) bool {
+	return !f.excludedClasses[statusClass(metadata.StatusCode)]
+}
+
+// IsActive returns true if any status classes are excluded
+func (f *StatusClassFilter) IsActive() bool {
+	return len(f.excludedClasses) > 0
+}
+
+// ToggleClass includes or excludes a status class
+func (f *StatusClassFilter) ToggleClass(class string, include bool) {
+	if include {
+		delete(f.excludedClasses, class)
+	} else {
+		f.excludedClasses[class] = true
+	}
+}
+
+// Clear removes all exclusions
+func (f *StatusClassFilter) Clear() {
+	f.excludedClasses = make(map[string]bool)
+}
+
+// statusClass maps a status code onto one of statusClassCategories
+func statusClass(code int) string {
+	switch {
+	case code >= 200 && code < 300:
+		return "2xx"
+	case code >= 300 && code < 400:
+		return "3xx"
+	case code >= 400 && code < 500:
+		return "4xx"
+	case code >= 500 && code < 600:
+		return "5xx"
+	default:
+		return "Other" // 1xx, 0 for aborted requests, and anything non-standard
+	}
+}
+
+// TimeRangeFilter shows entries started between two instants; either bound may be left open
+type TimeRangeFilter struct {
+	from           time.Time // zero = no lower bound
+	to             time.Time // zero = no upper bound
+	excludeUntimed bool      // hide entries without a parseable startedDateTime
+}
+
+// NewTimeRangeFilter creates a new time range filter
+func NewTimeRangeFilter() *TimeRangeFilter {
+	return &TimeRangeFilter{}
+}
+
+// ShouldShow returns true if the entry started within the range (bounds inclusive). entries
+// without a timestamp pass unless they are excluded.
+func (f *TimeRangeFilter) ShouldShow(index int, metadata *motor.EntryMetadata) bool {
+	if metadata.Timestamp.IsZero() {
+		return !f.excludeUntimed
+	}
+	if !f.from.IsZero() && metadata.Timestamp.Before(f.from) {
+		return false
+	}
+	if !f.to.IsZero() && metadata.Timestamp.After(f.to) {
+		return false
+	}
+	return true
+}
+
+// IsActive returns true if a bound is set or untimed entries are excluded
+func (f *TimeRangeFilter) IsActive() bool {
+	return !f.from.IsZero() || !f.to.IsZero() || f.excludeUntimed
+}
+
+// SetRange sets the bounds; a zero time leaves that side open
+func (f *TimeRangeFilter) SetRange(from, to time.Time) {
+	f.from = from
+	f.to = to
+}
+
+// SetExcludeUntimed hides or shows entries without a timestamp
+func (f *TimeRangeFilter) SetExcludeUntimed(exclude bool) {
+	f.excludeUntimed = exclude
+}
+
+// Clear removes both bounds and shows untimed entries again
+func (f *TimeRangeFilter) Clear() {
+	f.from = time.Time{}
+	f.to = time.Time{}
+	f.excludeUntimed = false
+}
+
+// EndpointFilter shows only the entries of the endpoint picked in the endpoints view
+type EndpointFilter struct {
+	endpoint string
+	indices  map[int]struct{} // nil when no endpoint is picked
+}
+
+// NewEndpointFilter creates a new endpoint filter
+func NewEndpointFilter() *EndpointFilter {
+	return &EndpointFilter{}
+}
+
+// ShouldShow returns true if the entry belongs to the picked endpoint
+func (f *EndpointFilter) ShouldShow(index int, metadata *motor.EntryMetadata) bool {
+	_, found := f.indices[index]
+	return found
+}
+
+// IsActive returns true if an endpoint is picked; a nil filter is never active
+func (f *EndpointFilter) IsActive() bool {
+	return f != nil && f.indices != nil
+}
+
+// SetEndpoint limits the entries to those of the endpoint group
+func (f *EndpointFilter) SetEndpoint(endpoint motor.EndpointStats) {
+	f.endpoint = endpoint.Endpoint
+	f.indices = make(map[int]struct{}, len(endpoint.Indices))
+	for _, i := range endpoint.Indices {
+		f.indices[i] = struct{}{}
+	}
+}
+
+// Endpoint returns the picked endpoint's normalized url
+func (f *EndpointFilter) Endpoint() string {
+	return f.endpoint
+}
+
+// Clear shows every endpoint again
+func (f *EndpointFilter) Clear() {
+	f.endpoint = ""
+	f.indices = nil
+}
+
+// SizeFilter shows only entries whose response size, the table's Size column, is in a range
+type SizeFilter struct {
+	MinBytes int64 // 0 = no lower bound
+	MaxBytes int64 // 0 = no upper bound
+}
+
+// NewSizeFilter creates a new size filter with both bounds open
+func NewSizeFilter() *SizeFilter {
+	return &SizeFilter{}
+}
+
+// ShouldShow returns true if the response size is within the bounds (inclusive). entries with
+// an unknown size only pass while the lower bound is open.
+func (f *SizeFilter) ShouldShow(index int, metadata *motor.EntryMetadata) bool {
+	size := max(metadata.ResponseSize, 0)
+	if f.MinBytes > 0 && size < f.MinBytes {
+		return false
+	}
+	if f.MaxBytes > 0 && size > f.MaxBytes {
+		return false
+	}
+	return true
+}
+
+// IsActive returns true if either bound is set; a nil filter is never active
+func (f *SizeFilter) IsActive() bool {
+	return f != nil && (f.MinBytes > 0 || f.MaxBytes > 0)
+}
+
+// SetRange sets the bounds in bytes; 0 leaves that side open
+func (f *SizeFilter) SetRange(minBytes, maxBytes int64) {
+	f.MinBytes = minBytes
+	f.MaxBytes = maxBytes
+}
+
+// Clear opens both bounds
+func (f *SizeFilter) Clear() {
+	f.MinBytes = 0
+	f.MaxBytes = 0
+}
@@ -0,0 +1,351 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/v2/table"
+	"github.com/pb33f/harific/motor"
+)
+
+func TestMethodFilter(t *testing.T) {
+	f := NewMethodFilter()
+	if f.IsActive() {
+		t.Fatal("new method filter should be inactive")
+	}
+
+	f.ToggleMethod("GET", false)
+	f.ToggleMethod("Other", false)
+	if !f.IsActive() {
+		t.Fatal("filter with exclusions should be active")
+	}
+
+	tests := []struct {
+		method string
+		want   bool
+	}{
+		{"GET", false},
+		{"get", false},
+		{"", false}, // displayed as GET
+		{"POST", true},
+		{"OPTIONS", true},
+		{"CONNECT", false}, // falls under Other
+	}
+	for _, tt := range tests {
+		if got := f.ShouldShow(0, &motor.EntryMetadata{Method: tt.method}); got != tt.want {
+			t.Errorf("ShouldShow(%q) = %v, want %v", tt.method, got, tt.want)
+		}
+	}
+
+	f.ToggleMethod("GET", true)
+	if !f.ShouldShow(0, &motor.EntryMetadata{Method: "GET"}) {
+		t.Error("re-included method should show")
+	}
+
+	f.Clear()
+	if f.IsActive() {
+		t.Error("cleared filter should be inactive")
+	}
+}
+
+func TestFileTypeFilter_ResourceType(t *testing.T) {
+	f := NewFileTypeFilter()
+	f.ExcludeCategory("JS")
+	f.ExcludeCategory("Graphics")
+	f.ExcludeCategory("All Files")
+
+	tests := []struct {
+		url, resourceType string
+		want              bool
+	}{
+		{"https://example.com/app.js", "", false},       // no resource type: the extension decides
+		{"https://example.com/bundle", "script", false}, // a script without an extension
+		{"https://example.com/app.js", "fetch", true},   // fetched as data, whatever the extension
+		{"https://example.com/pixel.gif", "Image", false},
+		{"https://example.com/page", "document", true},
+		{"https://example.com/film.mp4", "media", false}, // unmapped types fall under All Files
+	}
+	for _, tt := range tests {
+		metadata := &motor.EntryMetadata{URL: tt.url, ResourceType: tt.resourceType}
+		if got := f.ShouldShow(0, metadata); got != tt.want {
+			t.Errorf("ShouldShow(%s, %q) = %v, want %v", tt.url, tt.resourceType, got, tt.want)
+		}
+	}
+}
+
+func TestMethodFilterComposesWithSearch(t *testing.T) {
+	entries := []*motor.EntryMetadata{
+		{Method: "GET", URL: "https://example.com/a"},
+		{Method: "POST", URL: "https://example.com/b"},
+		{Method: "POST", URL: "https://example.com/c"},
+	}
+	rows := make([]table.Row, len(entries))
+
+	search := NewSearchFilter()
+	search.SetSearched(true)
+	search.AddMatch(0)
+	search.AddMatch(2)
+
+	methods := NewMethodFilter()
+	methods.ToggleMethod("GET", false)
+
+	chain := NewFilterChain()
+	chain.Add(search)
+	chain.Add(methods)
+
+	_, indices := chain.BuildFilteredRows(entries, rows)
+	if len(indices) != 1 || indices[0] != 2 {
+		t.Errorf("filtered indices = %v, want [2]", indices)
+	}
+}
+
+func TestMoveCheckboxCursor(t *testing.T) {
+	if got := moveCheckboxCursor(0, -1, 3); got != 3 {
+		t.Errorf("up from first = %d, want reset option 3", got)
+	}
+	if got := moveCheckboxCursor(3, 1, 3); got != 0 {
+		t.Errorf("down from reset = %d, want 0", got)
+	}
+	if got := moveCheckboxCursor(1, 1, 3); got != 2 {
+		t.Errorf("down = %d, want 2", got)
+	}
+}
+
+func TestStatusClassFilter(t *testing.T) {
+	f := NewStatusClassFilter()
+	if f.IsActive() {
+		t.Fatal("new status filter should be inactive")
+	}
+
+	// triage errors only
+	for _, class := range []string{"2xx", "3xx", "Other"} {
+		f.ToggleClass(class, false)
+	}
+
+	tests := []struct {
+		code int
+		want bool
+	}{
+		{200, false},
+		{204, false},
+		{301, false},
+		{404, true},
+		{499, true},
+		{500, true},
+		{503, true},
+		{0, false},   // aborted
+		{101, false}, // switching protocols
+	}
+	for _, tt := range tests {
+		if got := f.ShouldShow(0, &motor.EntryMetadata{StatusCode: tt.code}); got != tt.want {
+			t.Errorf("ShouldShow(%d) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+
+	f.Clear()
+	if f.IsActive() {
+		t.Error("cleared filter should be inactive")
+	}
+}
+
+func TestStatusClassFilterComposesWithMethodFilter(t *testing.T) {
+	// failed POSTs only
+	entries := []*motor.EntryMetadata{
+		{Method: "POST", StatusCode: 200},
+		{Method: "POST", StatusCode: 500},
+		{Method: "GET", StatusCode: 500},
+		{Method: "POST", StatusCode: 422},
+	}
+
+	status := NewStatusClassFilter()
+	status.ToggleClass("2xx", false)
+
+	methods := NewMethodFilter()
+	methods.ToggleMethod("GET", false)
+
+	indices := PassingIndices(entries, status, methods)
+	if len(indices) != 2 || indices[0] != 1 || indices[1] != 3 {
+		t.Errorf("passing indices = %v, want [1 3]", indices)
+	}
+}
+
+func TestTimeRangeFilter(t *testing.T) {
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	f := NewTimeRangeFilter()
+	if f.IsActive() {
+		t.Error("new filter should be inactive")
+	}
+
+	f.SetRange(start.Add(10*time.Second), start.Add(time.Minute))
+	if !f.IsActive() {
+		t.Error("filter with a range should be active")
+	}
+
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{start, false},
+		{start.Add(10 * time.Second), true}, // bounds are inclusive
+		{start.Add(30 * time.Second), true},
+		{start.Add(time.Minute), true},
+		{start.Add(2 * time.Minute), false},
+		{time.Time{}, true}, // untimed entries pass until excluded
+	}
+	for _, tt := range tests {
+		if got := f.ShouldShow(0, &motor.EntryMetadata{Timestamp: tt.at}); got != tt.want {
+			t.Errorf("ShouldShow(%v) = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+
+	f.SetExcludeUntimed(true)
+	if f.ShouldShow(0, &motor.EntryMetadata{}) {
+		t.Error("untimed entry should be hidden once excluded")
+	}
+
+	// an open upper bound
+	f.SetRange(start.Add(time.Minute), time.Time{})
+	if !f.ShouldShow(0, &motor.EntryMetadata{Timestamp: start.Add(time.Hour)}) {
+		t.Error("open upper bound should show later entries")
+	}
+
+	f.Clear()
+	if f.IsActive() {
+		t.Error("cleared filter should be inactive")
+	}
+}
+
+func TestTimeRangeFilterComposesWithStatusFilter(t *testing.T) {
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	entries := []*motor.EntryMetadata{
+		{StatusCode: 500, Timestamp: start},
+		{StatusCode: 500, Timestamp: start.Add(time.Minute)},
+		{StatusCode: 200, Timestamp: start.Add(time.Minute)},
+		{StatusCode: 500},
+	}
+
+	status := NewStatusClassFilter()
+	status.ToggleClass("2xx", false)
+
+	times := NewTimeRangeFilter()
+	times.SetRange(start.Add(30*time.Second), time.Time{})
+	times.SetExcludeUntimed(true)
+
+	indices := PassingIndices(entries, status, times)
+	if len(indices) != 1 || indices[0] != 1 {
+		t.Errorf("passing indices = %v, want [1]", indices)
+	}
+}
+
+func TestParseTimeBound(t *testing.T) {
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"", time.Time{}, false},
+		{"+90s", start.Add(90 * time.Second), false},
+		{"+1m30s", start.Add(90 * time.Second), false},
+		{"10:04", start.Add(4 * time.Minute), false},
+		{"10:04:05", start.Add(4*time.Minute + 5*time.Second), false},
+		{"10:04:05.250", start.Add(4*time.Minute + 5250*time.Millisecond), false},
+		{"2025-03-01T11:00:00Z", start.Add(time.Hour), false},
+		{"+soon", time.Time{}, true},
+		{"yesterday", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseTimeBound(tt.input, start)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseTimeBound(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseTimeBound(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	// relative and clock times need a capture start; absolute times don't
+	if _, err := parseTimeBound("+10s", time.Time{}); err == nil {
+		t.Error("offset without a capture start should fail")
+	}
+	if _, err := parseTimeBound("2025-03-01T11:00:00Z", time.Time{}); err != nil {
+		t.Errorf("absolute time without a capture start: %v", err)
+	}
+}
+
+func TestSizeFilter(t *testing.T) {
+	entries := []*motor.EntryMetadata{
+		{ResponseSize: 500, StatusCode: 200},
+		{ResponseSize: 2 << 20, StatusCode: 200},
+		{ResponseSize: 3 << 20, StatusCode: 500},
+		{ResponseSize: -1, StatusCode: 200},
+	}
+
+	f := NewSizeFilter()
+	if f.IsActive() {
+		t.Error("new size filter should be inactive")
+	}
+
+	f.SetRange(1<<20, 0)
+	if got := PassingIndices(entries, f); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("larger than 1MB = %v, want [1 2]", got)
+	}
+
+	// composes with the other filters
+	status := NewStatusClassFilter()
+	status.ToggleClass("5xx", false)
+	if got := PassingIndices(entries, f, status); len(got) != 1 || got[0] != 1 {
+		t.Errorf("larger than 1MB without 5xx = %v, want [1]", got)
+	}
+
+	f.SetRange(0, 1024)
+	if got := PassingIndices(entries, f); len(got) != 2 || got[0] != 0 || got[1] != 3 {
+		t.Errorf("at most 1KB = %v, want [0 3] including the unknown size", got)
+	}
+
+	f.Clear()
+	if f.IsActive() {
+		t.Error("cleared filter should be inactive")
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"512", 512, false},
+		{"512B", 512, false},
+		{"64kb", 64 << 10, false},
+		{"1.5MB", 3 << 19, false},
+		{" 2 m ", 2 << 20, false},
+		{"1GB", 1 << 30, false},
+		{"MB", 0, true},
+		{"12TB", 0, true},
+		{"-5KB", 0, true},
+		{"lots", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseSize(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSize(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseSize(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+
+	for _, bytes := range []int64{0, -1} {
+		if got := formatSize(bytes); got != "---" {
+			t.Errorf("formatSize(%d) = %q, want ---", bytes, got)
+		}
+	}
+	if got := formatSize(3 << 29); got != "1.5GB" {
+		t.Errorf("formatSize(1.5GB) = %q", got)
+	}
+}
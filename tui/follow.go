@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/pb33f/harific/motor"
+)
+
+// followInterval is how often a followed file is checked for new entries
+const followInterval = time.Second
+
+type followTickMsg struct{}
+
+type followResultMsg struct {
+	result motor.FollowResult
+	err    error
+}
+
+// follower is implemented by streamers that pick up entries appended to the file they read
+type follower interface {
+	Follow(ctx context.Context) (motor.FollowResult, error)
+}
+
+// refresher is implemented by readers that can be moved onto a grown copy of their index
+type refresher interface {
+	RefreshIndex(index *motor.Index)
+}
+
+// scheduleFollow waits a followInterval before the next check of a followed file
+func (m *HARViewModel) scheduleFollow() tea.Cmd {
+	if !m.follow {
+		return nil
+	}
+	return tea.Tick(followInterval, func(time.Time) tea.Msg { return followTickMsg{} })
+}
+
+// checkFollowed indexes whatever was written to the file since the last check, in the background
+func (m *HARViewModel) checkFollowed() tea.Cmd {
+	streamer, ok := m.streamer.(follower)
+	if !ok {
+		return nil
+	}
+	ctx := m.indexingCtx
+	return func() tea.Msg {
+		result, err := streamer.Follow(ctx)
+		return followResultMsg{result: result, err: err}
+	}
+}
+
+// applyFollowResult adds new entries to the table, keeping the cursor on the last row when it was
+// already there so the view tails the file. a rewritten file replaces every row. a search in
+// progress is run again so the new entries are searched too.
+func (m *HARViewModel) applyFollowResult(msg followResultMsg) tea.Cmd {
+	next := m.scheduleFollow()
+	if msg.err != nil {
+		return tea.Batch(next, showStatusMessage(fmt.Sprintf("Follow failed: %v", msg.err)))
+	}
+	if msg.result.Added == 0 {
+		return next
+	}
+
+	tailing := m.table.Cursor() >= len(m.table.Rows())-1
+
+	status := fmt.Sprintf("%d new entries", msg.result.Added)
+	if msg.result.Rebuilt {
+		old := m.reader
+		index := m.streamer.GetIndex()
+		if err := m.openReader(index, m.streamer); err != nil {
+			return tea.Batch(next, showStatusMessage(fmt.Sprintf("Follow failed: %v", err)))
+		}
+		if old != nil {
+			old.Close()
+		}
+		m.index = index
+		status = fmt.Sprintf("File was rewritten, reindexed %d entries", msg.result.Added)
+	} else {
+		// Follow swaps in a grown copy of the index rather than appending to this one
+		m.index = m.streamer.GetIndex()
+		if reader, ok := m.reader.(refresher); ok {
+			reader.RefreshIndex(m.index)
+		}
+	}
+
+	m.allEntries = m.index.Entries
+	m.slowestEntry = slowestDuration(m.allEntries)
+	m.timeline = nil // an appended entry can start before, or overlap, the ones already there
+	if !m.ready {
+		return tea.Batch(next, showStatusMessage(status))
+	}
+	m.buildTableRows()
+	m.applyFilters()
+	if tailing {
+		m.table.GotoBottom()
+	}
+
+	cmds := []tea.Cmd{next, showStatusMessage(status)}
+	if m.searchInput.Value() != "" {
+		m.debounceID++
+		cmds = append(cmds, func() tea.Msg { return searchStartMsg{} })
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/pb33f/harific/motor"
+)
+
+func liveHAR(names ...string) string {
+	entries := make([]string, len(names))
+	for i, name := range names {
+		entries[i] = fmt.Sprintf(`{"startedDateTime": "2024-01-01T00:00:00Z", "time": 1,
+ "request": {"method": "GET", "url": "https://example.com/%s", "headers": [], "bodySize": 0},
+ "response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 0, "mimeType": "text/plain"}, "bodySize": 0}}`, name)
+	}
+	return `{"log": {"version": "1.2", "creator": {"name": "proxy", "version": "1.0"}, "entries": [` +
+		strings.Join(entries, ",")
+}
+
+func TestFollow_AddsAppendedRows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "live.har")
+	if err := os.WriteFile(path, []byte(liveHAR("first")), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := motor.DefaultStreamerOptions()
+	opts.Follow = true
+	streamer, err := motor.NewHARStreamer(path, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := streamer.Initialize(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := NewHARViewModel(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.SetFollow(true)
+	m.indexingCtx = context.Background()
+	defer m.Cleanup()
+
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	if _, cmd := m.Update(indexCompleteMsg{index: streamer.GetIndex(), streamer: streamer}); cmd == nil {
+		t.Fatalf("expected the first follow check to be scheduled")
+	}
+	if len(m.table.Rows()) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(m.table.Rows()))
+	}
+
+	if err := os.WriteFile(path, []byte(liveHAR("first", "second", "third")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	msg, ok := m.checkFollowed()().(followResultMsg)
+	if !ok || msg.err != nil || msg.result.Added != 2 {
+		t.Fatalf("unexpected follow result %#v", msg)
+	}
+	m.Update(msg)
+
+	if len(m.allEntries) != 3 || len(m.table.Rows()) != 3 {
+		t.Fatalf("expected 3 entries and rows, got %d and %d", len(m.allEntries), len(m.table.Rows()))
+	}
+	if m.table.Cursor() != 2 {
+		t.Errorf("a cursor on the last row should follow the new entries, got %d", m.table.Cursor())
+	}
+	if _, err := m.reader.ReadMetadata(m.allEntries[2].FileOffset); err != nil {
+		t.Errorf("the reader should see the appended entries: %v", err)
+	}
+}
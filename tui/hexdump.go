@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const hexBytesPerLine = 16
+
+// binaryMIMEPrefixes are MIME types whose bodies are never meaningful as text
+var binaryMIMEPrefixes = []string{
+	"image/",
+	"audio/",
+	"video/",
+	"font/",
+	"application/octet-stream",
+	"application/protobuf",
+	"application/x-protobuf",
+	"application/grpc",
+	"application/pdf",
+	"application/zip",
+	"application/gzip",
+	"application/wasm",
+	"application/font-",
+	"application/x-font-",
+}
+
+// decodeBody returns the raw bytes of a body, decoding base64 content when the HAR marks it as encoded
+func decodeBody(content, encoding string) []byte {
+	if strings.EqualFold(encoding, "base64") {
+		if decoded, err := base64.StdEncoding.DecodeString(content); err == nil {
+			return decoded
+		}
+	}
+	return []byte(content)
+}
+
+// isBinaryBody reports whether body bytes should be displayed as a hex dump rather than text.
+// Image formats that are text (svg+xml) are left alone.
+func isBinaryBody(data []byte, mimeType string) bool {
+	if len(data) == 0 {
+		return false
+	}
+
+	lower := strings.ToLower(mimeType)
+	if !strings.Contains(lower, "svg") {
+		for _, prefix := range binaryMIMEPrefixes {
+			if strings.HasPrefix(lower, prefix) {
+				return true
+			}
+		}
+	}
+
+	return !utf8.Valid(data)
+}
+
+// formatHexDump renders bytes as offset, 16 hex bytes (split into two groups of 8) and an ASCII gutter
+func formatHexDump(data []byte) string {
+	var b strings.Builder
+	// each line: 8 offset + 2 + 16*3 hex + 1 group gap + 2 + 16 ascii + 2 bars + newline
+	b.Grow((len(data)/hexBytesPerLine + 1) * 80)
+
+	for offset := 0; offset < len(data); offset += hexBytesPerLine {
+		end := offset + hexBytesPerLine
+		if end > len(data) {
+			end = len(data)
+		}
+		line := data[offset:end]
+
+		fmt.Fprintf(&b, "%08x  ", offset)
+
+		for i := 0; i < hexBytesPerLine; i++ {
+			if i == hexBytesPerLine/2 {
+				b.WriteByte(' ')
+			}
+			if i < len(line) {
+				fmt.Fprintf(&b, "%02x ", line[i])
+			} else {
+				b.WriteString("   ")
+			}
+		}
+
+		b.WriteString(" |")
+		for _, c := range line {
+			if c >= 0x20 && c < 0x7f {
+				b.WriteByte(c)
+			} else {
+				b.WriteByte('.')
+			}
+		}
+		b.WriteString("|")
+
+		if end < len(data) {
+			b.WriteByte('\n')
+		}
+	}
+
+	return b.String()
+}
+
+// applyBinaryBody replaces the body content in sections with a hex dump, or a placeholder
+// hint when hex view is off, if the body is binary. Text bodies are returned untouched.
+func applyBinaryBody(sections []Section, data []byte, mimeType string, hexView bool) ([]Section, bool) {
+	if !isBinaryBody(data, mimeType) {
+		return sections, false
+	}
+
+	for i, section := range sections {
+		if section.Title != "Body" {
+			continue
+		}
+		for j, pair := range section.Pairs {
+			if pair.Key != "Content" {
+				continue
+			}
+			if hexView {
+				sections[i].Pairs[j].Value = formatHexDump(data)
+			} else {
+				sections[i].Pairs[j].Value = fmt.Sprintf("<binary content, %d bytes>", len(data))
+			}
+		}
+	}
+
+	return sections, true
+}
+
+// replaceBodyContent swaps the body content shown in sections, e.g. for a decoded body
+func replaceBodyContent(sections []Section, content string) []Section {
+	for i, section := range sections {
+		if section.Title != "Body" {
+			continue
+		}
+		for j, pair := range section.Pairs {
+			if pair.Key == "Content" {
+				sections[i].Pairs[j].Value = content
+			}
+		}
+	}
+	return sections
+}
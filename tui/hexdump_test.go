@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestFormatHexDump(t *testing.T) {
+	data := []byte("Hello, World!\x00\x01\xff\x7fABC")
+
+	dump := formatHexDump(data)
+	lines := strings.Split(dump, "\n")
+
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d:\n%s", len(lines), dump)
+	}
+
+	expected := "00000000  48 65 6c 6c 6f 2c 20 57  6f 72 6c 64 21 00 01 ff  |Hello, World!...|"
+	if lines[0] != expected {
+		t.Errorf("line 0 mismatch:\n got: %q\nwant: %q", lines[0], expected)
+	}
+
+	// short final line is padded so the ASCII gutter stays aligned
+	expected = "00000010  7f 41 42 43                                       |.ABC|"
+	if lines[1] != expected {
+		t.Errorf("line 1 mismatch:\n got: %q\nwant: %q", lines[1], expected)
+	}
+
+	if strings.Index(lines[0], "|") != strings.Index(lines[1], "|") {
+		t.Error("ASCII gutter is not aligned across lines")
+	}
+}
+
+func TestFormatHexDumpEmpty(t *testing.T) {
+	if dump := formatHexDump(nil); dump != "" {
+		t.Errorf("expected empty dump, got %q", dump)
+	}
+}
+
+func TestIsBinaryBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		mimeType string
+		want     bool
+	}{
+		{"json text", []byte(`{"a":1}`), "application/json", false},
+		{"png mime", []byte("anything"), "image/png", true},
+		{"svg is text", []byte("<svg></svg>"), "image/svg+xml", false},
+		{"font mime", []byte("wOFF"), "font/woff2", true},
+		{"invalid utf8", []byte{0xff, 0xfe, 0x00}, "text/plain", true},
+		{"empty", nil, "image/png", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isBinaryBody(tt.data, tt.mimeType); got != tt.want {
+				t.Errorf("isBinaryBody() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyBase64(t *testing.T) {
+	raw := []byte{0x89, 'P', 'N', 'G', 0x00}
+	encoded := base64.StdEncoding.EncodeToString(raw)
+
+	decoded := decodeBody(encoded, "base64")
+	if string(decoded) != string(raw) {
+		t.Errorf("expected decoded bytes %v, got %v", raw, decoded)
+	}
+
+	// invalid base64 falls back to the raw content
+	if got := decodeBody("not base64!", "base64"); string(got) != "not base64!" {
+		t.Errorf("expected raw fallback, got %q", got)
+	}
+}
@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/pb33f/harific/hargen"
+)
+
+const (
+	injectionMarker      = "★"
+	injectionMarkerWidth = 2 // marker plus separating space
+)
+
+// InjectionReport associates injected search terms (from a hargen report) with indexed entries
+type InjectionReport struct {
+	byEntry map[int][]hargen.InjectedTerm
+	skipped int // terms referencing entries outside the index
+}
+
+// NewInjectionReport groups injected terms by entry index, dropping terms that reference
+// entries outside [0, totalEntries) so a stale report can't point at the wrong rows
+func NewInjectionReport(terms []hargen.InjectedTerm, totalEntries int) *InjectionReport {
+	report := &InjectionReport{
+		byEntry: make(map[int][]hargen.InjectedTerm),
+	}
+
+	for _, term := range terms {
+		if term.EntryIndex < 0 || term.EntryIndex >= totalEntries {
+			report.skipped++
+			continue
+		}
+		report.byEntry[term.EntryIndex] = append(report.byEntry[term.EntryIndex], term)
+	}
+
+	return report
+}
+
+// IsInjected returns true if any term was injected into the entry
+func (r *InjectionReport) IsInjected(index int) bool {
+	if r == nil {
+		return false
+	}
+	_, ok := r.byEntry[index]
+	return ok
+}
+
+// TermsFor returns the terms injected into the entry
+func (r *InjectionReport) TermsFor(index int) []hargen.InjectedTerm {
+	if r == nil {
+		return nil
+	}
+	return r.byEntry[index]
+}
+
+// EntryCount returns the number of entries containing at least one injected term
+func (r *InjectionReport) EntryCount() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.byEntry)
+}
+
+// Skipped returns the number of terms that referenced out-of-range entries
+func (r *InjectionReport) Skipped() int {
+	if r == nil {
+		return 0
+	}
+	return r.skipped
+}
+
+// bodyTermFor returns the first term injected into the request or response body of the entry
+func (r *InjectionReport) bodyTermFor(index int, location hargen.InjectionLocation) (hargen.InjectedTerm, bool) {
+	for _, term := range r.TermsFor(index) {
+		if term.Location == location {
+			return term, true
+		}
+	}
+	return hargen.InjectedTerm{}, false
+}
+
+// injectionSection builds a detail section listing the terms injected into an entry
+func injectionSection(terms []hargen.InjectedTerm) Section {
+	pairs := make([]KeyValuePair, 0, len(terms))
+	for _, term := range terms {
+		location := term.Location.String()
+		if term.FieldPath != "" {
+			location = fmt.Sprintf("%s (%s)", location, term.FieldPath)
+		}
+		pairs = append(pairs, KeyValuePair{term.Term, location})
+	}
+
+	return Section{
+		Title: injectionMarker + " Injected Terms",
+		Pairs: pairs,
+	}
+}
+
+// SetInjectedTerms loads injected terms from a hargen report; they are matched to entries once indexing completes
+func (m *HARViewModel) SetInjectedTerms(terms []hargen.InjectedTerm) {
+	m.injectedTerms = terms
+	if m.allEntries != nil {
+		m.injections = NewInjectionReport(terms, len(m.allEntries))
+	}
+}
+
+// highlightInjectedTerm pre-fills the detail search with the term injected into the open body, if any
+func (m *HARViewModel) highlightInjectedTerm() {
+	if m.injections == nil || m.selectedEntry == nil {
+		return
+	}
+
+	var jsonContent string
+	location := hargen.RequestBody
+	if m.activeModal == ModalRequestFull {
+		jsonContent = m.selectedEntry.Request.Body.Content
+	} else {
+		location = hargen.ResponseBody
+		jsonContent = m.responseBodyText()
+	}
+
+	term, ok := m.injections.bodyTermFor(m.selectedEntryIndex(), location)
+	if !ok || !isValidJSON(jsonContent) {
+		return
+	}
+
+	modalWidth := int(float64(m.width) * 0.9)
+	m.detailSearchState.Activate()
+	m.detailSearchState.keySearchOnly = false // injected terms live in values
+	m.detailSearchState.regex = false         // and are matched literally
+	m.detailSearchState.SetContent(jsonContent, modalWidth-4)
+	m.detailSearchState.UpdateQuery(term.Term)
+	m.detailSearchState.searchInput.Blur()
+	m.updateDetailContent()
+}
@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pb33f/harific/hargen"
+	"github.com/pb33f/harific/motor"
+)
+
+func TestInjectionReportAssociatesByIndex(t *testing.T) {
+	terms := []hargen.InjectedTerm{
+		{Term: "apple", Location: hargen.URL, EntryIndex: 0},
+		{Term: "banana", Location: hargen.ResponseBody, EntryIndex: 2, FieldPath: "data.items[0].name"},
+		{Term: "cherry", Location: hargen.RequestBody, EntryIndex: 2},
+		{Term: "stale", Location: hargen.URL, EntryIndex: 10},
+		{Term: "negative", Location: hargen.URL, EntryIndex: -1},
+	}
+
+	report := NewInjectionReport(terms, 3)
+
+	if !report.IsInjected(0) || report.IsInjected(1) || !report.IsInjected(2) {
+		t.Errorf("unexpected injected entries: 0=%v 1=%v 2=%v",
+			report.IsInjected(0), report.IsInjected(1), report.IsInjected(2))
+	}
+
+	if got := len(report.TermsFor(2)); got != 2 {
+		t.Errorf("expected 2 terms for entry 2, got %d", got)
+	}
+
+	if report.EntryCount() != 2 {
+		t.Errorf("expected 2 injected entries, got %d", report.EntryCount())
+	}
+
+	if report.Skipped() != 2 {
+		t.Errorf("expected 2 skipped terms, got %d", report.Skipped())
+	}
+
+	term, ok := report.bodyTermFor(2, hargen.ResponseBody)
+	if !ok || term.Term != "banana" {
+		t.Errorf("expected response body term 'banana', got %q (found=%v)", term.Term, ok)
+	}
+
+	if _, ok := report.bodyTermFor(0, hargen.RequestBody); ok {
+		t.Error("expected no request body term for entry 0")
+	}
+}
+
+func TestInjectionReportNilSafe(t *testing.T) {
+	var report *InjectionReport
+
+	if report.IsInjected(0) || report.TermsFor(0) != nil || report.EntryCount() != 0 {
+		t.Error("nil report should report no injections")
+	}
+}
+
+func TestBuildTableRowsMarksInjectedEntries(t *testing.T) {
+	m := &HARViewModel{
+		width: 120,
+		allEntries: []*motor.EntryMetadata{
+			{Method: "GET", URL: "https://example.com/plain", StatusCode: 200},
+			{Method: "POST", URL: "https://example.com/injected", StatusCode: 201},
+		},
+	}
+	m.SetInjectedTerms([]hargen.InjectedTerm{
+		{Term: "apple", Location: hargen.URL, EntryIndex: 1},
+	})
+
+	m.buildTableRows()
+
+	if strings.HasPrefix(m.rows[0][1], injectionMarker) {
+		t.Errorf("row 0 should not be marked: %q", m.rows[0][1])
+	}
+	if !strings.HasPrefix(m.rows[1][1], injectionMarker) {
+		t.Errorf("row 1 should be marked: %q", m.rows[1][1])
+	}
+}
+
+func TestInjectionSection(t *testing.T) {
+	section := injectionSection([]hargen.InjectedTerm{
+		{Term: "banana", Location: hargen.ResponseBody, FieldPath: "data.name"},
+	})
+
+	if len(section.Pairs) != 1 {
+		t.Fatalf("expected 1 pair, got %d", len(section.Pairs))
+	}
+	if section.Pairs[0].Key != "banana" || !strings.Contains(section.Pairs[0].Value, "data.name") {
+		t.Errorf("unexpected pair: %+v", section.Pairs[0])
+	}
+}
@@ -0,0 +1,210 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// jsonContainer is an object or array in the last render, path "" being the root
+type jsonContainer struct {
+	path       string
+	start, end int // first and last rendered line
+}
+
+// renderCollapsed renders a folded object or array as {…} or […] with its child count
+func (r *JSONRenderer) renderCollapsed(node interface{}) string {
+	countStyle := lipgloss.NewStyle().Foreground(RGBGrey).Faint(true)
+	switch v := node.(type) {
+	case map[string]interface{}:
+		return SyntaxDashStyle.Render("{…}") + countStyle.Render(" "+plural(len(v), "key"))
+	case []interface{}:
+		return SyntaxNumberStyle.Render("[…]") + countStyle.Render(" "+plural(len(v), "item"))
+	}
+	return ""
+}
+
+// isCollapsed reports whether the node at path is folded; the root never is
+func (r *JSONRenderer) isCollapsed(path string) bool {
+	return path != "" && r.collapsed[path]
+}
+
+// ToggleCollapsed folds the object or array at path, or unfolds it when already folded
+func (r *JSONRenderer) ToggleCollapsed(path string) {
+	if r.collapsed[path] {
+		delete(r.collapsed, path)
+		return
+	}
+	if r.collapsed == nil {
+		r.collapsed = make(map[string]bool)
+	}
+	r.collapsed[path] = true
+}
+
+// CollapseAll folds every object and array below the root, leaving the paths to search matches
+// unfolded
+func (r *JSONRenderer) CollapseAll() {
+	r.collapsed = make(map[string]bool)
+	var walk func(node interface{}, path string)
+	walk = func(node interface{}, path string) {
+		switch v := node.(type) {
+		case map[string]interface{}:
+			for key, value := range v {
+				child := key
+				if path != "" {
+					child = path + "." + key
+				}
+				walk(value, child)
+			}
+		case []interface{}:
+			for i, item := range v {
+				walk(item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		default:
+			return
+		}
+		if path != "" {
+			r.collapsed[path] = true
+		}
+	}
+	walk(r.searchEngine.parsed, "")
+	r.expandMatches()
+}
+
+// ExpandAll unfolds every object and array
+func (r *JSONRenderer) ExpandAll() {
+	r.collapsed = nil
+}
+
+// HasCollapsed reports whether anything is folded
+func (r *JSONRenderer) HasCollapsed() bool {
+	return len(r.collapsed) > 0
+}
+
+// expandMatches unfolds every object and array holding a search match, so no match is hidden
+func (r *JSONRenderer) expandMatches() {
+	for _, match := range r.searchEngine.matches {
+		delete(r.collapsed, match.Path)
+		for i := 1; i < len(match.Path); i++ {
+			if c := match.Path[i]; c == '.' || c == '[' {
+				delete(r.collapsed, match.Path[:i])
+			}
+		}
+	}
+}
+
+// ContainerAt returns the innermost object or array below the root that the rendered line is
+// part of, its opening line included
+func (r *JSONRenderer) ContainerAt(line int) (string, bool) {
+	// containers are recorded as rendering enters them, so nested ones come after their parent
+	for i := len(r.containers) - 1; i >= 0; i-- {
+		node := r.containers[i]
+		if node.path != "" && node.start <= line && line <= node.end {
+			return node.path, true
+		}
+	}
+	return "", false
+}
+
+// ContainerLine returns the line the object or array at path starts on in the last render
+func (r *JSONRenderer) ContainerLine(path string) (int, bool) {
+	for _, node := range r.containers {
+		if node.path == path {
+			return node.start, true
+		}
+	}
+	return 0, false
+}
+
+// plural is count and noun, with an s unless count is one
+func plural(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// StartFolding draws jsonContent through the renderer from now on, search or not, so its objects
+// and arrays can be folded. the layout then matches the search view.
+func (s *ViewportSearchState) StartFolding(jsonContent string, width int) error {
+	if s.renderer == nil {
+		if err := s.SetContent(jsonContent, width); err != nil {
+			return err
+		}
+	}
+	s.folding = true
+	return nil
+}
+
+// Folding reports whether the JSON body is drawn by the renderer for folding
+func (s *ViewportSearchState) Folding() bool {
+	return s.folding && s.renderer != nil
+}
+
+// ToggleFoldAt folds or unfolds the object or array shown on viewport row, returning its path.
+// ok is false when the row is not inside one.
+func (s *ViewportSearchState) ToggleFoldAt(row int) (path string, ok bool) {
+	if s.renderer == nil {
+		return "", false
+	}
+	line, ok := s.jsonLine(row)
+	if !ok {
+		return "", false
+	}
+	path, ok = s.renderer.ContainerAt(line)
+	if ok {
+		s.renderer.ToggleCollapsed(path)
+		s.currentMatch = -1 // match lines move
+	}
+	return path, ok
+}
+
+// ToggleFoldAll folds every object and array, or unfolds them all when anything is folded, and
+// reports whether the body is now folded
+func (s *ViewportSearchState) ToggleFoldAll() bool {
+	if s.renderer == nil {
+		return false
+	}
+	s.currentMatch = -1
+	if s.renderer.HasCollapsed() {
+		s.renderer.ExpandAll()
+		return false
+	}
+	s.renderer.CollapseAll()
+	return true
+}
+
+// FoldRow returns the viewport row the object or array at path starts on after the last render
+func (s *ViewportSearchState) FoldRow(path string) (int, bool) {
+	if s.renderer == nil {
+		return 0, false
+	}
+	line, ok := s.renderer.ContainerLine(path)
+	if !ok {
+		return 0, false
+	}
+	return s.viewportRow(line), true
+}
+
+// viewportRow maps a rendered JSON line onto the viewport
+func (s *ViewportSearchState) viewportRow(line int) int {
+	if line < len(s.lineStarts) {
+		line = s.lineStarts[line] // the json was wrapped, so earlier lines may span several rows
+	}
+	return s.contentLine + line
+}
+
+// jsonLine maps a viewport row back onto the rendered JSON line shown there
+func (s *ViewportSearchState) jsonLine(row int) (int, bool) {
+	row -= s.contentLine
+	if row < 0 {
+		return 0, false
+	}
+	if s.lineStarts == nil {
+		return row, true
+	}
+	// the last line starting at or before the row
+	line := sort.SearchInts(s.lineStarts, row+1) - 1
+	return line, line >= 0
+}
@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+const foldFixture = `{"user": {"name": "ada", "roles": [{"id": 1}, {"id": 2}]}, "tags": ["a"], "count": 2}`
+
+func renderFolded(r *JSONRenderer) string {
+	return ansi.Strip(r.Render())
+}
+
+func TestJSONRendererCollapsesPath(t *testing.T) {
+	renderer, _ := renderPlainJSON(t, foldFixture, 80, "")
+
+	renderer.ToggleCollapsed("user.roles")
+	got := renderFolded(renderer)
+	if !strings.Contains(got, `"roles": […] 2 items`) {
+		t.Errorf("collapsed array not folded:\n%s", got)
+	}
+	if strings.Contains(got, `"id"`) {
+		t.Errorf("collapsed array still shows its items:\n%s", got)
+	}
+
+	renderer.ToggleCollapsed("user")
+	if got := renderFolded(renderer); !strings.Contains(got, `"user": {…} 2 keys`) {
+		t.Errorf("collapsed object not folded:\n%s", got)
+	}
+
+	renderer.ToggleCollapsed("user")
+	if got := renderFolded(renderer); !strings.Contains(got, `"name": "ada"`) || strings.Contains(got, `"id"`) {
+		t.Errorf("unfolding the object should keep the array folded:\n%s", got)
+	}
+}
+
+func TestJSONRendererCollapseAll(t *testing.T) {
+	renderer, _ := renderPlainJSON(t, foldFixture, 80, "")
+
+	renderer.CollapseAll()
+	want := strings.Join([]string{
+		`{`,
+		`  "count": 2,`,
+		`  "tags": ["a"],`,
+		`  "user": {…} 2 keys`,
+		`}`,
+	}, "\n")
+	if got := renderFolded(renderer); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	renderer.ExpandAll()
+	if renderer.HasCollapsed() || !strings.Contains(renderFolded(renderer), `"id": 2`) {
+		t.Error("ExpandAll left something folded")
+	}
+}
+
+func TestJSONRendererSearchExpandsMatches(t *testing.T) {
+	renderer, _ := renderPlainJSON(t, foldFixture, 80, "")
+	renderer.CollapseAll()
+
+	renderer.SetSearch("id", true)
+	got := renderFolded(renderer)
+	if !strings.Contains(got, `"id": 1`) {
+		t.Errorf("the path to a match stayed folded:\n%s", got)
+	}
+	if len(renderer.MatchLines()) != 2 {
+		t.Errorf("match lines = %v, want both ids", renderer.MatchLines())
+	}
+}
+
+func TestJSONRendererContainerAt(t *testing.T) {
+	renderer, lines := renderPlainJSON(t, foldFixture, 80, "")
+	// {, "count", "tags", "user": {, "name", "roles": [, {, "id": 1, }, ...
+	tests := []struct {
+		line int
+		want string
+		ok   bool
+	}{
+		{0, "", false},          // the root is not foldable
+		{1, "", false},          // a scalar at the top level
+		{3, "user", true},       // the line opening the object
+		{4, "user", true},       // inside it
+		{5, "user.roles", true}, // the line opening the array
+		{6, "user.roles[0]", true},
+		{7, "user.roles[0]", true},
+	}
+	for _, tt := range tests {
+		path, ok := renderer.ContainerAt(tt.line)
+		if path != tt.want || ok != tt.ok {
+			t.Errorf("line %d (%q): got %q, %v, want %q, %v", tt.line, lines[tt.line], path, ok, tt.want, tt.ok)
+		}
+	}
+
+	if line, ok := renderer.ContainerLine("user.roles"); !ok || line != 5 {
+		t.Errorf("ContainerLine(user.roles) = %d, %v, want 5", line, ok)
+	}
+}
+
+func TestViewportSearchStateFoldAt(t *testing.T) {
+	state := NewViewportSearchState()
+	if err := state.StartFolding(foldFixture, 80); err != nil {
+		t.Fatalf("StartFolding failed: %v", err)
+	}
+	if !state.Folding() {
+		t.Fatal("expected the state to be folding")
+	}
+	state.GetRenderedContent()
+
+	// the json starts on viewport row 10, with its fourth line wrapped over two rows
+	state.contentLine = 10
+	state.lineStarts = []int{0, 1, 2, 3, 5, 6}
+	path, ok := state.ToggleFoldAt(15)
+	if !ok || path != "user" {
+		t.Fatalf("ToggleFoldAt(15) = %q, %v, want user", path, ok)
+	}
+	if !state.renderer.collapsed["user"] {
+		t.Error("user was not folded")
+	}
+
+	state.GetRenderedContent()
+	if row, ok := state.FoldRow("user"); !ok || row != 13 {
+		t.Errorf("FoldRow(user) = %d, %v, want 13", row, ok)
+	}
+	if _, ok := state.ToggleFoldAt(5); ok {
+		t.Error("a row above the json should not fold anything")
+	}
+
+	state.Clear()
+	if state.Folding() {
+		t.Error("Clear should stop folding")
+	}
+}
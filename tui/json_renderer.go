@@ -3,6 +3,7 @@ package tui
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -16,6 +17,12 @@ type JSONRenderer struct {
 	indent         string
 	width          int
 	hasSearched    bool   // Track if search has been performed
+	line           int               // Current output line while rendering
+	pathLines      map[string][2]int // First and last rendered line of each path
+	matchLines     []int             // Sorted, distinct lines holding a match in the last render
+	column         int               // Width already taken on the current line, for fitting arrays inline
+	collapsed      map[string]bool   // Paths of the objects and arrays shown folded
+	containers     []jsonContainer   // Objects and arrays in the last render, in line order
 }
 
 // NewJSONRenderer creates a new JSON renderer
@@ -37,6 +44,15 @@ func NewJSONRenderer(jsonContent string, width int) (*JSONRenderer, error) {
 func (r *JSONRenderer) SetSearch(query string, keysOnly bool) {
 	r.searchEngine.Search(query, keysOnly)
 	r.hasSearched = true
+	r.expandMatches()
+}
+
+// SetSearchWithOptions updates the search query, returning the error for an invalid regex
+func (r *JSONRenderer) SetSearchWithOptions(query string, opts JSONSearchOptions) error {
+	_, err := r.searchEngine.SearchWithOptions(query, opts)
+	r.hasSearched = true
+	r.expandMatches()
+	return err
 }
 
 // ToggleFiltered toggles between filtered and full view
@@ -59,10 +75,21 @@ func (r *JSONRenderer) GetMatchCount() int {
 	return len(r.searchEngine.matches)
 }
 
+// MatchLines returns the lines (relative to the rendered JSON) holding a match, in order
+func (r *JSONRenderer) MatchLines() []int {
+	return r.matchLines
+}
+
 // Render renders the JSON with highlighting and optional filtering
 func (r *JSONRenderer) Render() string {
 	var data interface{}
 
+	r.line = 0
+	r.column = 0
+	r.pathLines = make(map[string][2]int)
+	r.matchLines = nil
+	r.containers = r.containers[:0]
+
 	if !r.hasSearched {
 		// renderNode always sorts keys deterministically for consistent ordering
 		return r.renderNode(r.searchEngine.parsed, "", 0)
@@ -81,9 +108,27 @@ func (r *JSONRenderer) Render() string {
 
 	// Render the JSON with proper indentation
 	rendered := r.renderNode(data, "", 0)
+	r.recordMatchLines()
 	return rendered
 }
 
+// recordMatchLines copies the rendered line of every match onto it and collects the distinct
+// match lines for navigation. matches hidden by the filtered view keep a line of -1.
+func (r *JSONRenderer) recordMatchLines() {
+	matches := r.searchEngine.matches
+	for i := range matches {
+		span, ok := r.pathLines[matches[i].Path]
+		if !ok {
+			matches[i].LineStart, matches[i].LineEnd = -1, -1
+			continue
+		}
+		matches[i].LineStart, matches[i].LineEnd = span[0], span[1]
+		r.matchLines = append(r.matchLines, span[0])
+	}
+	sort.Ints(r.matchLines)
+	r.matchLines = slices.Compact(r.matchLines)
+}
+
 // renderNode recursively renders a JSON node with highlighting
 func (r *JSONRenderer) renderNode(node interface{}, path string, depth int) string {
 	var out strings.Builder
@@ -94,8 +139,15 @@ func (r *JSONRenderer) renderNode(node interface{}, path string, depth int) stri
 		if len(v) == 0 {
 			return SyntaxDashStyle.Render("{") + SyntaxDashStyle.Render("}")
 		}
+		if r.isCollapsed(path) {
+			r.containers = append(r.containers, jsonContainer{path: path, start: r.line, end: r.line})
+			return r.renderCollapsed(v)
+		}
+		container := len(r.containers)
+		r.containers = append(r.containers, jsonContainer{path: path, start: r.line})
 
 		out.WriteString(SyntaxDashStyle.Render("{") + "\n")
+		r.line++
 		keys := make([]string, 0, len(v))
 		for k := range v {
 			keys = append(keys, k)
@@ -119,56 +171,60 @@ func (r *JSONRenderer) renderNode(node interface{}, path string, depth int) stri
 			out.WriteString(": ")
 
 			// Render the value
+			r.column = len(indent+r.indent) + len(fmt.Sprintf("%q", key)) + len(": ")
+			start := r.line
 			valueStr := r.renderNode(value, keyPath, depth+1)
 			out.WriteString(valueStr)
+			r.pathLines[keyPath] = [2]int{start, r.line}
 
 			if i < len(keys)-1 {
 				out.WriteString(",")
 			}
 			out.WriteString("\n")
+			r.line++
 		}
 
 		out.WriteString(indent + SyntaxDashStyle.Render("}"))
+		r.containers[container].end = r.line
 
 	case []interface{}:
 		if len(v) == 0 {
 			return SyntaxNumberStyle.Render("[") + SyntaxNumberStyle.Render("]")
 		}
+		if inline, ok := r.renderInlineArray(v, path); ok {
+			return inline
+		}
+		if r.isCollapsed(path) {
+			r.containers = append(r.containers, jsonContainer{path: path, start: r.line, end: r.line})
+			return r.renderCollapsed(v)
+		}
+		container := len(r.containers)
+		r.containers = append(r.containers, jsonContainer{path: path, start: r.line})
 
 		out.WriteString(SyntaxNumberStyle.Render("[") + "\n")
+		r.line++
 		for i, item := range v {
 			indexPath := fmt.Sprintf("%s[%d]", path, i)
 
 			out.WriteString(indent + r.indent)
+			r.column = len(indent + r.indent)
+			start := r.line
 			itemStr := r.renderNode(item, indexPath, depth+1)
 			out.WriteString(itemStr)
+			r.pathLines[indexPath] = [2]int{start, r.line}
 
 			if i < len(v)-1 {
 				out.WriteString(",")
 			}
 			out.WriteString("\n")
+			r.line++
 		}
 		out.WriteString(indent + SyntaxNumberStyle.Render("]"))
+		r.containers[container].end = r.line
 
-	case string:
-		isMatched := r.searchEngine.IsPathMatched(path)
-		return r.renderValue(fmt.Sprintf("%q", v), isMatched)
-
-	case float64:
-		isMatched := r.searchEngine.IsPathMatched(path)
-		// Format number nicely
-		if v == float64(int64(v)) {
-			return r.renderValue(fmt.Sprintf("%d", int64(v)), isMatched)
-		}
-		return r.renderValue(fmt.Sprintf("%g", v), isMatched)
-
-	case bool:
-		isMatched := r.searchEngine.IsPathMatched(path)
-		return r.renderValue(fmt.Sprintf("%v", v), isMatched)
-
-	case nil:
-		isMatched := r.searchEngine.IsPathMatched(path)
-		return r.renderValue("null", isMatched)
+	case string, float64, bool, nil:
+		text, _ := scalarText(v)
+		return r.renderValue(text, r.searchEngine.IsPathMatched(path))
 
 	default:
 		// Fallback for any other type
@@ -179,6 +235,60 @@ func (r *JSONRenderer) renderNode(node interface{}, path string, depth int) stri
 	return out.String()
 }
 
+// renderInlineArray renders an array of scalars on one line, [1, 2, 3], when it fits in the
+// width left on the line. arrays holding objects or arrays, or too long to fit, are not inlined.
+func (r *JSONRenderer) renderInlineArray(items []interface{}, path string) (string, bool) {
+	if r.width <= 0 {
+		return "", false
+	}
+
+	// brackets, the ", " between items and the "," that may follow the array
+	width := r.column + len("[]") + len(", ")*(len(items)-1) + len(",")
+	for _, item := range items {
+		text, ok := scalarText(item)
+		if !ok {
+			return "", false
+		}
+		width += len(text)
+		if width > r.width {
+			return "", false
+		}
+	}
+
+	var out strings.Builder
+	out.WriteString(SyntaxNumberStyle.Render("["))
+	for i, item := range items {
+		indexPath := fmt.Sprintf("%s[%d]", path, i)
+		if i > 0 {
+			out.WriteString(", ")
+		}
+		out.WriteString(r.renderNode(item, indexPath, 0))
+		r.pathLines[indexPath] = [2]int{r.line, r.line}
+	}
+	out.WriteString(SyntaxNumberStyle.Render("]"))
+	return out.String(), true
+}
+
+// scalarText is the unstyled text renderNode shows for a scalar, or false for objects and arrays
+func scalarText(node interface{}) (string, bool) {
+	switch v := node.(type) {
+	case string:
+		return fmt.Sprintf("%q", v), true
+	case float64:
+		// whole numbers without an exponent
+		if v == float64(int64(v)) {
+			return fmt.Sprintf("%d", int64(v)), true
+		}
+		return fmt.Sprintf("%g", v), true
+	case bool:
+		return fmt.Sprintf("%v", v), true
+	case nil:
+		return "null", true
+	default:
+		return "", false
+	}
+}
+
 // renderKey renders a JSON key with appropriate styling
 func (r *JSONRenderer) renderKey(key string, isMatched, isParent, inFilteredView bool) string {
 	quotedKey := fmt.Sprintf("%q", key)
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func renderPlainJSON(t *testing.T, content string, width int, query string) (*JSONRenderer, []string) {
+	t.Helper()
+	renderer, err := NewJSONRenderer(content, width)
+	if err != nil {
+		t.Fatalf("NewJSONRenderer failed: %v", err)
+	}
+	if query != "" {
+		renderer.SetSearch(query, false)
+	}
+	return renderer, strings.Split(ansi.Strip(renderer.Render()), "\n")
+}
+
+func TestJSONRendererInlineScalarArrays(t *testing.T) {
+	_, lines := renderPlainJSON(t, `{"tags": ["a", "b", "c"], "ids": [1, 2.5, true, null]}`, 80, "")
+	want := []string{
+		`{`,
+		`  "ids": [1, 2.5, true, null],`,
+		`  "tags": ["a", "b", "c"]`,
+		`}`,
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestJSONRendererExpandsOtherArrays(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		width   int
+	}{
+		{"objects", `{"items": [{"id": 1}, {"id": 2}]}`, 80},
+		{"nested arrays", `{"grid": [[1, 2], [3, 4]]}`, 80},
+		{"too long", `{"numbers": [100000, 200000, 300000, 400000, 500000]}`, 40},
+	}
+
+	for _, tt := range tests {
+		_, lines := renderPlainJSON(t, tt.content, tt.width, "")
+		if !strings.HasSuffix(lines[1], "[") {
+			t.Errorf("%s: expected the array to open on its own line, got %q", tt.name, lines[1])
+		}
+		if len(lines) < 6 {
+			t.Errorf("%s: expected one element per line, got %d lines", tt.name, len(lines))
+		}
+	}
+}
+
+func TestJSONRendererInlineArrayMatches(t *testing.T) {
+	renderer, lines := renderPlainJSON(t, `{"name": "test", "tags": ["alpha", "beta", "gamma"]}`, 80, "beta")
+
+	if renderer.GetMatchCount() != 1 {
+		t.Fatalf("expected 1 match, got %d", renderer.GetMatchCount())
+	}
+	matchLines := renderer.MatchLines()
+	if len(matchLines) != 1 || matchLines[0] != 2 {
+		t.Fatalf("expected the match on line 2, got %v", matchLines)
+	}
+	if !strings.Contains(lines[2], `["alpha", "beta", "gamma"]`) {
+		t.Errorf("expected the array inline on its match line, got %q", lines[2])
+	}
+}
@@ -3,6 +3,7 @@ package tui
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 )
 
@@ -34,6 +35,24 @@ const (
 	SearchKeysAndValues
 )
 
+// JSONSearchOptions controls how a query is matched against keys and values
+type JSONSearchOptions struct {
+	KeysOnly      bool // match keys only, not values
+	Regex         bool // the query is a regular expression
+	CaseSensitive bool // match case exactly (default: ignore case)
+}
+
+// compileJSONQuery turns a query into the pattern keys and values are matched with
+func compileJSONQuery(query string, opts JSONSearchOptions) (*regexp.Regexp, error) {
+	if !opts.Regex {
+		query = regexp.QuoteMeta(query)
+	}
+	if !opts.CaseSensitive {
+		query = "(?i)" + query
+	}
+	return regexp.Compile(query)
+}
+
 // NewJSONSearchEngine creates a new JSON search engine
 func NewJSONSearchEngine(jsonContent string) (*JSONSearchEngine, error) {
 	engine := &JSONSearchEngine{
@@ -75,22 +94,35 @@ func (e *JSONSearchEngine) buildPathIndex(data interface{}, parentPath string) {
 	}
 }
 
-// Search finds all matches for the given query
+// Search finds all case-insensitive substring matches for the given query
 func (e *JSONSearchEngine) Search(query string, keysOnly bool) []JSONMatch {
+	matches, _ := e.SearchWithOptions(query, JSONSearchOptions{KeysOnly: keysOnly})
+	return matches
+}
+
+// SearchWithOptions finds all matches for the given query. in regex mode a value also matches
+// when the pattern matches its `"key": value` pair as written in JSON, so `"id":\s*\d{6,}` finds
+// ids of six or more digits. an invalid regex leaves no matches and returns the compile error.
+func (e *JSONSearchEngine) SearchWithOptions(query string, opts JSONSearchOptions) ([]JSONMatch, error) {
+	e.matches = []JSONMatch{}
+
 	// Trim whitespace and check if empty
 	query = strings.TrimSpace(query)
 	if query == "" {
-		return []JSONMatch{}
+		return e.matches, nil
 	}
 
-	e.matches = []JSONMatch{}
+	pattern, err := compileJSONQuery(query, opts)
+	if err != nil {
+		return e.matches, err
+	}
+
+	keysOnly := opts.KeysOnly
 	e.searchMode = SearchKeysAndValues
 	if keysOnly {
 		e.searchMode = SearchKeysOnly
 	}
 
-	queryLower := strings.ToLower(query)
-
 	// Search through all paths
 	for path, value := range e.pathIndex {
 		// Extract the key from the path
@@ -101,7 +133,7 @@ func (e *JSONSearchEngine) Search(query string, keysOnly bool) []JSONMatch {
 		keyClean := strings.Split(key, "[")[0]
 
 		// Check if key matches
-		if strings.Contains(strings.ToLower(keyClean), queryLower) {
+		if pattern.MatchString(keyClean) {
 			match := JSONMatch{
 				Path:       path,
 				Key:        keyClean,
@@ -115,7 +147,7 @@ func (e *JSONSearchEngine) Search(query string, keysOnly bool) []JSONMatch {
 
 		// If searching values too, check the value
 		if !keysOnly {
-			if matchesValue(value, queryLower) {
+			if matchesValue(value, pattern) || (opts.Regex && matchesPair(keyClean, value, pattern)) {
 				match := JSONMatch{
 					Path:       path,
 					Key:        keyClean,
@@ -128,7 +160,7 @@ func (e *JSONSearchEngine) Search(query string, keysOnly bool) []JSONMatch {
 		}
 	}
 
-	return e.matches
+	return e.matches, nil
 }
 
 // GetMatchedPaths returns all paths that have matches
@@ -207,20 +239,34 @@ func getAllParentPaths(path string) []string {
 	return parents
 }
 
-func matchesValue(value interface{}, query string) bool {
+func matchesValue(value interface{}, pattern *regexp.Regexp) bool {
 	switch v := value.(type) {
 	case string:
-		return strings.Contains(strings.ToLower(v), query)
+		return pattern.MatchString(v)
 	case float64:
-		return strings.Contains(fmt.Sprintf("%v", v), query)
+		return pattern.MatchString(fmt.Sprintf("%v", v))
 	case bool:
-		return strings.Contains(fmt.Sprintf("%v", v), query)
+		return pattern.MatchString(fmt.Sprintf("%v", v))
 	case nil:
-		return strings.Contains("null", query)
+		return pattern.MatchString("null")
 	}
 	return false
 }
 
+// matchesPair matches a primitive value together with its key, written `"key": value` as in the JSON
+func matchesPair(key string, value interface{}, pattern *regexp.Regexp) bool {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	}
+	encodedKey, _ := json.Marshal(key)
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	return pattern.MatchString(string(encodedKey) + ": " + string(encodedValue))
+}
+
 // FilterJSON returns a filtered version of the JSON containing only matched paths
 func (e *JSONSearchEngine) FilterJSON(includeParents bool) (interface{}, error) {
 	if len(e.matches) == 0 {
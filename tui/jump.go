@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// openJumpPrompt asks for the index of an entry to move the cursor to
+func (m *HARViewModel) openJumpPrompt() tea.Cmd {
+	if len(m.allEntries) == 0 {
+		return showStatusMessage("No entries to jump to")
+	}
+
+	input := textinput.New()
+	input.Prompt = "Jump to entry #"
+	input.Placeholder = fmt.Sprintf("0-%d", len(m.allEntries)-1)
+	input.CharLimit = 12
+	m.jumpInput = input
+	m.jumpPrompt = true
+	return m.jumpInput.Focus()
+}
+
+// handleJumpPromptKey edits the entry index; Enter jumps and Esc cancels
+func (m *HARViewModel) handleJumpPromptKey(msg tea.KeyPressMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.jumpPrompt = false
+		m.jumpInput.Blur()
+		return nil
+
+	case "enter", "return":
+		value := strings.ReplaceAll(strings.TrimSpace(m.jumpInput.Value()), ",", "")
+		index, err := strconv.Atoi(strings.TrimPrefix(value, "#"))
+		if err != nil {
+			return showStatusMessage(fmt.Sprintf("Invalid entry index %q", m.jumpInput.Value()))
+		}
+		m.jumpPrompt = false
+		m.jumpInput.Blur()
+		return m.jumpToEntry(index)
+	}
+
+	var cmd tea.Cmd
+	m.jumpInput, cmd = m.jumpInput.Update(msg)
+	return cmd
+}
+
+// jumpToEntry moves the cursor to the row of entry index, the same zero-based index the search
+// and stats commands print. an index past either end is clamped to the first or last entry, and
+// an entry hidden by the filters or a collapsed page lands on the visible entry nearest to it.
+func (m *HARViewModel) jumpToEntry(index int) tea.Cmd {
+	if len(m.allEntries) == 0 {
+		return showStatusMessage("No entries to jump to")
+	}
+
+	var notice string
+	if index < 0 || index >= len(m.allEntries) {
+		clamped := min(max(index, 0), len(m.allEntries)-1)
+		notice = fmt.Sprintf("Entry #%d is out of range (0-%d), jumped to #%d", index, len(m.allEntries)-1, clamped)
+		index = clamped
+	}
+
+	row := m.nearestVisibleRow(index)
+	if row < 0 {
+		return showStatusMessage("No entries are visible")
+	}
+	m.table.SetCursor(row)
+	m.selectedIndex = row
+
+	if shown := m.filteredIndices[row]; shown != index {
+		notice = fmt.Sprintf("Entry #%d is hidden, jumped to #%d", index, shown)
+	}
+	if notice != "" {
+		return showStatusMessage(notice)
+	}
+	return nil
+}
+
+// nearestVisibleRow returns the table row showing entry index, or when it is not shown the row
+// of the visible entry closest to it, preferring the later one on a tie; -1 if no entry is shown
+func (m *HARViewModel) nearestVisibleRow(index int) int {
+	best, bestDistance := -1, 0
+	for row, shown := range m.filteredIndices {
+		if shown == pageHeaderIndex {
+			continue
+		}
+		if shown == index {
+			return row
+		}
+		distance := shown - index
+		if distance < 0 {
+			distance = -distance*2 + 1 // an earlier entry loses a tie with a later one
+		} else {
+			distance *= 2
+		}
+		if best < 0 || distance < bestDistance {
+			best, bestDistance = row, distance
+		}
+	}
+	return best
+}
+
+// renderJumpStatus is the prompt line while the jump prompt is open
+func (m *HARViewModel) renderJumpStatus() (string, bool) {
+	if !m.jumpPrompt {
+		return "", false
+	}
+	return m.jumpInput.View() + "  (Enter: Jump | Esc: Cancel)", true
+}
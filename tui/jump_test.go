@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/v2/table"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/pb33f/harific/motor"
+)
+
+func newJumpTestModel(entryCount int) *HARViewModel {
+	entries := make([]*motor.EntryMetadata, entryCount)
+	rows := make([]table.Row, entryCount)
+	indices := make([]int, entryCount)
+	for i := range entries {
+		entries[i] = &motor.EntryMetadata{Method: "GET", URL: "https://example.com/", StatusCode: 200}
+		rows[i] = ColumnsDefault.row(entries[i], 120)
+		indices[i] = i
+	}
+	m := &HARViewModel{allEntries: entries, rows: rows, filteredIndices: indices}
+	m.table = table.New(table.WithColumns(ColumnsDefault.columns(120, TableSort{})), table.WithRows(rows), table.WithHeight(5))
+	return m
+}
+
+func TestJumpToEntry(t *testing.T) {
+	m := newJumpTestModel(10)
+
+	if cmd := m.jumpToEntry(7); cmd != nil {
+		t.Error("an exact jump should not show a notice")
+	}
+	if m.table.Cursor() != 7 || m.selectedEntryIndex() != 7 {
+		t.Errorf("cursor = %d, selected = %d, want 7", m.table.Cursor(), m.selectedEntryIndex())
+	}
+
+	for _, tt := range []struct{ index, want int }{{25, 9}, {-3, 0}} {
+		if cmd := m.jumpToEntry(tt.index); cmd == nil {
+			t.Errorf("jumping to %d should report that it was out of range", tt.index)
+		}
+		if m.selectedEntryIndex() != tt.want {
+			t.Errorf("jumping to %d selected %d, want %d", tt.index, m.selectedEntryIndex(), tt.want)
+		}
+	}
+}
+
+func TestJumpToEntry_Filtered(t *testing.T) {
+	m := newJumpTestModel(10)
+	// entries 2, 4 and 8 are visible, sorted and with a page header in front
+	m.filteredIndices = []int{pageHeaderIndex, 8, 2, 4}
+	m.table.SetRows([]table.Row{m.rows[0], m.rows[8], m.rows[2], m.rows[4]})
+
+	tests := []struct{ index, want int }{
+		{4, 4},
+		{3, 4}, // 2 and 4 are equally close: the later one wins
+		{5, 4},
+		{6, 8},
+		{9, 8},
+		{0, 2},
+	}
+	for _, tt := range tests {
+		m.jumpToEntry(tt.index)
+		if m.selectedEntryIndex() != tt.want {
+			t.Errorf("jumping to %d selected %d, want %d", tt.index, m.selectedEntryIndex(), tt.want)
+		}
+	}
+}
+
+func TestJumpPrompt(t *testing.T) {
+	m := newJumpTestModel(10)
+	m.openJumpPrompt()
+	if !m.jumpPrompt {
+		t.Fatal("expected the jump prompt to open")
+	}
+
+	m.jumpInput.SetValue("abc")
+	m.handleJumpPromptKey(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if !m.jumpPrompt {
+		t.Error("an invalid index should keep the prompt open")
+	}
+
+	m.jumpInput.SetValue("#6")
+	m.handleJumpPromptKey(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.jumpPrompt || m.selectedEntryIndex() != 6 {
+		t.Errorf("prompt open = %v, selected = %d, want closed on 6", m.jumpPrompt, m.selectedEntryIndex())
+	}
+}
@@ -3,24 +3,25 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/pb33f/harific/motor/model"
 )
 
-// pre-computed styles to avoid allocation in hot path
+// pre-computed styles to avoid allocation in hot path; the colored ones are set by buildStyles
 var (
-	keyStyleBase = lipgloss.NewStyle().
-		Foreground(RGBGrey).
-		Align(lipgloss.Right)
-
-	sectionHeaderStyleBase = lipgloss.NewStyle().
-		Bold(true).
-		Foreground(RGBPink)
+	keyStyleBase           lipgloss.Style
+	sectionHeaderStyleBase lipgloss.Style
 
 	emptyValueText = lipgloss.NewStyle().Faint(true).Render("(empty)")
 )
 
+// wrapBreakpoints are the characters, besides spaces and hyphens, a wrapped value may break after,
+// so urls and header lists split between their parts
+const wrapBreakpoints = "/&?,;"
+
 // KeyValuePair represents a single key-value pair
 type KeyValuePair struct {
 	Key   string
@@ -35,19 +36,15 @@ type Section struct {
 
 // RenderOptions configures key-value rendering
 type RenderOptions struct {
-	Width    int  // total available width
-	Truncate bool // whether to truncate long values
-	KeyWidth int  // key column width (0 = auto-calculate)
+	Width     int  // total available width
+	Truncate  bool // whether to truncate long values
+	WrapValue bool // soft-wrap long values at the value column width
+	KeyWidth  int  // key column width (0 = auto-calculate)
 }
 
-// renderSections renders multiple sections as formatted key-value output
-func renderSections(sections []Section, opts RenderOptions) string {
-	if len(sections) == 0 {
-		return ""
-	}
-
-	// calculate column widths
-	keyWidth := opts.KeyWidth
+// columnWidths returns the key and value column widths for opts
+func columnWidths(opts RenderOptions) (keyWidth, valueWidth int) {
+	keyWidth = opts.KeyWidth
 	if keyWidth == 0 {
 		keyWidth = opts.Width * 3 / 10 // 30% for keys
 		if keyWidth > 25 {
@@ -57,7 +54,16 @@ func renderSections(sections []Section, opts RenderOptions) string {
 			keyWidth = 15 // minimum 15 chars
 		}
 	}
-	valueWidth := opts.Width - keyWidth - 3 // -3 for spacing
+	return keyWidth, opts.Width - keyWidth - 3 // -3 for spacing
+}
+
+// renderSections renders multiple sections as formatted key-value output
+func renderSections(sections []Section, opts RenderOptions) string {
+	if len(sections) == 0 {
+		return ""
+	}
+
+	keyWidth, valueWidth := columnWidths(opts)
 
 	var output strings.Builder
 
@@ -70,6 +76,9 @@ func renderSections(sections []Section, opts RenderOptions) string {
 
 		// render pairs
 		for _, pair := range section.Pairs {
+			if opts.WrapValue {
+				pair.Value = wrapValue(pair.Value, valueWidth, keyWidth+2)
+			}
 			row := renderKeyValueRow(pair, keyWidth, valueWidth, opts.Truncate)
 			output.WriteString(row)
 			output.WriteString("\n")
@@ -103,9 +112,40 @@ func renderKeyValueRow(pair KeyValuePair, keyWidth, valueWidth int, truncate boo
 	return keyStyle.Render(pair.Key) + "  " + value
 }
 
+// wrapValue soft-wraps every line of value at width display cells, keeping any styling, and
+// indents each line after the first by indent so it sits under the value column
+func wrapValue(value string, width, indent int) string {
+	if width < 1 || value == "" {
+		return value
+	}
+
+	lines := strings.Split(value, "\n")
+	for i, line := range lines {
+		if ansi.StringWidth(line) > width {
+			lines[i] = ansi.Wrap(line, width, wrapBreakpoints)
+		}
+	}
+	return strings.ReplaceAll(strings.Join(lines, "\n"), "\n", "\n"+strings.Repeat(" ", indent))
+}
+
+// wrappedLineStarts returns the row each line of value starts on once wrapped at width
+func wrappedLineStarts(value string, width int) []int {
+	lines := strings.Split(value, "\n")
+	starts := make([]int, len(lines))
+	row := 0
+	for i, line := range lines {
+		starts[i] = row
+		row++
+		if width > 0 && ansi.StringWidth(line) > width {
+			row += strings.Count(ansi.Wrap(line, width, wrapBreakpoints), "\n")
+		}
+	}
+	return starts
+}
+
 // buildRequestSections converts a HAR request to sections
 func buildRequestSections(req *model.Request) []Section {
-	sections := make([]Section, 1, 5) // pre-allocate for typical case
+	sections := make([]Section, 1, 6) // pre-allocate for typical case
 	sections[0] = Section{
 		Title: "Request",
 		Pairs: []KeyValuePair{
@@ -114,6 +154,9 @@ func buildRequestSections(req *model.Request) []Section {
 			{"HTTP Version", req.HTTPVersion},
 		},
 	}
+	if req.Comment != "" {
+		sections[0].Pairs = append(sections[0].Pairs, KeyValuePair{"Comment", req.Comment})
+	}
 
 	if len(req.Headers) > 0 {
 		sections = append(sections, Section{
@@ -129,6 +172,13 @@ func buildRequestSections(req *model.Request) []Section {
 		})
 	}
 
+	if len(req.Body.Params) > 0 {
+		sections = append(sections, Section{
+			Title: "Form Parameters",
+			Pairs: postParamsToPairs(req.Body.Params),
+		})
+	}
+
 	if len(req.Cookies) > 0 {
 		sections = append(sections, Section{
 			Title: "Cookies",
@@ -152,7 +202,7 @@ func buildRequestSections(req *model.Request) []Section {
 
 // buildResponseSections converts a HAR response to sections
 func buildResponseSections(resp *model.Response, timings *model.Timings) []Section {
-	sections := make([]Section, 1, 4) // pre-allocate for typical case
+	sections := make([]Section, 1, 5) // pre-allocate for typical case
 	sections[0] = Section{
 		Title: "Response",
 		Pairs: []KeyValuePair{
@@ -160,6 +210,12 @@ func buildResponseSections(resp *model.Response, timings *model.Timings) []Secti
 			{"HTTP Version", resp.HTTPVersion},
 		},
 	}
+	if resp.RedirectURL != "" {
+		sections[0].Pairs = append(sections[0].Pairs, KeyValuePair{"Redirect URL", resp.RedirectURL})
+	}
+	if resp.Comment != "" {
+		sections[0].Pairs = append(sections[0].Pairs, KeyValuePair{"Comment", resp.Comment})
+	}
 
 	if len(resp.Headers) > 0 {
 		sections = append(sections, Section{
@@ -168,6 +224,13 @@ func buildResponseSections(resp *model.Response, timings *model.Timings) []Secti
 		})
 	}
 
+	if len(resp.Cookies) > 0 {
+		sections = append(sections, Section{
+			Title: "Cookies",
+			Pairs: setCookiesToPairs(resp.Cookies),
+		})
+	}
+
 	if resp.Body.Content != "" {
 		sections = append(sections, Section{
 			Title: "Body",
@@ -213,6 +276,71 @@ func buildResponseSections(resp *model.Response, timings *model.Timings) []Secti
 	return sections
 }
 
+// maxWebSocketPayloadLength caps each frame payload shown in the WebSocket section
+const maxWebSocketPayloadLength = 200
+
+// webSocketOpcodes names the frame opcodes Chrome records
+var webSocketOpcodes = map[int]string{
+	1:  "text",
+	2:  "binary",
+	8:  "close",
+	9:  "ping",
+	10: "pong",
+}
+
+// webSocketOpcodeName names a frame opcode, e.g. "text" or "opcode 3" for reserved ones
+func webSocketOpcodeName(opcode int) string {
+	if name, ok := webSocketOpcodes[opcode]; ok {
+		return name
+	}
+	return fmt.Sprintf("opcode %d", opcode)
+}
+
+// buildWebSocketSection lists WebSocket frames with direction, timestamp, opcode and truncated payload
+func buildWebSocketSection(messages []model.WebSocketMessage) Section {
+	pairs := make([]KeyValuePair, 0, len(messages))
+	for _, msg := range messages {
+		direction := "↓"
+		if msg.Type == "send" {
+			direction = "↑"
+		}
+
+		sec := int64(msg.Time)
+		ts := time.Unix(sec, int64((msg.Time-float64(sec))*float64(time.Second)))
+
+		payload := msg.Data
+		if len(payload) > maxWebSocketPayloadLength {
+			payload = payload[:maxWebSocketPayloadLength] + "..."
+		}
+
+		key := direction + " " + ts.Format("15:04:05.000") + " " + webSocketOpcodeName(msg.Opcode)
+		pairs = append(pairs, KeyValuePair{key, payload})
+	}
+
+	return Section{
+		Title: fmt.Sprintf("WebSocket Messages (%d)", len(messages)),
+		Pairs: pairs,
+	}
+}
+
+// prependEntryComment puts the entry's comment, often a note such as "this is the failing
+// request", above the request sections
+func prependEntryComment(sections []Section, entry *model.Entry) []Section {
+	if entry.Comment == "" {
+		return sections
+	}
+	comment := Section{Title: "Entry", Pairs: []KeyValuePair{{"Comment", entry.Comment}}}
+	return append([]Section{comment}, sections...)
+}
+
+// appendWebSocketSection adds the WebSocket section when the entry has frames
+func appendWebSocketSection(sections []Section, entry *model.Entry) []Section {
+	if len(entry.WebSocketMessages) == 0 {
+		return sections
+	}
+	return append(sections, buildWebSocketSection(entry.WebSocketMessages))
+}
+
 // nameValuePairsToPairs converts HAR name-value pairs to KeyValuePairs
 func nameValuePairsToPairs(nvps []model.NameValuePair) []KeyValuePair {
 	pairs := make([]KeyValuePair, len(nvps))
@@ -222,6 +350,23 @@ func nameValuePairsToPairs(nvps []model.NameValuePair) []KeyValuePair {
 	return pairs
 }
 
+// postParamsToPairs converts url-encoded post data params to KeyValuePairs, showing uploaded
+// files by name and content type
+func postParamsToPairs(params []model.PostNameValuePair) []KeyValuePair {
+	pairs := make([]KeyValuePair, len(params))
+	for i, p := range params {
+		value := p.Value
+		if p.FileName != "" {
+			value = p.FileName
+			if p.ContentType != "" {
+				value += " (" + p.ContentType + ")"
+			}
+		}
+		pairs[i] = KeyValuePair{p.Name, value}
+	}
+	return pairs
+}
+
 func cookiesToPairs(cookies []model.Cookie) []KeyValuePair {
 	pairs := make([]KeyValuePair, len(cookies))
 	for i, c := range cookies {
@@ -230,18 +375,45 @@ func cookiesToPairs(cookies []model.Cookie) []KeyValuePair {
 	return pairs
 }
 
+// setCookiesToPairs converts response cookies to KeyValuePairs, keeping the attributes that
+// decide where the cookie is sent, e.g. "abc; Path=/; Secure; HttpOnly"
+func setCookiesToPairs(cookies []model.Cookie) []KeyValuePair {
+	pairs := make([]KeyValuePair, len(cookies))
+	for i, c := range cookies {
+		value := c.Value
+		if c.Domain != "" {
+			value += "; Domain=" + c.Domain
+		}
+		if c.Path != "" {
+			value += "; Path=" + c.Path
+		}
+		if c.Expires != "" {
+			value += "; Expires=" + c.Expires
+		}
+		if c.Secure {
+			value += "; Secure"
+		}
+		if c.HTTPOnly {
+			value += "; HttpOnly"
+		}
+		pairs[i] = KeyValuePair{c.Name, value}
+	}
+	return pairs
+}
+
 // renderSectionsWithSearch renders sections with JSON search support
 func renderSectionsWithSearch(sections []Section, opts RenderOptions, searchState *ViewportSearchState) string {
 	if len(sections) == 0 {
 		return ""
 	}
 
-	// Only process if search state is provided and active
-	if searchState == nil || !searchState.active {
+	// Only process if search state is provided and active, or folding the JSON
+	if searchState == nil || !(searchState.active || searchState.Folding()) {
 		return renderSections(sections, opts)
 	}
 
 	// Check if we have a body section with JSON content
+	var renderedJSON string
 	for i, section := range sections {
 		if section.Title == "Body" {
 			for j, pair := range section.Pairs {
@@ -258,7 +430,8 @@ func renderSectionsWithSearch(sections []Section, opts RenderOptions, searchStat
 
 					// If we have a renderer, use it to render the content
 					if searchState.HasJSONContent() {
-						sections[i].Pairs[j].Value = searchState.GetRenderedContent()
+						renderedJSON = searchState.GetRenderedContent()
+						sections[i].Pairs[j].Value = renderedJSON
 					}
 				}
 			}
@@ -266,5 +439,19 @@ func renderSectionsWithSearch(sections []Section, opts RenderOptions, searchStat
 	}
 
 	// Render normally
-	return renderSections(sections, opts)
+	output := renderSections(sections, opts)
+
+	// Remember where the JSON starts so match lines can be mapped onto the viewport
+	if renderedJSON != "" {
+		searchState.lineStarts = nil
+		if opts.WrapValue {
+			keyWidth, valueWidth := columnWidths(opts)
+			searchState.lineStarts = wrappedLineStarts(renderedJSON, valueWidth)
+			renderedJSON = wrapValue(renderedJSON, valueWidth, keyWidth+2)
+		}
+		if pos := strings.Index(output, renderedJSON); pos >= 0 {
+			searchState.contentLine = strings.Count(output[:pos], "\n")
+		}
+	}
+	return output
 }
@@ -0,0 +1,208 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/pb33f/harific/motor/model"
+)
+
+func TestBuildRequestSections_FormParameters(t *testing.T) {
+	req := &model.Request{
+		Method:      "POST",
+		URL:         "https://example.com/login?next=home",
+		QueryParams: []model.NameValuePair{{Name: "next", Value: "home"}},
+		Body: model.BodyType{
+			MIMEType: "application/x-www-form-urlencoded",
+			Params: []model.PostNameValuePair{
+				{Name: "username", Value: "admin"},
+				{Name: "avatar", FileName: "me.png", ContentType: "image/png"},
+			},
+		},
+	}
+
+	sections := buildRequestSections(req)
+	var titles []string
+	var form *Section
+	for i := range sections {
+		titles = append(titles, sections[i].Title)
+		if sections[i].Title == "Form Parameters" {
+			form = &sections[i]
+		}
+	}
+
+	if form == nil {
+		t.Fatalf("expected a Form Parameters section, got %v", titles)
+	}
+	if titles[1] != "Query Parameters" || titles[2] != "Form Parameters" {
+		t.Errorf("form parameters should follow query parameters, got %v", titles)
+	}
+
+	want := []KeyValuePair{{"username", "admin"}, {"avatar", "me.png (image/png)"}}
+	if len(form.Pairs) != len(want) {
+		t.Fatalf("got %v, want %v", form.Pairs, want)
+	}
+	for i := range want {
+		if form.Pairs[i] != want[i] {
+			t.Errorf("pair %d: got %v, want %v", i, form.Pairs[i], want[i])
+		}
+	}
+}
+
+func TestBuildRequestSections_NoFormParameters(t *testing.T) {
+	req := &model.Request{Method: "POST", Body: model.BodyType{MIMEType: "application/json", Content: `{"a":1}`}}
+	for _, section := range buildRequestSections(req) {
+		if section.Title == "Form Parameters" {
+			t.Error("requests without params should not get a Form Parameters section")
+		}
+	}
+}
+
+func TestBuildResponseSections_Cookies(t *testing.T) {
+	resp := &model.Response{
+		StatusCode: 200,
+		Cookies: []model.Cookie{
+			{Name: "session", Value: "abc", Path: "/", Secure: true, HTTPOnly: true},
+			{Name: "theme", Value: "dark"},
+		},
+	}
+
+	var cookies *Section
+	sections := buildResponseSections(resp, nil)
+	for i := range sections {
+		if sections[i].Title == "Cookies" {
+			cookies = &sections[i]
+		}
+	}
+	if cookies == nil {
+		t.Fatal("expected a Cookies section for response cookies")
+	}
+
+	want := []KeyValuePair{{"session", "abc; Path=/; Secure; HttpOnly"}, {"theme", "dark"}}
+	if len(cookies.Pairs) != len(want) {
+		t.Fatalf("got %v, want %v", cookies.Pairs, want)
+	}
+	for i := range want {
+		if cookies.Pairs[i] != want[i] {
+			t.Errorf("pair %d: got %v, want %v", i, cookies.Pairs[i], want[i])
+		}
+	}
+}
+
+func TestBuildResponseSections_RedirectURL(t *testing.T) {
+	resp := &model.Response{StatusCode: 302, StatusText: "Found", RedirectURL: "https://example.com/login"}
+	pairs := buildResponseSections(resp, nil)[0].Pairs
+	if last := pairs[len(pairs)-1]; last != (KeyValuePair{"Redirect URL", "https://example.com/login"}) {
+		t.Errorf("expected the redirect URL in the response section, got %v", pairs)
+	}
+
+	resp.RedirectURL = ""
+	for _, pair := range buildResponseSections(resp, nil)[0].Pairs {
+		if pair.Key == "Redirect URL" {
+			t.Error("a response that does not redirect should not show a redirect URL")
+		}
+	}
+}
+
+func TestRenderSections_WrapValue(t *testing.T) {
+	url := "https://example.com/" + strings.Repeat("segment/", 12) + "?q=1"
+	styled := lipgloss.NewStyle().Foreground(RGBPink).Render(strings.Repeat("token ", 20))
+	sections := []Section{{
+		Title: "Request",
+		Pairs: []KeyValuePair{{"URL", url}, {"Content", styled + "\nshort"}},
+	}}
+
+	opts := RenderOptions{Width: 60, WrapValue: true}
+	keyWidth, valueWidth := columnWidths(opts)
+	lines := strings.Split(strings.TrimSuffix(renderSections(sections, opts), "\n"), "\n")
+
+	// title, url and body each wrap onto several lines
+	if len(lines) < 6 {
+		t.Fatalf("expected wrapped output, got %d lines", len(lines))
+	}
+	indent := strings.Repeat(" ", keyWidth+2)
+	for _, line := range lines[1:] {
+		if w := ansi.StringWidth(line); w > keyWidth+2+valueWidth {
+			t.Errorf("line is %d cells wide, wider than %d: %q", w, keyWidth+2+valueWidth, ansi.Strip(line))
+		}
+		plain := ansi.Strip(line)
+		key := strings.TrimSpace(plain[:keyWidth])
+		if key != "URL" && key != "Content" && !strings.HasPrefix(plain, indent) {
+			t.Errorf("continuation line is not indented under the value column: %q", plain)
+		}
+	}
+	if got := ansi.Strip(strings.Join(lines, "")); !strings.Contains(strings.ReplaceAll(got, " ", ""), strings.ReplaceAll(url, " ", "")) {
+		t.Error("wrapping lost part of the url")
+	}
+
+	unwrapped := renderSections(sections, RenderOptions{Width: 60})
+	if strings.Count(unwrapped, "\n") != 4 {
+		t.Errorf("values should only wrap with WrapValue set, got %d lines", strings.Count(unwrapped, "\n"))
+	}
+}
+
+func TestWrappedLineStarts(t *testing.T) {
+	value := "short\n" + strings.Repeat("x", 25) + "\nshort"
+	got := wrappedLineStarts(value, 10)
+	if len(got) != 3 || got[0] != 0 || got[1] != 1 || got[2] != 4 {
+		t.Errorf("expected line starts [0 1 4], got %v", got)
+	}
+}
+
+func TestBuildWebSocketSection(t *testing.T) {
+	section := buildWebSocketSection([]model.WebSocketMessage{
+		{Type: "send", Time: 1700000000.25, Opcode: 1, Data: `{"op":"subscribe"}`},
+		{Type: "receive", Time: 1700000001, Opcode: 2, Data: "AAEC"},
+		{Type: "receive", Time: 1700000002, Opcode: 3, Data: strings.Repeat("x", maxWebSocketPayloadLength+10)},
+	})
+
+	if section.Title != "WebSocket Messages (3)" {
+		t.Errorf("unexpected title %q", section.Title)
+	}
+	if len(section.Pairs) != 3 {
+		t.Fatalf("expected 3 frames, got %d", len(section.Pairs))
+	}
+
+	wants := []struct{ prefix, suffix string }{{"↑ ", " text"}, {"↓ ", " binary"}, {"↓ ", " opcode 3"}}
+	for i, want := range wants {
+		key := section.Pairs[i].Key
+		if !strings.HasPrefix(key, want.prefix) || !strings.HasSuffix(key, want.suffix) {
+			t.Errorf("frame %d key = %q, want direction %q and opcode %q", i, key, want.prefix, want.suffix)
+		}
+	}
+	if section.Pairs[0].Value != `{"op":"subscribe"}` {
+		t.Errorf("unexpected payload %q", section.Pairs[0].Value)
+	}
+	if got := section.Pairs[2].Value; len(got) != maxWebSocketPayloadLength+3 || !strings.HasSuffix(got, "...") {
+		t.Errorf("long payload should be truncated, got %d bytes", len(got))
+	}
+}
+
+func TestComments(t *testing.T) {
+	entry := &model.Entry{
+		Comment:  "this is the failing request",
+		Request:  model.Request{Method: "GET", URL: "https://example.com", Comment: "retried twice"},
+		Response: model.Response{StatusCode: 500, Comment: "upstream timeout"},
+	}
+
+	sections := prependEntryComment(buildRequestSections(&entry.Request), entry)
+	if sections[0].Title != "Entry" || sections[0].Pairs[0] != (KeyValuePair{"Comment", "this is the failing request"}) {
+		t.Errorf("entry comment should lead the request sections, got %+v", sections[0])
+	}
+	if pairs := sections[1].Pairs; pairs[len(pairs)-1] != (KeyValuePair{"Comment", "retried twice"}) {
+		t.Errorf("request comment missing from %+v", pairs)
+	}
+
+	response := buildResponseSections(&entry.Response, nil)
+	if pairs := response[0].Pairs; pairs[len(pairs)-1] != (KeyValuePair{"Comment", "upstream timeout"}) {
+		t.Errorf("response comment missing from %+v", pairs)
+	}
+
+	entry = &model.Entry{}
+	if sections := prependEntryComment(buildRequestSections(&entry.Request), entry); sections[0].Title != "Request" || len(sections[0].Pairs) != 3 {
+		t.Errorf("entries without comments should be unchanged, got %+v", sections[0])
+	}
+}
@@ -0,0 +1,152 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// LauncherModel lists recently opened files so one can be reopened quickly
+type LauncherModel struct {
+	recent   *RecentFiles
+	cursor   int
+	selected string
+	changed  bool // list was modified (removal/prune) and should be saved
+	width    int
+	height   int
+}
+
+// NewLauncherModel creates a launcher for the given recent files, marking missing ones
+func NewLauncherModel(recent *RecentFiles) *LauncherModel {
+	recent.CheckExists()
+	return &LauncherModel{recent: recent}
+}
+
+// Selected returns the path chosen by the user, or "" if the launcher was dismissed
+func (l *LauncherModel) Selected() string {
+	return l.selected
+}
+
+// Changed returns true if the recent files list was modified in the launcher
+func (l *LauncherModel) Changed() bool {
+	return l.changed
+}
+
+func (l *LauncherModel) Init() tea.Cmd {
+	return nil
+}
+
+func (l *LauncherModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		l.width = msg.Width
+		l.height = msg.Height
+
+	case tea.KeyPressMsg:
+		files := l.recent.Files
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return l, tea.Quit
+
+		case "up", "k":
+			if l.cursor > 0 {
+				l.cursor--
+			}
+
+		case "down", "j":
+			if l.cursor < len(files)-1 {
+				l.cursor++
+			}
+
+		case "enter":
+			if l.cursor < len(files) && !files[l.cursor].Missing {
+				l.selected = files[l.cursor].Path
+				return l, tea.Quit
+			}
+
+		case "d", "delete":
+			if l.cursor < len(files) {
+				l.recent.Remove(files[l.cursor].Path)
+				l.changed = true
+				l.clampCursor()
+			}
+
+		case "p":
+			if l.recent.Prune() > 0 {
+				l.changed = true
+				l.clampCursor()
+			}
+		}
+	}
+
+	return l, nil
+}
+
+func (l *LauncherModel) clampCursor() {
+	if l.cursor >= len(l.recent.Files) {
+		l.cursor = len(l.recent.Files) - 1
+	}
+	if l.cursor < 0 {
+		l.cursor = 0
+	}
+}
+
+func (l *LauncherModel) View() string {
+	missingStyle := lipgloss.NewStyle().Foreground(RGBRed).Faint(true)
+	helpStyle := lipgloss.NewStyle().Foreground(RGBGrey).Faint(true)
+
+	var content strings.Builder
+	content.WriteString(TitleStyle.Render("Recent HAR Files"))
+	content.WriteString("\n\n")
+
+	if len(l.recent.Files) == 0 {
+		content.WriteString(SubtitleStyle.Render("No recently opened files"))
+		content.WriteString("\n")
+	}
+
+	for i, f := range l.recent.Files {
+		line := fmt.Sprintf("%-40s %8d entries   %s",
+			filepath.Base(f.Path), f.Entries, formatLastOpened(f.LastOpened))
+
+		switch {
+		case f.Missing:
+			line = missingStyle.Render(line + "   (missing)")
+		case i == l.cursor:
+			line = SelectedStyle.Render(line)
+		}
+
+		cursor := "  "
+		if i == l.cursor {
+			cursor = "> "
+		}
+		content.WriteString(cursor + line + "\n")
+
+		if i == l.cursor {
+			content.WriteString("  " + SubtitleStyle.Render(f.Path) + "\n")
+		}
+	}
+
+	content.WriteString("\n")
+	content.WriteString(helpStyle.Render("↑/↓: Navigate | Enter: Open | d: Remove | p: Prune missing | q: Quit"))
+
+	return BorderStyle.Padding(1, 2).Render(content.String())
+}
+
+// formatLastOpened renders a timestamp relative to now for recent opens
+func formatLastOpened(t time.Time) string {
+	since := time.Since(t)
+	switch {
+	case since < time.Minute:
+		return "just now"
+	case since < time.Hour:
+		return fmt.Sprintf("%dm ago", int(since.Minutes()))
+	case since < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(since.Hours()))
+	default:
+		return t.Format("2006-01-02")
+	}
+}
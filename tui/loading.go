@@ -55,11 +55,28 @@ func (m *HARViewModel) startIndexing() tea.Cmd {
 	ctx, cancel := context.WithCancel(context.Background())
 	m.indexingCtx = ctx
 	m.indexingCancel = cancel
+	m.indexingStarted = time.Now()
 
 	indexCmd := func() tea.Msg {
 		start := time.Now()
 
-		streamer, err := motor.NewHARStreamer(m.fileName, motor.DefaultStreamerOptions())
+		opts := motor.DefaultStreamerOptions()
+		opts.IndexWebSockets = m.webSockets
+		opts.EnableCache = true // paging back and forth re-reads the same entries
+		opts.UseIndexSidecar = true
+		opts.MaxEntrySize = m.maxEntrySize
+		opts.Follow = m.follow
+		opts.StartEntry = m.startEntry
+		opts.MaxEntries = m.maxEntries
+		opts.ExternalBodyDir = m.externalBodyDir
+
+		var streamer *motor.DefaultHARStreamer
+		var err error
+		if m.source != nil {
+			streamer, err = motor.NewHARStreamerFromReader(m.source, opts)
+		} else {
+			streamer, err = motor.NewHARStreamer(m.fileName, opts)
+		}
 		if err != nil {
 			// Close progress channel to prevent listener goroutine leak
 			close(m.progressChan)
@@ -120,10 +137,12 @@ func (m *HARViewModel) renderLoadingView() string {
 
 	// show progress bar if we have progress data
 	if m.indexingPercent > 0 {
-		content.WriteString(m.progressBar.ViewAs(m.indexingPercent))
-		content.WriteString("  ")
+		content.WriteString(m.progressBar.View())
+		content.WriteString("\n\n")
 		content.WriteString(messageStyle.Render(fmt.Sprintf("Processed %d entries (%.1f%%)",
 			m.indexingEntries, m.indexingPercent*100)))
+		content.WriteString("\n")
+		content.WriteString(fileInfoStyle.Render(formatIndexingRate(m.indexingBytes, m.indexingTotal, time.Since(m.indexingStarted))))
 	} else if m.indexingMessage != "" {
 		content.WriteString(messageStyle.Render(m.indexingMessage))
 	}
@@ -132,6 +151,30 @@ func (m *HARViewModel) renderLoadingView() string {
 	return borderStyle.Render(centeredContent)
 }
 
+// minETAElapsed is how long indexing runs before the rate is trusted enough to show an ETA
+const minETAElapsed = 500 * time.Millisecond
+
+// indexingETA estimates the time left from the average rate since indexing started
+func indexingETA(bytesRead, totalBytes int64, elapsed time.Duration) (time.Duration, bool) {
+	if bytesRead <= 0 || totalBytes <= 0 || elapsed < minETAElapsed {
+		return 0, false
+	}
+	remaining := max(totalBytes-bytesRead, 0)
+	return time.Duration(float64(elapsed) * float64(remaining) / float64(bytesRead)), true
+}
+
+// formatIndexingRate describes indexing throughput, e.g. "120.0MB of 2000.0MB, 45.2MB/s, ETA 42s"
+func formatIndexingRate(bytesRead, totalBytes int64, elapsed time.Duration) string {
+	progress := fmt.Sprintf("%s of %s", formatSize(bytesRead), formatSize(totalBytes))
+
+	eta, ok := indexingETA(bytesRead, totalBytes, elapsed)
+	if !ok {
+		return progress + ", estimating time left..."
+	}
+	rate := int64(float64(bytesRead) / elapsed.Seconds())
+	return fmt.Sprintf("%s, %s/s, ETA %s", progress, formatSize(rate), eta.Round(time.Second))
+}
+
 func (m *HARViewModel) renderErrorView() string {
 	errorStyle := lipgloss.NewStyle().
 		Width(m.width).
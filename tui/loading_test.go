@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pb33f/harific/motor"
+)
+
+func TestIndexingETA(t *testing.T) {
+	if _, ok := indexingETA(0, 1000, time.Second); ok {
+		t.Error("no ETA before any bytes are read")
+	}
+	if _, ok := indexingETA(500, 1000, 100*time.Millisecond); ok {
+		t.Error("no ETA until indexing has run for a while")
+	}
+
+	eta, ok := indexingETA(250, 1000, 2*time.Second)
+	if !ok || eta != 6*time.Second {
+		t.Errorf("expected 6s left at a quarter done after 2s, got %v (%v)", eta, ok)
+	}
+
+	eta, ok = indexingETA(1000, 1000, 2*time.Second)
+	if !ok || eta != 0 {
+		t.Errorf("expected no time left when done, got %v", eta)
+	}
+}
+
+func TestFormatIndexingRate(t *testing.T) {
+	mb := int64(1024 * 1024)
+
+	got := formatIndexingRate(50*mb, 200*mb, 2*time.Second)
+	for _, want := range []string{"50.0MB of 200.0MB", "25.0MB/s", "ETA 6s"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in %q", want, got)
+		}
+	}
+
+	got = formatIndexingRate(mb, 200*mb, time.Millisecond)
+	if !strings.Contains(got, "estimating") || strings.Contains(got, "ETA") {
+		t.Errorf("expected no ETA yet, got %q", got)
+	}
+}
+
+func TestRenderIndexWindow(t *testing.T) {
+	m := &HARViewModel{index: &motor.Index{}}
+	if got := m.renderIndexWindow(); got != "" {
+		t.Errorf("a whole index has no window note, got %q", got)
+	}
+
+	m.allEntries = make([]*motor.EntryMetadata, 3)
+	m.index = &motor.Index{EntriesCutOff: true, HAREntries: 2000}
+	if got := m.renderIndexWindow(); got != ", showing 1-3 of ~2000" {
+		t.Errorf("cut off index = %q", got)
+	}
+
+	m.index = &motor.Index{FirstEntry: 10, HAREntries: 13}
+	if got := m.renderIndexWindow(); got != ", showing 11-13 of 13" {
+		t.Errorf("skipped entries = %q", got)
+	}
+}
@@ -0,0 +1,343 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// XML and HTML bodies are indented and highlighted by a small tokenizer rather than a full parser:
+// elements are indented by nesting, short elements stay on one line, and tags, attribute names and
+// attribute values are colored. malformed markup still renders, it just may not indent cleanly.
+
+type markupTokenKind int
+
+const (
+	markupText        markupTokenKind = iota
+	markupOpen                        // <name ...>
+	markupClose                       // </name>
+	markupSelfClosing                 // <name ... /> or an html void element
+	markupComment                     // <!-- ... -->
+	markupDirective                   // <!DOCTYPE ...>, <?xml ...?>, <![CDATA[...]]>
+	markupRaw                         // verbatim content of html script, style, pre and textarea
+)
+
+type markupToken struct {
+	kind markupTokenKind
+	text string
+	name string
+}
+
+// htmlVoidElements never have a closing tag in html
+var htmlVoidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// htmlRawTextElements hold content that must not be parsed as markup or re-indented
+var htmlRawTextElements = map[string]bool{
+	"script": true, "style": true, "pre": true, "textarea": true,
+}
+
+var markupCommentStyle lipgloss.Style // set by buildStyles
+
+// tokenizeMarkup splits XML or HTML into tags, text, comments and directives
+func tokenizeMarkup(content string, html bool) []markupToken {
+	var tokens []markupToken
+	addText := func(text string) {
+		if text == "" {
+			return
+		}
+		if n := len(tokens); n > 0 && tokens[n-1].kind == markupText {
+			tokens[n-1].text += text
+			return
+		}
+		tokens = append(tokens, markupToken{kind: markupText, text: text})
+	}
+
+	for i := 0; i < len(content); {
+		if content[i] != '<' {
+			next := strings.IndexByte(content[i:], '<')
+			if next < 0 {
+				addText(content[i:])
+				break
+			}
+			addText(content[i : i+next])
+			i += next
+			continue
+		}
+
+		rest := content[i:]
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			end := markupTerminator(rest, "-->")
+			tokens = append(tokens, markupToken{kind: markupComment, text: rest[:end]})
+			i += end
+
+		case strings.HasPrefix(rest, "<![CDATA["):
+			end := markupTerminator(rest, "]]>")
+			tokens = append(tokens, markupToken{kind: markupDirective, text: rest[:end]})
+			i += end
+
+		case strings.HasPrefix(rest, "<!"), strings.HasPrefix(rest, "<?"):
+			end := markupTerminator(rest, ">")
+			tokens = append(tokens, markupToken{kind: markupDirective, text: rest[:end]})
+			i += end
+
+		case len(rest) > 1 && (isMarkupNameStart(rest[1]) || (rest[1] == '/' && len(rest) > 2 && isMarkupNameStart(rest[2]))):
+			end := markupTagEnd(rest)
+			if end < 0 {
+				// unterminated tag, show the remainder as text
+				addText(rest)
+				i = len(content)
+				continue
+			}
+
+			tag := rest[:end]
+			tok := markupToken{text: tag, name: markupTagName(tag)}
+			lowerName := strings.ToLower(tok.name)
+			switch {
+			case strings.HasPrefix(tag, "</"):
+				tok.kind = markupClose
+			case strings.HasSuffix(tag, "/>") || (html && htmlVoidElements[lowerName]):
+				tok.kind = markupSelfClosing
+			default:
+				tok.kind = markupOpen
+			}
+			tokens = append(tokens, tok)
+			i += end
+
+			if html && tok.kind == markupOpen && htmlRawTextElements[lowerName] {
+				closeAt := strings.Index(strings.ToLower(content[i:]), "</"+lowerName)
+				if closeAt < 0 {
+					closeAt = len(content) - i
+				}
+				if closeAt > 0 {
+					tokens = append(tokens, markupToken{kind: markupRaw, text: content[i : i+closeAt]})
+				}
+				i += closeAt
+			}
+
+		default:
+			// a bare '<' in text, e.g. "a < b"
+			addText("<")
+			i++
+		}
+	}
+
+	return tokens
+}
+
+// markupTerminator returns the index just past terminator, or the end of s if it never appears
+func markupTerminator(s, terminator string) int {
+	if end := strings.Index(s, terminator); end >= 0 {
+		return end + len(terminator)
+	}
+	return len(s)
+}
+
+// markupTagEnd returns the index just past the '>' closing the tag at the start of s, skipping
+// quoted attribute values, or -1 if the tag is unterminated
+func markupTagEnd(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func markupTagName(tag string) string {
+	name := strings.TrimPrefix(strings.TrimPrefix(tag, "<"), "/")
+	if end := strings.IndexAny(name, " \t\r\n/>"); end >= 0 {
+		name = name[:end]
+	}
+	return name
+}
+
+func isMarkupNameStart(c byte) bool {
+	return c == '_' || c == ':' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// collapseTagWhitespace puts a tag on one line, collapsing whitespace runs outside quoted values
+func collapseTagWhitespace(tag string) string {
+	var out strings.Builder
+	var quote byte
+	space := false
+	for i := 0; i < len(tag); i++ {
+		c := tag[i]
+		if quote == 0 && (c == ' ' || c == '\t' || c == '\r' || c == '\n') {
+			space = true
+			continue
+		}
+		if space {
+			if c != '>' {
+				out.WriteByte(' ')
+			}
+			space = false
+		}
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		}
+		out.WriteByte(c)
+	}
+	return out.String()
+}
+
+// prettyPrintMarkup indents XML or HTML by element nesting
+func prettyPrintMarkup(content string, html bool) string {
+	if !strings.Contains(content, "<") {
+		return content
+	}
+
+	tokens := tokenizeMarkup(content, html)
+	var lines []string
+	depth := 0
+	write := func(s string) {
+		lines = append(lines, strings.Repeat("  ", depth)+s)
+	}
+	closes := func(i int, name string) bool {
+		return i < len(tokens) && tokens[i].kind == markupClose && strings.EqualFold(tokens[i].name, name)
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch tok.kind {
+		case markupOpen:
+			open := collapseTagWhitespace(tok.text)
+			// keep empty and single text elements on one line, e.g. <id>42</id>
+			if closes(i+1, tok.name) {
+				write(open + tokens[i+1].text)
+				i++
+				continue
+			}
+			if i+1 < len(tokens) && tokens[i+1].kind == markupText && closes(i+2, tok.name) {
+				if text := strings.TrimSpace(tokens[i+1].text); !strings.Contains(text, "\n") {
+					write(open + text + tokens[i+2].text)
+					i += 2
+					continue
+				}
+			}
+			write(open)
+			depth++
+
+		case markupClose:
+			if depth > 0 {
+				depth--
+			}
+			write(tok.text)
+
+		case markupSelfClosing:
+			write(collapseTagWhitespace(tok.text))
+
+		case markupText:
+			for _, line := range strings.Split(tok.text, "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					write(line)
+				}
+			}
+
+		case markupRaw:
+			if raw := strings.Trim(tok.text, "\r\n"); strings.TrimSpace(raw) != "" {
+				lines = append(lines, raw)
+			}
+
+		default:
+			write(tok.text)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// applyMarkupHighlighting colors tags, attribute names and values, comments and directives
+func applyMarkupHighlighting(content string, html bool) string {
+	if content == "" {
+		return content
+	}
+
+	var out strings.Builder
+	for _, tok := range tokenizeMarkup(content, html) {
+		switch tok.kind {
+		case markupOpen, markupClose, markupSelfClosing:
+			out.WriteString(highlightMarkupTag(tok.text))
+		case markupComment:
+			out.WriteString(renderMarkupLines(markupCommentStyle, tok.text))
+		case markupDirective:
+			out.WriteString(renderMarkupLines(SyntaxDashStyle, tok.text))
+		default:
+			out.WriteString(tok.text)
+		}
+	}
+	return out.String()
+}
+
+// highlightMarkupTag styles the tag name blue, attribute names yellow and attribute values pink
+func highlightMarkupTag(tag string) string {
+	prefix := "<"
+	if strings.HasPrefix(tag, "</") {
+		prefix = "</"
+	}
+	name := markupTagName(tag)
+	i := len(prefix) + len(name)
+
+	var out strings.Builder
+	out.WriteString(SyntaxKeyStyle.Render(prefix + name))
+
+	for i < len(tag) {
+		c := tag[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '=':
+			out.WriteByte(c)
+			i++
+
+		case c == '>' || (c == '/' && i+1 < len(tag) && tag[i+1] == '>'):
+			out.WriteString(SyntaxKeyStyle.Render(tag[i:]))
+			i = len(tag)
+
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(tag[i+1:], c)
+			if end < 0 {
+				end = len(tag) - i - 2
+			}
+			out.WriteString(renderMarkupLines(SyntaxDashStyle, tag[i:i+end+2]))
+			i += end + 2
+
+		default:
+			start := i
+			for i < len(tag) && !strings.ContainsRune(" \t\r\n=>", rune(tag[i])) && !(tag[i] == '/' && i+1 < len(tag) && tag[i+1] == '>') {
+				i++
+			}
+			style := SyntaxNumberStyle
+			if start > 0 && tag[start-1] == '=' {
+				// unquoted attribute value
+				style = SyntaxDashStyle
+			}
+			out.WriteString(style.Render(tag[start:i]))
+		}
+	}
+
+	return out.String()
+}
+
+// renderMarkupLines styles each line separately so multi-line text isn't padded into a block
+func renderMarkupLines(style lipgloss.Style, text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = style.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var ansiSequence = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func TestPrettyPrintMarkupXML(t *testing.T) {
+	input := `<?xml version="1.0"?><order id="7"><item sku="a-1">Widget</item><note/><empty></empty><!-- done --></order>`
+
+	expected := strings.Join([]string{
+		`<?xml version="1.0"?>`,
+		`<order id="7">`,
+		`  <item sku="a-1">Widget</item>`,
+		`  <note/>`,
+		`  <empty></empty>`,
+		`  <!-- done -->`,
+		`</order>`,
+	}, "\n")
+
+	if got := prettyPrintMarkup(input, false); got != expected {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", got, expected)
+	}
+}
+
+func TestPrettyPrintMarkupHTML(t *testing.T) {
+	input := "<!DOCTYPE html><html><head><meta charset=\"utf-8\">\n<script>if (a < b) { go(); }</script></head>" +
+		"<body><p>Hello <b>there</b></p><br></body></html>"
+
+	expected := strings.Join([]string{
+		`<!DOCTYPE html>`,
+		`<html>`,
+		`  <head>`,
+		`    <meta charset="utf-8">`,
+		`    <script>`,
+		`if (a < b) { go(); }`,
+		`    </script>`,
+		`  </head>`,
+		`  <body>`,
+		`    <p>`,
+		`      Hello`,
+		`      <b>there</b>`,
+		`    </p>`,
+		`    <br>`,
+		`  </body>`,
+		`</html>`,
+	}, "\n")
+
+	if got := prettyPrintMarkup(input, true); got != expected {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", got, expected)
+	}
+}
+
+func TestPrettyPrintMarkupCollapsesTagWhitespace(t *testing.T) {
+	input := "<a\n    href=\"x  y\"\n    class='c' >link</a>"
+	if got := prettyPrintMarkup(input, true); got != `<a href="x  y" class='c'>link</a>` {
+		t.Errorf("unexpected output: %q", got)
+	}
+}
+
+func TestPrettyPrintMarkupMalformed(t *testing.T) {
+	// unbalanced and unterminated markup must not panic or lose content
+	input := "</stray><open><unterminated attr=\"x"
+	got := prettyPrintMarkup(input, false)
+	if !strings.Contains(got, `<unterminated attr="x`) || !strings.HasPrefix(got, "</stray>") {
+		t.Errorf("unexpected output: %q", got)
+	}
+
+	if got := prettyPrintMarkup("no markup here", false); got != "no markup here" {
+		t.Errorf("plain text should be unchanged, got %q", got)
+	}
+}
+
+func TestApplyMarkupHighlightingPreservesText(t *testing.T) {
+	input := prettyPrintMarkup(`<root a="1" b=two><child/><!-- a
+multi-line comment --></root>`, false)
+
+	highlighted := applyMarkupHighlighting(input, false)
+	if highlighted == input {
+		t.Fatal("expected tags to be styled")
+	}
+	if stripped := ansiSequence.ReplaceAllString(highlighted, ""); stripped != input {
+		t.Errorf("highlighting changed the text:\n%s\nwant:\n%s", stripped, input)
+	}
+}
+
+func TestDetectContentTypeMarkup(t *testing.T) {
+	tests := map[string]string{
+		"application/json":         "json",
+		"text/html; charset=utf-8": "html",
+		"application/xhtml+xml":    "html",
+		"application/xml":          "xml",
+		"application/atom+xml":     "xml",
+		"text/yaml":                "yaml",
+		"text/plain":               "plain",
+	}
+	for mimeType, expected := range tests {
+		if got := detectContentType(mimeType); got != expected {
+			t.Errorf("detectContentType(%q) = %q, want %q", mimeType, got, expected)
+		}
+	}
+}
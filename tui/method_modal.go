@@ -0,0 +1,63 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// methodCategories are the method filter checkboxes; "Other" covers anything else (CONNECT, TRACE, ...)
+var methodCategories = []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS", "Other"}
+
+func (m *HARViewModel) renderMethodFilterModal() string {
+	return renderCheckboxModal("Method Filters", methodCategories, m.methodCheckboxes[:], m.methodCursor)
+}
+
+func (m *HARViewModel) toggleMethodCheckbox() {
+	if m.methodCursor < len(methodCategories) {
+		m.methodCheckboxes[m.methodCursor] = !m.methodCheckboxes[m.methodCursor]
+		for i, method := range methodCategories {
+			m.methodFilter.ToggleMethod(method, m.methodCheckboxes[i])
+		}
+		m.applyFilters()
+	} else {
+		// reset option selected
+		m.resetMethodFilters()
+	}
+}
+
+func (m *HARViewModel) resetMethodFilters() {
+	for i := range m.methodCheckboxes {
+		m.methodCheckboxes[i] = true
+	}
+	m.methodFilter.Clear()
+	m.applyFilters()
+}
+
+func (m *HARViewModel) handleMethodModalKeys(key string) (bool, tea.Cmd) {
+	if m.activeModal != ModalMethodFilter {
+		return false, nil
+	}
+
+	switch key {
+	case "esc", "m":
+		m.activeModal = ModalNone
+		return true, nil
+
+	case "r":
+		m.resetMethodFilters()
+		return true, nil
+
+	case "up":
+		m.methodCursor = moveCheckboxCursor(m.methodCursor, -1, len(methodCategories))
+		return true, nil
+
+	case "down":
+		m.methodCursor = moveCheckboxCursor(m.methodCursor, 1, len(methodCategories))
+		return true, nil
+
+	case " ", "space", "enter":
+		m.toggleMethodCheckbox()
+		return true, nil
+	}
+
+	return false, nil
+}
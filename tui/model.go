@@ -2,6 +2,8 @@ package tui
 
 import (
     "context"
+    "fmt"
+    "io"
     "time"
 
     "github.com/charmbracelet/bubbles/v2/progress"
@@ -11,6 +13,7 @@ import (
     "github.com/charmbracelet/bubbles/v2/viewport"
     tea "github.com/charmbracelet/bubbletea/v2"
     "github.com/charmbracelet/lipgloss/v2"
+    "github.com/pb33f/harific/hargen"
     "github.com/pb33f/harific/motor"
     "github.com/pb33f/harific/motor/model"
 )
@@ -23,6 +26,7 @@ const (
     ViewModeTableWithSplit
     ViewModeTableWithSearch
     ViewModeTableFiltered // viewing filtered results without search panel
+    ViewModeEndpoints     // entries grouped by normalized url, Enter filters to one group
 )
 
 // ViewportFocus represents which viewport has focus
@@ -39,8 +43,15 @@ type ModalType int
 const (
     ModalNone ModalType = iota
     ModalFileTypeFilter
+    ModalMethodFilter
+    ModalStatusFilter
+    ModalTimeRange
+    ModalSizeRange
     ModalRequestFull
     ModalResponseFull
+    ModalStats
+    ModalEntryDiff
+    ModalSearchSettings
 )
 
 // Search messages for async search execution
@@ -55,7 +66,9 @@ type detailSearchDebounceMsg struct {
 type searchStartMsg struct{}
 
 type searchResultsMsg struct {
-    matches []motor.SearchResult
+    matches   []motor.SearchResult
+    truncated bool // search stopped at maxSearchResults
+    stats     motor.SearchStats
 }
 
 type searchCompleteMsg struct{}
@@ -69,7 +82,15 @@ type HARViewModel struct {
     allEntries      []*motor.EntryMetadata
     rows            []table.Row
     columns         []table.Column
-    filteredIndices []int // maps filtered table row position to original entry index
+    columnPreset    ColumnPreset  // which columns the table shows, cycled with C
+    viewSettings    *ViewSettings // preferences saved between sessions; the column preset is recorded here
+    filteredIndices []int         // maps filtered table row position to original entry index
+    tableSort       TableSort     // column ordering applied on top of the filtered rows
+
+    // page grouping: header rows (pageHeaderIndex in filteredIndices) segment the table by pageref
+    groupByPage    bool
+    collapsedPages map[string]bool // page ids whose entries are hidden under their header
+    pageHeaders    map[int]string  // table row -> page id of each header row
 
     streamer      motor.HARStreamer
     index         *motor.Index
@@ -85,22 +106,61 @@ type HARViewModel struct {
     requestViewport  viewport.Model
     responseViewport viewport.Model
     focusedViewport  ViewportFocus
-
-    searchInput   textinput.Model
-    searchQuery   string
-    searchOptions [4]bool // checkbox states: 0=ResponseBody, 1=Regex, 2=AllMatches, 3=LiveSearch
-    searchCursor  int     // focus position: 0=input, 1-4=checkboxes
+    panelFullscreen  bool // focused split panel fills the screen, table hidden
+
+    statusMessage   string // transient status bar confirmation, e.g. after copying
+    statusMessageID int64  // guards against an older clear timer wiping a newer message
+
+    // export of the filtered entries to a new har: path prompt, then background progress
+    exportPrompt   bool
+    exportInput    textinput.Model
+    exporting      bool
+    exportProgress motor.ExportProgress
+    exportChan     chan motor.ExportProgress
+
+    // saving the selected entry's response body to a file: path prompt, then a background write
+    bodySavePrompt bool
+    bodySaveInput  textinput.Model
+    bodySaveIndex  int // entry the prompt was opened on
+
+    // jumping the cursor to an entry by index
+    jumpPrompt bool
+    jumpInput  textinput.Model
+
+    searchInput     textinput.Model
+    searchQuery     string
+    searchOptions   [9]bool // checkbox states: 0=ResponseBody, 1=Regex, 2=AllMatches, 3=LiveSearch, 4=IgnoreCase, 5=WholeWord, 6=Fuzzy, 7=Glob, 8=RequestBody
+    searchCursor    int     // focus position: 0=input, 1-9=checkboxes
+    searchTruncated bool    // last search stopped at maxSearchResults
+    searchHistory   *SearchHistory // past queries, stepped through with up/down on the search input
+    searchStats     motor.SearchStats // last completed search, shown in the search panel
+
+    searchHighlight bool // matches are marked among every entry rather than filtering the table
+
+    // the last query compiled, reused while its pattern is unchanged
+    compiledSearch   *motor.CompiledQuery
+    searchPatternErr error // why the query does not compile, shown in the search panel
+
+    // search settings modal: worker count and batch size fed to every search, 0 = automatic
+    searchWorkers   int
+    searchChunkSize int
+    workersInput    textinput.Model
+    chunkSizeInput  textinput.Model
+    settingsCursor  int
+    settingsError   string // why the last apply was rejected
 
     // search engine
-    searcher      *motor.HARSearcher
-    reader        motor.EntryReader
-    searchFilter  *SearchFilter
-    filterChain   *FilterChain
-    isSearching   bool
-    searchSpinner spinner.Model
-    searchCtx     context.Context
-    searchCancel  context.CancelFunc
-    debounceID    int64 // increments on each keystroke to cancel stale debounces
+    searcher       *motor.HARSearcher
+    reader         motor.EntryReader
+    searchFilter   *SearchFilter
+    filterChain    *FilterChain
+    isSearching    bool
+    searchSpinner  spinner.Model
+    searchCtx      context.Context
+    searchCancel   context.CancelFunc
+    debounceID     int64         // increments on each keystroke to cancel stale debounces
+    searchDebounce time.Duration // live search delay after a keystroke
+    liveSearchOff  bool          // live search starts unchecked, so queries run on enter
 
     // file type filter modal
     activeModal      ModalType
@@ -108,9 +168,53 @@ type HARViewModel struct {
     filterCheckboxes [6]bool // Graphics, JS, CSS, Fonts, Markup, AllFiles
     filterCursor     int     // which checkbox is focused in modal
 
+    // method filter modal state
+    methodFilter     *MethodFilter
+    methodCheckboxes [8]bool // one per methodCategories entry
+    methodCursor     int
+
+    // status class filter modal state
+    statusFilter     *StatusClassFilter
+    statusCheckboxes [5]bool // one per statusClassCategories entry
+    statusCursor     int
+
+    // time range filter modal state
+    timeFilter         *TimeRangeFilter
+    timeFromInput      textinput.Model
+    timeToInput        textinput.Model
+    timeExcludeUntimed bool
+    timeCursor         int
+    timeError          string // why the last apply was rejected
+
+    // response size range filter modal state
+    sizeFilter   *SizeFilter
+    sizeMinInput textinput.Model
+    sizeMaxInput textinput.Model
+    sizeCursor   int
+    sizeError    string // why the last apply was rejected
+
+    // endpoints view: the filtered entries grouped by normalized url
+    endpoints           []motor.EndpointStats
+    endpointTable       table.Model
+    endpointFilter      *EndpointFilter // entries of the endpoint drilled into from the view
+    endpointsReturnMode ViewMode        // view mode the endpoints view was opened from
+
+    // stats modal: aggregates of the filtered entries, refreshed as filters change
+    filterStats *motor.IndexStats
+
+    // entry diff modal: b marks the baseline, d compares the selected entry against it
+    diffBaseline      int
+    diffBaselineSet   bool
+    diffTarget        int
+    diffRows          []diffRow
+    diffRendered      string // diffRows rendered at diffRenderedWidth per column
+    diffRenderedWidth int
+    diffViewport      viewport.Model
+
     // detail viewport modal (full request/response view)
     detailViewport viewport.Model
     detailViewType string // "request" or "response"
+    detailHexView  bool   // show binary bodies as a hex dump
 
     // cache for colorized table during search mode
     cachedColorizedTable string
@@ -119,8 +223,39 @@ type HARViewModel struct {
     // detail modal search state
     detailSearchState    *ViewportSearchState
     detailDebounceID     int64 // increments on each keystroke to cancel stale debounces
+    detailDebounce       time.Duration
+
+    // injected terms from a hargen report, associated with entries once indexed
+    injectedTerms []hargen.InjectedTerm
+    injections    *InjectionReport
+
+    // index and search _webSocketMessages frames
+    webSockets bool
+
+    // show and search base64 / gzip response bodies as decoded text
+    decodeBodies bool
+
+    // largest entry the reader will load, in bytes (0 = motor.MaxEntrySize)
+    maxEntrySize int64
+
+    // watch the file for appended entries, see SetFollow
+    follow bool
+
+    // index only part of the entries array, see SetEntryWindow
+    startEntry int
+    maxEntries int
+
+    // directory response body files (content._file) are read from, see SetExternalBodyDir
+    externalBodyDir string
+
+    // longest entry duration in the har, the scale the split view's waterfall bar is drawn to
+    slowestEntry float64
+
+    // each entry's place on the capture timeline, by entry index, for the timeline columns
+    timeline []motor.TimelineEntry
 
     fileName string
+    source   io.Reader // har read from a pipe rather than fileName, see SetSource
 
     loadState       LoadState
     loadingSpinner  spinner.Model
@@ -130,6 +265,9 @@ type HARViewModel struct {
     progressChan    chan motor.IndexProgress
     indexingPercent float64
     indexingEntries int
+    indexingBytes   int64 // bytes indexed so far, of indexingTotal
+    indexingTotal   int64
+    indexingStarted time.Time
     indexingCtx     context.Context
     indexingCancel  context.CancelFunc
 
@@ -137,13 +275,7 @@ type HARViewModel struct {
 }
 
 func NewHARViewModel(fileName string) (*HARViewModel, error) {
-    columns := []table.Column{
-        {Title: "Method", Width: methodColumnWidth},
-        {Title: "URL", Width: 50}, // Initial width, will be adjusted dynamically
-        {Title: "Status", Width: statusColumnWidth},
-        {Title: "Size", Width: sizeColumnWidth},
-        {Title: "Duration", Width: durationColumnWidth},
-    }
+    columns := ColumnsDefault.columns(0, TableSort{}) // widths are adjusted once the terminal size is known
 
     searchInput := textinput.New()
     searchInput.CharLimit = 200
@@ -168,15 +300,31 @@ func NewHARViewModel(fileName string) (*HARViewModel, error) {
         indexingMessage:  "Building index...",
         searchInput:      searchInput,
         searchCursor:     searchCursorInput,
+        searchHistory:    NewSearchHistory(0),
         searchFilter:     NewSearchFilter(),
         filterChain:      NewFilterChain(),
         searchSpinner:    searchSpinner,
         activeModal:         ModalNone,
         fileTypeFilter:      NewFileTypeFilter(),
         filterCheckboxes:    [6]bool{true, true, true, true, true, true}, // all enabled by default
+        methodFilter:        NewMethodFilter(),
+        methodCheckboxes:    [8]bool{true, true, true, true, true, true, true, true},
+        statusFilter:        NewStatusClassFilter(),
+        statusCheckboxes:    [5]bool{true, true, true, true, true},
+        timeFilter:          NewTimeRangeFilter(),
+        endpointFilter:      NewEndpointFilter(),
+        timeFromInput:       newTimeInput("From: "),
+        timeToInput:         newTimeInput("To:   "),
+        sizeFilter:          NewSizeFilter(),
+        sizeMinInput:        newSizeInput("Min: "),
+        sizeMaxInput:        newSizeInput("Max: "),
+        workersInput:        newSettingsInput("Workers: "),
+        chunkSizeInput:      newSettingsInput("Chunk:   "),
         progressBar:         progressBar,
         progressChan:        make(chan motor.IndexProgress, 10),
         detailSearchState:   NewViewportSearchState(),
+        searchDebounce:      defaultSearchDebounceMS * time.Millisecond,
+        detailDebounce:      defaultDetailDebounceMS * time.Millisecond,
     }
 
     return m, nil
@@ -196,33 +344,65 @@ func (m *HARViewModel) executeSearch() tea.Cmd {
 
     query := m.searchInput.Value()
 
-    // cancel previous search
-    if m.searchCancel != nil {
-        m.searchCancel()
-    }
-
     // build search options from checkboxes
     opts := motor.DefaultSearchOptions
     opts.SearchResponseBody = m.searchOptions[0] // Response Bodies
+    opts.SkipRequestBody = !m.searchOptions[8]   // Request Bodies (inverted)
     opts.FirstMatchOnly = !m.searchOptions[2]    // All Matches (inverted)
+    opts.CaseInsensitive = m.searchOptions[4]    // Ignore Case
+    opts.WholeWord = m.searchOptions[5]          // Whole Word
+    opts.MaxResults = maxSearchResults
+    opts.SortByRelevance = true // results are collected before display anyway
+    if m.searchWorkers > 0 {
+        opts.WorkerCount = m.searchWorkers
+    }
+    opts.ChunkSize = m.searchChunkSize
 
-    if m.searchOptions[1] {
+    if m.searchOptions[6] {
+        opts.Mode = motor.Fuzzy // Fuzzy, takes precedence over Regex Mode
+    } else if m.searchOptions[1] {
         opts.Mode = motor.Regex // Regex Mode
+    } else if m.searchOptions[7] {
+        opts.Mode = motor.Glob // Glob, shell-style wildcards
     } else {
         opts.Mode = motor.PlainText
     }
 
+    // only search entries that survive the other active filters - anything else would be hidden anyway
+    opts.Indices = PassingIndices(m.allEntries, m.fileTypeFilter, m.methodFilter, m.statusFilter, m.timeFilter, m.sizeFilter, m.endpointFilter)
+    opts.SearchWebSockets = m.webSockets
+    opts.DecodeBodies = m.decodeBodies
+
+    // a pattern still being typed, like "[", is flagged in the panel; the last results stay up.
+    // the compiled query is kept while only options that do not change the pattern change
+    compiled := m.compiledSearch
+    if compiled != nil && compiled.CompiledFor(query, opts) {
+        compiled = compiled.WithOptions(opts)
+    } else {
+        var err error
+        if compiled, err = m.searcher.Compile(query, opts); err != nil {
+            m.searchPatternErr = err
+            return nil
+        }
+    }
+    m.compiledSearch = compiled
+    m.searchPatternErr = nil
+    m.searchQuery = query // Store the active search query
+    m.isSearching = true
+
+    // cancel previous search
+    if m.searchCancel != nil {
+        m.searchCancel()
+    }
+
     // create new search context
     ctx, cancel := context.WithCancel(context.Background())
     m.searchCtx = ctx
     m.searchCancel = cancel
 
-    // capture query for the Cmd closure
-    searchQuery := query
-
     // start search in background
     return func() tea.Msg {
-        resultsChan, err := m.searcher.Search(ctx, searchQuery, opts)
+        resultsChan, err := m.searcher.SearchCompiled(ctx, compiled)
         if err != nil {
             return searchErrorMsg{err: err}
         }
@@ -234,17 +414,18 @@ func (m *HARViewModel) executeSearch() tea.Cmd {
         }
 
         // always return results message (even if empty)
-        return searchResultsMsg{matches: allMatches}
+        stats := m.searcher.Stats()
+        return searchResultsMsg{matches: allMatches, truncated: stats.Truncated, stats: stats}
     }
 }
 
 func (m *HARViewModel) startDebounceTimer() tea.Cmd {
     m.debounceID++
     currentID := m.debounceID
+    delay := m.searchDebounce
 
     return func() tea.Msg {
-        // 300ms debounce for live search - gives users time to finish typing
-        time.Sleep(300 * time.Millisecond)
+        time.Sleep(delay)
         return searchDebounceMsg{id: currentID}
     }
 }
@@ -252,9 +433,10 @@ func (m *HARViewModel) startDebounceTimer() tea.Cmd {
 func (m *HARViewModel) startDetailDebounceTimer() tea.Cmd {
     m.detailDebounceID++
     currentID := m.detailDebounceID
+    delay := m.detailDebounce
 
     return func() tea.Msg {
-        time.Sleep(200 * time.Millisecond)
+        time.Sleep(delay)
         return detailSearchDebounceMsg{id: currentID}
     }
 }
@@ -262,7 +444,8 @@ func (m *HARViewModel) startDetailDebounceTimer() tea.Cmd {
 func (m *HARViewModel) applyFilters() {
     m.filterChain.Clear()
 
-    if m.searchFilter.IsActive() {
+    // highlighted matches are marked below instead of filtering out everything else
+    if m.searchFilter.IsActive() && !m.searchHighlight {
         m.filterChain.Add(m.searchFilter)
     }
 
@@ -270,15 +453,48 @@ func (m *HARViewModel) applyFilters() {
         m.filterChain.Add(m.fileTypeFilter)
     }
 
-    // future filters added here
-    // if m.methodFilter.IsActive() { m.filterChain.Add(m.methodFilter) }
+    if m.methodFilter.IsActive() {
+        m.filterChain.Add(m.methodFilter)
+    }
+
+    if m.statusFilter.IsActive() {
+        m.filterChain.Add(m.statusFilter)
+    }
+
+    if m.timeFilter.IsActive() {
+        m.filterChain.Add(m.timeFilter)
+    }
+
+    if m.sizeFilter.IsActive() {
+        m.filterChain.Add(m.sizeFilter)
+    }
+
+    if m.endpointFilter.IsActive() {
+        m.filterChain.Add(m.endpointFilter)
+    }
 
     filteredRows, indices := m.filterChain.BuildFilteredRows(m.allEntries, m.rows)
+    if m.searchFilter.IsActive() && m.searchHighlight {
+        filteredRows = m.markSearchMatches(filteredRows, indices)
+    }
+    filteredRows, indices = m.tableSort.Apply(m.allEntries, filteredRows, indices)
+    m.pageHeaders = nil
+    if m.groupByPage {
+        var pages []model.Page
+        if m.index != nil {
+            pages = m.index.Pages
+        }
+        filteredRows, indices, m.pageHeaders = groupRowsByPage(pages, m.allEntries, filteredRows, indices, m.collapsedPages, m.columnPreset, m.width)
+    }
     m.table.SetRows(filteredRows)
     m.filteredIndices = indices
 
     // invalidate colorized table cache when filters change
     m.cachedColorizedTable = ""
+
+    if m.activeModal == ModalStats {
+        m.filterStats = m.filteredStats()
+    }
 }
 
 func (m *HARViewModel) Init() tea.Cmd {
@@ -300,27 +516,62 @@ func (m *HARViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
     }
 
     switch msg := msg.(type) {
+    case statusMessageMsg:
+        m.statusMessage = msg.text
+        m.statusMessageID++
+        id := m.statusMessageID
+        return m, tea.Tick(statusMessageDuration, func(time.Time) tea.Msg {
+            return clearStatusMessageMsg{id: id}
+        })
+
+    case clearStatusMessageMsg:
+        if msg.id == m.statusMessageID {
+            m.statusMessage = ""
+        }
+        return m, nil
+
     case indexCompleteMsg:
         m.loadState = LoadStateLoaded
         m.index = msg.index
         m.streamer = msg.streamer
         m.allEntries = msg.index.Entries
         m.indexingTime = msg.duration
+        m.slowestEntry = slowestDuration(m.allEntries)
 
-        // initialize reader and searcher
-        reader, err := motor.NewEntryReader(m.fileName, msg.index)
-        if err != nil {
+        if err := m.openReader(msg.index, msg.streamer); err != nil {
             m.err = err
             m.loadState = LoadStateError
             return m, nil
         }
-        m.reader = reader
-        m.searcher = motor.NewSearcher(msg.streamer, reader)
+
+        if m.injectedTerms != nil {
+            m.injections = NewInjectionReport(m.injectedTerms, len(m.allEntries))
+        }
 
         if m.width > 0 && m.height > 0 {
             m.initializeTable()
             m.ready = true
         }
+        return m, m.scheduleFollow()
+
+    case followTickMsg:
+        return m, m.checkFollowed()
+
+    case followResultMsg:
+        return m, m.applyFollowResult(msg)
+
+    case exportProgressMsg:
+        m.exportProgress = msg.progress
+        return m, m.listenForExportProgress()
+
+    case exportCompleteMsg:
+        return m, m.finishExport(msg)
+
+    case bodySavedMsg:
+        return m, m.finishBodySave(msg)
+
+    case filteredJSONSavedMsg:
+        m.finishFilteredJSONSave(msg)
         return m, nil
 
     case indexErrorMsg:
@@ -329,12 +580,22 @@ func (m *HARViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
         return m, nil
 
     case indexProgressMsg:
+        var animate tea.Cmd
         if msg.totalBytes > 0 {
             m.indexingPercent = float64(msg.bytesRead) / float64(msg.totalBytes)
+            animate = m.progressBar.SetPercent(m.indexingPercent)
         }
         m.indexingEntries = msg.entriesSoFar
+        m.indexingBytes = msg.bytesRead
+        m.indexingTotal = msg.totalBytes
         // continue listening for more progress (recursive command pattern)
-        return m, m.listenForProgress()
+        return m, tea.Batch(m.listenForProgress(), animate)
+
+    case progress.FrameMsg:
+        // the bar eases towards the latest percentage instead of jumping between updates
+        var cmd tea.Cmd
+        m.progressBar, cmd = m.progressBar.Update(msg)
+        return m, cmd
 
     case searchDebounceMsg:
         // only execute if this is the latest debounce (not stale)
@@ -367,6 +628,8 @@ func (m *HARViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
             if m.viewMode == ViewModeTableWithSearch {
                 // User cleared the search input while in search mode
                 m.searchQuery = ""
+                m.searchStats = motor.SearchStats{}
+                m.searchPatternErr = nil
                 m.searchFilter.Clear()
                 m.applyFilters()
             }
@@ -374,8 +637,6 @@ func (m *HARViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
             return m, nil
         }
 
-        m.searchQuery = query  // Store the active search query
-        m.isSearching = true
         // DON'T clear the filter yet - wait for results
         // This prevents the filter from becoming inactive between searches
         return m, tea.Batch(m.executeSearch(), m.searchSpinner.Tick)
@@ -385,6 +646,8 @@ func (m *HARViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
         // This ensures we always show results from the latest search
         m.searchFilter.ClearMatches()  // Just clear matches, keep filter active
         m.searchFilter.SetSearched(true)
+        m.searchTruncated = msg.truncated
+        m.searchStats = msg.stats
         for _, result := range msg.matches {
             if result.Error == nil {
                 m.searchFilter.AddMatch(result.Index)
@@ -392,6 +655,9 @@ func (m *HARViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
         }
         m.isSearching = false
         m.applyFilters()
+        if len(msg.matches) > 0 && msg.matches[0].Error == nil {
+            m.selectEntry(msg.matches[0].Index) // start on the most relevant match
+        }
         return m, nil
 
     case searchCompleteMsg:
@@ -401,8 +667,9 @@ func (m *HARViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
         return m, nil
 
     case searchErrorMsg:
+        // the search never started, so whatever it would have replaced is still on screen
         m.isSearching = false
-        m.err = msg.err
+        m.searchPatternErr = msg.err
         return m, nil
 
     case tea.WindowSizeMsg:
@@ -419,10 +686,24 @@ func (m *HARViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
         if m.viewMode == ViewModeTableWithSplit {
             m.updateViewportDimensions()
         }
+        if m.viewMode == ViewModeEndpoints {
+            m.updateEndpointTable()
+        }
 
     case tea.KeyPressMsg:
         key := msg.String()
 
+        // the export path prompt takes every key while open
+        if m.exportPrompt && key != "ctrl+c" {
+            return m, m.handleExportPromptKey(msg)
+        }
+        if m.bodySavePrompt && key != "ctrl+c" {
+            return m, m.handleBodySavePromptKey(msg)
+        }
+        if m.jumpPrompt && key != "ctrl+c" {
+            return m, m.handleJumpPromptKey(msg)
+        }
+
         // modal keys have priority (check detail modal first, then filter modal, then viewport search)
         if handled, cmd := m.handleDetailModalKeys(key); handled {
             return m, cmd
@@ -430,6 +711,30 @@ func (m *HARViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
         if handled, cmd := m.handleFilterModalKeys(key); handled {
             return m, cmd
         }
+        if handled, cmd := m.handleMethodModalKeys(key); handled {
+            return m, cmd
+        }
+        if handled, cmd := m.handleStatusModalKeys(key); handled {
+            return m, cmd
+        }
+        if handled, cmd := m.handleTimeModalKeys(msg); handled {
+            return m, cmd
+        }
+        if handled, cmd := m.handleSizeModalKeys(msg); handled {
+            return m, cmd
+        }
+        if handled, cmd := m.handleStatsModalKeys(key); handled {
+            return m, cmd
+        }
+        if handled, cmd := m.handleEntryDiffModalKeys(key); handled {
+            return m, cmd
+        }
+        if handled, cmd := m.handleEndpointsKeys(msg); handled {
+            return m, cmd
+        }
+        if handled, cmd := m.handleSearchSettingsKeys(msg); handled {
+            return m, cmd
+        }
 
         switch key {
         case "ctrl+c":
@@ -464,7 +769,10 @@ func (m *HARViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
         case "enter", "return":
             if m.loadState == LoadStateLoaded {
-                if m.viewMode == ViewModeTable || m.viewMode == ViewModeTableFiltered {
+                if id, ok := m.selectedPageHeader(); ok && (m.viewMode == ViewModeTable || m.viewMode == ViewModeTableFiltered) {
+                    // Enter on a page header collapses or expands the page
+                    m.togglePageCollapsed(id)
+                } else if m.viewMode == ViewModeTable || m.viewMode == ViewModeTableFiltered {
                     // In table or filtered mode, Enter opens split view
                     m.toggleSplitView()
                     if m.viewMode == ViewModeTableWithSplit {
@@ -475,6 +783,7 @@ func (m *HARViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
                 } else if m.viewMode == ViewModeTableWithSearch {
                     if m.searchCursor == searchCursorInput {
                         // In search mode on input, Enter triggers search immediately
+                        m.recordSearchHistory()
                         m.debounceID++ // invalidate any pending debounce timers
                         return m, func() tea.Msg { return searchStartMsg{} }
                     } else {
@@ -494,6 +803,7 @@ func (m *HARViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
                         m.detailViewport.GotoTop() // reset scroll when opening
                         m.detailSearchState.Clear() // clear any previous search
                     }
+                    m.highlightInjectedTerm()
                 }
             }
             return m, nil
@@ -502,11 +812,16 @@ func (m *HARViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
             if m.loadState == LoadStateLoaded {
                 if m.viewMode == ViewModeTableWithSearch {
                     // first Esc: close search panel, keep filters (go to Filtered mode)
+                    m.recordSearchHistory()
                     m.viewMode = ViewModeTableFiltered
                     m.updateTableDimensions()
                     m.debounceID++ // cancel pending timers
                     m.searchInput.Blur()
                     return m, nil
+                } else if m.viewMode == ViewModeTableFiltered && m.endpointFilter.IsActive() {
+                    // Esc in an endpoint's entries: back to the endpoints view
+                    m.leaveEndpoint()
+                    return m, nil
                 } else if m.viewMode == ViewModeTableFiltered {
                     // second Esc: clear filters, return to full table
                     m.viewMode = ViewModeTable
@@ -519,9 +834,13 @@ func (m *HARViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
                     m.applyFilters()
                     m.updateTableDimensions()  // Update table height when returning to normal view
                     return m, nil
+                } else if m.viewMode == ViewModeTableWithSplit && m.panelFullscreen {
+                    // Esc in fullscreen panel: restore the split view first
+                    m.togglePanelFullscreen()
+                    return m, nil
                 } else if m.viewMode == ViewModeTableWithSplit {
                     // Esc in split view: return to filtered or table based on active filters
-                    if m.searchFilter.IsActive() {
+                    if m.searchFilter.IsActive() || m.endpointFilter.IsActive() {
                         m.viewMode = ViewModeTableFiltered
                     } else {
                         m.viewMode = ViewModeTable
@@ -532,6 +851,93 @@ func (m *HARViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
             }
             return m, nil
 
+        case "c":
+            // copy the selected request as a curl command (not in search mode - 'c' is typed there)
+            if m.loadState == LoadStateLoaded && (m.viewMode == ViewModeTable || m.viewMode == ViewModeTableWithSplit || m.viewMode == ViewModeTableFiltered) {
+                return m, m.copySelectedAsCurl()
+            }
+
+        case "y", "Y":
+            // copy the selected URL, or with shift the whole row as TSV, from the index without loading the entry
+            if m.loadState == LoadStateLoaded && (m.viewMode == ViewModeTable || m.viewMode == ViewModeTableWithSplit || m.viewMode == ViewModeTableFiltered) {
+                if key == "Y" {
+                    return m, m.copySelectedRow()
+                }
+                return m, m.copySelectedURL()
+            }
+
+        case "H":
+            // filter the table down to search matches, or mark them in place among every entry
+            if m.loadState == LoadStateLoaded && (m.viewMode == ViewModeTable || m.viewMode == ViewModeTableFiltered) {
+                return m, m.toggleSearchHighlight()
+            }
+
+        case "n", "N":
+            // step between highlighted matches (not in search mode - typed there)
+            if m.loadState == LoadStateLoaded && m.searchHighlight && m.searchFilter.IsActive() &&
+                (m.viewMode == ViewModeTable || m.viewMode == ViewModeTableFiltered) {
+                return m, m.jumpToSearchMatch(key == "n")
+            }
+
+        case "W":
+            // save the selected response body to a file from the split view
+            if m.loadState == LoadStateLoaded && m.viewMode == ViewModeTableWithSplit {
+                return m, m.openBodySavePrompt()
+            }
+
+        case "w":
+            // write the filtered entries (or every entry) to a new har (not in search mode - typed there)
+            if m.loadState == LoadStateLoaded && (m.viewMode == ViewModeTable || m.viewMode == ViewModeTableFiltered) {
+                return m, m.openExportPrompt()
+            }
+
+        case "o", "O":
+            // o cycles the sort column, O flips the direction (not in search mode - typed there)
+            if m.loadState == LoadStateLoaded && (m.viewMode == ViewModeTable || m.viewMode == ViewModeTableFiltered) {
+                if key == "o" {
+                    m.tableSort = m.tableSort.Next()
+                } else {
+                    m.tableSort.Descending = !m.tableSort.Descending
+                }
+                m.applySort()
+                return m, nil
+            }
+
+        case ":":
+            // jump to an entry by index (not in search mode - typed there)
+            if m.loadState == LoadStateLoaded && (m.viewMode == ViewModeTable || m.viewMode == ViewModeTableFiltered) {
+                return m, m.openJumpPrompt()
+            }
+
+        case "C":
+            // cycle the optional table columns (not in search mode - typed there)
+            if m.loadState == LoadStateLoaded && (m.viewMode == ViewModeTable || m.viewMode == ViewModeTableFiltered) {
+                return m, m.cycleColumnPreset()
+            }
+
+        case "p":
+            // group the table by page (not in search mode - typed there)
+            if m.loadState == LoadStateLoaded && (m.viewMode == ViewModeTable || m.viewMode == ViewModeTableFiltered) {
+                if !m.groupByPage && !m.hasPages() {
+                    return m, showStatusMessage("No pages in this HAR")
+                }
+                m.toggleGroupByPage()
+                return m, nil
+            }
+
+        case "u":
+            // group the filtered entries by endpoint (not in search mode - typed there)
+            if m.loadState == LoadStateLoaded && (m.viewMode == ViewModeTable || m.viewMode == ViewModeTableFiltered) && m.activeModal == ModalNone {
+                return m, m.openEndpointsView()
+            }
+
+        case "z":
+            // zoom the focused split panel to fullscreen (and back)
+            if m.loadState == LoadStateLoaded && m.viewMode == ViewModeTableWithSplit {
+                m.togglePanelFullscreen()
+                return m, nil
+            }
+
         case "tab":
             if m.loadState == LoadStateLoaded {
                 if m.viewMode == ViewModeTableWithSplit {
@@ -549,6 +955,10 @@ func (m *HARViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
         case "up":
             if m.loadState == LoadStateLoaded && m.viewMode == ViewModeTableWithSearch {
+                // on the input, up steps back through past queries before reaching the checkboxes
+                if cmd, ok := m.recallSearchHistory(true); ok {
+                    return m, cmd
+                }
                 m.searchCursor--
                 if m.searchCursor < 0 {
                     m.searchCursor = searchCursorCount - 1
@@ -563,6 +973,9 @@ func (m *HARViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
         case "down":
             if m.loadState == LoadStateLoaded && m.viewMode == ViewModeTableWithSearch {
+                if cmd, ok := m.recallSearchHistory(false); ok {
+                    return m, cmd
+                }
                 m.searchCursor = (m.searchCursor + 1) % searchCursorCount
                 if m.searchCursor == searchCursorInput {
                     return m, m.searchInput.Focus()
@@ -588,6 +1001,60 @@ func (m *HARViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
             }
             // in search mode, let 'f' fall through to input
 
+        case "m":
+            // method filter modal: blocked in search mode (would type 'm' in input)
+            if m.loadState == LoadStateLoaded && m.viewMode != ViewModeTableWithSearch {
+                m.activeModal = ModalMethodFilter
+                m.methodCursor = 0
+                return m, nil
+            }
+
+        case "e":
+            // status class filter modal: blocked in search mode (would type 'e' in input)
+            if m.loadState == LoadStateLoaded && m.viewMode != ViewModeTableWithSearch {
+                m.activeModal = ModalStatusFilter
+                m.statusCursor = 0
+                return m, nil
+            }
+
+        case "t":
+            // time range filter modal: blocked in search mode (would type 't' in input)
+            if m.loadState == LoadStateLoaded && m.viewMode != ViewModeTableWithSearch {
+                return m, m.openTimeModal()
+            }
+
+        case "ctrl+o":
+            // search settings modal, from the search view so the next search picks them up
+            if m.loadState == LoadStateLoaded && m.viewMode == ViewModeTableWithSearch {
+                return m, m.openSearchSettings()
+            }
+
+        case "S":
+            // response size range filter modal: blocked in search mode (would type 'S' in input)
+            if m.loadState == LoadStateLoaded && m.viewMode != ViewModeTableWithSearch {
+                return m, m.openSizeModal()
+            }
+
+        case "i":
+            // stats modal for the filtered entries: blocked in search mode (would type 'i' in input)
+            if m.loadState == LoadStateLoaded && m.viewMode != ViewModeTableWithSearch {
+                m.openStatsModal()
+                return m, nil
+            }
+
+        case "b":
+            // mark the selected entry as the diff baseline: blocked in search mode (would type 'b' in input)
+            if m.loadState == LoadStateLoaded && m.viewMode != ViewModeTableWithSearch && m.activeModal == ModalNone {
+                return m, m.markDiffBaseline()
+            }
+
+        case "d":
+            // compare the selected entry against the baseline: blocked in search mode (would type 'd' in input)
+            // and in the detail modal, where d decodes the body
+            if m.loadState == LoadStateLoaded && m.viewMode != ViewModeTableWithSearch && m.activeModal == ModalNone {
+                return m, m.openEntryDiff()
+            }
+
         case "F": // Shift+F
             // Shift+F opens filter modal from ANY mode (including search)
             if m.loadState == LoadStateLoaded {
@@ -696,6 +1163,29 @@ func (m *HARViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
     return m, tea.Batch(cmds...)
 }
 
+// externalBodySource is implemented by streamers that resolve response bodies stored in files of
+// their own, such as motor.DefaultHARStreamer
+type externalBodySource interface {
+    ExternalBodies() *motor.ExternalBodies
+}
+
+// openReader creates the reader and searcher over index
+func (m *HARViewModel) openReader(index *motor.Index, streamer motor.HARStreamer) error {
+    reader, err := motor.NewEntryReader(index.FilePath, index) // the copy actually indexed, for gzip or piped input
+    if err == nil && m.maxEntrySize > 0 {
+        err = reader.SetMaxEntrySize(m.maxEntrySize)
+    }
+    if err != nil {
+        return err
+    }
+    if source, ok := streamer.(externalBodySource); ok {
+        reader.SetExternalBodies(source.ExternalBodies())
+    }
+    m.reader = reader
+    m.searcher = motor.NewSearcher(streamer, reader)
+    return nil
+}
+
 func (m *HARViewModel) View() string {
     if m.quitting {
         return ""
@@ -728,8 +1218,10 @@ func (m *HARViewModel) View() string {
         modal := m.renderActiveModal()
         if modal != "" {
             var x, y int
-            if m.activeModal == ModalRequestFull || m.activeModal == ModalResponseFull {
+            if m.activeModal == ModalRequestFull || m.activeModal == ModalResponseFull || m.activeModal == ModalEntryDiff {
                 x, y = m.calculateDetailModalPosition()
+            } else if m.activeModal == ModalStats {
+                x, y = m.calculateStatsModalPosition(modal)
             } else {
                 x, y = m.calculateModalPosition()
             }
@@ -826,6 +1318,11 @@ func (m *HARViewModel) calculateTableHeight() int {
 }
 
 func (m *HARViewModel) calculatePanelDimensions() (panelWidth, panelHeight int) {
+    if m.panelFullscreen {
+        // only the title (2 rows) and status bar (1 row) remain
+        return m.width, m.height - 3
+    }
+
     panelWidth = m.width / 2
     // Panel height matches table height in split view
     panelHeight = (m.height - 5) / 2
@@ -857,6 +1354,7 @@ func (m *HARViewModel) updateViewportDimensions() {
 }
 
 func (m *HARViewModel) toggleSplitView() {
+    m.panelFullscreen = false
     if m.viewMode == ViewModeTable || m.viewMode == ViewModeTableFiltered {
         m.viewMode = ViewModeTableWithSplit
         m.focusedViewport = ViewportFocusRequest // Reset focus to request when opening
@@ -873,6 +1371,19 @@ func (m *HARViewModel) toggleSplitView() {
     }
 }
 
+// togglePanelFullscreen expands the focused split panel to fill the screen, or restores the split
+func (m *HARViewModel) togglePanelFullscreen() {
+    if m.viewMode != ViewModeTableWithSplit {
+        return
+    }
+
+    m.panelFullscreen = !m.panelFullscreen
+    m.updateViewportDimensions()
+
+    // re-render so content is truncated to the new panel width
+    m.updateViewportContent()
+}
+
 func (m *HARViewModel) toggleViewportFocus() {
     if m.focusedViewport == ViewportFocusRequest {
         m.focusedViewport = ViewportFocusResponse
@@ -928,8 +1439,22 @@ func (m *HARViewModel) renderActiveModal() string {
     switch m.activeModal {
     case ModalFileTypeFilter:
         return m.renderFilterModal()
+    case ModalMethodFilter:
+        return m.renderMethodFilterModal()
+    case ModalStatusFilter:
+        return m.renderStatusFilterModal()
+    case ModalTimeRange:
+        return m.renderTimeRangeModal()
+    case ModalSizeRange:
+        return m.renderSizeRangeModal()
     case ModalRequestFull, ModalResponseFull:
         return m.renderDetailModal()
+    case ModalStats:
+        return m.renderStatsModal()
+    case ModalEntryDiff:
+        return m.renderEntryDiffModal()
+    case ModalSearchSettings:
+        return m.renderSearchSettingsModal()
     default:
         return ""
     }
@@ -943,24 +1468,109 @@ func (m *HARViewModel) toggleSearchView() tea.Cmd {
     m.updateTableDimensions()
     // cache the colorized table to avoid re-rendering on every keystroke
     tableView := m.table.View()
-    m.cachedColorizedTable = ColorizeHARTableOutput(tableView, m.table.Cursor(), m.rows)
+    m.cachedColorizedTable = ColorizeHARTableOutput(tableView, m.table.Cursor(), m.table.Rows())
     m.cachedTableCursor = m.table.Cursor()
     // reset search state and focus input
     m.searchCursor = searchCursorInput
     m.searchQuery = ""
-    m.searchOptions = [4]bool{false, false, false, true} // Live Search ON by default
+    m.searchPatternErr = nil
+    m.searchOptions = [9]bool{false, false, false, !m.liveSearchOff, false, false, false, false, true} // Live Search and Request Bodies ON by default
     m.searchInput.SetValue("")
+    if m.searchHistory != nil {
+        m.searchHistory.Reset()
+    }
     return m.searchInput.Focus()
 }
 
-func (m *HARViewModel) loadSelectedEntry() error {
-    // Get the actual entry index, accounting for filtering
-    actualIndex := m.selectedIndex
+// recordSearchHistory remembers the query in the search input
+func (m *HARViewModel) recordSearchHistory() {
+    if m.searchHistory != nil {
+        m.searchHistory.Add(m.searchInput.Value())
+    }
+}
+
+// recallSearchHistory replaces the search input with an older (or newer) past query when the
+// input is focused. ok is false when there is no such query, leaving up/down to the checkboxes.
+func (m *HARViewModel) recallSearchHistory(older bool) (tea.Cmd, bool) {
+    if m.searchHistory == nil || m.searchCursor != searchCursorInput {
+        return nil, false
+    }
+
+    var query string
+    var ok bool
+    if older {
+        query, ok = m.searchHistory.Previous(m.searchInput.Value())
+    } else {
+        query, ok = m.searchHistory.Next()
+    }
+    if !ok {
+        return nil, false
+    }
+
+    m.searchInput.SetValue(query)
+    m.searchInput.CursorEnd()
+    if m.searchOptions[3] {
+        // live search runs the recalled query as if it had been typed
+        return m.startDebounceTimer(), true
+    }
+    return nil, true
+}
+
+// selectedEntryIndex maps the selected table row to the original entry index, accounting for filtering
+func (m *HARViewModel) selectedEntryIndex() int {
     if len(m.filteredIndices) > 0 && m.selectedIndex < len(m.filteredIndices) {
-        actualIndex = m.filteredIndices[m.selectedIndex]
+        return m.filteredIndices[m.selectedIndex]
     }
+    return m.selectedIndex
+}
+
+// applySort re-orders the table for the current sort while keeping the selected entry selected
+func (m *HARViewModel) applySort() {
+    selected := m.selectedEntryIndex()
+    selectedPage, onHeader := m.selectedPageHeader()
 
-    if actualIndex >= len(m.allEntries) {
+    m.applyFilters()
+
+    m.adjustColumnWidths()
+
+    if onHeader {
+        m.selectPageHeader(selectedPage)
+        return
+    }
+    m.selectEntry(selected)
+}
+
+// selectEntry moves the cursor to the row showing entryIndex, if it is visible
+func (m *HARViewModel) selectEntry(entryIndex int) {
+    for row, index := range m.filteredIndices {
+        if index == entryIndex {
+            m.table.SetCursor(row)
+            m.selectedIndex = row
+            return
+        }
+    }
+}
+
+// copySelectedAsCurl copies the selected entry's request to the clipboard as a curl command
+func (m *HARViewModel) copySelectedAsCurl() tea.Cmd {
+    actualIndex := m.selectedEntryIndex()
+    if actualIndex < 0 || actualIndex >= len(m.allEntries) {
+        return nil
+    }
+
+    entry, err := m.streamer.GetEntry(context.Background(), actualIndex)
+    if err != nil {
+        return showStatusMessage(fmt.Sprintf("copy failed: %v", err))
+    }
+
+    return copyToClipboard(BuildCurlCommand(&entry.Request), "Copied request as curl")
+}
+
+func (m *HARViewModel) loadSelectedEntry() error {
+    // Get the actual entry index, accounting for filtering
+    actualIndex := m.selectedEntryIndex()
+
+    if actualIndex < 0 || actualIndex >= len(m.allEntries) {
         return nil
     }
 
@@ -979,19 +1589,116 @@ func (m *HARViewModel) loadSelectedEntry() error {
     return nil
 }
 
+// adjustColumnWidths lays out the preset's columns for the terminal width, titled for the sort
 func (m *HARViewModel) adjustColumnWidths() {
-    urlWidth := m.width - methodColumnWidth - statusColumnWidth - sizeColumnWidth - durationColumnWidth - borderPadding
-    if urlWidth < minURLColumnWidth {
-        urlWidth = minURLColumnWidth
+    m.columns = m.columnPreset.columns(m.width, m.tableSort)
+    m.table.SetColumns(m.columns)
+}
+
+// cycleColumnPreset switches the table to the next column preset, keeping the selection
+func (m *HARViewModel) cycleColumnPreset() tea.Cmd {
+    selected := m.selectedEntryIndex()
+    selectedPage, onHeader := m.selectedPageHeader()
+
+    m.columnPreset = m.columnPreset.Next()
+    if m.viewSettings != nil {
+        m.viewSettings.Columns = m.columnPreset
     }
 
-    m.columns[0].Width = methodColumnWidth
-    m.columns[1].Width = urlWidth
-    m.columns[2].Width = statusColumnWidth
-    m.columns[3].Width = sizeColumnWidth
-    m.columns[4].Width = durationColumnWidth
+    // rows wider than the new columns can't be rendered, so drop them before the columns change
+    m.table.SetRows(nil)
+    m.adjustColumnWidths()
+    m.buildTableRows()
+    m.applyFilters()
 
-    m.table.SetColumns(m.columns)
+    if onHeader {
+        m.selectPageHeader(selectedPage)
+    } else {
+        m.selectEntry(selected)
+    }
+    return showStatusMessage("Columns: " + m.columnPreset.String())
+}
+
+// SetViewSettings replaces the view settings, e.g. with ones loaded from DefaultViewSettingsPath,
+// and shows the columns they name; call before Init
+func (m *HARViewModel) SetViewSettings(settings *ViewSettings) {
+    m.viewSettings = settings
+    if settings != nil {
+        m.columnPreset = settings.Columns
+        m.columns = m.columnPreset.columns(m.width, m.tableSort)
+        m.searchDebounce = debounceDelay(settings.SearchDebounceMS, defaultSearchDebounceMS)
+        m.detailDebounce = debounceDelay(settings.DetailDebounceMS, defaultDetailDebounceMS)
+        m.liveSearchOff = settings.DisableLiveSearch
+        m.searchHighlight = settings.HighlightSearch
+    }
+}
+
+// SetSearchDebounce overrides the live search delay of the view settings for this session
+func (m *HARViewModel) SetSearchDebounce(delay time.Duration) {
+    m.searchDebounce = delay
+}
+
+// debounceDelay is a delay from the view settings, fallback when it is not set
+func debounceDelay(ms, fallback int) time.Duration {
+    if ms <= 0 {
+        ms = fallback
+    }
+    return time.Duration(ms) * time.Millisecond
+}
+
+// SetSearchHistory replaces the search history, e.g. with one loaded from DefaultSearchHistoryPath
+func (m *HARViewModel) SetSearchHistory(history *SearchHistory) {
+    m.searchHistory = history
+}
+
+// SearchHistory returns the search history, including queries from this session
+func (m *HARViewModel) SearchHistory() *SearchHistory {
+    return m.searchHistory
+}
+
+// SetWebSocketSupport enables indexing and searching of `_webSocketMessages` frames; call before Init
+func (m *HARViewModel) SetWebSocketSupport(enabled bool) {
+    m.webSockets = enabled
+}
+
+// SetDecodeBodies shows and searches base64 encoded (and gzip compressed) response bodies as decoded text
+func (m *HARViewModel) SetDecodeBodies(enabled bool) {
+    m.decodeBodies = enabled
+}
+
+// SetMaxEntrySize raises the size cap for a single entry, in bytes, above the 100MB default; call before Init
+func (m *HARViewModel) SetMaxEntrySize(size int64) {
+    m.maxEntrySize = size
+}
+
+// SetFollow watches the file for entries appended while it is open, adding them to the table as
+// they arrive; call before Init
+func (m *HARViewModel) SetFollow(enabled bool) {
+    m.follow = enabled
+}
+
+// SetEntryWindow indexes at most limit entries (0 = all) starting at position start of the har's
+// entries array, leaving the rest unparsed; call before Init
+func (m *HARViewModel) SetEntryWindow(start, limit int) {
+    m.startEntry = start
+    m.maxEntries = limit
+}
+
+// SetExternalBodyDir reads response bodies the har stores in files of their own, refusing any
+// outside dir; call before Init
+func (m *HARViewModel) SetExternalBodyDir(dir string) {
+    m.externalBodyDir = dir
+}
+
+// SetSource reads the har from r (e.g. stdin) instead of opening the model's file name, which
+// is then only shown in the title; call before Init
+func (m *HARViewModel) SetSource(r io.Reader) {
+    m.source = r
+}
+
+// EntryCount returns the number of indexed entries (0 if indexing did not complete)
+func (m *HARViewModel) EntryCount() int {
+    return len(m.allEntries)
 }
 
 // Cleanup releases resources when the model is destroyed
@@ -0,0 +1,197 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/v2/table"
+	"github.com/pb33f/harific/motor"
+	"github.com/pb33f/harific/motor/model"
+)
+
+// pageHeaderIndex marks a table row as a page header rather than an entry
+const pageHeaderIndex = -1
+
+// noPageTitle labels the group of entries without a pageref
+const noPageTitle = "No page"
+
+// pageGroup is one page's slice of the (filtered, sorted) table rows
+type pageGroup struct {
+	id      string
+	rows    []table.Row
+	indices []int
+}
+
+// groupRowsByPage segments rows under a header row per page, in log.pages order, followed by
+// pagerefs missing from log.pages and then entries with no page. rows keep their order within a
+// page, so sorting applies per page. collapsed pages keep their header but hide their entries.
+// it returns the new rows and indices, with pageHeaderIndex for headers, and the page id of
+// each header row.
+func groupRowsByPage(pages []model.Page, allEntries []*motor.EntryMetadata, rows []table.Row, indices []int,
+	collapsed map[string]bool, preset ColumnPreset, terminalWidth int) ([]table.Row, []int, map[int]string) {
+
+	groups := make(map[string]*pageGroup)
+	var order []string
+	for _, page := range pages {
+		if _, ok := groups[page.ID]; !ok {
+			groups[page.ID] = &pageGroup{id: page.ID}
+			order = append(order, page.ID)
+		}
+	}
+
+	for i, entryIndex := range indices {
+		pageRef := allEntries[entryIndex].PageRef
+		group, ok := groups[pageRef]
+		if !ok {
+			group = &pageGroup{id: pageRef}
+			groups[pageRef] = group
+			if pageRef != "" {
+				order = append(order, pageRef)
+			}
+		}
+		group.rows = append(group.rows, rows[i])
+		group.indices = append(group.indices, entryIndex)
+	}
+	if _, ok := groups[""]; ok {
+		order = append(order, "")
+	}
+
+	groupedRows := make([]table.Row, 0, len(rows)+len(order))
+	groupedIndices := make([]int, 0, len(rows)+len(order))
+	headers := make(map[int]string)
+	for _, id := range order {
+		group := groups[id]
+		if len(group.indices) == 0 {
+			continue // every entry of this page was filtered out
+		}
+
+		headers[len(groupedRows)] = id
+		groupedRows = append(groupedRows, pageHeaderRow(pages, group, collapsed[id], preset, terminalWidth))
+		groupedIndices = append(groupedIndices, pageHeaderIndex)
+		if !collapsed[id] {
+			groupedRows = append(groupedRows, group.rows...)
+			groupedIndices = append(groupedIndices, group.indices...)
+		}
+	}
+	return groupedRows, groupedIndices, headers
+}
+
+// pageHeaderRow renders a page header: title and entry count in the url column, and the
+// onContentLoad / onLoad timings in the size and duration columns
+func pageHeaderRow(pages []model.Page, group *pageGroup, collapsed bool, preset ColumnPreset, terminalWidth int) table.Row {
+	marker := "▾ PAGE"
+	if collapsed {
+		marker = "▸ PAGE"
+	}
+
+	title := group.id
+	var timings model.PageTiming
+	if group.id == "" {
+		title = noPageTitle
+	}
+	for _, page := range pages {
+		if page.ID == group.id {
+			if page.Title != "" {
+				title = page.Title
+			}
+			timings = page.PageTimings
+			break
+		}
+	}
+
+	label := fmt.Sprintf("%s (%d entries)", title, len(group.indices))
+	availableWidth := preset.urlTextWidth(terminalWidth)
+	if len(label) > availableWidth {
+		label = label[:availableWidth-3] + "..."
+	}
+
+	var contentLoad, load string
+	if timings.OnContentLoad > 0 {
+		contentLoad = "DCL " + formatDuration(timings.OnContentLoad)
+	}
+	if timings.OnLoad > 0 {
+		load = "Load " + formatDuration(timings.OnLoad)
+	}
+
+	specs := preset.specs()
+	row := make(table.Row, len(specs))
+	for i, spec := range specs {
+		switch spec.sort {
+		case SortMethod:
+			row[i] = marker
+		case SortURL:
+			row[i] = label
+		case SortSize:
+			row[i] = contentLoad
+		case SortDuration:
+			row[i] = load
+		}
+	}
+	return row
+}
+
+// hasPages returns true if the har declares pages or any entry references one
+func (m *HARViewModel) hasPages() bool {
+	if m.index != nil && len(m.index.Pages) > 0 {
+		return true
+	}
+	for _, entry := range m.allEntries {
+		if entry.PageRef != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// selectedPageHeader returns the page id of the selected row if it is a page header
+func (m *HARViewModel) selectedPageHeader() (string, bool) {
+	if m.selectedIndex < 0 || m.selectedIndex >= len(m.filteredIndices) || m.filteredIndices[m.selectedIndex] != pageHeaderIndex {
+		return "", false
+	}
+	id, ok := m.pageHeaders[m.selectedIndex]
+	return id, ok
+}
+
+// pageTitle returns the display title of a page id
+func (m *HARViewModel) pageTitle(id string) string {
+	if id == "" {
+		return noPageTitle
+	}
+	if m.index != nil {
+		if page, ok := m.index.Page(id); ok && page.Title != "" {
+			return page.Title
+		}
+	}
+	return id
+}
+
+// toggleGroupByPage segments the table by page (and back), keeping the selected entry selected
+func (m *HARViewModel) toggleGroupByPage() {
+	m.groupByPage = !m.groupByPage
+	m.applySort()
+}
+
+// togglePageCollapsed hides or shows the entries of the selected page header
+func (m *HARViewModel) togglePageCollapsed(id string) {
+	if m.collapsedPages == nil {
+		m.collapsedPages = make(map[string]bool)
+	}
+	m.collapsedPages[id] = !m.collapsedPages[id]
+	m.applyFilters()
+	m.selectPageHeader(id)
+}
+
+// selectPageHeader moves the cursor to the header row of a page
+func (m *HARViewModel) selectPageHeader(id string) {
+	for row, headerID := range m.pageHeaders {
+		if headerID == id {
+			m.table.SetCursor(row)
+			m.selectedIndex = row
+			return
+		}
+	}
+}
+
+// visibleEntryCount is the number of entry rows in the table, excluding page headers
+func (m *HARViewModel) visibleEntryCount() int {
+	return len(m.filteredIndices) - len(m.pageHeaders)
+}
@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/v2/table"
+	"github.com/pb33f/harific/motor"
+	"github.com/pb33f/harific/motor/model"
+)
+
+func pageTestEntries() ([]*motor.EntryMetadata, []table.Row, []model.Page) {
+	entries := []*motor.EntryMetadata{
+		{Method: "GET", URL: "https://example.com/", PageRef: "page_1"},
+		{Method: "GET", URL: "https://example.com/beacon"},
+		{Method: "GET", URL: "https://example.com/checkout", PageRef: "page_2"},
+		{Method: "GET", URL: "https://example.com/app.js", PageRef: "page_1"},
+		{Method: "GET", URL: "https://example.com/orphan", PageRef: "page_9"},
+	}
+	rows := make([]table.Row, len(entries))
+	for i, entry := range entries {
+		rows[i] = ColumnsDefault.row(entry, 120)
+	}
+	pages := []model.Page{
+		{ID: "page_2", Title: "Checkout", PageTimings: model.PageTiming{OnLoad: 90}},
+		{ID: "page_1", Title: "Home", PageTimings: model.PageTiming{OnContentLoad: 120, OnLoad: 340}},
+		{ID: "page_empty", Title: "Nothing"},
+	}
+	return entries, rows, pages
+}
+
+func TestGroupRowsByPage(t *testing.T) {
+	entries, rows, pages := pageTestEntries()
+
+	groupedRows, indices, headers := groupRowsByPage(pages, entries, rows, []int{0, 1, 2, 3, 4}, nil, ColumnsDefault, 120)
+
+	// log.pages order, then pagerefs missing from log.pages, then entries without a page
+	want := []int{pageHeaderIndex, 2, pageHeaderIndex, 0, 3, pageHeaderIndex, 4, pageHeaderIndex, 1}
+	if !slices.Equal(indices, want) {
+		t.Fatalf("indices = %v, want %v", indices, want)
+	}
+
+	wantHeaders := map[int]string{0: "page_2", 2: "page_1", 5: "page_9", 7: ""}
+	for row, id := range wantHeaders {
+		if headers[row] != id {
+			t.Errorf("header at row %d = %q, want %q", row, headers[row], id)
+		}
+	}
+	if len(headers) != len(wantHeaders) {
+		t.Errorf("pages without entries should not get a header, got %v", headers)
+	}
+
+	home := groupedRows[2]
+	if !strings.HasPrefix(home[1], "Home (2 entries)") || home[3] != "DCL 120ms" || home[4] != "Load 340ms" {
+		t.Errorf("unexpected page header row %v", home)
+	}
+	if !strings.HasPrefix(groupedRows[7][1], noPageTitle) {
+		t.Errorf("entries without a page should be grouped under %q, got %v", noPageTitle, groupedRows[7])
+	}
+	if groupedRows[3][1] != rows[0][1] {
+		t.Errorf("entry rows should follow their header, got %v", groupedRows[3])
+	}
+}
+
+func TestGroupRowsByPage_CollapsedAndFiltered(t *testing.T) {
+	entries, rows, pages := pageTestEntries()
+
+	// entry 2 (the only checkout entry) is filtered out, page_1 is collapsed
+	groupedRows, indices, _ := groupRowsByPage(pages, entries, []table.Row{rows[3], rows[0], rows[1]}, []int{3, 0, 1},
+		map[string]bool{"page_1": true}, ColumnsDefault, 120)
+
+	want := []int{pageHeaderIndex, pageHeaderIndex, 1}
+	if !slices.Equal(indices, want) {
+		t.Fatalf("indices = %v, want %v", indices, want)
+	}
+	if !strings.HasPrefix(groupedRows[0][0], "▸") || !strings.Contains(groupedRows[0][1], "(2 entries)") {
+		t.Errorf("collapsed header should keep its entry count, got %v", groupedRows[0])
+	}
+}
@@ -0,0 +1,54 @@
+package tui
+
+import "testing"
+
+func TestPanelFullscreenDimensions(t *testing.T) {
+	m := &HARViewModel{
+		width:    120,
+		height:   40,
+		viewMode: ViewModeTableWithSplit,
+	}
+	m.updateViewportDimensions()
+
+	w, h := m.calculatePanelDimensions()
+	if w != 60 || h != 17 {
+		t.Fatalf("split panel dimensions = %dx%d, want 60x17", w, h)
+	}
+	if m.requestViewport.Width() != 58 || m.requestViewport.Height() != 15 {
+		t.Errorf("split viewport = %dx%d, want 58x15", m.requestViewport.Width(), m.requestViewport.Height())
+	}
+
+	m.togglePanelFullscreen()
+	if !m.panelFullscreen {
+		t.Fatal("expected fullscreen panel after toggle")
+	}
+
+	w, h = m.calculatePanelDimensions()
+	if w != 120 || h != 37 {
+		t.Errorf("fullscreen panel dimensions = %dx%d, want 120x37", w, h)
+	}
+	if m.requestViewport.Width() != 118 || m.requestViewport.Height() != 35 {
+		t.Errorf("fullscreen request viewport = %dx%d, want 118x35", m.requestViewport.Width(), m.requestViewport.Height())
+	}
+	if m.responseViewport.Width() != 118 || m.responseViewport.Height() != 35 {
+		t.Errorf("fullscreen response viewport = %dx%d, want 118x35", m.responseViewport.Width(), m.responseViewport.Height())
+	}
+
+	// toggling off restores the split dimensions
+	m.togglePanelFullscreen()
+	if m.panelFullscreen {
+		t.Fatal("expected split view after second toggle")
+	}
+	if m.requestViewport.Width() != 58 || m.requestViewport.Height() != 15 {
+		t.Errorf("restored viewport = %dx%d, want 58x15", m.requestViewport.Width(), m.requestViewport.Height())
+	}
+}
+
+func TestPanelFullscreenOnlyInSplitView(t *testing.T) {
+	m := &HARViewModel{width: 120, height: 40, viewMode: ViewModeTable}
+
+	m.togglePanelFullscreen()
+	if m.panelFullscreen {
+		t.Error("fullscreen panel should only toggle in split view")
+	}
+}
@@ -0,0 +1,143 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultRecentFilesLimit is the number of recently opened files remembered
+const DefaultRecentFilesLimit = 10
+
+// RecentFile is a single entry in the recently opened files list
+type RecentFile struct {
+	Path       string    `json:"path"`
+	Entries    int       `json:"entries"`
+	LastOpened time.Time `json:"lastOpened"`
+	Missing    bool      `json:"-"` // set by CheckExists, never persisted
+}
+
+// RecentFiles tracks recently opened HAR files, most recent first
+type RecentFiles struct {
+	Files []RecentFile `json:"files"`
+	Limit int          `json:"-"`
+}
+
+// NewRecentFiles creates an empty list holding at most limit files (0 = DefaultRecentFilesLimit)
+func NewRecentFiles(limit int) *RecentFiles {
+	if limit <= 0 {
+		limit = DefaultRecentFilesLimit
+	}
+	return &RecentFiles{Limit: limit}
+}
+
+// DefaultRecentFilesPath returns the state file location under the user config directory
+func DefaultRecentFilesPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "harific", "recent.json"), nil
+}
+
+// LoadRecentFiles reads the state file; a missing file yields an empty list
+func LoadRecentFiles(path string, limit int) (*RecentFiles, error) {
+	recent := NewRecentFiles(limit)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return recent, nil
+		}
+		return nil, fmt.Errorf("failed to read recent files: %w", err)
+	}
+
+	if err := json.Unmarshal(data, recent); err != nil {
+		return nil, fmt.Errorf("failed to parse recent files: %w", err)
+	}
+	recent.truncate()
+
+	return recent, nil
+}
+
+// Save writes the list to the state file, creating its directory if needed
+func (r *RecentFiles) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recent files: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write recent files: %w", err)
+	}
+	return nil
+}
+
+// Add records a file as opened, moving it to the front if it is already listed
+func (r *RecentFiles) Add(path string, entries int, openedAt time.Time) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	for i, f := range r.Files {
+		if f.Path == path {
+			r.Files = append(r.Files[:i], r.Files[i+1:]...)
+			break
+		}
+	}
+
+	r.Files = append([]RecentFile{{Path: path, Entries: entries, LastOpened: openedAt}}, r.Files...)
+	r.truncate()
+}
+
+// Remove drops a file from the list, returning true if it was present
+func (r *RecentFiles) Remove(path string) bool {
+	for i, f := range r.Files {
+		if f.Path == path {
+			r.Files = append(r.Files[:i], r.Files[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// CheckExists marks files that no longer exist on disk
+func (r *RecentFiles) CheckExists() {
+	for i := range r.Files {
+		_, err := os.Stat(r.Files[i].Path)
+		r.Files[i].Missing = err != nil
+	}
+}
+
+// Prune removes files that no longer exist on disk, returning how many were removed
+func (r *RecentFiles) Prune() int {
+	r.CheckExists()
+
+	kept := r.Files[:0]
+	for _, f := range r.Files {
+		if !f.Missing {
+			kept = append(kept, f)
+		}
+	}
+
+	removed := len(r.Files) - len(kept)
+	r.Files = kept
+	return removed
+}
+
+func (r *RecentFiles) truncate() {
+	limit := r.Limit
+	if limit <= 0 {
+		limit = DefaultRecentFilesLimit
+	}
+	if len(r.Files) > limit {
+		r.Files = r.Files[:limit]
+	}
+}
@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecentFilesAddDedupe(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.har")
+	b := filepath.Join(dir, "b.har")
+
+	recent := NewRecentFiles(5)
+	now := time.Now()
+	recent.Add(a, 10, now)
+	recent.Add(b, 20, now.Add(time.Second))
+	recent.Add(a, 11, now.Add(2*time.Second))
+
+	if len(recent.Files) != 2 {
+		t.Fatalf("expected 2 files after dedupe, got %d", len(recent.Files))
+	}
+	if recent.Files[0].Path != a || recent.Files[0].Entries != 11 {
+		t.Errorf("expected re-opened file at front with updated count, got %+v", recent.Files[0])
+	}
+	if recent.Files[1].Path != b {
+		t.Errorf("expected b second, got %s", recent.Files[1].Path)
+	}
+}
+
+func TestRecentFilesLimit(t *testing.T) {
+	recent := NewRecentFiles(3)
+	for i := 0; i < 5; i++ {
+		recent.Add(filepath.Join(t.TempDir(), "f.har"), i, time.Now())
+	}
+
+	if len(recent.Files) != 3 {
+		t.Fatalf("expected list truncated to 3, got %d", len(recent.Files))
+	}
+	if recent.Files[0].Entries != 4 {
+		t.Errorf("expected most recent first, got entries=%d", recent.Files[0].Entries)
+	}
+}
+
+func TestRecentFilesPrune(t *testing.T) {
+	dir := t.TempDir()
+	exists := filepath.Join(dir, "exists.har")
+	if err := os.WriteFile(exists, []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	recent := NewRecentFiles(0)
+	recent.Add(filepath.Join(dir, "gone.har"), 1, time.Now())
+	recent.Add(exists, 2, time.Now())
+
+	recent.CheckExists()
+	if recent.Files[0].Missing || !recent.Files[1].Missing {
+		t.Errorf("unexpected missing flags: %+v", recent.Files)
+	}
+
+	if removed := recent.Prune(); removed != 1 {
+		t.Errorf("expected 1 pruned, got %d", removed)
+	}
+	if len(recent.Files) != 1 || recent.Files[0].Path != exists {
+		t.Errorf("expected only existing file to remain, got %+v", recent.Files)
+	}
+}
+
+func TestRecentFilesSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "recent.json")
+
+	// missing state file is not an error
+	recent, err := LoadRecentFiles(path, 0)
+	if err != nil {
+		t.Fatalf("unexpected error loading missing file: %v", err)
+	}
+	if len(recent.Files) != 0 {
+		t.Fatalf("expected empty list, got %d", len(recent.Files))
+	}
+
+	opened := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	recent.Add("/tmp/one.har", 42, opened)
+	if err := recent.Save(path); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	loaded, err := LoadRecentFiles(path, 0)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if len(loaded.Files) != 1 || loaded.Files[0].Entries != 42 || !loaded.Files[0].LastOpened.Equal(opened) {
+		t.Errorf("round trip mismatch: %+v", loaded.Files)
+	}
+}
@@ -0,0 +1,193 @@
+package tui
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/pb33f/harific/motor"
+)
+
+// bodySavedMsg ends a response body save; err is nil on success
+type bodySavedMsg struct {
+	path  string
+	bytes int64
+	err   error
+}
+
+// bodyStreamer is implemented by readers that stream a response body without loading its entry
+type bodyStreamer interface {
+	StreamResponseBody(offset int64) (io.ReadCloser, error)
+}
+
+// bodyExtensions covers the common mime types whose mime.ExtensionsByType answer is not the usual
+// extension (image/jpeg gives .jfif first) or is missing from a minimal system mime table
+var bodyExtensions = map[string]string{
+	"application/json":       ".json",
+	"application/javascript": ".js",
+	"text/javascript":        ".js",
+	"text/html":              ".html",
+	"text/css":               ".css",
+	"text/plain":             ".txt",
+	"application/xml":        ".xml",
+	"text/xml":               ".xml",
+	"image/jpeg":             ".jpg",
+	"image/png":              ".png",
+	"image/gif":              ".gif",
+	"image/webp":             ".webp",
+	"image/svg+xml":          ".svg",
+	"application/pdf":        ".pdf",
+}
+
+// defaultBodyFileName names a saved body after the last segment of its url, adding an extension
+// for the mime type when the segment has none: https://example.com/api/users?page=2 with
+// application/json becomes users.json
+func defaultBodyFileName(rawURL, mimeType string) string {
+	name := ""
+	if u, err := url.Parse(rawURL); err == nil {
+		name = path.Base(u.Path)
+	}
+	name = filepath.Base(name) // nothing that could climb out of the working directory
+	if name == "." || name == "/" || name == ".." || name == "" {
+		name = "response"
+	}
+
+	if path.Ext(name) != "" {
+		return name
+	}
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return name
+	}
+	if ext, ok := bodyExtensions[mediaType]; ok {
+		return name + ext
+	}
+	if exts, err := mime.ExtensionsByType(mediaType); err == nil && len(exts) > 0 {
+		return name + exts[0]
+	}
+	return name
+}
+
+// openBodySavePrompt asks where to write the selected entry's response body
+func (m *HARViewModel) openBodySavePrompt() tea.Cmd {
+	if m.selectedEntry == nil {
+		return showStatusMessage("No entry selected")
+	}
+	body := &m.selectedEntry.Response.Body
+	if body.Content == "" {
+		return showStatusMessage("Response has no body to save")
+	}
+
+	input := textinput.New()
+	input.Prompt = "Save body to: "
+	input.CharLimit = 1024
+	input.SetValue(defaultBodyFileName(m.selectedEntry.Request.URL, body.MIMEType))
+	input.CursorEnd()
+	m.bodySaveInput = input
+	m.bodySaveIndex = m.selectedEntryIndex()
+	m.bodySavePrompt = true
+	return m.bodySaveInput.Focus()
+}
+
+// handleBodySavePromptKey edits the save path; Enter writes the body and Esc cancels
+func (m *HARViewModel) handleBodySavePromptKey(msg tea.KeyPressMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.bodySavePrompt = false
+		m.bodySaveInput.Blur()
+		return nil
+
+	case "enter", "return":
+		path := strings.TrimSpace(m.bodySaveInput.Value())
+		if path == "" {
+			return showStatusMessage("Enter a file name to save the body to")
+		}
+		m.bodySavePrompt = false
+		m.bodySaveInput.Blur()
+		return m.saveResponseBody(path)
+	}
+
+	var cmd tea.Cmd
+	m.bodySaveInput, cmd = m.bodySaveInput.Update(msg)
+	return cmd
+}
+
+// saveResponseBody writes the body of the entry the prompt was opened on in the background. it is
+// streamed from the har file when the reader can, rather than copied out of the loaded entry.
+func (m *HARViewModel) saveResponseBody(path string) tea.Cmd {
+	if m.selectedEntry == nil {
+		return showStatusMessage("No entry selected")
+	}
+	content := m.selectedEntry.Response.Body.Content
+	base64Encoded := m.responseBodyIsEncoded()
+
+	var offset int64 = -1
+	if m.bodySaveIndex >= 0 && m.bodySaveIndex < len(m.allEntries) {
+		offset = m.allEntries[m.bodySaveIndex].FileOffset
+	}
+	streamer, _ := m.reader.(bodyStreamer)
+
+	return func() tea.Msg {
+		var source io.ReadCloser = io.NopCloser(strings.NewReader(content))
+		if streamer != nil && offset >= 0 {
+			stream, err := streamer.StreamResponseBody(offset)
+			if err != nil {
+				return bodySavedMsg{path: path, err: err}
+			}
+			source = stream
+		}
+		defer source.Close()
+
+		written, err := writeBody(path, source, base64Encoded)
+		return bodySavedMsg{path: path, bytes: written, err: err}
+	}
+}
+
+// writeBody copies body to a new file at path, decoding it first when it is stored as base64 so
+// images and other binaries come out usable
+func writeBody(path string, body io.Reader, base64Encoded bool) (int64, error) {
+	if base64Encoded {
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	written, err := io.Copy(file, body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path) // don't leave a truncated body behind
+		return 0, err
+	}
+	return written, nil
+}
+
+// finishBodySave reports the outcome of a body save in the status bar
+func (m *HARViewModel) finishBodySave(msg bodySavedMsg) tea.Cmd {
+	if msg.err != nil {
+		return showStatusMessage(fmt.Sprintf("Save failed: %v", msg.err))
+	}
+	return showStatusMessage(fmt.Sprintf("Saved %s to %s", formatSize(msg.bytes), msg.path))
+}
+
+// renderBodySaveStatus is the prompt line while the body save prompt is open
+func (m *HARViewModel) renderBodySaveStatus() (string, bool) {
+	if !m.bodySavePrompt {
+		return "", false
+	}
+	return m.bodySaveInput.View() + "  (Enter: Save | Esc: Cancel)", true
+}
+
+// ensure the default reader streams bodies; the fallback copies the loaded entry's text
+var _ bodyStreamer = (*motor.DefaultEntryReader)(nil)
@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/harific/motor"
+	"github.com/pb33f/harific/motor/model"
+)
+
+func TestDefaultBodyFileName(t *testing.T) {
+	tests := []struct {
+		url      string
+		mimeType string
+		want     string
+	}{
+		{"https://example.com/api/users?page=2", "application/json; charset=utf-8", "users.json"},
+		{"https://example.com/static/logo.png", "image/png", "logo.png"},
+		{"https://example.com/photos/42", "image/jpeg", "42.jpg"},
+		{"https://example.com/", "text/html", "response.html"},
+		{"https://example.com/files/report%20final", "application/pdf", "report final.pdf"},
+		{"https://example.com/blob", "application/x-unknown-thing", "blob"},
+		{"https://example.com/blob", "", "blob"},
+	}
+
+	for _, tt := range tests {
+		if got := defaultBodyFileName(tt.url, tt.mimeType); got != tt.want {
+			t.Errorf("defaultBodyFileName(%q, %q) = %q, want %q", tt.url, tt.mimeType, got, tt.want)
+		}
+	}
+}
+
+func TestSaveResponseBody(t *testing.T) {
+	image := []byte{0x89, 'P', 'N', 'G', 0x00, 0xff, 0x10}
+	m := &HARViewModel{
+		allEntries: []*motor.EntryMetadata{{URL: "https://example.com/logo"}},
+		selectedEntry: &model.Entry{
+			Request: model.Request{URL: "https://example.com/logo"},
+			Response: model.Response{Body: model.BodyResponseType{
+				MIMEType: "image/png",
+				Encoding: "base64",
+				Content:  base64.StdEncoding.EncodeToString(image),
+			}},
+		},
+	}
+
+	m.openBodySavePrompt()
+	if !m.bodySavePrompt || m.bodySaveInput.Value() != "logo.png" {
+		t.Fatalf("expected the prompt to suggest logo.png, got %q", m.bodySaveInput.Value())
+	}
+
+	// without a streaming reader the loaded entry's text is written, base64 decoded
+	path := filepath.Join(t.TempDir(), "logo.png")
+	msg, ok := m.saveResponseBody(path)().(bodySavedMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("save failed: %#v", msg)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading the saved body: %v", err)
+	}
+	if string(data) != string(image) || msg.bytes != int64(len(image)) {
+		t.Errorf("saved %d bytes %v, want %v", msg.bytes, data, image)
+	}
+
+	bad, _ := m.saveResponseBody(filepath.Join(t.TempDir(), "missing", "dir", "x"))().(bodySavedMsg)
+	if bad.err == nil {
+		t.Errorf("expected an error writing into a missing directory")
+	}
+
+	m.selectedEntry.Response.Body.Content = ""
+	m.bodySavePrompt = false
+	m.openBodySavePrompt()
+	if m.bodySavePrompt {
+		t.Errorf("an empty body should not open the prompt")
+	}
+}
+
+func TestWriteBody_RemovesPartialFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.bin")
+	if _, err := writeBody(path, strings.NewReader("not base64 at all!"), true); err == nil {
+		t.Fatalf("expected invalid base64 to fail")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("a failed save should not leave %s behind", path)
+	}
+}
@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	"github.com/pb33f/harific/motor"
+)
+
+func TestExecuteSearch_InvalidRegex(t *testing.T) {
+	m := &HARViewModel{
+		searcher:       &motor.HARSearcher{},
+		searchInput:    textinput.New(),
+		searchQuery:    "previous",
+		searchFilter:   NewSearchFilter(),
+		fileTypeFilter: NewFileTypeFilter(),
+		methodFilter:   NewMethodFilter(),
+		statusFilter:   NewStatusClassFilter(),
+		timeFilter:     NewTimeRangeFilter(),
+		sizeFilter:     NewSizeFilter(),
+		endpointFilter: NewEndpointFilter(),
+		width:          80,
+		height:         40,
+	}
+	m.searchOptions[1] = true // Regex Mode
+
+	m.searchInput.SetValue("[")
+	if cmd := m.executeSearch(); cmd != nil {
+		t.Fatal("an invalid pattern should not start a search")
+	}
+	if m.searchPatternErr == nil || m.isSearching {
+		t.Fatalf("pattern error = %v, searching = %v", m.searchPatternErr, m.isSearching)
+	}
+	if m.searchQuery != "previous" {
+		t.Errorf("the previous search should stay active, got %q", m.searchQuery)
+	}
+	if panel := m.renderSearchPanel(); !strings.Contains(panel, "invalid pattern") {
+		t.Errorf("expected the search panel to show the error:\n%s", panel)
+	}
+
+	m.searchInput.SetValue("[a]")
+	if cmd := m.executeSearch(); cmd == nil {
+		t.Fatal("a valid pattern should start a search")
+	}
+	m.searchCancel()
+	if m.searchPatternErr != nil || m.searchQuery != "[a]" {
+		t.Errorf("pattern error = %v, query = %q after a valid pattern", m.searchPatternErr, m.searchQuery)
+	}
+}
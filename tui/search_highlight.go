@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/bubbles/v2/table"
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+const (
+	searchMatchMarker      = "●"
+	searchMatchMarkerWidth = 2 // marker plus separating space
+)
+
+// toggleSearchHighlight switches search results between filtering the table down to the matches
+// and marking them in place among every other entry, keeping the selected entry selected
+func (m *HARViewModel) toggleSearchHighlight() tea.Cmd {
+	m.searchHighlight = !m.searchHighlight
+	if m.viewSettings != nil {
+		m.viewSettings.HighlightSearch = m.searchHighlight
+	}
+	m.applySort()
+
+	if m.searchHighlight {
+		return showStatusMessage("Search: highlight matches (n/N to step)")
+	}
+	return showStatusMessage("Search: show only matches")
+}
+
+// markSearchMatches prefixes the url of every matching row with searchMatchMarker. rows may be
+// the shared m.rows, so marked rows are copies.
+func (m *HARViewModel) markSearchMatches(rows []table.Row, indices []int) []table.Row {
+	rows = slices.Clone(rows)
+	for i, index := range indices {
+		if !m.searchFilter.ShouldShow(index, nil) {
+			continue
+		}
+		width := m.width - searchMatchMarkerWidth
+		prefix := searchMatchMarker + " "
+		if m.injections.IsInjected(index) {
+			width -= injectionMarkerWidth
+			prefix += injectionMarker + " "
+		}
+		row := m.entryRow(index, width)
+		row[1] = prefix + row[1]
+		rows[i] = row
+	}
+	return rows
+}
+
+// jumpToSearchMatch moves the cursor to the next (or previous) highlighted match, wrapping around
+// at either end of the table
+func (m *HARViewModel) jumpToSearchMatch(forward bool) tea.Cmd {
+	if m.searchFilter.MatchCount() == 0 {
+		return showStatusMessage("No search matches")
+	}
+
+	count := len(m.filteredIndices)
+	step := 1
+	if !forward {
+		step = count - 1
+	}
+	for offset := 1; offset <= count; offset++ {
+		row := (m.selectedIndex + offset*step) % count
+		index := m.filteredIndices[row]
+		if index != pageHeaderIndex && m.searchFilter.ShouldShow(index, nil) {
+			m.table.SetCursor(row)
+			m.selectedIndex = row
+			return nil
+		}
+	}
+	return showStatusMessage("No search matches in view")
+}
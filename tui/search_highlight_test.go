@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/v2/table"
+)
+
+func newHighlightTestModel() *HARViewModel {
+	entries, rows := sortTestEntries()
+	m := &HARViewModel{
+		allEntries:     entries,
+		rows:           rows,
+		width:          120,
+		columns:        ColumnsDefault.columns(120, TableSort{}),
+		filterChain:    NewFilterChain(),
+		searchFilter:   NewSearchFilter(),
+		fileTypeFilter: NewFileTypeFilter(),
+		methodFilter:   NewMethodFilter(),
+		statusFilter:   NewStatusClassFilter(),
+		timeFilter:     NewTimeRangeFilter(),
+		sizeFilter:     NewSizeFilter(),
+		endpointFilter: NewEndpointFilter(),
+	}
+	m.table = table.New(table.WithColumns(m.columns), table.WithRows(rows), table.WithHeight(10))
+	m.searchFilter.SetSearched(true)
+	m.searchFilter.AddMatch(0)
+	m.searchFilter.AddMatch(2)
+	return m
+}
+
+func TestSearchHighlight_MarksInPlace(t *testing.T) {
+	m := newHighlightTestModel()
+	m.applyFilters()
+	if !slices.Equal(m.filteredIndices, []int{0, 2}) {
+		t.Fatalf("filtering should show only the matches, got %v", m.filteredIndices)
+	}
+
+	settings := &ViewSettings{}
+	m.viewSettings = settings
+	m.toggleSearchHighlight()
+	if !slices.Equal(m.filteredIndices, []int{0, 1, 2}) {
+		t.Fatalf("highlighting should keep every entry, got %v", m.filteredIndices)
+	}
+	if !settings.HighlightSearch {
+		t.Error("the presentation should be remembered in the view settings")
+	}
+
+	rows := m.table.Rows()
+	for i, want := range []bool{true, false, true} {
+		if marked := strings.HasPrefix(rows[i][1], searchMatchMarker+" "); marked != want {
+			t.Errorf("row %d url %q marked = %v, want %v", i, rows[i][1], marked, want)
+		}
+	}
+	if strings.HasPrefix(m.rows[0][1], searchMatchMarker) {
+		t.Error("marking must not change the unfiltered rows")
+	}
+
+	m.toggleSearchHighlight()
+	if !slices.Equal(m.filteredIndices, []int{0, 2}) {
+		t.Errorf("toggling back should filter again, got %v", m.filteredIndices)
+	}
+}
+
+func TestJumpToSearchMatch(t *testing.T) {
+	m := newHighlightTestModel()
+	m.searchHighlight = true
+	m.applyFilters()
+
+	// forward from the first match skips the entry in between, then wraps
+	for _, want := range []int{2, 0, 2} {
+		m.jumpToSearchMatch(true)
+		if m.selectedEntryIndex() != want {
+			t.Fatalf("selected %d, want %d", m.selectedEntryIndex(), want)
+		}
+	}
+	m.jumpToSearchMatch(false)
+	if m.selectedEntryIndex() != 0 {
+		t.Errorf("backward selected %d, want 0", m.selectedEntryIndex())
+	}
+
+	m.searchFilter.ClearMatches()
+	if cmd := m.jumpToSearchMatch(true); cmd == nil {
+		t.Error("expected a notice when there is nothing to jump to")
+	}
+}
@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultSearchHistoryLimit is the number of search queries remembered
+const DefaultSearchHistoryLimit = 100
+
+// maxHistoryQueryLength keeps the history file small; longer queries are not remembered
+const maxHistoryQueryLength = 200
+
+// SearchHistory is the list of past search queries, oldest first, with a cursor for stepping
+// back through them from the search input
+type SearchHistory struct {
+	Queries []string
+	Limit   int
+
+	pos   int    // index into Queries while browsing, len(Queries) when not
+	draft string // what was typed before browsing started, restored past the newest query
+}
+
+// NewSearchHistory creates an empty history holding at most limit queries (0 = DefaultSearchHistoryLimit)
+func NewSearchHistory(limit int) *SearchHistory {
+	if limit <= 0 {
+		limit = DefaultSearchHistoryLimit
+	}
+	return &SearchHistory{Limit: limit}
+}
+
+// DefaultSearchHistoryPath returns the history file location under the user config directory
+func DefaultSearchHistoryPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "harific", "history"), nil
+}
+
+// LoadSearchHistory reads the history file, one query per line; a missing file yields an empty history
+func LoadSearchHistory(path string, limit int) (*SearchHistory, error) {
+	history := NewSearchHistory(limit)
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return history, nil
+		}
+		return nil, fmt.Errorf("failed to read search history: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		history.Add(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read search history: %w", err)
+	}
+	return history, nil
+}
+
+// Save writes the history file, creating its directory if needed
+func (h *SearchHistory) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	var data strings.Builder
+	for _, query := range h.Queries {
+		data.WriteString(query)
+		data.WriteString("\n")
+	}
+
+	if err := os.WriteFile(path, []byte(data.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write search history: %w", err)
+	}
+	return nil
+}
+
+// Add records a query as the newest, unless it repeats the newest one. empty, multi-line and
+// very long queries are skipped. browsing starts over from the newest query.
+func (h *SearchHistory) Add(query string) {
+	defer h.Reset()
+
+	query = strings.TrimSpace(query)
+	if query == "" || len(query) > maxHistoryQueryLength || strings.ContainsAny(query, "\r\n") {
+		return
+	}
+	if n := len(h.Queries); n > 0 && h.Queries[n-1] == query {
+		return
+	}
+
+	h.Queries = append(h.Queries, query)
+	limit := h.Limit
+	if limit <= 0 {
+		limit = DefaultSearchHistoryLimit
+	}
+	if len(h.Queries) > limit {
+		h.Queries = h.Queries[len(h.Queries)-limit:]
+	}
+}
+
+// Previous steps back to an older query. current is the input's value, kept as the draft when
+// browsing starts. ok is false when there is nothing older.
+func (h *SearchHistory) Previous(current string) (query string, ok bool) {
+	if h.pos > len(h.Queries) {
+		h.pos = len(h.Queries)
+	}
+	if h.pos == 0 {
+		return "", false
+	}
+	if h.pos == len(h.Queries) {
+		h.draft = current
+	}
+	h.pos--
+	return h.Queries[h.pos], true
+}
+
+// Next steps forward to a newer query, and past the newest back to the draft. ok is false when
+// not browsing.
+func (h *SearchHistory) Next() (query string, ok bool) {
+	if h.pos >= len(h.Queries) {
+		return "", false
+	}
+	h.pos++
+	if h.pos == len(h.Queries) {
+		return h.draft, true
+	}
+	return h.Queries[h.pos], true
+}
+
+// Reset stops browsing, so the next Previous starts from the newest query
+func (h *SearchHistory) Reset() {
+	h.pos = len(h.Queries)
+	h.draft = ""
+}
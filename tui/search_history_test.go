@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+func TestSearchHistoryAdd(t *testing.T) {
+	history := NewSearchHistory(3)
+	for _, query := range []string{"token", "token", " ", "login", "multi\nline", strings.Repeat("x", 300), "token", "cookie"} {
+		history.Add(query)
+	}
+
+	want := []string{"login", "token", "cookie"}
+	if strings.Join(history.Queries, ",") != strings.Join(want, ",") {
+		t.Errorf("expected %v, got %v", want, history.Queries)
+	}
+}
+
+func TestSearchHistoryBrowse(t *testing.T) {
+	history := NewSearchHistory(0)
+	history.Add("first")
+	history.Add("second")
+
+	steps := []struct {
+		older bool
+		want  string
+		ok    bool
+	}{
+		{true, "second", true},
+		{true, "first", true},
+		{true, "", false},
+		{false, "second", true},
+		{false, "draft", true}, // past the newest, the typed text comes back
+		{false, "", false},
+	}
+	for i, step := range steps {
+		var got string
+		var ok bool
+		if step.older {
+			got, ok = history.Previous("draft")
+		} else {
+			got, ok = history.Next()
+		}
+		if got != step.want || ok != step.ok {
+			t.Errorf("step %d: got (%q, %v), want (%q, %v)", i, got, ok, step.want, step.ok)
+		}
+	}
+}
+
+func TestSearchHistorySaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "harific", "history")
+
+	history, err := LoadSearchHistory(path, 0)
+	if err != nil || len(history.Queries) != 0 {
+		t.Fatalf("missing history should load empty, got %v, %v", history.Queries, err)
+	}
+
+	history.Add("token")
+	history.Add("status:500")
+	if err := history.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// a smaller limit keeps the newest queries
+	loaded, err := LoadSearchHistory(path, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Queries) != 1 || loaded.Queries[0] != "status:500" {
+		t.Errorf("expected [status:500], got %v", loaded.Queries)
+	}
+	if got, ok := loaded.Previous(""); !ok || got != "status:500" {
+		t.Errorf("a loaded history should browse from the newest query, got %q", got)
+	}
+
+	if data, _ := os.ReadFile(path); string(data) != "token\nstatus:500\n" {
+		t.Errorf("unexpected history file %q", data)
+	}
+}
+
+func TestSearchInputRecallsHistory(t *testing.T) {
+	history := NewSearchHistory(0)
+	history.Add("token")
+
+	m := &HARViewModel{
+		loadState:     LoadStateLoaded,
+		viewMode:      ViewModeTableWithSearch,
+		searchInput:   textinput.New(),
+		searchCursor:  searchCursorInput,
+		searchHistory: history,
+	}
+	m.searchInput.SetValue("tok")
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	if m.searchInput.Value() != "token" || m.searchCursor != searchCursorInput {
+		t.Errorf("up on the input should recall the last query, got %q (cursor %d)", m.searchInput.Value(), m.searchCursor)
+	}
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if m.searchInput.Value() != "tok" {
+		t.Errorf("down past the newest query should restore the typed text, got %q", m.searchInput.Value())
+	}
+
+	// once history runs out, down moves on to the checkboxes as before
+	m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if m.searchCursor == searchCursorInput {
+		t.Error("down without history to step through should move to the checkboxes")
+	}
+}
@@ -0,0 +1,216 @@
+package tui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/pb33f/harific/motor"
+)
+
+// search settings modal rows, in cursor order
+const (
+	settingsCursorWorkers = iota
+	settingsCursorChunkSize
+	settingsCursorCount
+)
+
+func newSettingsInput(prompt string) textinput.Model {
+	input := textinput.New()
+	input.Prompt = prompt
+	input.Placeholder = "auto"
+	input.CharLimit = 8
+	input.SetWidth(10)
+	return input
+}
+
+// parseSetting reads a worker count or chunk size: empty input is automatic (0), anything else a
+// non-negative number, clamped to limit when limit is above 0
+func parseSetting(input string, limit int) (int, error) {
+	input = strings.TrimSpace(input)
+	if input == "" || strings.EqualFold(input, "auto") {
+		return 0, nil
+	}
+	value, err := strconv.Atoi(input)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid number %q", input)
+	}
+	if limit > 0 {
+		value = min(value, limit)
+	}
+	return value, nil
+}
+
+// formatSetting is the input text for a setting, empty for automatic so the placeholder shows
+func formatSetting(value int) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.Itoa(value)
+}
+
+// openSearchSettings opens the settings modal on the current values, workers focused
+func (m *HARViewModel) openSearchSettings() tea.Cmd {
+	m.activeModal = ModalSearchSettings
+	m.settingsError = ""
+	m.settingsCursor = settingsCursorWorkers
+	m.workersInput.SetValue(formatSetting(m.searchWorkers))
+	m.chunkSizeInput.SetValue(formatSetting(m.searchChunkSize))
+	m.chunkSizeInput.Blur()
+	return m.workersInput.Focus()
+}
+
+func (m *HARViewModel) closeSearchSettings() {
+	m.activeModal = ModalNone
+	m.workersInput.Blur()
+	m.chunkSizeInput.Blur()
+}
+
+// applySearchSettings stores both inputs for the next search; a bad input leaves the settings as they were
+func (m *HARViewModel) applySearchSettings() bool {
+	workers, err := parseSetting(m.workersInput.Value(), motor.MaxSearchWorkers)
+	if err != nil {
+		m.settingsError = "Workers: " + err.Error()
+		return false
+	}
+	chunkSize, err := parseSetting(m.chunkSizeInput.Value(), len(m.allEntries))
+	if err != nil {
+		m.settingsError = "Chunk: " + err.Error()
+		return false
+	}
+
+	m.settingsError = ""
+	m.searchWorkers = workers
+	m.searchChunkSize = chunkSize
+	return true
+}
+
+// handleSearchSettingsKeys takes the whole key message so typed digits reach the inputs. applying
+// the settings reruns the current query with them.
+func (m *HARViewModel) handleSearchSettingsKeys(msg tea.KeyPressMsg) (bool, tea.Cmd) {
+	if m.activeModal != ModalSearchSettings {
+		return false, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.closeSearchSettings()
+		return true, nil
+
+	case "tab", "down", "shift+tab", "up":
+		m.settingsCursor = (m.settingsCursor + 1) % settingsCursorCount
+		if m.settingsCursor == settingsCursorWorkers {
+			m.chunkSizeInput.Blur()
+			return true, m.workersInput.Focus()
+		}
+		m.workersInput.Blur()
+		return true, m.chunkSizeInput.Focus()
+
+	case "enter":
+		if !m.applySearchSettings() {
+			return true, nil
+		}
+		m.closeSearchSettings()
+		if m.searchInput.Value() == "" {
+			return true, nil
+		}
+		m.debounceID++ // a pending live search would run with the old settings
+		return true, func() tea.Msg { return searchStartMsg{} }
+	}
+
+	var cmd tea.Cmd
+	switch m.settingsCursor {
+	case settingsCursorWorkers:
+		m.workersInput, cmd = m.workersInput.Update(msg)
+	case settingsCursorChunkSize:
+		m.chunkSizeInput, cmd = m.chunkSizeInput.Update(msg)
+	}
+	return true, cmd
+}
+
+// searchStatsLine describes the last search, including the workers and batch size it actually ran with
+func searchStatsLine(stats motor.SearchStats) string {
+	if stats.WorkerCount == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d searched in %s · %d workers × %d entries",
+		stats.EntriesSearched, stats.SearchDuration.Round(100*time.Microsecond), stats.WorkerCount, stats.ChunkSize)
+}
+
+// maxBreakdownFields is how many field categories matchBreakdown names before summing the rest
+const maxBreakdownFields = 4
+
+// matchBreakdown summarizes where the last search matched, most matched category first, e.g.
+// "matches: 40 url, 3 response.body"
+func matchBreakdown(byField map[string]int64) string {
+	if len(byField) == 0 {
+		return ""
+	}
+	fields := make([]string, 0, len(byField))
+	for field := range byField {
+		fields = append(fields, field)
+	}
+	slices.SortFunc(fields, func(a, b string) int {
+		if c := cmp.Compare(byField[b], byField[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+
+	parts := make([]string, 0, maxBreakdownFields+1)
+	var rest int64
+	for i, field := range fields {
+		if i < maxBreakdownFields {
+			parts = append(parts, fmt.Sprintf("%d %s", byField[field], field))
+		} else {
+			rest += byField[field]
+		}
+	}
+	if rest > 0 {
+		parts = append(parts, fmt.Sprintf("%d other", rest))
+	}
+	return "matches: " + strings.Join(parts, ", ")
+}
+
+func (m *HARViewModel) renderSearchSettingsModal() string {
+	modalStyle := lipgloss.NewStyle().
+		Width(36).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(RGBBlue).
+		Padding(1)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(RGBBlue)
+
+	helpStyle := lipgloss.NewStyle().Foreground(RGBGrey).Faint(true)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Search Settings"))
+	content.WriteString("\n\n")
+
+	content.WriteString(m.workersInput.View())
+	content.WriteString("\n")
+	content.WriteString(m.chunkSizeInput.View())
+
+	if line := searchStatsLine(m.searchStats); line != "" {
+		content.WriteString("\n\n")
+		content.WriteString(helpStyle.Render("Last: " + line))
+	}
+
+	if m.settingsError != "" {
+		content.WriteString("\n\n")
+		content.WriteString(lipgloss.NewStyle().Foreground(RGBRed).Render(m.settingsError))
+	}
+
+	content.WriteString("\n\n")
+	content.WriteString(helpStyle.Render(fmt.Sprintf("Empty is auto, at most %d workers | Tab: Next | Enter: Apply | Esc: Close", motor.MaxSearchWorkers)))
+
+	return modalStyle.Render(content.String())
+}
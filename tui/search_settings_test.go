@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/pb33f/harific/motor"
+)
+
+func TestParseSetting(t *testing.T) {
+	tests := []struct {
+		input   string
+		limit   int
+		want    int
+		wantErr bool
+	}{
+		{"", 64, 0, false},
+		{"auto", 64, 0, false},
+		{" 8 ", 64, 8, false},
+		{"500", 64, 64, false},
+		{"500", 0, 500, false},
+		{"-2", 64, 0, true},
+		{"four", 64, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseSetting(tt.input, tt.limit)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSetting(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseSetting(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestSearchSettingsModal(t *testing.T) {
+	m := &HARViewModel{
+		allEntries:     make([]*motor.EntryMetadata, 100),
+		workersInput:   newSettingsInput("Workers: "),
+		chunkSizeInput: newSettingsInput("Chunk:   "),
+	}
+	m.openSearchSettings()
+	if m.activeModal != ModalSearchSettings {
+		t.Fatalf("expected the settings modal to open")
+	}
+
+	m.workersInput.SetValue("200")
+	m.handleSearchSettingsKeys(tea.KeyPressMsg{Code: tea.KeyTab})
+	m.chunkSizeInput.SetValue("x")
+	m.handleSearchSettingsKeys(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.activeModal != ModalSearchSettings || m.settingsError == "" {
+		t.Fatalf("a bad chunk size should keep the modal open with an error")
+	}
+	if m.searchWorkers != 0 {
+		t.Errorf("rejected settings should not be stored, got %d workers", m.searchWorkers)
+	}
+
+	m.chunkSizeInput.SetValue("5000")
+	m.handleSearchSettingsKeys(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.activeModal != ModalNone {
+		t.Fatalf("expected the modal to close on apply")
+	}
+	if m.searchWorkers != motor.MaxSearchWorkers {
+		t.Errorf("workers should clamp to %d, got %d", motor.MaxSearchWorkers, m.searchWorkers)
+	}
+	if m.searchChunkSize != 100 {
+		t.Errorf("chunk size should clamp to the entry count, got %d", m.searchChunkSize)
+	}
+
+	line := searchStatsLine(motor.SearchStats{EntriesSearched: 100, WorkerCount: 4, ChunkSize: 25})
+	if line != "100 searched in 0s · 4 workers × 25 entries" {
+		t.Errorf("unexpected stats line %q", line)
+	}
+}
+
+func TestMatchBreakdown(t *testing.T) {
+	if got := matchBreakdown(nil); got != "" {
+		t.Errorf("expected no breakdown without matches, got %q", got)
+	}
+
+	got := matchBreakdown(map[string]int64{"response.body": 3, "url": 40})
+	if got != "matches: 40 url, 3 response.body" {
+		t.Errorf("unexpected breakdown %q", got)
+	}
+
+	got = matchBreakdown(map[string]int64{
+		"url": 9, "request.headers": 5, "response.headers": 5, "cookie": 2, "comment": 1, "response.body": 1,
+	})
+	if got != "matches: 9 url, 5 request.headers, 5 response.headers, 2 cookie, 2 other" {
+		t.Errorf("unexpected breakdown %q", got)
+	}
+}
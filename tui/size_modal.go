@@ -0,0 +1,207 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// size range modal rows, in cursor order
+const (
+	sizeCursorMin = iota
+	sizeCursorMax
+	sizeCursorReset
+	sizeCursorCount
+)
+
+// sizeUnits are the suffixes parseSize accepts, in the binary units formatSize renders
+var sizeUnits = map[string]int64{
+	"":   1,
+	"b":  1,
+	"k":  1 << 10,
+	"kb": 1 << 10,
+	"m":  1 << 20,
+	"mb": 1 << 20,
+	"g":  1 << 30,
+	"gb": 1 << 30,
+}
+
+// parseSize turns a size range input into bytes: empty input is an open bound (0), otherwise a
+// number with an optional B, KB, MB or GB suffix such as "512", "1.5MB" or "200 kb"
+func parseSize(input string) (int64, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if input == "" {
+		return 0, nil
+	}
+
+	number := strings.TrimRight(input, "bkmg ")
+	multiplier, ok := sizeUnits[strings.TrimSpace(input[len(number):])]
+	if !ok {
+		return 0, fmt.Errorf("invalid size %q", input)
+	}
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid size %q", input)
+	}
+	return int64(value * float64(multiplier)), nil
+}
+
+func newSizeInput(prompt string) textinput.Model {
+	input := textinput.New()
+	input.Prompt = prompt
+	input.Placeholder = "512KB, 1.5MB"
+	input.CharLimit = 32
+	input.SetWidth(18)
+	return input
+}
+
+// openSizeModal opens the size range modal with the min input focused
+func (m *HARViewModel) openSizeModal() tea.Cmd {
+	m.activeModal = ModalSizeRange
+	m.sizeError = ""
+	m.sizeCursor = sizeCursorMin
+	m.sizeMaxInput.Blur()
+	return m.sizeMinInput.Focus()
+}
+
+// moveSizeCursor moves between the modal rows, focusing whichever input the cursor lands on
+func (m *HARViewModel) moveSizeCursor(delta int) tea.Cmd {
+	m.sizeCursor = (m.sizeCursor + delta + sizeCursorCount) % sizeCursorCount
+	m.sizeMinInput.Blur()
+	m.sizeMaxInput.Blur()
+
+	switch m.sizeCursor {
+	case sizeCursorMin:
+		return m.sizeMinInput.Focus()
+	case sizeCursorMax:
+		return m.sizeMaxInput.Focus()
+	}
+	return nil
+}
+
+// applySizeRange parses both inputs into the size filter; a bad input leaves the filter as it was
+func (m *HARViewModel) applySizeRange() bool {
+	minBytes, err := parseSize(m.sizeMinInput.Value())
+	if err != nil {
+		m.sizeError = "Min: " + err.Error()
+		return false
+	}
+	maxBytes, err := parseSize(m.sizeMaxInput.Value())
+	if err != nil {
+		m.sizeError = "Max: " + err.Error()
+		return false
+	}
+	if minBytes > 0 && maxBytes > 0 && maxBytes < minBytes {
+		m.sizeError = "Max is below Min"
+		return false
+	}
+
+	m.sizeError = ""
+	m.sizeFilter.SetRange(minBytes, maxBytes)
+	m.applyFilters()
+	return true
+}
+
+func (m *HARViewModel) resetSizeFilter() {
+	m.sizeMinInput.SetValue("")
+	m.sizeMaxInput.SetValue("")
+	m.sizeError = ""
+	m.sizeFilter.Clear()
+	m.applyFilters()
+}
+
+// handleSizeModalKeys takes the whole key message so typed characters reach the inputs
+func (m *HARViewModel) handleSizeModalKeys(msg tea.KeyPressMsg) (bool, tea.Cmd) {
+	if m.activeModal != ModalSizeRange {
+		return false, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.activeModal = ModalNone
+		m.sizeMinInput.Blur()
+		m.sizeMaxInput.Blur()
+		return true, nil
+
+	case "tab", "down":
+		return true, m.moveSizeCursor(1)
+
+	case "shift+tab", "up":
+		return true, m.moveSizeCursor(-1)
+
+	case "enter":
+		if m.sizeCursor == sizeCursorReset {
+			m.resetSizeFilter()
+			return true, nil
+		}
+		if m.applySizeRange() {
+			m.activeModal = ModalNone
+			m.sizeMinInput.Blur()
+			m.sizeMaxInput.Blur()
+		}
+		return true, nil
+
+	case " ", "space":
+		if m.sizeCursor == sizeCursorReset {
+			m.resetSizeFilter()
+			return true, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	switch m.sizeCursor {
+	case sizeCursorMin:
+		m.sizeMinInput, cmd = m.sizeMinInput.Update(msg)
+	case sizeCursorMax:
+		m.sizeMaxInput, cmd = m.sizeMaxInput.Update(msg)
+	}
+	return true, cmd
+}
+
+func (m *HARViewModel) renderSizeRangeModal() string {
+	modalStyle := lipgloss.NewStyle().
+		Width(30).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(RGBBlue).
+		Padding(1)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(RGBBlue)
+
+	highlightStyle := lipgloss.NewStyle().
+		Background(RGBSubtlePink).
+		Foreground(RGBPink).
+		Bold(true)
+
+	helpStyle := lipgloss.NewStyle().Foreground(RGBGrey).Faint(true)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Response Size"))
+	content.WriteString("\n\n")
+
+	content.WriteString(m.sizeMinInput.View())
+	content.WriteString("\n")
+	content.WriteString(m.sizeMaxInput.View())
+	content.WriteString("\n\n")
+
+	resetLine := " [ ] Reset Size Range"
+	if m.sizeCursor == sizeCursorReset {
+		resetLine = highlightStyle.Render("> [*] Reset Size Range")
+	}
+	content.WriteString(resetLine)
+
+	if m.sizeError != "" {
+		content.WriteString("\n\n")
+		content.WriteString(lipgloss.NewStyle().Foreground(RGBRed).Render(m.sizeError))
+	}
+
+	content.WriteString("\n\n")
+	content.WriteString(helpStyle.Render("512, 64KB or 1.5MB | Tab: Next | Enter: Apply | Esc: Close"))
+
+	return modalStyle.Render(content.String())
+}
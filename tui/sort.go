@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/table"
+	"github.com/pb33f/harific/motor"
+)
+
+// SortColumn identifies the table column entries are ordered by
+type SortColumn int
+
+const (
+	SortNone SortColumn = iota // original file order
+	SortMethod
+	SortURL
+	SortStatus
+	SortSize
+	SortDuration
+)
+
+// sortColumnCount is the number of SortColumn values, used when cycling
+const sortColumnCount = 6
+
+// columnTitles are the table headers in column order; sort indicators are appended to these
+var columnTitles = []string{"Method", "URL", "Status", "Size", "Duration"}
+
+// TableSort orders the (filtered) table rows by a column
+type TableSort struct {
+	Column     SortColumn
+	Descending bool
+}
+
+// IsActive returns true if rows are ordered by a column rather than file order
+func (s TableSort) IsActive() bool {
+	return s.Column != SortNone
+}
+
+// Next cycles to the next sort column, wrapping back to file order
+func (s TableSort) Next() TableSort {
+	s.Column = (s.Column + 1) % sortColumnCount
+	return s
+}
+
+// Apply reorders rows and their entry indices, returning new slices and leaving the inputs untouched.
+// the sort is stable, so ties keep file order.
+func (s TableSort) Apply(allEntries []*motor.EntryMetadata, rows []table.Row, indices []int) ([]table.Row, []int) {
+	if !s.IsActive() || len(rows) != len(indices) {
+		return rows, indices
+	}
+
+	order := make([]int, len(indices))
+	for i := range order {
+		order[i] = i
+	}
+
+	slices.SortStableFunc(order, func(a, b int) int {
+		c := s.compare(allEntries[indices[a]], allEntries[indices[b]])
+		if s.Descending {
+			return -c
+		}
+		return c
+	})
+
+	sortedRows := make([]table.Row, len(rows))
+	sortedIndices := make([]int, len(indices))
+	for i, pos := range order {
+		sortedRows[i] = rows[pos]
+		sortedIndices[i] = indices[pos]
+	}
+	return sortedRows, sortedIndices
+}
+
+func (s TableSort) compare(a, b *motor.EntryMetadata) int {
+	switch s.Column {
+	case SortMethod:
+		return strings.Compare(a.Method, b.Method)
+	case SortURL:
+		return strings.Compare(a.URL, b.URL)
+	case SortStatus:
+		return cmp.Compare(a.StatusCode, b.StatusCode)
+	case SortSize:
+		return cmp.Compare(a.ResponseSize, b.ResponseSize)
+	case SortDuration:
+		return cmp.Compare(a.Duration, b.Duration)
+	default:
+		return 0
+	}
+}
+
+// ColumnTitle returns the header for a table column, marked with the sort direction if sorted by it
+func (s TableSort) ColumnTitle(column int) string {
+	title := columnTitles[column]
+	if s.IsActive() && int(s.Column)-1 == column {
+		if s.Descending {
+			return title + " ▼"
+		}
+		return title + " ▲"
+	}
+	return title
+}
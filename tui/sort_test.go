@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/v2/table"
+	"github.com/pb33f/harific/motor"
+)
+
+func sortTestEntries() ([]*motor.EntryMetadata, []table.Row) {
+	entries := []*motor.EntryMetadata{
+		{Method: "POST", URL: "https://example.com/b", StatusCode: 500, ResponseSize: 300, Duration: 20},
+		{Method: "GET", URL: "https://example.com/c", StatusCode: 200, ResponseSize: 100, Duration: 900},
+		{Method: "PUT", URL: "https://example.com/a", StatusCode: 404, ResponseSize: 200, Duration: 20},
+	}
+	rows := make([]table.Row, len(entries))
+	for i, entry := range entries {
+		rows[i] = ColumnsDefault.row(entry, 120)
+	}
+	return entries, rows
+}
+
+func TestTableSort_Apply(t *testing.T) {
+	entries, rows := sortTestEntries()
+	identity := []int{0, 1, 2}
+
+	tests := []struct {
+		sort TableSort
+		want []int
+	}{
+		{TableSort{}, []int{0, 1, 2}},
+		{TableSort{Column: SortMethod}, []int{1, 0, 2}},
+		{TableSort{Column: SortURL}, []int{2, 0, 1}},
+		{TableSort{Column: SortStatus}, []int{1, 2, 0}},
+		{TableSort{Column: SortSize, Descending: true}, []int{0, 2, 1}},
+		{TableSort{Column: SortDuration}, []int{0, 2, 1}}, // stable: ties keep file order
+		{TableSort{Column: SortDuration, Descending: true}, []int{1, 0, 2}},
+	}
+
+	for _, tt := range tests {
+		sortedRows, indices := tt.sort.Apply(entries, rows, identity)
+		for i, want := range tt.want {
+			if indices[i] != want {
+				t.Errorf("sort %+v: indices = %v, want %v", tt.sort, indices, tt.want)
+				break
+			}
+			if sortedRows[i][1] != rows[want][1] {
+				t.Errorf("sort %+v: row %d is %q, want %q", tt.sort, i, sortedRows[i][1], rows[want][1])
+			}
+		}
+	}
+
+	// inputs are never reordered in place
+	if identity[0] != 0 || rows[0][1] != "/b" {
+		t.Error("Apply must not modify its input slices")
+	}
+}
+
+func TestTableSort_AppliesToFilteredView(t *testing.T) {
+	entries, rows := sortTestEntries()
+
+	// only entries 0 and 2 survived filtering
+	filteredRows := []table.Row{rows[0], rows[2]}
+	_, indices := TableSort{Column: SortStatus}.Apply(entries, filteredRows, []int{0, 2})
+
+	if len(indices) != 2 || indices[0] != 2 || indices[1] != 0 {
+		t.Errorf("filtered sort indices = %v, want [2 0]", indices)
+	}
+}
+
+func TestTableSort_NextAndTitles(t *testing.T) {
+	s := TableSort{}
+	for i := 0; i < sortColumnCount; i++ {
+		s = s.Next()
+	}
+	if s.Column != SortNone {
+		t.Errorf("cycling through every column should return to file order, got %d", s.Column)
+	}
+
+	s = TableSort{Column: SortSize, Descending: true}
+	if got := s.ColumnTitle(3); got != "Size ▼" {
+		t.Errorf("sorted column title = %q", got)
+	}
+	if got := s.ColumnTitle(1); got != "URL" {
+		t.Errorf("unsorted column title = %q", got)
+	}
+}
+
+func TestApplySortKeepsSelection(t *testing.T) {
+	entries, rows := sortTestEntries()
+	m := &HARViewModel{
+		allEntries:     entries,
+		rows:           rows,
+		columns:        []table.Column{{Title: "Method"}, {Title: "URL"}, {Title: "Status"}, {Title: "Size"}, {Title: "Duration"}},
+		filterChain:    NewFilterChain(),
+		searchFilter:   NewSearchFilter(),
+		fileTypeFilter: NewFileTypeFilter(),
+		methodFilter:   NewMethodFilter(),
+		statusFilter:   NewStatusClassFilter(),
+		timeFilter:     NewTimeRangeFilter(),
+	}
+	m.table = table.New(table.WithColumns(m.columns), table.WithRows(rows))
+	m.filteredIndices = []int{0, 1, 2}
+
+	// select entry 1 (the GET), then sort by URL: it moves to the last row
+	m.table.SetCursor(1)
+	m.selectedIndex = 1
+	m.tableSort = TableSort{Column: SortURL}
+	m.applySort()
+
+	if m.selectedEntryIndex() != 1 {
+		t.Errorf("selected entry = %d after sort, want 1", m.selectedEntryIndex())
+	}
+	if m.table.Cursor() != 2 {
+		t.Errorf("cursor = %d after sort, want 2", m.table.Cursor())
+	}
+	if m.columns[1].Title != "URL ▲" {
+		t.Errorf("URL column title = %q", m.columns[1].Title)
+	}
+}
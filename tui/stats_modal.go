@@ -0,0 +1,191 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/pb33f/harific/motor"
+)
+
+const (
+	statsModalWidth   = 64
+	statsTopN         = 5  // slowest and largest entries listed
+	statsMaxBuckets   = 8  // status codes and mime types listed before the rest are folded away
+	statsBarWidth     = 20 // width of the longest histogram bar
+	statsURLMaxLength = 36
+)
+
+// filteredStats aggregates the entries that pass every active filter, page grouping aside.
+// only index metadata is used, so no bodies are read.
+func (m *HARViewModel) filteredStats() *motor.IndexStats {
+	if m.index == nil {
+		return nil
+	}
+
+	indices := PassingIndices(m.allEntries, m.searchFilter, m.fileTypeFilter, m.methodFilter, m.statusFilter, m.timeFilter, m.sizeFilter, m.endpointFilter)
+	if indices == nil {
+		indices = make([]int, len(m.allEntries))
+		for i := range indices {
+			indices[i] = i
+		}
+	}
+	return m.index.StatsFor(indices, statsTopN)
+}
+
+// openStatsModal snapshots the stats for the current filters; applyFilters refreshes the snapshot
+// while the modal is open, so live search results are reflected too
+func (m *HARViewModel) openStatsModal() {
+	m.activeModal = ModalStats
+	m.filterStats = m.filteredStats()
+}
+
+// handleStatsModalKeys keeps every key but ctrl+c while the stats modal is open
+func (m *HARViewModel) handleStatsModalKeys(key string) (bool, tea.Cmd) {
+	if m.activeModal != ModalStats {
+		return false, nil
+	}
+
+	switch key {
+	case "ctrl+c":
+		return false, nil
+	case "esc", "i", "q":
+		m.activeModal = ModalNone
+		m.filterStats = nil
+	}
+	return true, nil
+}
+
+// calculateStatsModalPosition centres the stats modal horizontally below the title bar
+func (m *HARViewModel) calculateStatsModalPosition(modal string) (int, int) {
+	x := (m.width - lipgloss.Width(modal)) / 2
+	if x < 0 {
+		x = 0
+	}
+	return x, 2
+}
+
+func (m *HARViewModel) renderStatsModal() string {
+	stats := m.filterStats
+	if stats == nil {
+		return ""
+	}
+
+	modalStyle := lipgloss.NewStyle().
+		Width(statsModalWidth).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(RGBBlue).
+		Padding(1)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(RGBBlue)
+
+	headingStyle := lipgloss.NewStyle().Bold(true).Foreground(RGBPink)
+	helpStyle := lipgloss.NewStyle().Foreground(RGBGrey).Faint(true)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Stats"))
+	content.WriteString(helpStyle.Render(fmt.Sprintf("  %d of %d entries", stats.TotalEntries, len(m.allEntries))))
+	content.WriteString("\n\n")
+
+	if stats.TotalEntries == 0 {
+		content.WriteString("No entries match the active filters")
+		content.WriteString("\n\n")
+		content.WriteString(helpStyle.Render("i/Esc: Close"))
+		return modalStyle.Render(content.String())
+	}
+
+	count := float64(stats.TotalEntries)
+	fmt.Fprintf(&content, "Unique URLs  %d\n", stats.UniqueURLs)
+	fmt.Fprintf(&content, "Body size    %s total, %s avg\n",
+		formatSize(stats.TotalBodyBytes), formatSize(int64(float64(stats.TotalBodyBytes)/count)))
+	fmt.Fprintf(&content, "Duration     %s total, %s avg, %s wall clock\n",
+		formatDuration(stats.TotalDuration), formatDuration(stats.TotalDuration/count), formatDuration(stats.WallClock))
+	if !stats.Start.IsZero() {
+		fmt.Fprintf(&content, "Time range   %s to %s\n", stats.Start.Format("15:04:05"), stats.End.Format("15:04:05"))
+	}
+	if m.streamer != nil {
+		if line := streamerStatsLine(m.streamer.Stats()); line != "" {
+			fmt.Fprintf(&content, "Reads        %s\n", line)
+		}
+	}
+
+	content.WriteString("\n")
+	content.WriteString(headingStyle.Render("Status codes"))
+	content.WriteString("\n")
+	writeStatsHistogram(&content, stats.StatusCodes)
+
+	content.WriteString("\n")
+	content.WriteString(headingStyle.Render("Methods"))
+	content.WriteString("\n")
+	methods := make([]string, 0, len(stats.Methods))
+	for _, bucket := range stats.Methods {
+		methods = append(methods, fmt.Sprintf("%s %d", bucket.Key, bucket.Count))
+	}
+	content.WriteString(strings.Join(methods, "  "))
+	content.WriteString("\n")
+
+	content.WriteString("\n")
+	content.WriteString(headingStyle.Render("Slowest"))
+	content.WriteString("\n")
+	for _, entry := range stats.Slowest {
+		writeStatsEntry(&content, entry, formatDuration(entry.Duration))
+	}
+
+	content.WriteString("\n")
+	content.WriteString(headingStyle.Render("Largest"))
+	content.WriteString("\n")
+	for _, entry := range stats.Largest {
+		writeStatsEntry(&content, entry, formatSize(entry.BodySize))
+	}
+
+	content.WriteString("\n")
+	content.WriteString(helpStyle.Render("Reflects the active filters | i/Esc: Close"))
+
+	return modalStyle.Render(content.String())
+}
+
+// streamerStatsLine sums up the streamer's reads: how many, their rates and the cache hit ratio.
+// empty before the first read.
+func streamerStatsLine(stats motor.StreamerStats) string {
+	if stats.TotalReads == 0 {
+		return ""
+	}
+	line := fmt.Sprintf("%d entries, %.1f/s, %s/s", stats.TotalReads, stats.EntriesPerSecond, formatSize(int64(stats.BytesPerSecond)))
+	if stats.CacheHits+stats.CacheMisses > 0 {
+		line += fmt.Sprintf(", %.0f%% cached", stats.CacheHitRatio*100)
+	}
+	return line
+}
+
+// writeStatsHistogram writes one bar per bucket, scaled to the largest count
+func writeStatsHistogram(content *strings.Builder, buckets []motor.CountBucket) {
+	most := 0
+	for _, bucket := range buckets {
+		most = max(most, bucket.Count)
+	}
+
+	for i, bucket := range buckets {
+		if i == statsMaxBuckets {
+			fmt.Fprintf(content, "  ... %d more\n", len(buckets)-statsMaxBuckets)
+			break
+		}
+		key := bucket.Key
+		if key == "0" {
+			key = "---" // no response, as the table shows it
+		}
+		bar := max(1, bucket.Count*statsBarWidth/most)
+		fmt.Fprintf(content, "  %-5s %-*s %d\n", key, statsBarWidth, strings.Repeat("█", bar), bucket.Count)
+	}
+}
+
+// writeStatsEntry writes a slowest or largest entry line: index, the ranked value, method and url
+func writeStatsEntry(content *strings.Builder, entry motor.RankedEntry, value string) {
+	url := entry.URL
+	if len(url) > statsURLMaxLength {
+		url = url[:statsURLMaxLength-3] + "..."
+	}
+	fmt.Fprintf(content, "  #%-5d %-8s %-7s %s\n", entry.Index, value, entry.Method, url)
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/v2/table"
+	"github.com/pb33f/harific/motor"
+)
+
+func TestStatsModalFollowsFilters(t *testing.T) {
+	entries := []*motor.EntryMetadata{
+		{Method: "GET", URL: "https://example.com/a", StatusCode: 200, Duration: 40, BodySize: 100},
+		{Method: "POST", URL: "https://example.com/b", StatusCode: 500, Duration: 900, BodySize: 300},
+		{Method: "GET", URL: "https://example.com/c", StatusCode: 404, Duration: 20, BodySize: 50},
+	}
+	rows := make([]table.Row, len(entries))
+	for i, entry := range entries {
+		rows[i] = ColumnsDefault.row(entry, 120)
+	}
+
+	m := &HARViewModel{
+		index:          &motor.Index{Entries: entries},
+		allEntries:     entries,
+		rows:           rows,
+		columns:        []table.Column{{Title: "Method"}, {Title: "URL"}, {Title: "Status"}, {Title: "Size"}, {Title: "Duration"}},
+		filterChain:    NewFilterChain(),
+		searchFilter:   NewSearchFilter(),
+		fileTypeFilter: NewFileTypeFilter(),
+		methodFilter:   NewMethodFilter(),
+		statusFilter:   NewStatusClassFilter(),
+		timeFilter:     NewTimeRangeFilter(),
+		width:          120,
+	}
+	m.table = table.New(table.WithColumns(m.columns), table.WithRows(rows))
+
+	m.openStatsModal()
+	if m.filterStats.TotalEntries != 3 {
+		t.Fatalf("unfiltered stats cover %d entries, want 3", m.filterStats.TotalEntries)
+	}
+
+	// filtering while the modal is open refreshes the snapshot
+	m.methodFilter.ToggleMethod("POST", false)
+	m.applyFilters()
+	if m.filterStats.TotalEntries != 2 {
+		t.Fatalf("filtered stats cover %d entries, want 2", m.filterStats.TotalEntries)
+	}
+	if m.filterStats.Slowest[0].Index != 0 {
+		t.Errorf("slowest visible entry = %d, want 0", m.filterStats.Slowest[0].Index)
+	}
+
+	view := m.renderStatsModal()
+	for _, want := range []string{"2 of 3 entries", "Status codes", "example.com/a"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("stats modal missing %q", want)
+		}
+	}
+	if strings.Contains(view, "example.com/b") {
+		t.Error("stats modal lists a filtered out entry")
+	}
+
+	if handled, _ := m.handleStatsModalKeys("esc"); !handled || m.activeModal != ModalNone {
+		t.Error("esc should close the stats modal")
+	}
+}
+
+func TestStreamerStatsLine(t *testing.T) {
+	if line := streamerStatsLine(motor.StreamerStats{}); line != "" {
+		t.Errorf("line before any read = %q, want empty", line)
+	}
+
+	stats := motor.StreamerStats{TotalReads: 4, CacheHits: 1, CacheMisses: 3, EntriesPerSecond: 2, BytesPerSecond: 2048, CacheHitRatio: 0.25}
+	if line, want := streamerStatsLine(stats), "4 entries, 2.0/s, "+formatSize(2048)+"/s, 25% cached"; line != want {
+		t.Errorf("line = %q, want %q", line, want)
+	}
+
+	stats.CacheHits, stats.CacheMisses, stats.CacheHitRatio = 0, 0, 0
+	if line := streamerStatsLine(stats); strings.Contains(line, "cached") {
+		t.Errorf("line without a cache = %q, want no hit ratio", line)
+	}
+}
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// statusClassCategories are the status filter checkboxes; "Other" covers 1xx and aborted (0) responses
+var statusClassCategories = []string{"2xx", "3xx", "4xx", "5xx", "Other"}
+
+func (m *HARViewModel) renderStatusFilterModal() string {
+	return renderCheckboxModal("Status Filters", statusClassCategories, m.statusCheckboxes[:], m.statusCursor)
+}
+
+func (m *HARViewModel) toggleStatusCheckbox() {
+	if m.statusCursor < len(statusClassCategories) {
+		m.statusCheckboxes[m.statusCursor] = !m.statusCheckboxes[m.statusCursor]
+		for i, class := range statusClassCategories {
+			m.statusFilter.ToggleClass(class, m.statusCheckboxes[i])
+		}
+		m.applyFilters()
+	} else {
+		// reset option selected
+		m.resetStatusFilters()
+	}
+}
+
+func (m *HARViewModel) resetStatusFilters() {
+	for i := range m.statusCheckboxes {
+		m.statusCheckboxes[i] = true
+	}
+	m.statusFilter.Clear()
+	m.applyFilters()
+}
+
+func (m *HARViewModel) handleStatusModalKeys(key string) (bool, tea.Cmd) {
+	if m.activeModal != ModalStatusFilter {
+		return false, nil
+	}
+
+	switch key {
+	case "esc", "e":
+		m.activeModal = ModalNone
+		return true, nil
+
+	case "r":
+		m.resetStatusFilters()
+		return true, nil
+
+	case "up":
+		m.statusCursor = moveCheckboxCursor(m.statusCursor, -1, len(statusClassCategories))
+		return true, nil
+
+	case "down":
+		m.statusCursor = moveCheckboxCursor(m.statusCursor, 1, len(statusClassCategories))
+		return true, nil
+
+	case " ", "space", "enter":
+		m.toggleStatusCheckbox()
+		return true, nil
+	}
+
+	return false, nil
+}
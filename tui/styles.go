@@ -1,30 +1,69 @@
 package tui
 
 import (
+    "image/color"
+    "strings"
+
     "github.com/charmbracelet/bubbles/v2/table"
     "github.com/charmbracelet/lipgloss/v2"
 )
 
-// Color constants matching vacuum EXACTLY
+// Color palette set by ApplyTheme; DefaultTheme matches vacuum EXACTLY
 var (
-    RGBBlue       = lipgloss.Color("45")
-    RGBPink       = lipgloss.Color("201")
-    RGBRed        = lipgloss.Color("196")
-    RGBYellow     = lipgloss.Color("220")
-    RGBGreen      = lipgloss.Color("46")
-    RGBGrey       = lipgloss.Color("246")
-    RGBSubtlePink = lipgloss.Color("#2a1a2a")
+    RGBBlue       color.Color
+    RGBPink       color.Color
+    RGBRed        color.Color
+    RGBYellow     color.Color
+    RGBGreen      color.Color
+    RGBGrey       color.Color
+    RGBSubtlePink color.Color
 )
 
 // Syntax highlighting styles for JSON/YAML
 var (
-    SyntaxKeyStyle    = lipgloss.NewStyle().Foreground(RGBBlue).Bold(true)
-    SyntaxNumberStyle = lipgloss.NewStyle().Foreground(RGBYellow).Bold(true)
-    SyntaxDashStyle   = lipgloss.NewStyle().Foreground(RGBPink)
+    SyntaxKeyStyle    lipgloss.Style
+    SyntaxNumberStyle lipgloss.Style
+    SyntaxDashStyle   lipgloss.Style
 )
 
 // General styles
 var (
+    TitleStyle         lipgloss.Style
+    SubtitleStyle      lipgloss.Style
+    HeaderStyle        lipgloss.Style
+    SelectedStyle      lipgloss.Style
+    StatusOKStyle      lipgloss.Style
+    StatusWarningStyle lipgloss.Style
+    StatusErrorStyle   lipgloss.Style
+    BorderStyle        lipgloss.Style
+    ViewportTitleStyle lipgloss.Style
+    HelpStyle          lipgloss.Style
+    HelpKeyStyle       lipgloss.Style
+    ErrorStyle         lipgloss.Style
+)
+
+// Table colorization styles for methods and status codes
+var (
+    // HTTP Methods
+    StyleMethodGreen  lipgloss.Style // GET, QUERY
+    StyleMethodYellow lipgloss.Style // PATCH
+    StyleMethodBlue   lipgloss.Style // PUT, POST
+    StyleMethodRed    lipgloss.Style // DELETE
+
+    // Status codes
+    StyleStatus4xx lipgloss.Style // 4xx errors
+    StyleStatus5xx lipgloss.Style // 5xx errors
+
+    // Duration (faint like entry count)
+    StyleDurationFaint lipgloss.Style
+)
+
+// buildStyles derives every package level style from the current palette
+func buildStyles() {
+    SyntaxKeyStyle = lipgloss.NewStyle().Foreground(RGBBlue).Bold(true)
+    SyntaxNumberStyle = lipgloss.NewStyle().Foreground(RGBYellow).Bold(true)
+    SyntaxDashStyle = lipgloss.NewStyle().Foreground(RGBPink)
+
     TitleStyle = lipgloss.NewStyle().
         Bold(true).
         Foreground(RGBPink)
@@ -68,23 +107,29 @@ var (
     ErrorStyle = lipgloss.NewStyle().
         Foreground(RGBRed).
         Bold(true)
-)
 
-// Table colorization styles for methods and status codes
-var (
-    // HTTP Methods
-    StyleMethodGreen  = lipgloss.NewStyle().Foreground(RGBGreen)  // GET, QUERY
-    StyleMethodYellow = lipgloss.NewStyle().Foreground(RGBYellow) // PATCH
-    StyleMethodBlue   = lipgloss.NewStyle().Foreground(RGBBlue)   // PUT, POST
-    StyleMethodRed    = lipgloss.NewStyle().Foreground(RGBRed)    // DELETE
+    StyleMethodGreen = lipgloss.NewStyle().Foreground(RGBGreen)
+    StyleMethodYellow = lipgloss.NewStyle().Foreground(RGBYellow)
+    StyleMethodBlue = lipgloss.NewStyle().Foreground(RGBBlue)
+    StyleMethodRed = lipgloss.NewStyle().Foreground(RGBRed)
 
-    // Status codes
-    StyleStatus4xx = lipgloss.NewStyle().Foreground(RGBYellow) // 4xx errors
-    StyleStatus5xx = lipgloss.NewStyle().Foreground(RGBRed)    // 5xx errors
+    StyleStatus4xx = lipgloss.NewStyle().Foreground(RGBYellow)
+    StyleStatus5xx = lipgloss.NewStyle().Foreground(RGBRed)
 
-    // Duration (faint like entry count)
     StyleDurationFaint = lipgloss.NewStyle().Faint(true)
-)
+
+    // styles other files pre-compute for their hot paths
+    keyStyleBase = lipgloss.NewStyle().
+        Foreground(RGBGrey).
+        Align(lipgloss.Right)
+    sectionHeaderStyleBase = lipgloss.NewStyle().
+        Bold(true).
+        Foreground(RGBPink)
+    markupCommentStyle = lipgloss.NewStyle().Foreground(RGBGrey).Faint(true)
+
+    prerenderMethods()
+    selectedLineMarker = styleEscapePrefix(tableSelectedStyle())
+}
 
 // ApplyTableStyles applies the Vacuum table theme to match exactly
 func ApplyTableStyles(t table.Model) table.Model {
@@ -101,11 +146,7 @@ func ApplyTableStyles(t table.Model) table.Model {
         Bold(true).
         Padding(0, 1)
 
-    s.Selected = lipgloss.NewStyle().
-        Bold(true).
-        Foreground(RGBPink).
-        Background(RGBSubtlePink).
-        Padding(0, 0)
+    s.Selected = tableSelectedStyle()
 
     s.Cell = lipgloss.NewStyle().
         BorderStyle(lipgloss.NormalBorder()).
@@ -116,3 +157,21 @@ func ApplyTableStyles(t table.Model) table.Model {
     t.SetStyles(s)
     return t
 }
+
+// tableSelectedStyle is the style of the table's selected row
+func tableSelectedStyle() lipgloss.Style {
+    return lipgloss.NewStyle().
+        Bold(true).
+        Foreground(RGBPink).
+        Background(RGBSubtlePink).
+        Padding(0, 0)
+}
+
+// styleEscapePrefix returns the escape sequence a style opens its output with
+func styleEscapePrefix(style lipgloss.Style) string {
+    rendered := style.Render("x")
+    if i := strings.Index(rendered, "x"); i > 0 {
+        return rendered[:i]
+    }
+    return ""
+}
@@ -12,8 +12,15 @@ import (
 func (m *HARViewModel) buildTableRows() {
 	rows := make([]table.Row, 0, len(m.allEntries))
 
-	for _, entry := range m.allEntries {
-		row := formatEntryRow(entry, m.width)
+	for i := range m.allEntries {
+		var row table.Row
+		if m.injections.IsInjected(i) {
+			// narrow the URL so the marker doesn't push it past the column width
+			row = m.entryRow(i, m.width-injectionMarkerWidth)
+			row[1] = injectionMarker + " " + row[1]
+		} else {
+			row = m.entryRow(i, m.width)
+		}
 		rows = append(rows, row)
 	}
 
@@ -26,14 +33,33 @@ func (m *HARViewModel) buildTableRows() {
 	}
 }
 
-func formatEntryRow(entry *motor.EntryMetadata, terminalWidth int) table.Row {
-	method := formatMethod(entry.Method)
-	urlPath := formatURL(entry.URL, terminalWidth)
-	status := formatStatus(entry.StatusCode, entry.StatusText)
-	size := formatSize(entry.ResponseSize)
-	duration := formatDuration(entry.Duration)
+// entryRow renders the cells of the entry at index for the table's columns
+func (m *HARViewModel) entryRow(index, terminalWidth int) table.Row {
+	var timeline *motor.TimelineEntry
+	if m.columnPreset.usesTimeline() {
+		timeline = m.entryTimeline(index)
+	}
+	return m.columnPreset.timedRow(m.allEntries[index], timeline, terminalWidth)
+}
 
-	return table.Row{method, urlPath, status, size, duration}
+// entryTimeline is the entry's place on the capture timeline, nil without a timestamp. the
+// timeline is laid out on first use.
+func (m *HARViewModel) entryTimeline(index int) *motor.TimelineEntry {
+	if m.timeline == nil && m.index != nil {
+		m.timeline = make([]motor.TimelineEntry, len(m.allEntries))
+		for i := range m.timeline {
+			m.timeline[i].Index = -1
+		}
+		for _, entry := range m.index.Timeline() {
+			if entry.Index < len(m.timeline) {
+				m.timeline[entry.Index] = entry
+			}
+		}
+	}
+	if index >= len(m.timeline) || m.timeline[index].Index < 0 {
+		return nil
+	}
+	return &m.timeline[index]
 }
 
 func formatMethod(method string) string {
@@ -48,19 +74,12 @@ func formatMethod(method string) string {
 	return method
 }
 
-func formatURL(fullURL string, terminalWidth int) string {
+// formatURL shows the path and query of a url, cut short to availableWidth
+func formatURL(fullURL string, availableWidth int) string {
 	if fullURL == "" {
 		return "/"
 	}
 
-	// Calculate the available width for URL column
-	// Account for all other columns and borders/padding
-	availableWidth := terminalWidth - methodColumnWidth - statusColumnWidth - sizeColumnWidth - durationColumnWidth - borderPadding - 6
-	if availableWidth < minURLColumnWidth {
-		availableWidth = minURLColumnWidth
-	}
-	// Don't limit to maxURLColumnWidth anymore - use all available space
-
 	u, err := url.Parse(fullURL)
 	if err != nil {
 		// If parsing fails, just truncate the raw URL
@@ -103,21 +122,25 @@ func formatStatus(code int, text string) string {
 	return fmt.Sprintf("%d", code)
 }
 
+// formatSize renders a byte count in binary units, e.g. "512B", "1.5KB" or "2.0GB"; zero and
+// unknown (negative) sizes render as "---"
 func formatSize(bytes int64) string {
-	if bytes == 0 {
+	if bytes <= 0 {
 		return "---"
 	}
 
 	kb := float64(bytes) / 1024.0
+	mb := kb / 1024.0
 
 	switch {
 	case kb < 1:
 		return fmt.Sprintf("%dB", bytes)
 	case kb < 1024:
 		return fmt.Sprintf("%.1fKB", kb)
-	default:
-		mb := kb / 1024.0
+	case mb < 1024:
 		return fmt.Sprintf("%.1fMB", mb)
+	default:
+		return fmt.Sprintf("%.1fGB", mb/1024.0)
 	}
 }
 
@@ -0,0 +1,203 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// teaPackage is where bubbletea's own messages live; they are meant for the program, not a tab
+var teaPackage = reflect.TypeOf(tea.QuitMsg{}).PkgPath()
+
+// tabMsg carries a message produced by one tab's commands back to that tab, so a search finishing
+// or indexing progress in a background file never lands in the active one
+type tabMsg struct {
+	tab int
+	msg tea.Msg
+}
+
+// TabsModel shows several HAR files, each in its own HARViewModel with its own streamer, reader,
+// searcher, filters and selection. only the first file is indexed up front, the others on the first
+// switch to them. ctrl+n and ctrl+p move between the tabs while no modal is open.
+type TabsModel struct {
+	tabs    []*HARViewModel
+	started []bool // whether the tab's Init has run, i.e. indexing has begun
+	active  int
+	width   int
+	height  int
+}
+
+// NewTabsModel creates a tabbed model over tabs, with the first tab active; a single tab is shown
+// without a tab bar
+func NewTabsModel(tabs ...*HARViewModel) *TabsModel {
+	return &TabsModel{
+		tabs:    tabs,
+		started: make([]bool, len(tabs)),
+	}
+}
+
+// Tabs returns every tab, in the order given to NewTabsModel
+func (t *TabsModel) Tabs() []*HARViewModel {
+	return t.tabs
+}
+
+// Started reports whether the tab at i was ever switched to, and so has a file open
+func (t *TabsModel) Started(i int) bool {
+	return i >= 0 && i < len(t.started) && t.started[i]
+}
+
+// Cleanup releases the resources of every started tab
+func (t *TabsModel) Cleanup() error {
+	var errs []error
+	for i, tab := range t.tabs {
+		if t.started[i] {
+			errs = append(errs, tab.Cleanup())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t *TabsModel) Init() tea.Cmd {
+	if len(t.tabs) == 0 {
+		return tea.Quit
+	}
+	return t.start(0)
+}
+
+// start runs a tab's Init the first time it is shown, sized to the current terminal
+func (t *TabsModel) start(i int) tea.Cmd {
+	if t.started[i] {
+		return nil
+	}
+	t.started[i] = true
+	cmds := []tea.Cmd{tagCmd(i, t.tabs[i].Init())}
+	if t.width > 0 {
+		cmds = append(cmds, t.forward(i, t.tabSize()))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (t *TabsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tabMsg:
+		return t, t.forward(msg.tab, msg.msg)
+
+	case tea.WindowSizeMsg:
+		t.width = msg.Width
+		t.height = msg.Height
+		var cmds []tea.Cmd
+		for i := range t.tabs {
+			if t.started[i] {
+				cmds = append(cmds, t.forward(i, t.tabSize()))
+			}
+		}
+		return t, tea.Batch(cmds...)
+
+	case tea.KeyPressMsg:
+		// an open modal keeps ctrl+n/ctrl+p, the detail view steps through its search matches with them
+		if len(t.tabs) > 1 && t.tabs[t.active].activeModal == ModalNone {
+			switch msg.String() {
+			case "ctrl+n":
+				return t, t.switchTo((t.active + 1) % len(t.tabs))
+			case "ctrl+p":
+				return t, t.switchTo((t.active + len(t.tabs) - 1) % len(t.tabs))
+			}
+		}
+	}
+
+	if len(t.tabs) == 0 {
+		return t, nil
+	}
+	return t, t.forward(t.active, msg)
+}
+
+// switchTo makes tab i active, starting it if it has never been shown
+func (t *TabsModel) switchTo(i int) tea.Cmd {
+	t.active = i
+	return t.start(i)
+}
+
+// forward hands msg to tab i, tagging the commands it returns with the tab
+func (t *TabsModel) forward(i int, msg tea.Msg) tea.Cmd {
+	if i < 0 || i >= len(t.tabs) {
+		return nil
+	}
+	_, cmd := t.tabs[i].Update(msg)
+	return tagCmd(i, cmd)
+}
+
+// tabSize is the window size a tab renders into, leaving a row for the tab bar
+func (t *TabsModel) tabSize() tea.WindowSizeMsg {
+	if len(t.tabs) > 1 {
+		return tea.WindowSizeMsg{Width: t.width, Height: t.height - 1}
+	}
+	return tea.WindowSizeMsg{Width: t.width, Height: t.height}
+}
+
+// tagCmd wraps cmd so its message comes back as a tabMsg for tab. batches are tagged command by
+// command, and bubbletea's own messages (quit, clipboard) still reach the program untouched.
+func tagCmd(tab int, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		msg := cmd()
+		switch msg := msg.(type) {
+		case nil:
+			return nil
+		case tea.BatchMsg:
+			tagged := make(tea.BatchMsg, len(msg))
+			for i, c := range msg {
+				tagged[i] = tagCmd(tab, c)
+			}
+			return tagged
+		}
+		if reflect.TypeOf(msg).PkgPath() == teaPackage {
+			return msg
+		}
+		return tabMsg{tab: tab, msg: msg}
+	}
+}
+
+func (t *TabsModel) View() string {
+	if len(t.tabs) == 0 {
+		return ""
+	}
+	view := t.tabs[t.active].View()
+	if len(t.tabs) == 1 || view == "" {
+		return view
+	}
+	return t.renderTabBar() + "\n" + view
+}
+
+// renderTabBar lists the open files by name, the active one highlighted
+func (t *TabsModel) renderTabBar() string {
+	activeStyle := lipgloss.NewStyle().
+		Background(RGBSubtlePink).
+		Foreground(RGBPink).
+		Bold(true)
+	inactiveStyle := lipgloss.NewStyle().Foreground(RGBGrey)
+	helpStyle := lipgloss.NewStyle().Foreground(RGBGrey).Faint(true)
+
+	labels := make([]string, len(t.tabs))
+	for i, tab := range t.tabs {
+		label := fmt.Sprintf(" %d %s ", i+1, filepath.Base(tab.fileName))
+		if i == t.active {
+			labels[i] = activeStyle.Render(label)
+		} else {
+			labels[i] = inactiveStyle.Render(label)
+		}
+	}
+
+	bar := strings.Join(labels, "│")
+	help := helpStyle.Render("ctrl+n/p: Switch File")
+	if padding := t.width - lipgloss.Width(bar) - lipgloss.Width(help); padding > 0 {
+		return bar + strings.Repeat(" ", padding) + help
+	}
+	return bar
+}
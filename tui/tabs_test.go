@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+func newTestTabs(t *testing.T, names ...string) *TabsModel {
+	t.Helper()
+	tabs := make([]*HARViewModel, 0, len(names))
+	for _, name := range names {
+		tab, err := NewHARViewModel(name)
+		if err != nil {
+			t.Fatalf("NewHARViewModel(%s): %v", name, err)
+		}
+		tabs = append(tabs, tab)
+	}
+	return NewTabsModel(tabs...)
+}
+
+func TestTabsModel_LazyStartAndSwitch(t *testing.T) {
+	model := newTestTabs(t, "/tmp/before.har", "/tmp/after.har")
+	before, after := model.Tabs()[0], model.Tabs()[1]
+
+	if model.Init() == nil {
+		t.Fatalf("expected the first tab to start indexing")
+	}
+	if !model.Started(0) || model.Started(1) {
+		t.Fatalf("only the first tab should start up front")
+	}
+
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	if before.height != 39 {
+		t.Errorf("the active tab should leave a row for the tab bar, got height %d", before.height)
+	}
+	if after.height != 0 {
+		t.Errorf("a tab not yet shown should not be sized, got height %d", after.height)
+	}
+
+	_, cmd := model.Update(tea.KeyPressMsg{Code: 'n', Mod: tea.ModCtrl})
+	if cmd == nil || model.active != 1 || !model.Started(1) {
+		t.Fatalf("ctrl+n should switch to and start the second tab")
+	}
+	if after.height != 39 || after.width != 120 {
+		t.Errorf("a started tab should take the current size, got %dx%d", after.width, after.height)
+	}
+
+	model.Update(tea.KeyPressMsg{Code: 'p', Mod: tea.ModCtrl})
+	if model.active != 0 {
+		t.Errorf("ctrl+p should go back to the first tab, got %d", model.active)
+	}
+	model.Update(tea.KeyPressMsg{Code: 'p', Mod: tea.ModCtrl})
+	if model.active != 1 {
+		t.Errorf("ctrl+p should wrap around to the last tab, got %d", model.active)
+	}
+
+	after.activeModal = ModalResponseFull
+	model.Update(tea.KeyPressMsg{Code: 'n', Mod: tea.ModCtrl})
+	if model.active != 1 {
+		t.Errorf("ctrl+n belongs to an open modal, not the tab bar")
+	}
+
+	bar := model.renderTabBar()
+	if !strings.Contains(bar, "1 before.har") || !strings.Contains(bar, "2 after.har") {
+		t.Errorf("tab bar should name both files, got %q", bar)
+	}
+}
+
+func TestTabsModel_RoutesMessagesToTheirTab(t *testing.T) {
+	model := newTestTabs(t, "a.har", "b.har")
+	model.Init()
+	model.switchTo(1)
+
+	// a command run by the background tab tags its message with that tab
+	msg := tagCmd(0, showStatusMessage("copied"))()
+	tagged, ok := msg.(tabMsg)
+	if !ok || tagged.tab != 0 {
+		t.Fatalf("expected a tabMsg for tab 0, got %#v", msg)
+	}
+
+	model.Update(tagged)
+	if model.Tabs()[0].statusMessage != "copied" {
+		t.Errorf("the message should reach the tab that produced it")
+	}
+	if model.Tabs()[1].statusMessage != "" {
+		t.Errorf("the active tab should not see another tab's message")
+	}
+
+	if _, ok := tagCmd(0, tea.Quit)().(tea.QuitMsg); !ok {
+		t.Errorf("quit should reach the program untagged")
+	}
+	batch, ok := tagCmd(1, tea.Batch(showStatusMessage("a"), showStatusMessage("b")))().(tea.BatchMsg)
+	if !ok || len(batch) != 2 {
+		t.Fatalf("batches should stay batches, tagged command by command")
+	}
+	if inner, ok := batch[0]().(tabMsg); !ok || inner.tab != 1 {
+		t.Errorf("expected the batched command to be tagged for tab 1, got %#v", inner)
+	}
+}
+
+func TestTabsModel_SingleTabHasNoTabBar(t *testing.T) {
+	model := newTestTabs(t, "only.har")
+	model.Init()
+	model.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	if model.Tabs()[0].height != 24 {
+		t.Errorf("a lone tab should get the full height, got %d", model.Tabs()[0].height)
+	}
+	if strings.Contains(model.View(), "ctrl+n/p") {
+		t.Errorf("a lone tab should not render a tab bar")
+	}
+}
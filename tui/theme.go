@@ -0,0 +1,171 @@
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// ThemeEnvVar names a theme file to load instead of the default path
+const ThemeEnvVar = "HARIFIC_THEME"
+
+// Theme is the tui color palette. every color is an ANSI 256 color index such as "201" or a hex
+// value such as "#2a1a2a".
+type Theme struct {
+	Primary   string // borders, titles, json keys, PUT and POST
+	Accent    string // headings, highlights and the selected row's text
+	Error     string // 5xx statuses, DELETE and errors
+	Warning   string // 4xx statuses, PATCH and numbers
+	Success   string // GET and QUERY
+	Muted     string // labels and help text
+	Selection string // background of the selected row and focused options
+}
+
+// DefaultTheme is the built in palette, matching vacuum
+func DefaultTheme() Theme {
+	return Theme{
+		Primary:   "45",
+		Accent:    "201",
+		Error:     "196",
+		Warning:   "220",
+		Success:   "46",
+		Muted:     "246",
+		Selection: "#2a1a2a",
+	}
+}
+
+// themeKeys maps theme file keys to the palette fields they set
+func (t *Theme) themeKeys() map[string]*string {
+	return map[string]*string{
+		"primary":   &t.Primary,
+		"accent":    &t.Accent,
+		"error":     &t.Error,
+		"warning":   &t.Warning,
+		"success":   &t.Success,
+		"muted":     &t.Muted,
+		"selection": &t.Selection,
+	}
+}
+
+// DefaultThemePath returns the theme file to load: $HARIFIC_THEME when set, otherwise
+// theme.toml in the harific config directory (e.g. ~/.config/harific/theme.toml)
+func DefaultThemePath() (string, error) {
+	if path := os.Getenv(ThemeEnvVar); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "harific", "theme.toml"), nil
+}
+
+// LoadTheme reads a theme file; a missing file yields DefaultTheme. the file is a flat TOML table
+// of color strings, and any color it leaves out keeps its default:
+//
+//	# light terminal
+//	accent = "127"
+//	selection = "#f0d8f0"
+func LoadTheme(path string) (Theme, error) {
+	theme := DefaultTheme()
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return theme, nil
+		}
+		return theme, fmt.Errorf("failed to read theme: %w", err)
+	}
+	defer file.Close()
+
+	fields := theme.themeKeys()
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		key, value, ok, err := parseThemeLine(scanner.Text())
+		if err != nil {
+			return DefaultTheme(), fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		if !ok {
+			continue
+		}
+
+		field, known := fields[key]
+		if !known {
+			return DefaultTheme(), fmt.Errorf("%s:%d: unknown color %q", path, line, key)
+		}
+		if !validThemeColor(value) {
+			return DefaultTheme(), fmt.Errorf("%s:%d: invalid color %q for %s (expected 0-255 or #rrggbb)", path, line, value, key)
+		}
+		*field = value
+	}
+	if err := scanner.Err(); err != nil {
+		return DefaultTheme(), fmt.Errorf("failed to read theme: %w", err)
+	}
+	return theme, nil
+}
+
+// parseThemeLine parses a `key = "value"` line; ok is false for blank and comment lines
+func parseThemeLine(line string) (key, value string, ok bool, err error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", false, nil
+	}
+
+	key, rest, found := strings.Cut(line, "=")
+	if !found {
+		return "", "", false, fmt.Errorf("expected key = \"color\"")
+	}
+	key = strings.TrimSpace(key)
+	rest = strings.TrimSpace(rest)
+
+	if len(rest) < 2 || (rest[0] != '"' && rest[0] != '\'') {
+		return "", "", false, fmt.Errorf("the value for %s must be a quoted string", key)
+	}
+	end := strings.IndexByte(rest[1:], rest[0])
+	if end < 0 {
+		return "", "", false, fmt.Errorf("unterminated string for %s", key)
+	}
+	value = rest[1 : end+1]
+
+	// only a comment may follow the closing quote
+	if trailing := strings.TrimSpace(rest[end+2:]); trailing != "" && !strings.HasPrefix(trailing, "#") {
+		return "", "", false, fmt.Errorf("unexpected %q after the value for %s", trailing, key)
+	}
+	return key, value, true, nil
+}
+
+// validThemeColor accepts an ANSI 256 color index or a #rgb / #rrggbb hex color
+func validThemeColor(value string) bool {
+	if hex, ok := strings.CutPrefix(value, "#"); ok {
+		if len(hex) != 3 && len(hex) != 6 {
+			return false
+		}
+		_, err := strconv.ParseUint(hex, 16, 32)
+		return err == nil
+	}
+	n, err := strconv.Atoi(value)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// ApplyTheme sets the palette and rebuilds every style derived from it. call it before the tui
+// starts; styles already rendered keep the colors they were rendered with.
+func ApplyTheme(theme Theme) {
+	RGBBlue = lipgloss.Color(theme.Primary)
+	RGBPink = lipgloss.Color(theme.Accent)
+	RGBRed = lipgloss.Color(theme.Error)
+	RGBYellow = lipgloss.Color(theme.Warning)
+	RGBGreen = lipgloss.Color(theme.Success)
+	RGBGrey = lipgloss.Color(theme.Muted)
+	RGBSubtlePink = lipgloss.Color(theme.Selection)
+	buildStyles()
+}
+
+func init() {
+	ApplyTheme(DefaultTheme())
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+func writeTheme(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "theme.toml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadThemeMissingFile(t *testing.T) {
+	theme, err := LoadTheme(filepath.Join(t.TempDir(), "theme.toml"))
+	if err != nil {
+		t.Fatalf("missing theme file should not be an error: %v", err)
+	}
+	if theme != DefaultTheme() {
+		t.Errorf("got %+v, want the default theme", theme)
+	}
+}
+
+func TestLoadThemeOverrides(t *testing.T) {
+	path := writeTheme(t, `# light terminal
+accent = "127"   # magenta
+selection = '#f0d8f0'
+
+muted="240"
+`)
+
+	theme, err := LoadTheme(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := DefaultTheme()
+	want.Accent = "127"
+	want.Selection = "#f0d8f0"
+	want.Muted = "240"
+	if theme != want {
+		t.Errorf("got %+v, want %+v", theme, want)
+	}
+}
+
+func TestLoadThemeErrors(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{`accnet = "127"`, "unknown color"},
+		{`accent = "pinkish"`, "invalid color"},
+		{`accent = "#12345"`, "invalid color"},
+		{`accent = "256"`, "invalid color"},
+		{`accent = 127`, "quoted string"},
+		{`accent = "127`, "unterminated"},
+		{`accent = "127" extra`, "unexpected"},
+		{`[colors]`, "expected key"},
+	}
+	for _, tt := range tests {
+		_, err := LoadTheme(writeTheme(t, "primary = \"45\"\n"+tt.content))
+		if err == nil {
+			t.Errorf("%q: expected an error", tt.content)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) || !strings.Contains(err.Error(), ":2:") {
+			t.Errorf("%q: error %q should mention %q on line 2", tt.content, err, tt.want)
+		}
+	}
+}
+
+func TestApplyTheme(t *testing.T) {
+	if selectedLineMarker != "\x1b[1;38;5;201;48;2;42;26;42m" {
+		t.Errorf("default selected row marker changed: %q", selectedLineMarker)
+	}
+
+	theme := DefaultTheme()
+	theme.Accent = "127"
+	theme.Success = "#00aa00"
+	ApplyTheme(theme)
+	defer ApplyTheme(DefaultTheme())
+
+	if !strings.Contains(renderedGET, "38;2;0;170;0") {
+		t.Errorf("GET was not re-rendered with the success color: %q", renderedGET)
+	}
+	if !strings.Contains(selectedLineMarker, "38;5;127") {
+		t.Errorf("selected row marker should follow the accent color: %q", selectedLineMarker)
+	}
+
+	// the selected row is still recognised and left alone by the table colorizer
+	selected := tableSelectedStyle().Render(" GET  https://example.com  200 ")
+	if got := ColorizeHARTableOutput("header\n"+selected, 0, nil); !strings.HasSuffix(got, selected) {
+		t.Errorf("selected row was recolorized: %q", got)
+	}
+	if lipgloss.Color(theme.Accent) != RGBPink {
+		t.Error("accent should set the pink palette color")
+	}
+}
@@ -0,0 +1,248 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// time range modal rows, in cursor order
+const (
+	timeCursorFrom = iota
+	timeCursorTo
+	timeCursorUntimed
+	timeCursorReset
+	timeCursorCount
+)
+
+// timeOfDayLayouts are clock times placed on the capture's first day
+var timeOfDayLayouts = []string{"15:04:05.000", "15:04:05", "15:04"}
+
+// parseTimeBound turns a time range input into an instant. empty input is an open bound,
+// "+90s" or "+1m30s" is an offset from captureStart, "10:04" or "10:04:05" is a clock time on
+// the day the capture started, and RFC 3339 timestamps are taken as they are.
+func parseTimeBound(input string, captureStart time.Time) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, input); err == nil {
+		return t, nil
+	}
+
+	if captureStart.IsZero() {
+		return time.Time{}, fmt.Errorf("no timestamps in this har; use an RFC 3339 time")
+	}
+
+	if offset, ok := strings.CutPrefix(input, "+"); ok {
+		d, err := time.ParseDuration(offset)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid offset %q", input)
+		}
+		return captureStart.Add(d), nil
+	}
+
+	for _, layout := range timeOfDayLayouts {
+		clock, err := time.Parse(layout, input)
+		if err != nil {
+			continue
+		}
+		year, month, day := captureStart.Date()
+		return time.Date(year, month, day, clock.Hour(), clock.Minute(), clock.Second(),
+			clock.Nanosecond(), captureStart.Location()), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q", input)
+}
+
+// captureStart is the earliest entry timestamp, the origin for relative offsets
+func (m *HARViewModel) captureStart() time.Time {
+	if m.index == nil {
+		return time.Time{}
+	}
+	return m.index.TimeRange.Start
+}
+
+func newTimeInput(prompt string) textinput.Model {
+	input := textinput.New()
+	input.Prompt = prompt
+	input.Placeholder = "+30s, 10:04"
+	input.CharLimit = 64
+	input.SetWidth(18)
+	return input
+}
+
+// openTimeModal opens the time range modal with the from input focused
+func (m *HARViewModel) openTimeModal() tea.Cmd {
+	m.activeModal = ModalTimeRange
+	m.timeError = ""
+	m.timeCursor = timeCursorFrom
+	m.timeToInput.Blur()
+	return m.timeFromInput.Focus()
+}
+
+// moveTimeCursor moves between the modal rows, focusing whichever input the cursor lands on
+func (m *HARViewModel) moveTimeCursor(delta int) tea.Cmd {
+	m.timeCursor = (m.timeCursor + delta + timeCursorCount) % timeCursorCount
+	m.timeFromInput.Blur()
+	m.timeToInput.Blur()
+
+	switch m.timeCursor {
+	case timeCursorFrom:
+		return m.timeFromInput.Focus()
+	case timeCursorTo:
+		return m.timeToInput.Focus()
+	}
+	return nil
+}
+
+// applyTimeRange parses both inputs into the time filter; a bad input leaves the filter as it was
+func (m *HARViewModel) applyTimeRange() bool {
+	start := m.captureStart()
+	from, err := parseTimeBound(m.timeFromInput.Value(), start)
+	if err != nil {
+		m.timeError = "From: " + err.Error()
+		return false
+	}
+	to, err := parseTimeBound(m.timeToInput.Value(), start)
+	if err != nil {
+		m.timeError = "To: " + err.Error()
+		return false
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		m.timeError = "To is before From"
+		return false
+	}
+
+	m.timeError = ""
+	m.timeFilter.SetRange(from, to)
+	m.timeFilter.SetExcludeUntimed(m.timeExcludeUntimed)
+	m.applyFilters()
+	return true
+}
+
+func (m *HARViewModel) resetTimeFilter() {
+	m.timeFromInput.SetValue("")
+	m.timeToInput.SetValue("")
+	m.timeExcludeUntimed = false
+	m.timeError = ""
+	m.timeFilter.Clear()
+	m.applyFilters()
+}
+
+// handleTimeModalKeys takes the whole key message so typed characters reach the inputs
+func (m *HARViewModel) handleTimeModalKeys(msg tea.KeyPressMsg) (bool, tea.Cmd) {
+	if m.activeModal != ModalTimeRange {
+		return false, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.activeModal = ModalNone
+		m.timeFromInput.Blur()
+		m.timeToInput.Blur()
+		return true, nil
+
+	case "tab", "down":
+		return true, m.moveTimeCursor(1)
+
+	case "shift+tab", "up":
+		return true, m.moveTimeCursor(-1)
+
+	case "enter":
+		if m.timeCursor == timeCursorReset {
+			m.resetTimeFilter()
+			return true, nil
+		}
+		if m.applyTimeRange() {
+			m.activeModal = ModalNone
+			m.timeFromInput.Blur()
+			m.timeToInput.Blur()
+		}
+		return true, nil
+
+	case " ", "space":
+		switch m.timeCursor {
+		case timeCursorUntimed:
+			m.timeExcludeUntimed = !m.timeExcludeUntimed
+			m.applyTimeRange()
+			return true, nil
+		case timeCursorReset:
+			m.resetTimeFilter()
+			return true, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	switch m.timeCursor {
+	case timeCursorFrom:
+		m.timeFromInput, cmd = m.timeFromInput.Update(msg)
+	case timeCursorTo:
+		m.timeToInput, cmd = m.timeToInput.Update(msg)
+	}
+	return true, cmd
+}
+
+func (m *HARViewModel) renderTimeRangeModal() string {
+	modalStyle := lipgloss.NewStyle().
+		Width(30).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(RGBBlue).
+		Padding(1)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(RGBBlue)
+
+	highlightStyle := lipgloss.NewStyle().
+		Background(RGBSubtlePink).
+		Foreground(RGBPink).
+		Bold(true)
+
+	helpStyle := lipgloss.NewStyle().Foreground(RGBGrey).Faint(true)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Time Range"))
+	content.WriteString("\n")
+	if start := m.captureStart(); !start.IsZero() {
+		content.WriteString(helpStyle.Render("Capture starts " + start.Format("15:04:05")))
+	}
+	content.WriteString("\n\n")
+
+	content.WriteString(m.timeFromInput.View())
+	content.WriteString("\n")
+	content.WriteString(m.timeToInput.View())
+	content.WriteString("\n\n")
+
+	checkbox := "[ ]"
+	if m.timeExcludeUntimed {
+		checkbox = "[x]"
+	}
+	untimedLine := fmt.Sprintf("  %s Hide untimed entries", checkbox)
+	if m.timeCursor == timeCursorUntimed {
+		untimedLine = highlightStyle.Render(fmt.Sprintf("> %s Hide untimed entries", checkbox))
+	}
+	content.WriteString(untimedLine)
+	content.WriteString("\n\n")
+
+	resetLine := " [ ] Reset Time Range"
+	if m.timeCursor == timeCursorReset {
+		resetLine = highlightStyle.Render("> [*] Reset Time Range")
+	}
+	content.WriteString(resetLine)
+
+	if m.timeError != "" {
+		content.WriteString("\n\n")
+		content.WriteString(lipgloss.NewStyle().Foreground(RGBRed).Render(m.timeError))
+	}
+
+	content.WriteString("\n\n")
+	content.WriteString(helpStyle.Render("+30s, 10:04 or RFC 3339 | Tab: Next | Enter: Apply | Esc: Close"))
+
+	return modalStyle.Render(content.String())
+}
@@ -30,6 +30,8 @@ func (m *HARViewModel) render() string {
         return m.renderSplitView()
     case ViewModeTableWithSearch:
         return m.renderSearchView()
+    case ViewModeEndpoints:
+        return m.renderEndpointsView()
     default:
         return m.renderTableView()
     }
@@ -43,7 +45,7 @@ func (m *HARViewModel) renderTableView() string {
 
     // post-process table view to add colorization (vacuum pattern)
     tableView := m.table.View()
-    colorizedTable := ColorizeHARTableOutput(tableView, m.table.Cursor(), m.rows)
+    colorizedTable := ColorizeHARTableOutput(tableView, m.table.Cursor(), m.table.Rows())
     builder.WriteString(colorizedTable)
 
     builder.WriteString("\n")
@@ -58,9 +60,16 @@ func (m *HARViewModel) renderSplitView() string {
     builder.WriteString(m.renderTitle())
     builder.WriteString("\n")
 
+    if m.panelFullscreen {
+        builder.WriteString(m.renderFullscreenPanel())
+        builder.WriteString("\n")
+        builder.WriteString(m.renderStatusBar())
+        return builder.String()
+    }
+
     // post-process table view to add colorization (vacuum pattern)
     tableView := m.table.View()
-    colorizedTable := ColorizeHARTableOutput(tableView, m.table.Cursor(), m.rows)
+    colorizedTable := ColorizeHARTableOutput(tableView, m.table.Cursor(), m.table.Rows())
     builder.WriteString(colorizedTable)
 
     builder.WriteString("\n")
@@ -83,7 +92,7 @@ func (m *HARViewModel) renderSearchView() string {
     } else {
         // fallback to dynamic rendering if cache is empty
         tableView := m.table.View()
-        colorizedTable := ColorizeHARTableOutput(tableView, m.table.Cursor(), m.rows)
+        colorizedTable := ColorizeHARTableOutput(tableView, m.table.Cursor(), m.table.Rows())
         builder.WriteString(colorizedTable)
     }
 
@@ -108,6 +117,10 @@ func (m *HARViewModel) renderTitle() string {
     titleText := titleTextStyle.Render(title)
 
     entryCount := fmt.Sprintf("(%d entries", len(m.allEntries))
+    if m.filterChain != nil && m.filterChain.HasActiveFilters() {
+        entryCount = fmt.Sprintf("(%d of %d entries", m.visibleEntryCount(), len(m.allEntries))
+    }
+    entryCount += m.renderIndexWindow()
     if m.indexingTime > 0 {
         entryCount += fmt.Sprintf(", loaded in %v", m.indexingTime.Round(time.Millisecond))
     }
@@ -119,30 +132,104 @@ func (m *HARViewModel) renderTitle() string {
     return titleStyle.Render(titleText + countStyle.Render(entryCount))
 }
 
+// renderIndexWindow notes that a windowed index holds only part of the har, e.g.
+// ", showing 1-50000 of ~2000000"; it is empty for a whole index
+func (m *HARViewModel) renderIndexWindow() string {
+    if m.index == nil || !m.index.Windowed() {
+        return ""
+    }
+    first := m.index.FirstEntry + 1
+    last := m.index.FirstEntry + len(m.allEntries)
+    switch {
+    case len(m.allEntries) == 0:
+        return fmt.Sprintf(", none from %d of %d", first, m.index.HAREntries)
+    case m.index.HAREntries == 0:
+        return fmt.Sprintf(", showing %d-%d of more", first, last)
+    case m.index.EntriesCutOff:
+        return fmt.Sprintf(", showing %d-%d of ~%d", first, last, m.index.HAREntries)
+    }
+    return fmt.Sprintf(", showing %d-%d of %d", first, last, m.index.HAREntries)
+}
+
 func (m *HARViewModel) renderStatusBar() string {
+    if status, ok := m.renderExportStatus(); ok {
+        return lipgloss.NewStyle().Foreground(RGBPink).Render(status)
+    }
+    if status, ok := m.renderBodySaveStatus(); ok {
+        return lipgloss.NewStyle().Foreground(RGBPink).Render(status)
+    }
+    if status, ok := m.renderJumpStatus(); ok {
+        return lipgloss.NewStyle().Foreground(RGBPink).Render(status)
+    }
+
     var parts []string
 
     if m.viewMode == ViewModeTable {
         parts = append(parts, "↑/↓: Navigate")
         parts = append(parts, "Enter: View Details")
         parts = append(parts, "s: Search")
+        parts = append(parts, "o/O: Sort")
+        parts = append(parts, "C: Columns")
+        parts = append(parts, "':': Jump")
+        parts = append(parts, "p: Pages")
+        parts = append(parts, "u: Endpoints")
+        parts = append(parts, "f/m/e/t/S: Filter")
+        parts = append(parts, "i: Stats")
+        parts = append(parts, "b/d: Diff")
+        parts = append(parts, "c: Copy curl")
+        parts = append(parts, "y/Y: Copy URL/row")
+        parts = append(parts, "w: Export")
     } else if m.viewMode == ViewModeTableWithSearch {
-        parts = append(parts, "↑/↓: Navigate")
+        if m.searchCursor == searchCursorInput && m.searchHistory != nil && len(m.searchHistory.Queries) > 0 {
+            parts = append(parts, "↑/↓: History")
+        } else {
+            parts = append(parts, "↑/↓: Navigate")
+        }
         parts = append(parts, "←/→: Jump to Input")
         parts = append(parts, "Space: Toggle")
         parts = append(parts, "Enter: Search")
+        parts = append(parts, "ctrl+o: Settings")
         parts = append(parts, "Esc: Review Results")
     } else if m.viewMode == ViewModeTableFiltered {
         parts = append(parts, "↑/↓: Navigate")
         parts = append(parts, "Enter: View Details")
         parts = append(parts, "s: Search")
-        parts = append(parts, "Esc: Clear Filters")
+        parts = append(parts, "o/O: Sort")
+        parts = append(parts, "C: Columns")
+        parts = append(parts, "':': Jump")
+        parts = append(parts, "i: Stats")
+        parts = append(parts, "b/d: Diff")
+        parts = append(parts, "u: Endpoints")
+        if m.searchHighlight {
+            parts = append(parts, "n/N: Next Match")
+        }
+        parts = append(parts, "H: Highlight/Filter")
+        parts = append(parts, "y/Y: Copy URL/row")
+        parts = append(parts, "w: Export")
+        if m.endpointFilter.IsActive() {
+            parts = append(parts, "Esc: Back to Endpoints")
+        } else {
+            parts = append(parts, "Esc: Clear Filters")
+        }
+    } else if m.viewMode == ViewModeEndpoints {
+        parts = append(parts, "↑/↓: Navigate")
+        parts = append(parts, "Enter: Show Entries")
+        parts = append(parts, "Esc: Back")
+        parts = append(parts, fmt.Sprintf("Endpoint %d/%d", m.endpointTable.Cursor()+1, len(m.endpoints)))
+        return lipgloss.NewStyle().Faint(true).Render(strings.Join(parts, " | "))
     } else {
         // ViewModeTableWithSplit
         parts = append(parts, "↑/↓: Scroll")
         parts = append(parts, "Tab: Switch Panel")
         parts = append(parts, "/: Search JSON")
-        parts = append(parts, "Esc: Close Details")
+        parts = append(parts, "c: Copy curl")
+        parts = append(parts, "W: Save Body")
+        if m.panelFullscreen {
+            parts = append(parts, "z/Esc: Restore Split")
+        } else {
+            parts = append(parts, "z: Fullscreen")
+            parts = append(parts, "Esc: Close Details")
+        }
     }
 
     parts = append(parts, "q: Quit")
@@ -150,7 +237,9 @@ func (m *HARViewModel) renderStatusBar() string {
     // Show correct entry counts based on filtering
     if len(m.filteredIndices) > 0 {
         // Filters are active - show filtered position and count
-        if m.selectedIndex < len(m.filteredIndices) {
+        if id, ok := m.selectedPageHeader(); ok {
+            parts = append(parts, "Page: "+m.pageTitle(id))
+        } else if m.selectedIndex < len(m.filteredIndices) {
             actualIndex := m.filteredIndices[m.selectedIndex]
             info := fmt.Sprintf("Entry %d/%d (filtered: %d/%d)",
                 actualIndex+1, len(m.allEntries),
@@ -177,12 +266,24 @@ func (m *HARViewModel) renderStatusBar() string {
     statusStyle := lipgloss.NewStyle().Faint(true)
     statusBar := strings.Join(parts, " | ")
 
+    // transient confirmations replace the right-hand indicator while visible
+    if m.statusMessage != "" {
+        messageStyle := lipgloss.NewStyle().Foreground(RGBPink).Bold(true)
+        return statusStyle.Render(statusBar) + "  " + messageStyle.Render(m.statusMessage)
+    }
+
     // Add active search indicator on the right when there's an active search
     if m.searchQuery != "" && (m.viewMode == ViewModeTableFiltered || m.viewMode == ViewModeTable) {
         searchIndicatorStyle := lipgloss.NewStyle().
             Foreground(RGBPink).
             Bold(true)
         searchText := fmt.Sprintf("[search: %s]", m.searchQuery)
+        if m.searchTruncated {
+            searchText = fmt.Sprintf("[search: %s (first %d)]", m.searchQuery, maxSearchResults)
+        }
+        if breakdown := matchBreakdown(m.searchStats.MatchesByField); breakdown != "" {
+            searchText = breakdown + " " + searchText
+        }
         searchIndicator := searchIndicatorStyle.Render(searchText)
 
         // Calculate padding to right-align the search indicator
@@ -232,6 +333,25 @@ func (m *HARViewModel) renderSplitPanel() string {
     return lipgloss.JoinHorizontal(lipgloss.Top, leftPanel, rightPanel)
 }
 
+// renderFullscreenPanel renders only the focused panel at full screen size
+func (m *HARViewModel) renderFullscreenPanel() string {
+    if m.selectedEntry == nil {
+        return m.renderEmptyPanel()
+    }
+
+    panelWidth, panelHeight := m.calculatePanelDimensions()
+
+    panelStyle := lipgloss.NewStyle().
+        Width(panelWidth).
+        Height(panelHeight).
+        BorderStyle(lipgloss.NormalBorder()).
+        BorderForeground(RGBBlue)
+
+    if m.focusedViewport == ViewportFocusRequest {
+        return panelStyle.Render(m.requestViewport.View())
+    }
+    return panelStyle.Render(m.responseViewport.View())
+}
 
 // renderSearchPanel creates the search input panel with pink border styling.
 // The panel takes 30% of the vertical space at the bottom of the screen.
@@ -258,9 +378,15 @@ func (m *HARViewModel) renderSearchPanel() string {
 
     // Search label with spinner after when actively searching
     content.WriteString(labelStyle.Render("Search:"))
-    if m.isSearching {
+    if m.searchPatternErr != nil {
+        content.WriteString(" ")
+        content.WriteString(lipgloss.NewStyle().Foreground(RGBRed).Render(m.searchPatternErr.Error()))
+    } else if m.isSearching {
         content.WriteString(" ")
         content.WriteString(m.searchSpinner.View())
+    } else if line := searchStatsLine(m.searchStats); line != "" {
+        content.WriteString(" ")
+        content.WriteString(lipgloss.NewStyle().Foreground(RGBGrey).Faint(true).Render(line))
     }
     content.WriteString("\n")
     content.WriteString(m.searchInput.View())
@@ -276,6 +402,11 @@ func (m *HARViewModel) renderSearchPanel() string {
         {"Regex Mode", m.searchOptions[1], searchCursorOpt2},
         {"All Matches", m.searchOptions[2], searchCursorOpt3},
         {"Live Search", m.searchOptions[3], searchCursorOpt4},
+        {"Ignore Case", m.searchOptions[4], searchCursorOpt5},
+        {"Whole Word", m.searchOptions[5], searchCursorOpt6},
+        {"Fuzzy", m.searchOptions[6], searchCursorOpt7},
+        {"Glob", m.searchOptions[7], searchCursorOpt8},
+        {"Request Bodies", m.searchOptions[8], searchCursorOpt9},
     }
 
     for i, cb := range checkboxes {
@@ -321,7 +452,12 @@ func (m *HARViewModel) formatRequest() string {
         return "No request data"
     }
 
-    sections := buildRequestSections(&m.selectedEntry.Request)
+    sections := prependEntryComment(buildRequestSections(&m.selectedEntry.Request), m.selectedEntry)
+    if terms := m.injections.TermsFor(m.selectedEntryIndex()); len(terms) > 0 {
+        sections = append(sections, injectionSection(terms))
+    }
+    body := &m.selectedEntry.Request.Body
+    sections, _ = applyBinaryBody(sections, decodeBody(body.Content, ""), body.MIMEType, false)
 
     opts := RenderOptions{
         Width:    m.requestViewport.Width(),
@@ -338,14 +474,24 @@ func (m *HARViewModel) formatResponse() string {
     }
 
     sections := buildResponseSections(&m.selectedEntry.Response, &m.selectedEntry.Timings)
+    sections = appendWebSocketSection(sections, m.selectedEntry)
+    data := m.responseBodyData()
+    sections, isBinary := applyBinaryBody(sections, data, m.selectedEntry.Response.Body.MIMEType, false)
+    if !isBinary && m.decodeBodies {
+        sections = replaceBodyContent(sections, string(data))
+    }
 
     opts := RenderOptions{
         Width:    m.responseViewport.Width(),
         Truncate: true,
     }
 
-    // Render normally without search
-    return renderSections(sections, opts)
+    // the waterfall shows where the time went before any of the detail
+    waterfall := renderWaterfall(&m.selectedEntry.Timings, m.slowestEntry, m.responseViewport.Width())
+    if waterfall == "" {
+        return renderSections(sections, opts)
+    }
+    return waterfall + "\n\n" + renderSections(sections, opts)
 }
 
 func (m *HARViewModel) renderError() string {
@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ViewSettings are the table preferences remembered between sessions
+type ViewSettings struct {
+	Columns           ColumnPreset `json:"columns"`
+	SearchDebounceMS  int          `json:"searchDebounceMs,omitempty"`  // live search delay after a keystroke, 0 for the default
+	DetailDebounceMS  int          `json:"detailDebounceMs,omitempty"`  // detail view search delay after a keystroke, 0 for the default
+	DisableLiveSearch bool         `json:"disableLiveSearch,omitempty"` // open the search panel with live search off, searching on enter
+	HighlightSearch   bool         `json:"highlightSearch,omitempty"`   // mark search matches among every entry instead of filtering
+}
+
+// DefaultViewSettingsPath returns the settings file location under the user config directory
+func DefaultViewSettingsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "harific", "view.json"), nil
+}
+
+// LoadViewSettings reads the settings file; a missing file yields the defaults
+func LoadViewSettings(path string) (*ViewSettings, error) {
+	settings := &ViewSettings{}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return settings, nil
+		}
+		return nil, fmt.Errorf("failed to read view settings: %w", err)
+	}
+
+	if err := json.Unmarshal(data, settings); err != nil {
+		return nil, fmt.Errorf("failed to parse view settings: %w", err)
+	}
+	return settings, nil
+}
+
+// Save writes the settings file, creating its directory if needed
+func (s *ViewSettings) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode view settings: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write view settings: %w", err)
+	}
+	return nil
+}
@@ -8,18 +8,35 @@ import (
 	"github.com/charmbracelet/lipgloss/v2"
 )
 
+// search panel cursor positions: the input, then each checkbox
+const (
+	searchPanelInput = iota
+	searchPanelKeysOnly
+	searchPanelRegex
+	searchPanelCaseSensitive
+	searchPanelCount
+)
+
 // ViewportSearchState tracks search state for a single viewport
 type ViewportSearchState struct {
 	active         bool
 	query          string
 	keySearchOnly  bool
+	regex          bool   // query is a regular expression
+	caseSensitive  bool   // match case exactly
+	queryErr       string // why the query could not be used, e.g. an invalid regex
+	notice         string // outcome of the last export of the filtered view, until the view changes
 	matches        []JSONMatch
 	filtered       bool
 	searchInput    textinput.Model
-	cursor         int  // 0 = input field, 1 = checkbox
+	cursor         int  // a searchPanel* position
 	renderer       *JSONRenderer
 	contentSet     bool // Track if content has been set
 	locked         bool // When true, search won't update on keystrokes
+	currentMatch   int  // Index into the renderer's match lines, -1 before the first jump
+	contentLine    int  // Line the rendered JSON starts at within the viewport content
+	lineStarts     []int // Viewport row of each rendered JSON line once wrapped, nil when unwrapped
+	folding        bool  // The renderer draws the JSON with the search closed too, see StartFolding
 }
 
 // NewViewportSearchState creates a new viewport search state
@@ -38,6 +55,7 @@ func NewViewportSearchState() *ViewportSearchState {
 		searchInput:   input,
 		cursor:        0,
 		renderer:      nil,
+		currentMatch:  -1,
 	}
 }
 
@@ -60,6 +78,9 @@ func (s *ViewportSearchState) Deactivate() {
 	s.query = ""
 	s.matches = []JSONMatch{}
 	s.searchInput.SetValue("")
+	s.currentMatch = -1
+	s.queryErr = ""
+	s.notice = ""
 
 	// Reset the renderer to show unfiltered, unsearched content
 	if s.renderer != nil {
@@ -77,6 +98,10 @@ func (s *ViewportSearchState) Clear() {
 	s.searchInput.SetValue("")
 	s.renderer = nil
 	s.contentSet = false
+	s.currentMatch = -1
+	s.contentLine = 0
+	s.lineStarts = nil
+	s.folding = false
 }
 
 // SetContent updates the content being searched
@@ -121,8 +146,13 @@ func (s *ViewportSearchState) performSearch() {
 		return
 	}
 
-	s.renderer.SetSearch(s.query, s.keySearchOnly)
+	s.queryErr = ""
+	s.notice = ""
+	if err := s.renderer.SetSearchWithOptions(s.query, s.searchOptions()); err != nil {
+		s.queryErr = "invalid regex"
+	}
 	s.matches = s.renderer.searchEngine.matches
+	s.currentMatch = -1
 
 	// Sync filtered state with renderer
 	s.renderer.filtered = s.filtered
@@ -135,31 +165,93 @@ func (s *ViewportSearchState) ToggleFiltered() {
 	}
 
 	s.filtered = !s.filtered
+	s.notice = ""
 	s.locked = s.filtered  // Lock search when entering filtered mode
 	s.renderer.ToggleFiltered()
+	s.currentMatch = -1 // Match lines move between the filtered and full view
+}
+
+// MatchLineCount returns how many distinct lines hold a match in the current render
+func (s *ViewportSearchState) MatchLineCount() int {
+	if s.renderer == nil {
+		return 0
+	}
+	return len(s.renderer.MatchLines())
+}
+
+// StepMatch moves to the next (direction > 0) or previous match line, wrapping around at
+// either end, and returns the viewport line to scroll to. ok is false when there are no matches.
+func (s *ViewportSearchState) StepMatch(direction int) (line int, ok bool) {
+	count := s.MatchLineCount()
+	if count == 0 {
+		return 0, false
+	}
+	if s.currentMatch >= count {
+		s.currentMatch = -1
+	}
+
+	switch {
+	case s.currentMatch < 0 && direction < 0:
+		s.currentMatch = count - 1
+	case s.currentMatch < 0:
+		s.currentMatch = 0
+	case direction < 0:
+		s.currentMatch = (s.currentMatch - 1 + count) % count
+	default:
+		s.currentMatch = (s.currentMatch + 1) % count
+	}
+
+	return s.viewportRow(s.renderer.MatchLines()[s.currentMatch]), true
+}
+
+// searchOptions returns the checkbox settings as engine options
+func (s *ViewportSearchState) searchOptions() JSONSearchOptions {
+	return JSONSearchOptions{KeysOnly: s.keySearchOnly, Regex: s.regex, CaseSensitive: s.caseSensitive}
 }
 
 // ToggleKeySearchOnly toggles the key search mode
 func (s *ViewportSearchState) ToggleKeySearchOnly() {
 	s.keySearchOnly = !s.keySearchOnly
-	// Re-run search with new mode
+	s.rerunSearch()
+}
+
+// ToggleRegex toggles between substring and regular expression queries
+func (s *ViewportSearchState) ToggleRegex() {
+	s.regex = !s.regex
+	s.rerunSearch()
+}
+
+// ToggleCaseSensitive toggles whether the query must match case exactly
+func (s *ViewportSearchState) ToggleCaseSensitive() {
+	s.caseSensitive = !s.caseSensitive
+	s.rerunSearch()
+}
+
+// ToggleFocusedCheckbox toggles whichever checkbox the cursor is on
+func (s *ViewportSearchState) ToggleFocusedCheckbox() {
+	switch s.cursor {
+	case searchPanelKeysOnly:
+		s.ToggleKeySearchOnly()
+	case searchPanelRegex:
+		s.ToggleRegex()
+	case searchPanelCaseSensitive:
+		s.ToggleCaseSensitive()
+	}
+}
+
+// rerunSearch repeats the current query after the search mode changed
+func (s *ViewportSearchState) rerunSearch() {
 	if s.query != "" {
 		s.performSearch()
 	}
 }
 
-// MoveCursor moves the cursor between input and checkbox
+// MoveCursor moves the cursor between the input and the checkboxes, wrapping around
 func (s *ViewportSearchState) MoveCursor(direction int) {
 	if direction > 0 {
-		s.cursor++
-		if s.cursor > 1 {
-			s.cursor = 0
-		}
+		s.cursor = (s.cursor + 1) % searchPanelCount
 	} else {
-		s.cursor--
-		if s.cursor < 0 {
-			s.cursor = 1
-		}
+		s.cursor = (s.cursor - 1 + searchPanelCount) % searchPanelCount
 	}
 
 	// Update focus
@@ -217,7 +309,9 @@ func (s *ViewportSearchState) RenderSearchPanel(width int) string {
 	content.WriteString(s.searchInput.View())
 
 	// Match count indicator
-	if s.query != "" && s.renderer != nil {
+	if s.queryErr != "" {
+		content.WriteString(lipgloss.NewStyle().Foreground(RGBRed).Render(" (" + s.queryErr + ")"))
+	} else if s.query != "" && s.renderer != nil {
 		matchCount := s.renderer.GetMatchCount()
 		countStyle := lipgloss.NewStyle().
 			Foreground(RGBPink).
@@ -230,27 +324,43 @@ func (s *ViewportSearchState) RenderSearchPanel(width int) string {
 			content.WriteString(countStyle.Render(" (no matches)"))
 		}
 	}
+	if s.notice != "" {
+		content.WriteString(lipgloss.NewStyle().Foreground(RGBGreen).Render(" " + s.notice))
+	}
 
 	content.WriteString("\n")
 
-	// Checkbox for key search
-	checkboxCursor := " "
-	if s.cursor == 1 {
-		checkboxCursor = ">"
+	// Checkboxes for the search mode
+	checkboxes := []struct {
+		label   string
+		checked bool
+	}{
+		{"Key Search Only", s.keySearchOnly},
+		{"Regex", s.regex},
+		{"Match Case", s.caseSensitive},
 	}
+	for i, option := range checkboxes {
+		checkboxCursor := " "
+		if s.cursor == i+1 {
+			checkboxCursor = ">"
+		}
 
-	checkbox := "[ ]"
-	if s.keySearchOnly {
-		checkbox = "[x]"
-	}
+		checkbox := "[ ]"
+		if option.checked {
+			checkbox = "[x]"
+		}
 
-	checkboxLine := fmt.Sprintf("%s %s Key Search Only", checkboxCursor, checkbox)
+		checkboxLine := fmt.Sprintf("%s %s %s", checkboxCursor, checkbox, option.label)
 
-	if s.cursor == 1 {
-		checkboxLine = highlightStyle.Render(checkboxLine)
-	}
+		if s.cursor == i+1 {
+			checkboxLine = highlightStyle.Render(checkboxLine)
+		}
 
-	content.WriteString(checkboxLine)
+		if i > 0 {
+			content.WriteString("  ")
+		}
+		content.WriteString(checkboxLine)
+	}
 
 	// Help text
 	content.WriteString("\n")
@@ -259,7 +369,7 @@ func (s *ViewportSearchState) RenderSearchPanel(width int) string {
 	helpText := "Tab: Switch field | "
 	if len(s.matches) > 0 {
 		if s.filtered {
-			helpText += "Enter/Space: Show all | "
+			helpText += "Enter/Space: Show all | ctrl+y/ctrl+s: Copy/Save filtered | "
 		} else {
 			helpText += "Enter/Space: Filter view | "
 		}
@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+const matchNavigationJSON = `{"a": {"id": 1}, "b": 2, "c": {"id": 3}}`
+
+func newMatchNavigationState(t *testing.T) *ViewportSearchState {
+	t.Helper()
+
+	state := NewViewportSearchState()
+	state.Activate()
+	if err := state.SetContent(matchNavigationJSON, 80); err != nil {
+		t.Fatalf("SetContent failed: %v", err)
+	}
+	state.UpdateQuery("id")
+	return state
+}
+
+func TestJSONRendererMatchLines(t *testing.T) {
+	state := newMatchNavigationState(t)
+	rendered := state.GetRenderedContent()
+	lines := strings.Split(rendered, "\n")
+
+	matchLines := state.renderer.MatchLines()
+	if len(matchLines) != 2 || matchLines[0] != 2 || matchLines[1] != 6 {
+		t.Fatalf("expected match lines [2 6], got %v", matchLines)
+	}
+	for _, line := range matchLines {
+		if !strings.Contains(lines[line], "id") {
+			t.Errorf("line %d does not hold a match: %q", line, lines[line])
+		}
+	}
+
+	for _, match := range state.matches {
+		if match.LineStart < 0 || match.LineEnd < match.LineStart {
+			t.Errorf("match %s has line range %d-%d", match.Path, match.LineStart, match.LineEnd)
+		}
+	}
+}
+
+func TestViewportSearchStepMatchWraps(t *testing.T) {
+	state := newMatchNavigationState(t)
+	state.GetRenderedContent()
+	state.contentLine = 10
+
+	var got []int
+	for i := 0; i < 3; i++ {
+		line, ok := state.StepMatch(1)
+		if !ok {
+			t.Fatal("expected a match to step to")
+		}
+		got = append(got, line)
+	}
+	if got[0] != 12 || got[1] != 16 || got[2] != 12 {
+		t.Errorf("expected forward steps [12 16 12], got %v", got)
+	}
+
+	if line, _ := state.StepMatch(-1); line != 16 {
+		t.Errorf("expected stepping back to wrap to line 16, got %d", line)
+	}
+	if state.currentMatch != 1 {
+		t.Errorf("expected current match 1, got %d", state.currentMatch)
+	}
+}
+
+func TestViewportSearchStepMatchNoMatches(t *testing.T) {
+	state := newMatchNavigationState(t)
+	state.UpdateQuery("missing")
+	state.GetRenderedContent()
+
+	if _, ok := state.StepMatch(1); ok {
+		t.Error("expected no match to step to")
+	}
+	if state.currentMatch != -1 {
+		t.Errorf("expected current match to stay unset, got %d", state.currentMatch)
+	}
+}
+
+func TestJSONSearchRegexAndCase(t *testing.T) {
+	engine, err := NewJSONSearchEngine(`{"user": {"id": 1234567, "Name": "Ada"}, "order": {"id": 42}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matches, err := engine.SearchWithOptions(`"id":\s*\d{6,}`, JSONSearchOptions{Regex: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Path != "user.id" {
+		t.Errorf("regex over the key/value pair matched %v, want user.id", matches)
+	}
+
+	matches, _ = engine.SearchWithOptions("name", JSONSearchOptions{CaseSensitive: true})
+	if len(matches) != 0 {
+		t.Errorf("case sensitive search matched %v", matches)
+	}
+	matches, _ = engine.SearchWithOptions("name", JSONSearchOptions{})
+	if len(matches) != 1 || matches[0].Path != "user.Name" {
+		t.Errorf("case insensitive search matched %v, want user.Name", matches)
+	}
+
+	if _, err := engine.SearchWithOptions("id(", JSONSearchOptions{Regex: true}); err == nil {
+		t.Error("expected an error for an invalid regex")
+	}
+	if matches, _ := engine.SearchWithOptions("id(", JSONSearchOptions{}); len(matches) != 0 {
+		t.Errorf("plain queries are literal, matched %v", matches)
+	}
+}
+
+func TestViewportSearchOptionCheckboxes(t *testing.T) {
+	state := newMatchNavigationState(t)
+	if len(state.matches) != 2 {
+		t.Fatalf("expected 2 matches for id, got %d", len(state.matches))
+	}
+
+	state.MoveCursor(1)
+	state.MoveCursor(1)
+	if state.cursor != searchPanelRegex {
+		t.Fatalf("cursor = %d, want the regex checkbox", state.cursor)
+	}
+	state.ToggleFocusedCheckbox()
+	state.UpdateQuery("i[")
+	if state.queryErr == "" || len(state.matches) != 0 {
+		t.Errorf("invalid regex should report an error and clear matches, got %q and %d matches", state.queryErr, len(state.matches))
+	}
+
+	state.UpdateQuery("^i.$")
+	if state.queryErr != "" || len(state.matches) != 2 {
+		t.Errorf("regex ^i.$ should match both ids, got %d (%q)", len(state.matches), state.queryErr)
+	}
+
+	state.MoveCursor(-1)
+	state.MoveCursor(-1)
+	state.MoveCursor(-1)
+	if state.cursor != searchPanelCaseSensitive {
+		t.Errorf("cursor should wrap back to the last checkbox, got %d", state.cursor)
+	}
+}
@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/pb33f/harific/motor"
+	"github.com/pb33f/harific/motor/model"
+)
+
+// timingPhase is one segment of an entry's waterfall bar
+type timingPhase struct {
+	name  string
+	ms    float64
+	color color.Color
+}
+
+// waterfallLabelWidth is the space kept right of the bar for the total duration
+const waterfallLabelWidth = 10
+
+// timingPhases splits an entry's timings into the phases of its waterfall, in the order they
+// happen. har uses -1 for phases that don't apply; those and zero phases are left out. ssl is
+// part of connect, so connect is shown without it.
+func timingPhases(t *model.Timings) []timingPhase {
+	connect := t.Connect
+	if t.SSL > 0 && connect >= t.SSL {
+		connect -= t.SSL
+	}
+
+	all := []timingPhase{
+		{"Blocked", t.Blocked, RGBGrey},
+		{"DNS", t.DNS, lipgloss.Color("37")},
+		{"Connect", connect, RGBYellow},
+		{"SSL", t.SSL, RGBPink},
+		{"Send", t.Send, RGBGreen},
+		{"Wait", t.Wait, RGBBlue},
+		{"Receive", t.Receive, lipgloss.Color("141")},
+	}
+
+	phases := make([]timingPhase, 0, len(all))
+	for _, phase := range all {
+		if phase.ms > 0 {
+			phases = append(phases, phase)
+		}
+	}
+	return phases
+}
+
+// slowestDuration is the longest entry duration, the scale every waterfall bar is drawn against
+func slowestDuration(entries []*motor.EntryMetadata) float64 {
+	var slowest float64
+	for _, entry := range entries {
+		slowest = max(slowest, entry.Duration)
+	}
+	return slowest
+}
+
+// renderWaterfall draws the entry's phases as a colored bar scaled so the slowest entry fills
+// width, followed by a legend with each phase's time
+func renderWaterfall(t *model.Timings, slowest float64, width int) string {
+	phases := timingPhases(t)
+	if len(phases) == 0 {
+		return ""
+	}
+
+	var total float64
+	for _, phase := range phases {
+		total += phase.ms
+	}
+	slowest = max(slowest, total)
+	barWidth := max(width-waterfallLabelWidth, 10)
+
+	var bar strings.Builder
+	used := 0
+	for _, phase := range phases {
+		// every phase gets at least one cell so short phases stay visible
+		cells := max(int(math.Round(phase.ms/slowest*float64(barWidth))), 1)
+		cells = min(cells, barWidth-used)
+		if cells <= 0 {
+			break
+		}
+		bar.WriteString(lipgloss.NewStyle().Foreground(phase.color).Render(strings.Repeat("█", cells)))
+		used += cells
+	}
+	bar.WriteString(strings.Repeat(" ", barWidth-used))
+	bar.WriteString(" " + lipgloss.NewStyle().Bold(true).Render(formatDuration(total)))
+
+	legend := make([]string, 0, len(phases))
+	for _, phase := range phases {
+		legend = append(legend, lipgloss.NewStyle().Foreground(phase.color).Render("■")+" "+
+			fmt.Sprintf("%s %s", phase.name, formatDuration(phase.ms)))
+	}
+
+	return bar.String() + "\n" + strings.Join(legend, "  ")
+}
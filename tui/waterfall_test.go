@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/pb33f/harific/motor/model"
+)
+
+func TestTimingPhases(t *testing.T) {
+	phases := timingPhases(&model.Timings{Blocked: -1, DNS: -1, Connect: 30, SSL: 20, Send: 1, Wait: 100, Receive: 0})
+
+	var names []string
+	for _, phase := range phases {
+		names = append(names, phase.name)
+	}
+	if got := strings.Join(names, ","); got != "Connect,SSL,Send,Wait" {
+		t.Fatalf("phases = %s, want Connect,SSL,Send,Wait", got)
+	}
+	if phases[0].ms != 10 {
+		t.Errorf("connect should exclude ssl, got %vms", phases[0].ms)
+	}
+}
+
+func TestRenderWaterfall(t *testing.T) {
+	if got := renderWaterfall(&model.Timings{Send: -1, Wait: -1, Receive: -1}, 100, 60); got != "" {
+		t.Errorf("no phases should render nothing, got %q", got)
+	}
+
+	timings := &model.Timings{Send: 1, Wait: 150, Receive: 49}
+	lines := strings.Split(renderWaterfall(timings, 400, 50), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected bar and legend lines, got %d", len(lines))
+	}
+
+	bar := stripANSI(lines[0])
+	// 200ms of a 400ms scale fills half of the 40 cell bar; the 1ms send still gets a cell
+	if cells := strings.Count(bar, "█"); cells != 21 {
+		t.Errorf("expected 21 filled cells, got %d in %q", cells, bar)
+	}
+	if lipgloss.Width(lines[0]) != 50-waterfallLabelWidth+1+len("200ms") {
+		t.Errorf("bar should be padded to a fixed width, got %q", bar)
+	}
+	if !strings.HasSuffix(bar, "200ms") {
+		t.Errorf("bar should end with the total, got %q", bar)
+	}
+
+	legend := stripANSI(lines[1])
+	for _, want := range []string{"Send 1ms", "Wait 150ms", "Receive 49ms"} {
+		if !strings.Contains(legend, want) {
+			t.Errorf("legend %q missing %q", legend, want)
+		}
+	}
+
+	// an entry slower than the given scale still fits the bar
+	bar = stripANSI(strings.Split(renderWaterfall(timings, 10, 50), "\n")[0])
+	if cells := strings.Count(bar, "█"); cells != 40 {
+		t.Errorf("expected a full bar, got %d cells", cells)
+	}
+}
+
+func stripANSI(s string) string {
+	return ansiSequence.ReplaceAllString(s, "")
+}